@@ -85,16 +85,7 @@ func main() {
 			AuthenticationToken: resp.AuthenticationToken,
 		},
 		UserIdentityToken: uatype.ExtensionObject{
-			TypeId: uatype.ExpandedNodeId{
-				NodeIdType: uatype.NodeIdTypeFourByte,
-				FourByte: uatype.FourByteNodeId{
-					NamespaceIndex: 0,
-					Identifier:     uatype.NodeIdAnonymousIdentityToken_Encoding_DefaultBinary,
-				},
-			},
-			Encoding:   1,
-			BodyLength: 13,
-			Body:       []byte{9, 0, 0, 0, 'a', 'n', 'o', 'n', 'y', 'm', 'o', 'u', 's'},
+			Value: uatype.AnonymousIdentityToken{PolicyId: "anonymous"},
 		},
 	}, deadline)
 
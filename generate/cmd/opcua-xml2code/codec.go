@@ -0,0 +1,619 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"strings"
+)
+
+// codecBase maps Go types that can be encoded without reflection to the base
+// type that decides which encoder helpers to use.
+var codecBase = map[string]string{
+	"bool":       "bool",
+	"int8":       "int8",
+	"uint8":      "uint8",
+	"byte":       "uint8",
+	"int16":      "int16",
+	"uint16":     "uint16",
+	"int32":      "int32",
+	"rune":       "int32",
+	"uint32":     "uint32",
+	"int64":      "int64",
+	"uint64":     "uint64",
+	"float32":    "float32",
+	"float64":    "float64",
+	"string":     "string",
+	"time.Time":  "DateTime",
+	"ByteString": "ByteString",
+	"Guid":       "Guid",
+	"StatusCode": "uint32",
+}
+
+// codecAppend lists the append helper and the argument type for each base
+// type.
+var codecAppend = map[string][2]string{
+	"bool":       {"appendBool", "bool"},
+	"int8":       {"appendUint8", "uint8"},
+	"uint8":      {"appendUint8", "uint8"},
+	"int16":      {"appendUint16", "uint16"},
+	"uint16":     {"appendUint16", "uint16"},
+	"int32":      {"appendUint32", "uint32"},
+	"uint32":     {"appendUint32", "uint32"},
+	"int64":      {"appendUint64", "uint64"},
+	"uint64":     {"appendUint64", "uint64"},
+	"float32":    {"appendFloat32", "float32"},
+	"float64":    {"appendFloat64", "float64"},
+	"string":     {"appendString", "string"},
+	"DateTime":   {"appendDateTime", "time.Time"},
+	"ByteString": {"appendByteString", "uatype.ByteString"},
+	"Guid":       {"appendGuid", "uatype.Guid"},
+}
+
+// codecRead lists the Decoder read method and the pointer type it accepts for
+// each base type.
+var codecRead = map[string][2]string{
+	"bool":       {"readBool", "bool"},
+	"int8":       {"readInt8", "int8"},
+	"uint8":      {"readUint8", "uint8"},
+	"int16":      {"readInt16", "int16"},
+	"uint16":     {"readUint16", "uint16"},
+	"int32":      {"readInt32", "int32"},
+	"uint32":     {"readUint32", "uint32"},
+	"int64":      {"readInt64", "int64"},
+	"uint64":     {"readUint64", "uint64"},
+	"float32":    {"readFloat32", "float32"},
+	"float64":    {"readFloat64", "float64"},
+	"string":     {"readString", "string"},
+	"DateTime":   {"readDateTime", "time.Time"},
+	"ByteString": {"readByteString", "uatype.ByteString"},
+	"Guid":       {"readGuid", "uatype.Guid"},
+}
+
+// encodeHooks lists hand-written code to run before a structured type is
+// encoded.
+var encodeHooks = map[string]string{
+	"ExtensionObject": `	// Encode the Value of ExtensionObjects into the Body.
+	if v.Value != nil {
+		eo, err := wrapExtensionObject(nil, *v)
+		if err != nil {
+			return b, wrapError(err, "Value")
+		}
+		v = &eo
+	}
+`,
+	"Variant": `	// Refuse to encode Variant arrays with inconsistent dimensions.
+	if _, err := v.Dimensions(); err != nil {
+		return b, wrapError(err, "ArrayDimensions")
+	}
+`,
+}
+
+// decodeHooks lists hand-written code to run after a structured type has been
+// decoded.
+var decodeHooks = map[string]string{
+	"ExtensionObject": `	if err := unwrapExtensionObject(dec, v); err != nil {
+		return wrapError(err, "Body")
+	}
+`,
+	"Variant": `	if _, err := v.Dimensions(); err != nil {
+		return wrapError(err, "ArrayDimensions")
+	}
+`,
+}
+
+// fieldDecodeHooks lists hand-written code to run right after a field of a
+// structured type has been decoded, keyed by "Type.Field". The code is written
+// for the outermost indentation level of a decode function.
+var fieldDecodeHooks = map[string]string{
+	// Refuse Variant array lengths above the limit before allocating them.
+	"Variant.ArrayLength": `	if err := dec.checkVariantArrayLength(int(v.ArrayLength)); err != nil {
+		return wrapError(err, "ArrayLength")
+	}
+`,
+}
+
+// codecGen generates reflection-free binary encoders and decoders for the
+// structured types in a type dictionary. The generated code must produce the
+// same wire format as the reflection based Encoder and Decoder.
+type codecGen struct {
+	enums   map[string]enumType
+	structs map[string]structType
+
+	// skip lists the structured types for which no code can be generated.
+	skip map[string]string
+}
+
+// CodecCode returns the generated binary encoders and decoders for d.
+func (d typeDict) CodecCode() string {
+	g := codecGen{
+		enums:   make(map[string]enumType),
+		structs: make(map[string]structType),
+		skip:    make(map[string]string),
+	}
+	for _, e := range d.Enums {
+		g.enums[e.Name] = e
+	}
+	for _, s := range d.Structs {
+		g.structs[s.Name] = s.renamed()
+	}
+
+	// Find types that can't be generated, including types that refer to them.
+	for _, s := range d.Structs {
+		if reason := g.check(g.structs[s.Name]); reason != "" {
+			g.skip[s.Name] = reason
+		}
+	}
+	for changed := true; changed; {
+		changed = false
+		for _, s := range d.Structs {
+			if g.skip[s.Name] != "" {
+				continue
+			}
+			for _, f := range s.Fields {
+				if t := goTypeName(f.TypeName); g.skip[t] != "" {
+					g.skip[s.Name] = "field " + f.Name + " has type " + t
+					changed = true
+				}
+			}
+		}
+	}
+
+	code := bytes.NewBuffer(nil)
+	g.dispatch(code, d.Structs)
+	for _, s := range d.Structs {
+		if reason := g.skip[s.Name]; reason != "" {
+			log.Printf("[WARN] no codec for %s: %s", s.Name, reason)
+			continue
+		}
+		g.appendFunc(code, g.structs[s.Name])
+		g.decodeFunc(code, g.structs[s.Name])
+	}
+
+	b := bytes.NewBuffer(nil)
+	fmt.Fprint(b, "\nimport (\n")
+	if bytes.Contains(code.Bytes(), []byte("time.")) {
+		fmt.Fprint(b, "\t\"time\"\n")
+	}
+	if bytes.Contains(code.Bytes(), []byte("unsafe.")) {
+		fmt.Fprint(b, "\t\"unsafe\"\n")
+	}
+	if bytes.Contains(code.Bytes(), []byte("time.")) || bytes.Contains(code.Bytes(), []byte("unsafe.")) {
+		fmt.Fprint(b, "\n")
+	}
+	fmt.Fprint(b, "\t\"github.com/searis/guma/stack/uatype\"\n)\n")
+	b.Write(code.Bytes())
+	return b.String()
+}
+
+// renamed returns s with fields renamed according to fieldNames.
+func (s structType) renamed() structType {
+	names := fieldNames[s.Name]
+	if names == nil {
+		return s
+	}
+	fields := make([]structField, len(s.Fields))
+	for i, f := range s.Fields {
+		if name, ok := names[f.Name]; ok {
+			f.Name = name
+		}
+		fields[i] = f
+	}
+	s.Fields = fields
+	return s
+}
+
+// bitLength returns the number of bits used to encode f if f is part of a bit
+// field, or 0 otherwise.
+func (g codecGen) bitLength(f structField) int {
+	t := goTypeName(f.TypeName)
+	if t == "Bit" {
+		if f.Length > 1 {
+			return f.Length
+		}
+		return 1
+	}
+	if e, ok := g.enums[t]; ok && e.NeedsBitLengther() {
+		return e.BitLength
+	}
+	return 0
+}
+
+// check returns why no code can be generated for s, or an empty string.
+func (g codecGen) check(s structType) string {
+	var bits int
+	for _, f := range s.Fields {
+		if n := g.bitLength(f); n > 0 {
+			if n > 8 || bits%8+n > 8 {
+				return "bit field " + f.Name + " crosses a byte boundary"
+			}
+			if f.LengthField != "" || f.SwitchField != "" {
+				return "bit field " + f.Name + " is optional"
+			}
+			bits += n
+			continue
+		}
+		if bits%8 != 0 {
+			return "bit field before " + f.Name + " does not fill a byte"
+		}
+		if f.Length > 1 {
+			return "fixed length field " + f.Name
+		}
+		t := goTypeName(f.TypeName)
+		if _, ok := g.structs[t]; !ok && g.base(t) == "" {
+			return "unknown type " + t + " of field " + f.Name
+		}
+	}
+	if bits%8 != 0 {
+		return "bit field does not fill a byte"
+	}
+	return ""
+}
+
+// base returns the base type of the Go type t, or an empty string if t is not
+// a base type or an enum.
+func (g codecGen) base(t string) string {
+	if e, ok := g.enums[t]; ok {
+		return codecBase[e.GoType()]
+	}
+	return codecBase[t]
+}
+
+// qualified returns the Go type t qualified with the uatype package name when
+// needed.
+func (g codecGen) qualified(t string) string {
+	if _, ok := codecBase[t]; ok && t != "ByteString" && t != "Guid" && t != "StatusCode" {
+		return t
+	}
+	return "uatype." + t
+}
+
+// dispatch writes the functions that select generated code for a value.
+func (g codecGen) dispatch(b *bytes.Buffer, structs []structType) {
+	fmt.Fprint(b, `
+// appendGenerated appends the binary encoding of v to b using generated code.
+// ok is false if there is no generated code for the type of v.
+func appendGenerated(b []byte, v interface{}) (_ []byte, ok bool, err error) {
+	switch v := v.(type) {`)
+	for _, s := range structs {
+		if g.skip[s.Name] == "" {
+			fmt.Fprintf(b, "\n\tcase uatype.%s:\n\t\tb, err = append%[1]s(b, &v)", s.Name)
+		}
+	}
+	fmt.Fprint(b, `
+	default:
+		return b, false, nil
+	}
+	return b, true, err
+}
+
+// decodeGenerated decodes into the value pointed to by v using generated code.
+// ok is false if there is no generated code for the type of v.
+func decodeGenerated(dec *Decoder, v interface{}) (ok bool, err error) {
+	switch v := v.(type) {`)
+	for _, s := range structs {
+		if g.skip[s.Name] == "" {
+			fmt.Fprintf(b, "\n\tcase *uatype.%s:\n\t\terr = decode%[1]s(dec, v)", s.Name)
+		}
+	}
+	fmt.Fprint(b, `
+	default:
+		return false, nil
+	}
+	return true, err
+}
+`)
+}
+
+// switchCond returns the condition under which f is encoded, or an empty
+// string if f is always encoded.
+func (g codecGen) switchCond(s structType, f structField) string {
+	if f.SwitchField == "" {
+		return ""
+	}
+	if f.SwitchValue == "" {
+		return "v." + f.SwitchField
+	}
+	op := "=="
+	switch f.SwitchOperand {
+	case "GreaterThan":
+		op = ">"
+	case "LessThan":
+		op = "<"
+	case "GreaterThanOrEqual":
+		op = ">="
+	case "LessThanOrEqual":
+		op = "<="
+	case "NotEqual":
+		op = "!="
+	}
+	return fmt.Sprintf("v.%s %s %s", f.SwitchField, op, f.SwitchValue)
+}
+
+// field returns the field of s with the given name.
+func (s structType) field(name string) (structField, bool) {
+	for _, f := range s.Fields {
+		if f.Name == name {
+			return f, true
+		}
+	}
+	return structField{}, false
+}
+
+// lengthCode writes code that sets n to the number of elements of the array
+// field f. A length field that has been switched off means that exactly one
+// element is encoded, which is how scalar Variant values are described.
+func (g codecGen) lengthCode(b *bytes.Buffer, s structType, f structField, indent string) {
+	lf, _ := s.field(f.LengthField)
+	if cond := g.switchCond(s, lf); cond != "" {
+		fmt.Fprintf(b, "%sn = 1\n%sif %s {\n%s\tn = int(v.%s)\n%s}\n", indent, indent, cond, indent, lf.Name, indent)
+	} else {
+		fmt.Fprintf(b, "%sn = int(v.%s)\n", indent, lf.Name)
+	}
+}
+
+// bitRun returns the number of fields from the start of fields that are part
+// of a bit field.
+func (g codecGen) bitRun(fields []structField) int {
+	var bits, i int
+	for i < len(fields) && bits < 8 {
+		n := g.bitLength(fields[i])
+		if n == 0 {
+			break
+		}
+		bits += n
+		i++
+	}
+	return i
+}
+
+func (g codecGen) appendFunc(b *bytes.Buffer, s structType) {
+	body := bytes.NewBuffer(nil)
+	fmt.Fprint(body, encodeHooks[s.Name])
+	for i := 0; i < len(s.Fields); i++ {
+		if n := g.bitRun(s.Fields[i:]); n > 0 {
+			g.appendBits(body, s.Fields[i:i+n])
+			i += n - 1
+			continue
+		}
+
+		f := s.Fields[i]
+		indent := "\t"
+		if cond := g.switchCond(s, f); cond != "" {
+			fmt.Fprintf(body, "\tif %s {\n", cond)
+			indent = "\t\t"
+		}
+		if f.LengthField != "" {
+			g.lengthCode(body, s, f, indent)
+			fmt.Fprintf(body, "%sif l := len(v.%s); l != n && !(l == 0 && n < 0) {\n", indent, f.Name)
+			fmt.Fprintf(body, "%s\treturn b, wrapError(ErrInvalidLength, %q)\n%s}\n", indent, f.Name, indent)
+			g.appendSlice(body, f, indent)
+		} else {
+			g.appendValue(body, f, "v."+f.Name, fmt.Sprintf("wrapError(err, %q)", f.Name), indent)
+		}
+		if indent != "\t" {
+			fmt.Fprint(body, "\t}\n")
+		}
+	}
+
+	fmt.Fprintf(b, "\nfunc append%s(b []byte, v *uatype.%[1]s) ([]byte, error) {\n", s.Name)
+	code := body.String()
+	if strings.Contains(code, "err = ") {
+		fmt.Fprint(b, "\tvar err error\n")
+	}
+	if strings.Contains(code, "bits, err = ") {
+		fmt.Fprint(b, "\tvar bits byte\n")
+	}
+	if strings.Contains(code, "\tn = ") {
+		fmt.Fprint(b, "\tvar n int\n")
+	}
+	fmt.Fprint(b, code)
+	fmt.Fprint(b, "\treturn b, nil\n}\n")
+}
+
+// appendBits writes code that encodes fields into a single byte.
+func (g codecGen) appendBits(b *bytes.Buffer, fields []structField) {
+	var off int
+	var packed bool
+	for _, f := range fields {
+		n := g.bitLength(f)
+		if goTypeName(f.TypeName) == "Bit" && n == 1 {
+			off += n
+			continue
+		}
+		value := "v." + f.Name
+		if goTypeName(f.TypeName) != "Bit" {
+			value = "byte(" + value + ")"
+		}
+		prev := "bits"
+		if !packed {
+			prev = "0"
+			packed = true
+		}
+		fmt.Fprintf(b, "\tif bits, err = packBits(%s, %s, %d, %d); err != nil {\n", prev, value, off, n)
+		fmt.Fprintf(b, "\t\treturn b, wrapError(err, %q)\n\t}\n", f.Name)
+		off += n
+	}
+
+	// Single bits are combined into one expression.
+	var terms []string
+	off = 0
+	for _, f := range fields {
+		n := g.bitLength(f)
+		if goTypeName(f.TypeName) == "Bit" && n == 1 {
+			terms = append(terms, fmt.Sprintf("bit(v.%s, %d)", f.Name, off))
+		}
+		off += n
+	}
+	switch {
+	case packed && len(terms) > 0:
+		fmt.Fprintf(b, "\tb = append(b, bits|%s)\n", strings.Join(terms, "|"))
+	case packed:
+		fmt.Fprint(b, "\tb = append(b, bits)\n")
+	default:
+		fmt.Fprintf(b, "\tb = append(b, %s)\n", strings.Join(terms, "|"))
+	}
+}
+
+// appendValue writes code that encodes the value expr of the field f. wrapped
+// is the expression that wraps encoder errors.
+func (g codecGen) appendValue(b *bytes.Buffer, f structField, expr, wrapped, indent string) {
+	t := goTypeName(f.TypeName)
+	if _, ok := g.structs[t]; ok {
+		ptr := "&" + expr
+		if t == "DiagnosticInfo" && !strings.HasSuffix(expr, "]") {
+			// Encode nil pointers as the zero value, like the decoder
+			// allocates them.
+			ptr = expr
+			fmt.Fprintf(b, "%sif %s == nil {\n%s\tb, err = append%s(b, &uatype.%[4]s{})\n", indent, expr, indent, t)
+			fmt.Fprintf(b, "%s} else {\n%s\tb, err = append%s(b, %s)\n%s}\n", indent, indent, t, ptr, indent)
+			fmt.Fprintf(b, "%sif err != nil {\n%s\treturn b, %s\n%s}\n", indent, indent, wrapped, indent)
+			return
+		}
+		fmt.Fprintf(b, "%sif b, err = append%s(b, %s); err != nil {\n", indent, t, ptr)
+		fmt.Fprintf(b, "%s\treturn b, %s\n%s}\n", indent, wrapped, indent)
+		return
+	}
+	base := g.base(t)
+	fn := codecAppend[base]
+	if t != fn[1] && g.qualified(t) != fn[1] {
+		expr = fmt.Sprintf("%s(%s)", fn[1], expr)
+	}
+	fmt.Fprintf(b, "%sb = %s(b, %s)\n", indent, fn[0], expr)
+}
+
+// appendSlice writes code that encodes the elements of the array field f.
+func (g codecGen) appendSlice(b *bytes.Buffer, f structField, indent string) {
+	t := goTypeName(f.TypeName)
+	if t == "uint8" {
+		fmt.Fprintf(b, "%sb = append(b, v.%s...)\n", indent, f.Name)
+		return
+	}
+	wrapped := ""
+	if _, ok := g.structs[t]; ok {
+		wrapped = fmt.Sprintf("wrapError(wrapError(err, i), %q)", f.Name)
+	}
+	fmt.Fprintf(b, "%sfor i := range v.%s {\n", indent, f.Name)
+	g.appendValue(b, f, fmt.Sprintf("v.%s[i]", f.Name), wrapped, indent+"\t")
+	fmt.Fprintf(b, "%s}\n", indent)
+}
+
+func (g codecGen) decodeFunc(out *bytes.Buffer, s structType) {
+	b := bytes.NewBuffer(nil)
+	for i := 0; i < len(s.Fields); i++ {
+		if n := g.bitRun(s.Fields[i:]); n > 0 {
+			g.decodeBits(b, s.Fields[i:i+n])
+			i += n - 1
+			continue
+		}
+
+		f := s.Fields[i]
+		indent := "\t"
+		if cond := g.switchCond(s, f); cond != "" {
+			fmt.Fprintf(b, "\tif %s {\n", cond)
+			indent = "\t\t"
+		}
+		if f.LengthField != "" {
+			// Negative lengths describe null arrays.
+			g.lengthCode(b, s, f, indent)
+			fmt.Fprintf(b, "%sif n < 0 {\n%s\tn = 0\n%s}\n", indent, indent, indent)
+			fmt.Fprintf(b, "%sif err := dec.allocArray(n, int(unsafe.Sizeof(v.%s[0]))); err != nil {\n", indent, f.Name)
+			fmt.Fprintf(b, "%s\treturn wrapError(err, %q)\n%s}\n", indent, f.Name, indent)
+			fmt.Fprintf(b, "%sv.%s = make([]%s, n)\n", indent, f.Name, g.qualified(goTypeName(f.TypeName)))
+			g.decodeSlice(b, f, indent)
+		} else {
+			g.decodeValue(b, f, "v."+f.Name, fmt.Sprintf("wrapError(err, %q)", f.Name), indent)
+		}
+		if hook, ok := fieldDecodeHooks[s.Name+"."+f.Name]; ok {
+			for _, line := range strings.SplitAfter(hook, "\n") {
+				if line != "" {
+					fmt.Fprint(b, indent[1:]+line)
+				}
+			}
+		}
+		if indent != "\t" {
+			fmt.Fprint(b, "\t}\n")
+		}
+	}
+	fmt.Fprint(b, decodeHooks[s.Name])
+
+	fmt.Fprintf(out, "\nfunc decode%s(dec *Decoder, v *uatype.%[1]s) error {\n", s.Name)
+	if bytes.Contains(b.Bytes(), []byte("&bits")) {
+		fmt.Fprint(out, "\tvar bits byte\n")
+	}
+	if bytes.Contains(b.Bytes(), []byte("\tn = ")) {
+		fmt.Fprint(out, "\tvar n int\n")
+	}
+	fmt.Fprint(out, "\tif err := dec.enter(); err != nil {\n\t\treturn err\n\t}\n\tdefer dec.leave()\n")
+	out.Write(b.Bytes())
+	fmt.Fprint(out, "\treturn nil\n}\n")
+}
+
+// decodeBits writes code that decodes fields from a single byte.
+func (g codecGen) decodeBits(b *bytes.Buffer, fields []structField) {
+	fmt.Fprint(b, "\tif err := dec.readUint8(&bits); err != nil {\n")
+	fmt.Fprintf(b, "\t\treturn wrapError(err, %q)\n\t}\n", fields[0].Name)
+	var off int
+	for _, f := range fields {
+		n := g.bitLength(f)
+		t := goTypeName(f.TypeName)
+		switch {
+		case t == "Bit" && n == 1:
+			fmt.Fprintf(b, "\tv.%s = bits&0x%02x != 0\n", f.Name, 1<<uint(off))
+		case t == "Bit":
+			fmt.Fprintf(b, "\tv.%s = %s\n", f.Name, bitsExpr(off, n))
+		default:
+			fmt.Fprintf(b, "\tv.%s = uatype.%s(%s)\n", f.Name, t, bitsExpr(off, n))
+		}
+		off += n
+	}
+}
+
+// bitsExpr returns an expression that extracts n bits at offset off from bits.
+func bitsExpr(off, n int) string {
+	mask := 0xff >> uint(8-n)
+	if off == 0 {
+		return fmt.Sprintf("bits & 0x%02x", mask)
+	}
+	return fmt.Sprintf("bits >> %d & 0x%02x", off, mask)
+}
+
+// decodeValue writes code that decodes into the value expr of the field f.
+// wrapped is the expression that wraps decoder errors.
+func (g codecGen) decodeValue(b *bytes.Buffer, f structField, expr, wrapped, indent string) {
+	t := goTypeName(f.TypeName)
+	ptr := "&" + expr
+	var call string
+	if _, ok := g.structs[t]; ok {
+		if t == "DiagnosticInfo" && !strings.HasSuffix(expr, "]") {
+			fmt.Fprintf(b, "%s%s = new(uatype.%s)\n", indent, expr, t)
+			ptr = expr
+		}
+		call = fmt.Sprintf("decode%s(dec, %s)", t, ptr)
+	} else {
+		fn := codecRead[g.base(t)]
+		if t != fn[1] && g.qualified(t) != fn[1] && t != "rune" && t != "byte" {
+			ptr = fmt.Sprintf("(*%s)(%s)", fn[1], ptr)
+		}
+		call = fmt.Sprintf("dec.%s(%s)", fn[0], ptr)
+	}
+	fmt.Fprintf(b, "%sif err := %s; err != nil {\n", indent, call)
+	fmt.Fprintf(b, "%s\treturn %s\n%s}\n", indent, wrapped, indent)
+}
+
+// decodeSlice writes code that decodes the elements of the array field f.
+func (g codecGen) decodeSlice(b *bytes.Buffer, f structField, indent string) {
+	t := goTypeName(f.TypeName)
+	if t == "uint8" {
+		fmt.Fprintf(b, "%sif err := dec.readBytes(v.%s); err != nil {\n", indent, f.Name)
+		fmt.Fprintf(b, "%s\treturn wrapError(err, %q)\n%s}\n", indent, f.Name, indent)
+		return
+	}
+	wrapped := fmt.Sprintf("wrapError(err, %q)", f.Name)
+	if _, ok := g.structs[t]; ok || t == "string" {
+		wrapped = fmt.Sprintf("wrapError(wrapError(err, i), %q)", f.Name)
+	}
+	fmt.Fprintf(b, "%sfor i := range v.%s {\n", indent, f.Name)
+	g.decodeValue(b, f, fmt.Sprintf("v.%s[i]", f.Name), wrapped, indent+"\t")
+	fmt.Fprintf(b, "%s}\n", indent)
+}
@@ -24,6 +24,7 @@ func init() {
 // the root.
 type root struct {
 	pname    string
+	codecs   bool
 	TypeDict typeDict `xml:"opc:TypeDictionary"`
 	NodeSet  nodeSet  `xml:"NodeSet"`
 	Services services `xml:"wsdl:definitions"`
@@ -33,6 +34,10 @@ func (r root) Code() string {
 	b := bytes.NewBuffer(nil)
 	fmt.Fprint(b, "// Code generated by opcua-xml2code. DO NOT EDIT.\n\n")
 	fmt.Fprintf(b, "package %s\n", r.pname)
+	if r.codecs {
+		fmt.Fprint(b, r.TypeDict.CodecCode())
+		return b.String()
+	}
 	fmt.Fprint(b, r.TypeDict.Code())
 	fmt.Fprint(b, r.NodeSet.Code())
 	fmt.Fprint(b, r.Services.Code())
@@ -73,6 +78,10 @@ func main() {
 	case "types":
 		root.pname = "uatype"
 		v = &root.TypeDict
+	case "codecs":
+		root.pname = "binary"
+		root.codecs = true
+		v = &root.TypeDict
 	case "services":
 		root.pname = "stack"
 		v = &root.Services
@@ -20,7 +20,7 @@ var servicesTmlp = template.Must(template.New("services.tmpl").Parse(`
 func (c *Client) {{.GoName}}(req uatype.{{.GoReq}}, deadline time.Time) (*uatype.{{.GoResp}}, error) {
 	var buf bytes.Buffer
 
-	if err := binary.NewEncoder(&buf).Encode(req); err != nil {
+	if err := c.newEncoder(&buf).Encode(req); err != nil {
 		return nil, err
 	}
 
@@ -31,21 +31,59 @@ func (c *Client) {{.GoName}}(req uatype.{{.GoReq}}, deadline time.Time) (*uatype
 	if err != nil {
 		return nil, err
 	}
+	defer resp.Close()
 	switch resp.NodeID.Uint() {
 	case {{.ResponseNodeID}}:
 		res := &uatype.{{.GoResp}}{}
-		if err := binary.NewDecoder(resp.Body).Decode(res); err != nil {
+		if err := c.newDecoder(resp).Decode(res); err != nil {
 			return res, err
 		}
 		return res, nil
 	case {{.FaultNodeID}}:
 		fault := uatype.{{.GoFault}}{}
-		if err := binary.NewDecoder(resp.Body).Decode(&fault); err != nil {
+		if err := c.newDecoder(resp).Decode(&fault); err != nil {
 			return nil, err
 		}
 		return nil, fault
 	}
 	return nil, fmt.Errorf("Unexpected NodeID: %d %s", resp.NodeID.Uint(), resp.NodeID.DisplayName())
+}
+
+// {{.GoName}}Context sends a {{.GoName}} request to the server, and waits for a
+// response or for ctx to be done. The deadline of ctx is sent as the timeout
+// hint of the request. If ctx is done first, a Cancel request is sent for the
+// request handle.
+func (c *Client) {{.GoName}}Context(ctx context.Context, req uatype.{{.GoReq}}) (*uatype.{{.GoResp}}, error) {
+	c.contextHeader(ctx, &req.RequestHeader)
+	var buf bytes.Buffer
+
+	if err := c.newEncoder(&buf).Encode(req); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.sendContext(ctx, req.RequestHeader, transport.Request{
+		NodeID: uatype.NewFourByteNodeID(0, {{.RequestNodeID}}).Expanded(),
+		Body: &buf,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Close()
+	switch resp.NodeID.Uint() {
+	case {{.ResponseNodeID}}:
+		res := &uatype.{{.GoResp}}{}
+		if err := c.newDecoder(resp).Decode(res); err != nil {
+			return res, c.contextError(ctx, req.RequestHeader, err)
+		}
+		return res, nil
+	case {{.FaultNodeID}}:
+		fault := uatype.{{.GoFault}}{}
+		if err := c.newDecoder(resp).Decode(&fault); err != nil {
+			return nil, c.contextError(ctx, req.RequestHeader, err)
+		}
+		return nil, fault
+	}
+	return nil, fmt.Errorf("Unexpected NodeID: %d %s", resp.NodeID.Uint(), resp.NodeID.DisplayName())
 }{{end}}
 {{end}}`))
 
@@ -53,9 +53,9 @@ func goTypeNameFromBitSize(n int) string {
 		return "byte"
 	case 1: // 2 bytes
 		return "uint16"
-	case 3: // 4 bytes
+	case 2, 3: // 3-4 bytes
 		return "uint32"
-	case 7: // 8 bytes
+	case 4, 5, 6, 7: // 5-8 bytes
 		return "uint64"
 	}
 	panic(fmt.Sprint("Unexpected bit length:", n))
@@ -77,9 +77,88 @@ func (d typeDict) Code() string {
 	for _, s := range d.Structs {
 		fmt.Fprintln(b, s.Code())
 	}
+
+	if err := registerTmpl.Execute(b, d); err != nil {
+		log.Println("[ERROR]", err)
+	}
+	if err := enumDataTypesTmpl.Execute(b, d); err != nil {
+		log.Println("[ERROR]", err)
+	}
 	return b.String()
 }
 
+// builtinTypes lists structured types that are built-in types in OPC UA, and
+// that therefore have no binary encoding node ID of their own.
+var builtinTypes = map[string]bool{
+	"XmlElement":       true,
+	"TwoByteNodeId":    true,
+	"FourByteNodeId":   true,
+	"NumericNodeId":    true,
+	"StringNodeId":     true,
+	"GuidNodeId":       true,
+	"ByteStringNodeId": true,
+	"NodeId":           true,
+	"ExpandedNodeId":   true,
+	"DiagnosticInfo":   true,
+	"QualifiedName":    true,
+	"LocalizedText":    true,
+	"DataValue":        true,
+	"ExtensionObject":  true,
+	"Variant":          true,
+}
+
+// extraFields lists hand-written fields to add to generated structs. The
+// fields are not part of the binary encoding.
+var extraFields = map[string][]string{
+	"ExtensionObject": {
+		"// Value holds the decoded Body of registered types, and may be set\n" +
+			"\t// instead of Body when encoding. See RegisterExtensionObject.\n" +
+			"\tValue interface{} `opcua:\"-\"`",
+		"// ValueError is set by binary Decoders when the Body of a registered\n" +
+			"\t// type can not be decoded. Value is then nil, and Body is kept as is.\n" +
+			"\tValueError error `opcua:\"-\"`",
+	},
+	"Variant": {
+		"// StringNull marks the String values that are null. It's only\n" +
+			"\t// encoded and decoded by binary Encoders and Decoders that preserve\n" +
+			"\t// null values. See NewVariant and NullStringValue.\n" +
+			"\tStringNull []bool `opcua:\"-\"`",
+	},
+}
+
+// fieldNames lists generated struct fields to rename, typically because the
+// schema name collides with a method of the struct.
+var fieldNames = map[string]map[string]string{
+	"NodeId":         {"String": "StringID"},
+	"ExpandedNodeId": {"String": "StringID"},
+}
+
+// fieldDocs lists documentation for generated struct fields, keyed by the
+// field names after renaming.
+var fieldDocs = map[string]map[string]string{
+	"NodeId":         {"StringID": stringIDDoc},
+	"ExpandedNodeId": {"StringID": stringIDDoc},
+}
+
+const stringIDDoc = "StringID holds the string identifier of String NodeIds. It's named\n" +
+	"\t// String in the schema, which collides with the String method."
+
+var registerTmpl = template.Must(template.New("register.tmpl").Parse(`{{if .Structs}}
+func init() {
+{{- range .Structs}}{{if .Registered}}
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeId{{.Name}}_Encoding_DefaultBinary), {{.Name}}{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeId{{.Name}}_Encoding_DefaultXml), {{.Name}}{}){{end}}{{end}}
+}
+{{end}}`))
+
+var enumDataTypesTmpl = template.Must(template.New("enum_data_types.tmpl").Parse(`{{if .Enums}}
+// enumDataTypes lists the Enumeration DataTypes of the OPC UA namespace.
+var enumDataTypes = map[uint16]bool{
+{{- range .Enums}}{{if .DataType}}
+	NodeId{{.Name}}: true,{{end}}{{end}}
+}
+{{end}}`))
+
 var enumTmpl = template.Must(template.New("enum.tmpl").Parse(`
 type {{.GoName}} {{.GoType}}
 
@@ -91,7 +170,16 @@ func (t {{.GoName}}) BitLength() int {
 {{$prefix := .Name}}{{$type := .GoName}}{{if .Doc}}// {{.Doc}}
 {{end}}const ({{range .Values}}
 	{{$prefix}}{{.Name}} {{$type}} = {{.Value}}{{end}}
-)`))
+)
+
+// String returns the name of t, or its value if t is unknown.
+func (t {{.GoName}}) String() string {
+	switch t { {{- range .Values}}
+	case {{$prefix}}{{.Name}}:
+		return "{{.Name}}"{{end}}
+	}
+	return strconv.FormatUint(uint64(t), 10)
+}`))
 
 type enumType struct {
 	Name      string      `xml:"Name,attr"`
@@ -117,6 +205,13 @@ func (e enumType) NeedsBitLengther() bool {
 	return true
 }
 
+// DataType returns true if e is an Enumeration DataType. Those are encoded
+// as Int32, while enumerated types of other sizes only describe parts of the
+// encoding of built-in types, such as NodeIdType.
+func (e enumType) DataType() bool {
+	return e.BitLength == 32
+}
+
 func (e enumType) GoName() string {
 	return e.Name
 }
@@ -135,7 +230,9 @@ var structTmpl = template.Must(template.New("struct.tmpl").Parse(`
 {{end}}type {{.Name}} struct {
 	{{if .BaseType}}{{.GoBaseType}}
 	{{end}}{{range .Fields}}
-	{{.Code}}{{end}}
+	{{.Code}}{{end}}{{range .ExtraFields}}
+
+	{{.}}{{end}}
 }`))
 
 type structType struct {
@@ -146,6 +243,17 @@ type structType struct {
 }
 
 func (s structType) Code() string {
+	if names := fieldNames[s.Name]; names != nil {
+		fields := make([]structField, len(s.Fields))
+		for i, f := range s.Fields {
+			if name, ok := names[f.Name]; ok {
+				f.Name = name
+			}
+			f.doc = fieldDocs[s.Name][f.Name]
+			fields[i] = f
+		}
+		s.Fields = fields
+	}
 	b := bytes.NewBuffer(nil)
 	if err := structTmpl.Execute(b, s); err != nil {
 		log.Println("[ERROR]", err)
@@ -153,6 +261,17 @@ func (s structType) Code() string {
 	return b.String()
 }
 
+// ExtraFields returns hand-written field declarations for s.
+func (s structType) ExtraFields() []string {
+	return extraFields[s.Name]
+}
+
+// Registered returns true if s should be registered for automatic encoding and
+// decoding of ExtensionObject bodies.
+func (s structType) Registered() bool {
+	return !builtinTypes[s.Name]
+}
+
 func (s structType) GoBaseType() string {
 	return goTypeName(s.BaseType)
 }
@@ -171,10 +290,16 @@ type structField struct {
 	SwitchField   string `xml:"SwitchField,attr,omitempty"`
 	SwitchValue   string `xml:"SwitchValue,attr,omitempty"`
 	SwitchOperand string `xml:"SwitchOperand,attr,omitempty"`
+
+	doc string // doc is the hand-written documentation of the field.
 }
 
 func (f structField) Code() string {
-	return fmt.Sprint(f.Name, " ", f.GoType(), " ", f.GoTags())
+	code := fmt.Sprint(f.Name, " ", f.GoType(), " ", f.GoTags())
+	if f.doc != "" {
+		return "// " + f.doc + "\n\t" + code
+	}
+	return code
 }
 
 func (f structField) GoType() string {
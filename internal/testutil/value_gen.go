@@ -0,0 +1,247 @@
+package testutil
+
+import (
+	"math/rand"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/searis/guma/stack/encoding/binary"
+	"github.com/searis/guma/stack/uatype"
+)
+
+// maxGenDepth limits the nesting of arrays, Variants and ExtensionObjects in
+// generated values.
+const maxGenDepth = 3
+
+// RegisteredTypes returns the structured types registered for ExtensionObjects
+// in namespace 0, ordered by encoding node ID.
+func RegisteredTypes() []reflect.Type {
+	var types []reflect.Type
+	for i := 0; i <= 0xFFFF; i++ {
+		if rt, ok := uatype.ExtensionObjectType(uatype.NewFourByteNodeID(0, uint16(i)).Expanded()); ok {
+			types = append(types, rt)
+		}
+	}
+	return types
+}
+
+// ValueGen generates random values that are valid according to the opcua
+// struct tags of their types, such that decoding their binary encoding returns
+// an equal value.
+type ValueGen struct {
+	r     *rand.Rand
+	types []reflect.Type
+}
+
+// NewValueGen returns a ValueGen that draws from r, and fills
+// ExtensionObjects with values of the registered types.
+func NewValueGen(r *rand.Rand) ValueGen {
+	return ValueGen{r: r, types: RegisteredTypes()}
+}
+
+// Value returns a random value of type rt.
+func (g ValueGen) Value(rt reflect.Type) interface{} {
+	rv := reflect.New(rt).Elem()
+	g.fill(rv, 0)
+	return rv.Interface()
+}
+
+func (g ValueGen) fill(rv reflect.Value, depth int) {
+	switch rv.Interface().(type) {
+	case time.Time:
+		rv.Set(reflect.ValueOf(time.Unix(g.r.Int63n(1<<33), g.r.Int63n(1e7)*100).UTC()))
+		return
+	case uatype.ByteString:
+		// Empty ByteStrings are encoded as null.
+		if b := g.bytes(); len(b) > 0 {
+			rv.SetBytes(b)
+		}
+		return
+	case uatype.ExtensionObject:
+		g.fillExtensionObject(rv, depth)
+		return
+	}
+
+	switch rv.Kind() {
+	case reflect.Bool:
+		rv.SetBool(g.r.Intn(2) == 1)
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		rv.SetInt(int64(g.r.Uint64()))
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		rv.SetUint(g.r.Uint64())
+		if bl, ok := rv.Interface().(binary.BitLengther); ok && bl.BitLength() < 8 {
+			rv.SetUint(rv.Uint() & (1<<uint(bl.BitLength()) - 1))
+		}
+	case reflect.Float32, reflect.Float64:
+		rv.SetFloat(g.r.NormFloat64())
+	case reflect.String:
+		rv.SetString(string(g.bytes()))
+	case reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			g.fill(rv.Index(i), depth)
+		}
+	case reflect.Slice:
+		n := 0
+		if depth < maxGenDepth {
+			n = g.r.Intn(4)
+		}
+		g.fillSlice(rv, n, depth)
+	case reflect.Ptr:
+		rv.Set(reflect.New(rv.Type().Elem()))
+		if depth < maxGenDepth {
+			g.fill(rv.Elem(), depth+1)
+		}
+	case reflect.Struct:
+		g.fillStruct(rv, depth)
+	}
+}
+
+func (g ValueGen) fillSlice(rv reflect.Value, n, depth int) {
+	// Empty arrays are decoded as empty, not nil, slices.
+	rv.Set(reflect.MakeSlice(rv.Type(), n, n))
+	for i := 0; i < n; i++ {
+		g.fill(rv.Index(i), depth+1)
+	}
+}
+
+func (g ValueGen) fillStruct(rv reflect.Value, depth int) {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		if f := rt.Field(i); f.PkgPath == "" && !f.Anonymous && f.Tag.Get("opcua") != "-" {
+			g.fill(rv.Field(i), depth)
+		}
+	}
+
+	switch v := rv.Addr().Interface().(type) {
+	case *uatype.Variant:
+		// Limit recursion, and don't bother with multi-dimensional arrays.
+		types := 26
+		if depth >= maxGenDepth {
+			types = 22
+		}
+		v.VariantType = byte(g.r.Intn(types))
+		v.ArrayLength = 0 // set from the array below, unless VariantType is null
+		v.ArrayDimensionsSpecified = false
+	case *uatype.NodeId:
+		v.NodeIdType = uatype.NodeIdType(g.r.Intn(6))
+	case *uatype.ExpandedNodeId:
+		v.NodeIdType = uatype.NodeIdType(g.r.Intn(6))
+	case *uatype.DiagnosticInfo:
+		if depth >= maxGenDepth {
+			v.InnerDiagnosticInfoSpecified = false
+		}
+	}
+
+	// Zero inactive fields, and make lengths match.
+	for i := 0; i < rt.NumField(); i++ {
+		tag := parseTag(rt.Field(i).Tag.Get("opcua"))
+		fv := rv.Field(i)
+		if bits, ok := tag["bits"]; ok {
+			n, _ := strconv.Atoi(bits)
+			fv.SetUint(fv.Uint() & (1<<uint(n) - 1))
+		}
+		if !switchActive(rv, tag) {
+			fv.Set(reflect.Zero(fv.Type()))
+			continue
+		}
+		if name, ok := tag["lengthField"]; ok {
+			lf := rv.FieldByName(name)
+			if switchActive(rv, parseTag(fieldTag(rt, name))) {
+				lf.SetInt(int64(fv.Len()))
+			} else if fv.Len() != 1 {
+				// Scalar Variant values are described as arrays of one.
+				g.fillSlice(fv, 1, depth)
+			}
+		}
+	}
+}
+
+// fillExtensionObject fills eo with a random registered value, as it is
+// returned from the decoder, or with an opaque body.
+func (g ValueGen) fillExtensionObject(eo reflect.Value, depth int) {
+	if depth >= maxGenDepth || g.r.Intn(3) == 0 {
+		body := g.bytes()
+		eo.Set(reflect.ValueOf(uatype.ExtensionObject{
+			Encoding:   2,
+			BodyLength: int32(len(body)),
+			Body:       body,
+		}))
+		return
+	}
+	rt := g.types[g.r.Intn(len(g.types))]
+	rv := reflect.New(rt).Elem()
+	g.fill(rv, depth+1)
+	v := rv.Interface()
+	id, _ := uatype.ExtensionObjectEncodingID(v)
+	body, err := binary.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+	eo.Set(reflect.ValueOf(uatype.ExtensionObject{
+		TypeId:     id.Expanded(),
+		Encoding:   1,
+		BodyLength: int32(len(body)),
+		Body:       append([]byte{}, body...),
+		Value:      v,
+	}))
+}
+
+func (g ValueGen) bytes() []byte {
+	b := make([]byte, g.r.Intn(8))
+	for i := range b {
+		b[i] = byte('a' + g.r.Intn(26))
+	}
+	return b
+}
+
+func fieldTag(rt reflect.Type, name string) string {
+	f, _ := rt.FieldByName(name)
+	return f.Tag.Get("opcua")
+}
+
+func parseTag(tag string) map[string]string {
+	m := make(map[string]string)
+	for _, s := range strings.Split(tag, ",") {
+		if kv := strings.SplitN(s, "=", 2); len(kv) == 2 {
+			m[kv[0]] = kv[1]
+		}
+	}
+	return m
+}
+
+// switchActive returns true if a field with the parsed tag is encoded for the
+// struct value rv.
+func switchActive(rv reflect.Value, tag map[string]string) bool {
+	name, ok := tag["switchField"]
+	if !ok {
+		return true
+	}
+	sv := rv.FieldByName(name)
+	value, ok := tag["switchValue"]
+	if !ok {
+		return sv.Bool()
+	}
+	var v int64
+	switch sv.Kind() {
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v = sv.Int()
+	default:
+		v = int64(sv.Uint())
+	}
+	want, _ := strconv.ParseInt(value, 10, 64)
+	switch tag["switchOperand"] {
+	case "GreaterThan":
+		return v > want
+	case "LessThan":
+		return v < want
+	case "GreaterThanOrEqual":
+		return v >= want
+	case "LessThanOrEqual":
+		return v <= want
+	case "NotEqual":
+		return v != want
+	}
+	return v == want
+}
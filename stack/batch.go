@@ -0,0 +1,741 @@
+package stack
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/searis/guma/stack/uatype"
+)
+
+// DefaultMaxConcurrentRequests is the number of requests sent concurrently by
+// batched service calls if Client.MaxConcurrentRequests is zero.
+const DefaultMaxConcurrentRequests = 4
+
+// OperationLimits holds the operation limits published by a server in the
+// Server/ServerCapabilities/OperationLimits object. Zero means no limit.
+type OperationLimits struct {
+	MaxNodesPerRead                          uint32
+	MaxNodesPerHistoryReadData               uint32
+	MaxNodesPerHistoryReadEvents             uint32
+	MaxNodesPerWrite                         uint32
+	MaxNodesPerHistoryUpdateData             uint32
+	MaxNodesPerHistoryUpdateEvents           uint32
+	MaxNodesPerMethodCall                    uint32
+	MaxNodesPerBrowse                        uint32
+	MaxNodesPerRegisterNodes                 uint32
+	MaxNodesPerTranslateBrowsePathsToNodeIds uint32
+	MaxNodesPerNodeManagement                uint32
+	MaxMonitoredItemsPerCall                 uint32
+}
+
+// operationLimits caches the operation limits of the server for the session
+// identified by token.
+type operationLimits struct {
+	sync.Mutex
+	token  uatype.NodeKey
+	read   bool
+	limits OperationLimits
+}
+
+// OperationLimits returns the operation limits of the server. The limits are
+// read once per session; they are read again if AuthenticationToken changes.
+// Limits the server does not publish are returned as zero.
+func (c *Client) OperationLimits(deadline time.Time) (OperationLimits, error) {
+	c.limits.Lock()
+	defer c.limits.Unlock()
+	token := c.AuthenticationToken().Key()
+	if c.limits.read && c.limits.token == token {
+		return c.limits.limits, nil
+	}
+
+	var limits OperationLimits
+	props := []struct {
+		id    uint16
+		value *uint32
+	}{
+		{uatype.NodeIdServer_ServerCapabilities_OperationLimits_MaxNodesPerRead, &limits.MaxNodesPerRead},
+		{uatype.NodeIdServer_ServerCapabilities_OperationLimits_MaxNodesPerHistoryReadData, &limits.MaxNodesPerHistoryReadData},
+		{uatype.NodeIdServer_ServerCapabilities_OperationLimits_MaxNodesPerHistoryReadEvents, &limits.MaxNodesPerHistoryReadEvents},
+		{uatype.NodeIdServer_ServerCapabilities_OperationLimits_MaxNodesPerWrite, &limits.MaxNodesPerWrite},
+		{uatype.NodeIdServer_ServerCapabilities_OperationLimits_MaxNodesPerHistoryUpdateData, &limits.MaxNodesPerHistoryUpdateData},
+		{uatype.NodeIdServer_ServerCapabilities_OperationLimits_MaxNodesPerHistoryUpdateEvents, &limits.MaxNodesPerHistoryUpdateEvents},
+		{uatype.NodeIdServer_ServerCapabilities_OperationLimits_MaxNodesPerMethodCall, &limits.MaxNodesPerMethodCall},
+		{uatype.NodeIdServer_ServerCapabilities_OperationLimits_MaxNodesPerBrowse, &limits.MaxNodesPerBrowse},
+		{uatype.NodeIdServer_ServerCapabilities_OperationLimits_MaxNodesPerRegisterNodes, &limits.MaxNodesPerRegisterNodes},
+		{uatype.NodeIdServer_ServerCapabilities_OperationLimits_MaxNodesPerTranslateBrowsePathsToNodeIds, &limits.MaxNodesPerTranslateBrowsePathsToNodeIds},
+		{uatype.NodeIdServer_ServerCapabilities_OperationLimits_MaxNodesPerNodeManagement, &limits.MaxNodesPerNodeManagement},
+		{uatype.NodeIdServer_ServerCapabilities_OperationLimits_MaxMonitoredItemsPerCall, &limits.MaxMonitoredItemsPerCall},
+	}
+	ids := make([]uatype.ReadValueId, len(props))
+	for i, p := range props {
+		ids[i] = uatype.ReadValueId{
+			NodeId:      uatype.NewFourByteNodeID(0, p.id),
+			AttributeId: uint32(uatype.AttrTypeValue),
+		}
+	}
+
+	// The limits are read without batching, as batching depends on them.
+	resp, err := c.Read(uatype.ReadRequest{
+		RequestHeader:      c.requestHeader(),
+		TimestampsToReturn: uatype.TimestampsToReturnNeither,
+		NoOfNodesToRead:    int32(len(ids)),
+		NodesToRead:        ids,
+	}, deadline)
+	if err != nil {
+		return limits, err
+	}
+	if len(resp.Results) != len(ids) {
+		return limits, uatype.StatusBadUnexpectedError
+	}
+	for i, dv := range resp.Results {
+		// Limits the server does not publish return a Bad status, and are
+		// left as zero.
+		if v, err := dv.Value.Value(); err == nil {
+			if n, ok := v.(uint32); ok {
+				*props[i].value = n
+			}
+		}
+	}
+
+	c.limits.token = token
+	c.limits.read = true
+	c.limits.limits = limits
+	return limits, nil
+}
+
+// BatchError is returned by batched service calls such as ReadBatched when
+// some, but not all, of the requests they are split into fail. The response
+// returned with it holds the results of the requests that succeeded. Results
+// of the operations of failed requests are left as zero values, except for
+// their status code, which is set to the status of the error.
+type BatchError struct {
+	// Requests holds the failed requests in the order of their operations.
+	Requests []BatchRequestError
+}
+
+// BatchRequestError is the error of one of the requests of a batched service
+// call.
+type BatchRequestError struct {
+	// Start and End is the range [Start, End) of operations of the request.
+	Start, End int
+
+	// Err is the error of the request, the service result of its response
+	// header if Bad, or StatusBadOperationAbandoned if the request was not
+	// sent because an earlier request failed.
+	Err error
+}
+
+// Error returns a human readable description of the error.
+func (err BatchError) Error() string {
+	msgs := make([]string, len(err.Requests))
+	for i, r := range err.Requests {
+		msgs[i] = fmt.Sprintf("operations %d-%d: %s", r.Start, r.End-1, r.Err)
+	}
+	return fmt.Sprintf("%d of the requests failed: %s", len(err.Requests), strings.Join(msgs, "; "))
+}
+
+// batchPart is the response to one of the requests of a batched service call.
+type batchPart struct {
+	header      uatype.ResponseHeader
+	results     int // number of results
+	diagnostics []uatype.DiagnosticInfo
+}
+
+// batchResponse holds the combined response header and diagnostic infos of
+// the requests sent by a batched service call.
+type batchResponse struct {
+	header      uatype.ResponseHeader
+	diagnostics []uatype.DiagnosticInfo
+}
+
+// batched calls send for consecutive ranges [start, end) of n operations, with
+// at most limit operations in each range. send must send the request for the
+// operations with the request header h, and copy its results to [start, end)
+// of results, which is a slice of n results, or nil if the service has none.
+//
+// If limit is zero or not exceeded, send is called once with header, and its
+// error is returned as is. Otherwise each request gets its own request handle,
+// up to MaxConcurrentRequests requests are sent concurrently, and no more
+// requests are sent after one fails. The response header of the first
+// successful request is returned. If only some requests fail, a BatchError is
+// returned along with the response.
+func (c *Client) batched(header uatype.RequestHeader, n int, limit uint32, results interface{}, send func(h uatype.RequestHeader, start, end int) (batchPart, error)) (*batchResponse, error) {
+	if limit == 0 || n <= int(limit) {
+		p, err := batchSend(send, header, 0, n)
+		if err != nil {
+			return nil, err
+		}
+		b := &batchResponse{header: p.header}
+		if len(p.diagnostics) == n {
+			b.diagnostics = p.diagnostics
+		}
+		return b, nil
+	}
+	size := int(limit)
+	parallel := c.MaxConcurrentRequests
+	if parallel <= 0 {
+		parallel = DefaultMaxConcurrentRequests
+	}
+
+	parts := make([]batchPart, (n+size-1)/size)
+	errs := make([]error, len(parts))
+	var failed int32
+	sem := make(chan struct{}, parallel)
+	var wg sync.WaitGroup
+	for i := range parts {
+		start, end := batchRange(i, size, n)
+		sem <- struct{}{}
+		if atomic.LoadInt32(&failed) != 0 {
+			<-sem
+			errs[i] = uatype.StatusBadOperationAbandoned
+			continue
+		}
+		h := header
+		h.RequestHandle = c.nextRequestHandle()
+		wg.Add(1)
+		go func(i, start, end int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			parts[i], errs[i] = batchSend(send, h, start, end)
+			if errs[i] != nil {
+				atomic.StoreInt32(&failed, 1)
+			}
+		}(i, start, end)
+	}
+	wg.Wait()
+
+	var b *batchResponse
+	var berr BatchError
+	for i, p := range parts {
+		start, end := batchRange(i, size, n)
+		if errs[i] != nil {
+			berr.Requests = append(berr.Requests, BatchRequestError{Start: start, End: end, Err: errs[i]})
+			setBatchStatus(results, start, end, errorStatus(errs[i]))
+			continue
+		}
+		if b == nil {
+			b = &batchResponse{header: p.header}
+		}
+		if len(p.diagnostics) == end-start {
+			if b.diagnostics == nil {
+				b.diagnostics = make([]uatype.DiagnosticInfo, n)
+			}
+			copy(b.diagnostics[start:end], p.diagnostics)
+		}
+	}
+	if b == nil {
+		return nil, errs[0]
+	}
+	if len(berr.Requests) > 0 {
+		return b, berr
+	}
+	return b, nil
+}
+
+// batchRange returns the range of operations of request i of a batched
+// service call of n operations split into requests of size operations.
+func batchRange(i, size, n int) (start, end int) {
+	start, end = i*size, (i+1)*size
+	if end > n {
+		end = n
+	}
+	return start, end
+}
+
+// batchSend calls send, and checks the service result and number of results
+// of the response.
+func batchSend(send func(h uatype.RequestHeader, start, end int) (batchPart, error), h uatype.RequestHeader, start, end int) (batchPart, error) {
+	p, err := send(h, start, end)
+	switch {
+	case err != nil:
+		return p, err
+	case p.header.ServiceResult.IsBad():
+		return p, p.header.ServiceResult
+	case p.results != end-start:
+		return p, uatype.StatusBadUnexpectedError
+	}
+	return p, nil
+}
+
+// errorStatus returns the status code describing err.
+func errorStatus(err error) uatype.StatusCode {
+	switch e := err.(type) {
+	case uatype.StatusCode:
+		return e
+	case uatype.ServiceFault:
+		return e.ResponseHeader.ServiceResult
+	}
+	return uatype.StatusBadCommunicationError
+}
+
+// setBatchStatus sets the status code of results [start, end) to code if the
+// results are status codes, or structures with a StatusCode field.
+func setBatchStatus(results interface{}, start, end int, code uatype.StatusCode) {
+	if results == nil {
+		return
+	}
+	rv := reflect.ValueOf(results)
+	for i := start; i < end; i++ {
+		switch v := rv.Index(i); {
+		case v.Type() == reflect.TypeOf(code):
+			v.Set(reflect.ValueOf(code))
+		case v.Kind() == reflect.Struct:
+			if f := v.FieldByName("StatusCode"); f.IsValid() && f.Type() == reflect.TypeOf(code) {
+				f.Set(reflect.ValueOf(code))
+			}
+		}
+	}
+}
+
+// ReadBatched sends req as one or more Read requests, with at most
+// MaxNodesPerRead nodes each, and returns the combined response. Results are
+// returned in the order of req.NodesToRead. See Client.OperationLimits.
+func (c *Client) ReadBatched(req uatype.ReadRequest, deadline time.Time) (*uatype.ReadResponse, error) {
+	limits, err := c.OperationLimits(deadline)
+	if err != nil {
+		return nil, err
+	}
+	nodes := req.NodesToRead
+	results := make([]uatype.DataValue, len(nodes))
+	b, err := c.batched(req.RequestHeader, len(nodes), limits.MaxNodesPerRead, results, func(h uatype.RequestHeader, start, end int) (batchPart, error) {
+		r := req
+		r.RequestHeader = h
+		r.NoOfNodesToRead, r.NodesToRead = int32(end-start), nodes[start:end]
+		resp, err := c.Read(r, deadline)
+		if err != nil {
+			return batchPart{}, err
+		}
+		copy(results[start:end], resp.Results)
+		return batchPart{resp.ResponseHeader, len(resp.Results), resp.DiagnosticInfos}, nil
+	})
+	if b == nil {
+		return nil, err
+	}
+	return &uatype.ReadResponse{
+		ResponseHeader:      b.header,
+		NoOfResults:         int32(len(results)),
+		Results:             results,
+		NoOfDiagnosticInfos: int32(len(b.diagnostics)),
+		DiagnosticInfos:     b.diagnostics,
+	}, err
+}
+
+// WriteBatched sends req as one or more Write requests, with at most
+// MaxNodesPerWrite nodes each, and returns the combined response. Results are
+// returned in the order of req.NodesToWrite. See Client.OperationLimits.
+func (c *Client) WriteBatched(req uatype.WriteRequest, deadline time.Time) (*uatype.WriteResponse, error) {
+	limits, err := c.OperationLimits(deadline)
+	if err != nil {
+		return nil, err
+	}
+	nodes := req.NodesToWrite
+	results := make([]uatype.StatusCode, len(nodes))
+	b, err := c.batched(req.RequestHeader, len(nodes), limits.MaxNodesPerWrite, results, func(h uatype.RequestHeader, start, end int) (batchPart, error) {
+		r := req
+		r.RequestHeader = h
+		r.NoOfNodesToWrite, r.NodesToWrite = int32(end-start), nodes[start:end]
+		resp, err := c.Write(r, deadline)
+		if err != nil {
+			return batchPart{}, err
+		}
+		copy(results[start:end], resp.Results)
+		return batchPart{resp.ResponseHeader, len(resp.Results), resp.DiagnosticInfos}, nil
+	})
+	if b == nil {
+		return nil, err
+	}
+	return &uatype.WriteResponse{
+		ResponseHeader:      b.header,
+		NoOfResults:         int32(len(results)),
+		Results:             results,
+		NoOfDiagnosticInfos: int32(len(b.diagnostics)),
+		DiagnosticInfos:     b.diagnostics,
+	}, err
+}
+
+// BrowseBatched sends req as one or more Browse requests, with at most
+// MaxNodesPerBrowse nodes each, and returns the combined response. Results
+// are returned in the order of req.NodesToBrowse. Continuation points are not
+// followed. See Client.OperationLimits.
+func (c *Client) BrowseBatched(req uatype.BrowseRequest, deadline time.Time) (*uatype.BrowseResponse, error) {
+	limits, err := c.OperationLimits(deadline)
+	if err != nil {
+		return nil, err
+	}
+	nodes := req.NodesToBrowse
+	results := make([]uatype.BrowseResult, len(nodes))
+	b, err := c.batched(req.RequestHeader, len(nodes), limits.MaxNodesPerBrowse, results, func(h uatype.RequestHeader, start, end int) (batchPart, error) {
+		r := req
+		r.RequestHeader = h
+		r.NoOfNodesToBrowse, r.NodesToBrowse = int32(end-start), nodes[start:end]
+		resp, err := c.Browse(r, deadline)
+		if err != nil {
+			return batchPart{}, err
+		}
+		copy(results[start:end], resp.Results)
+		return batchPart{resp.ResponseHeader, len(resp.Results), resp.DiagnosticInfos}, nil
+	})
+	if b == nil {
+		return nil, err
+	}
+	return &uatype.BrowseResponse{
+		ResponseHeader:      b.header,
+		NoOfResults:         int32(len(results)),
+		Results:             results,
+		NoOfDiagnosticInfos: int32(len(b.diagnostics)),
+		DiagnosticInfos:     b.diagnostics,
+	}, err
+}
+
+// TranslateBrowsePathsToNodeIdsBatched sends req as one or more
+// TranslateBrowsePathsToNodeIds requests, with at most
+// MaxNodesPerTranslateBrowsePathsToNodeIds browse paths each, and returns the
+// combined response. Results are returned in the order of req.BrowsePaths. See
+// Client.OperationLimits.
+func (c *Client) TranslateBrowsePathsToNodeIdsBatched(req uatype.TranslateBrowsePathsToNodeIdsRequest, deadline time.Time) (*uatype.TranslateBrowsePathsToNodeIdsResponse, error) {
+	limits, err := c.OperationLimits(deadline)
+	if err != nil {
+		return nil, err
+	}
+	paths := req.BrowsePaths
+	results := make([]uatype.BrowsePathResult, len(paths))
+	b, err := c.batched(req.RequestHeader, len(paths), limits.MaxNodesPerTranslateBrowsePathsToNodeIds, results, func(h uatype.RequestHeader, start, end int) (batchPart, error) {
+		r := req
+		r.RequestHeader = h
+		r.NoOfBrowsePaths, r.BrowsePaths = int32(end-start), paths[start:end]
+		resp, err := c.TranslateBrowsePathsToNodeIds(r, deadline)
+		if err != nil {
+			return batchPart{}, err
+		}
+		copy(results[start:end], resp.Results)
+		return batchPart{resp.ResponseHeader, len(resp.Results), resp.DiagnosticInfos}, nil
+	})
+	if b == nil {
+		return nil, err
+	}
+	return &uatype.TranslateBrowsePathsToNodeIdsResponse{
+		ResponseHeader:      b.header,
+		NoOfResults:         int32(len(results)),
+		Results:             results,
+		NoOfDiagnosticInfos: int32(len(b.diagnostics)),
+		DiagnosticInfos:     b.diagnostics,
+	}, err
+}
+
+// CallBatched sends req as one or more Call requests, with at most
+// MaxNodesPerMethodCall methods each, and returns the combined response.
+// Results are returned in the order of req.MethodsToCall. See
+// Client.OperationLimits.
+func (c *Client) CallBatched(req uatype.CallRequest, deadline time.Time) (*uatype.CallResponse, error) {
+	limits, err := c.OperationLimits(deadline)
+	if err != nil {
+		return nil, err
+	}
+	methods := req.MethodsToCall
+	results := make([]uatype.CallMethodResult, len(methods))
+	b, err := c.batched(req.RequestHeader, len(methods), limits.MaxNodesPerMethodCall, results, func(h uatype.RequestHeader, start, end int) (batchPart, error) {
+		r := req
+		r.RequestHeader = h
+		r.NoOfMethodsToCall, r.MethodsToCall = int32(end-start), methods[start:end]
+		resp, err := c.Call(r, deadline)
+		if err != nil {
+			return batchPart{}, err
+		}
+		copy(results[start:end], resp.Results)
+		return batchPart{resp.ResponseHeader, len(resp.Results), resp.DiagnosticInfos}, nil
+	})
+	if b == nil {
+		return nil, err
+	}
+	return &uatype.CallResponse{
+		ResponseHeader:      b.header,
+		NoOfResults:         int32(len(results)),
+		Results:             results,
+		NoOfDiagnosticInfos: int32(len(b.diagnostics)),
+		DiagnosticInfos:     b.diagnostics,
+	}, err
+}
+
+// RegisterNodesBatched sends req as one or more RegisterNodes requests, with
+// at most MaxNodesPerRegisterNodes nodes each, and returns the combined
+// response. Registered node IDs are returned in the order of
+// req.NodesToRegister. See Client.OperationLimits.
+func (c *Client) RegisterNodesBatched(req uatype.RegisterNodesRequest, deadline time.Time) (*uatype.RegisterNodesResponse, error) {
+	limits, err := c.OperationLimits(deadline)
+	if err != nil {
+		return nil, err
+	}
+	nodes := req.NodesToRegister
+	results := make([]uatype.NodeId, len(nodes))
+	b, err := c.batched(req.RequestHeader, len(nodes), limits.MaxNodesPerRegisterNodes, results, func(h uatype.RequestHeader, start, end int) (batchPart, error) {
+		r := req
+		r.RequestHeader = h
+		r.NoOfNodesToRegister, r.NodesToRegister = int32(end-start), nodes[start:end]
+		resp, err := c.RegisterNodes(r, deadline)
+		if err != nil {
+			return batchPart{}, err
+		}
+		copy(results[start:end], resp.RegisteredNodeIds)
+		return batchPart{resp.ResponseHeader, len(resp.RegisteredNodeIds), nil}, nil
+	})
+	if b == nil {
+		return nil, err
+	}
+	return &uatype.RegisterNodesResponse{
+		ResponseHeader:        b.header,
+		NoOfRegisteredNodeIds: int32(len(results)),
+		RegisteredNodeIds:     results,
+	}, err
+}
+
+// UnregisterNodesBatched sends req as one or more UnregisterNodes requests,
+// with at most MaxNodesPerRegisterNodes nodes each. See
+// Client.OperationLimits.
+func (c *Client) UnregisterNodesBatched(req uatype.UnregisterNodesRequest, deadline time.Time) (*uatype.UnregisterNodesResponse, error) {
+	limits, err := c.OperationLimits(deadline)
+	if err != nil {
+		return nil, err
+	}
+	nodes := req.NodesToUnregister
+	b, err := c.batched(req.RequestHeader, len(nodes), limits.MaxNodesPerRegisterNodes, nil, func(h uatype.RequestHeader, start, end int) (batchPart, error) {
+		r := req
+		r.RequestHeader = h
+		r.NoOfNodesToUnregister, r.NodesToUnregister = int32(end-start), nodes[start:end]
+		resp, err := c.UnregisterNodes(r, deadline)
+		if err != nil {
+			return batchPart{}, err
+		}
+		// UnregisterNodes has no results.
+		return batchPart{resp.ResponseHeader, end - start, nil}, nil
+	})
+	if b == nil {
+		return nil, err
+	}
+	return &uatype.UnregisterNodesResponse{ResponseHeader: b.header}, err
+}
+
+// CreateMonitoredItemsBatched sends req as one or more CreateMonitoredItems
+// requests, with at most MaxMonitoredItemsPerCall items each, and returns the
+// combined response. Results are returned in the order of req.ItemsToCreate.
+// See Client.OperationLimits.
+func (c *Client) CreateMonitoredItemsBatched(req uatype.CreateMonitoredItemsRequest, deadline time.Time) (*uatype.CreateMonitoredItemsResponse, error) {
+	limits, err := c.OperationLimits(deadline)
+	if err != nil {
+		return nil, err
+	}
+	items := req.ItemsToCreate
+	results := make([]uatype.MonitoredItemCreateResult, len(items))
+	b, err := c.batched(req.RequestHeader, len(items), limits.MaxMonitoredItemsPerCall, results, func(h uatype.RequestHeader, start, end int) (batchPart, error) {
+		r := req
+		r.RequestHeader = h
+		r.NoOfItemsToCreate, r.ItemsToCreate = int32(end-start), items[start:end]
+		resp, err := c.CreateMonitoredItems(r, deadline)
+		if err != nil {
+			return batchPart{}, err
+		}
+		copy(results[start:end], resp.Results)
+		return batchPart{resp.ResponseHeader, len(resp.Results), resp.DiagnosticInfos}, nil
+	})
+	if b == nil {
+		return nil, err
+	}
+	return &uatype.CreateMonitoredItemsResponse{
+		ResponseHeader:      b.header,
+		NoOfResults:         int32(len(results)),
+		Results:             results,
+		NoOfDiagnosticInfos: int32(len(b.diagnostics)),
+		DiagnosticInfos:     b.diagnostics,
+	}, err
+}
+
+// ModifyMonitoredItemsBatched sends req as one or more ModifyMonitoredItems
+// requests, with at most MaxMonitoredItemsPerCall items each, and returns the
+// combined response. Results are returned in the order of req.ItemsToModify.
+// See Client.OperationLimits.
+func (c *Client) ModifyMonitoredItemsBatched(req uatype.ModifyMonitoredItemsRequest, deadline time.Time) (*uatype.ModifyMonitoredItemsResponse, error) {
+	limits, err := c.OperationLimits(deadline)
+	if err != nil {
+		return nil, err
+	}
+	items := req.ItemsToModify
+	results := make([]uatype.MonitoredItemModifyResult, len(items))
+	b, err := c.batched(req.RequestHeader, len(items), limits.MaxMonitoredItemsPerCall, results, func(h uatype.RequestHeader, start, end int) (batchPart, error) {
+		r := req
+		r.RequestHeader = h
+		r.NoOfItemsToModify, r.ItemsToModify = int32(end-start), items[start:end]
+		resp, err := c.ModifyMonitoredItems(r, deadline)
+		if err != nil {
+			return batchPart{}, err
+		}
+		copy(results[start:end], resp.Results)
+		return batchPart{resp.ResponseHeader, len(resp.Results), resp.DiagnosticInfos}, nil
+	})
+	if b == nil {
+		return nil, err
+	}
+	return &uatype.ModifyMonitoredItemsResponse{
+		ResponseHeader:      b.header,
+		NoOfResults:         int32(len(results)),
+		Results:             results,
+		NoOfDiagnosticInfos: int32(len(b.diagnostics)),
+		DiagnosticInfos:     b.diagnostics,
+	}, err
+}
+
+// SetMonitoringModeBatched sends req as one or more SetMonitoringMode
+// requests, with at most MaxMonitoredItemsPerCall items each, and returns the
+// combined response. Results are returned in the order of
+// req.MonitoredItemIds. See Client.OperationLimits.
+func (c *Client) SetMonitoringModeBatched(req uatype.SetMonitoringModeRequest, deadline time.Time) (*uatype.SetMonitoringModeResponse, error) {
+	limits, err := c.OperationLimits(deadline)
+	if err != nil {
+		return nil, err
+	}
+	ids := req.MonitoredItemIds
+	results := make([]uatype.StatusCode, len(ids))
+	b, err := c.batched(req.RequestHeader, len(ids), limits.MaxMonitoredItemsPerCall, results, func(h uatype.RequestHeader, start, end int) (batchPart, error) {
+		r := req
+		r.RequestHeader = h
+		r.NoOfMonitoredItemIds, r.MonitoredItemIds = int32(end-start), ids[start:end]
+		resp, err := c.SetMonitoringMode(r, deadline)
+		if err != nil {
+			return batchPart{}, err
+		}
+		copy(results[start:end], resp.Results)
+		return batchPart{resp.ResponseHeader, len(resp.Results), resp.DiagnosticInfos}, nil
+	})
+	if b == nil {
+		return nil, err
+	}
+	return &uatype.SetMonitoringModeResponse{
+		ResponseHeader:      b.header,
+		NoOfResults:         int32(len(results)),
+		Results:             results,
+		NoOfDiagnosticInfos: int32(len(b.diagnostics)),
+		DiagnosticInfos:     b.diagnostics,
+	}, err
+}
+
+// DeleteMonitoredItemsBatched sends req as one or more DeleteMonitoredItems
+// requests, with at most MaxMonitoredItemsPerCall items each, and returns the
+// combined response. Results are returned in the order of
+// req.MonitoredItemIds. See Client.OperationLimits.
+func (c *Client) DeleteMonitoredItemsBatched(req uatype.DeleteMonitoredItemsRequest, deadline time.Time) (*uatype.DeleteMonitoredItemsResponse, error) {
+	limits, err := c.OperationLimits(deadline)
+	if err != nil {
+		return nil, err
+	}
+	ids := req.MonitoredItemIds
+	results := make([]uatype.StatusCode, len(ids))
+	b, err := c.batched(req.RequestHeader, len(ids), limits.MaxMonitoredItemsPerCall, results, func(h uatype.RequestHeader, start, end int) (batchPart, error) {
+		r := req
+		r.RequestHeader = h
+		r.NoOfMonitoredItemIds, r.MonitoredItemIds = int32(end-start), ids[start:end]
+		resp, err := c.DeleteMonitoredItems(r, deadline)
+		if err != nil {
+			return batchPart{}, err
+		}
+		copy(results[start:end], resp.Results)
+		return batchPart{resp.ResponseHeader, len(resp.Results), resp.DiagnosticInfos}, nil
+	})
+	if b == nil {
+		return nil, err
+	}
+	return &uatype.DeleteMonitoredItemsResponse{
+		ResponseHeader:      b.header,
+		NoOfResults:         int32(len(results)),
+		Results:             results,
+		NoOfDiagnosticInfos: int32(len(b.diagnostics)),
+		DiagnosticInfos:     b.diagnostics,
+	}, err
+}
+
+// HistoryReadBatched sends req as one or more HistoryRead requests, with at
+// most MaxNodesPerHistoryReadEvents nodes each when reading events, or
+// MaxNodesPerHistoryReadData nodes each otherwise, and returns the combined
+// response. Results are returned in the order of req.NodesToRead. See
+// Client.OperationLimits.
+func (c *Client) HistoryReadBatched(req uatype.HistoryReadRequest, deadline time.Time) (*uatype.HistoryReadResponse, error) {
+	limits, err := c.OperationLimits(deadline)
+	if err != nil {
+		return nil, err
+	}
+	limit := limits.MaxNodesPerHistoryReadData
+	switch req.HistoryReadDetails.Value.(type) {
+	case uatype.ReadEventDetails, *uatype.ReadEventDetails:
+		limit = limits.MaxNodesPerHistoryReadEvents
+	}
+	nodes := req.NodesToRead
+	results := make([]uatype.HistoryReadResult, len(nodes))
+	b, err := c.batched(req.RequestHeader, len(nodes), limit, results, func(h uatype.RequestHeader, start, end int) (batchPart, error) {
+		r := req
+		r.RequestHeader = h
+		r.NoOfNodesToRead, r.NodesToRead = int32(end-start), nodes[start:end]
+		resp, err := c.HistoryRead(r, deadline)
+		if err != nil {
+			return batchPart{}, err
+		}
+		copy(results[start:end], resp.Results)
+		return batchPart{resp.ResponseHeader, len(resp.Results), resp.DiagnosticInfos}, nil
+	})
+	if b == nil {
+		return nil, err
+	}
+	return &uatype.HistoryReadResponse{
+		ResponseHeader:      b.header,
+		NoOfResults:         int32(len(results)),
+		Results:             results,
+		NoOfDiagnosticInfos: int32(len(b.diagnostics)),
+		DiagnosticInfos:     b.diagnostics,
+	}, err
+}
+
+// HistoryUpdateBatched sends req as one or more HistoryUpdate requests, with
+// at most MaxNodesPerHistoryUpdateData details each, or the lower of it and
+// MaxNodesPerHistoryUpdateEvents if any details update or delete events, and
+// returns the combined response. Results are returned in the order of
+// req.HistoryUpdateDetails. See Client.OperationLimits.
+func (c *Client) HistoryUpdateBatched(req uatype.HistoryUpdateRequest, deadline time.Time) (*uatype.HistoryUpdateResponse, error) {
+	limits, err := c.OperationLimits(deadline)
+	if err != nil {
+		return nil, err
+	}
+	limit := limits.MaxNodesPerHistoryUpdateData
+	for _, eo := range req.HistoryUpdateDetails {
+		switch eo.Value.(type) {
+		case uatype.UpdateEventDetails, uatype.DeleteEventDetails, *uatype.UpdateEventDetails, *uatype.DeleteEventDetails:
+			if n := limits.MaxNodesPerHistoryUpdateEvents; n > 0 && (limit == 0 || n < limit) {
+				limit = n
+			}
+		}
+	}
+	details := req.HistoryUpdateDetails
+	results := make([]uatype.HistoryUpdateResult, len(details))
+	b, err := c.batched(req.RequestHeader, len(details), limit, results, func(h uatype.RequestHeader, start, end int) (batchPart, error) {
+		r := req
+		r.RequestHeader = h
+		r.NoOfHistoryUpdateDetails, r.HistoryUpdateDetails = int32(end-start), details[start:end]
+		resp, err := c.HistoryUpdate(r, deadline)
+		if err != nil {
+			return batchPart{}, err
+		}
+		copy(results[start:end], resp.Results)
+		return batchPart{resp.ResponseHeader, len(resp.Results), resp.DiagnosticInfos}, nil
+	})
+	if b == nil {
+		return nil, err
+	}
+	return &uatype.HistoryUpdateResponse{
+		ResponseHeader:      b.header,
+		NoOfResults:         int32(len(results)),
+		Results:             results,
+		NoOfDiagnosticInfos: int32(len(b.diagnostics)),
+		DiagnosticInfos:     b.diagnostics,
+	}, err
+}
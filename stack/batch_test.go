@@ -0,0 +1,248 @@
+package stack_test
+
+import (
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/searis/guma/stack"
+	"github.com/searis/guma/stack/uatype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func uint32Value(t *testing.T, v uint32) uatype.DataValue {
+	variant, err := uatype.NewVariant(v)
+	require.NoError(t, err, "NewVariant")
+	return uatype.DataValue{ValueSpecified: true, Value: variant}
+}
+
+func TestReadBatched(t *testing.T) {
+	var m sync.Mutex
+	var sizes []int
+	limitReads := 0
+	c := &stack.Client{MaxConcurrentRequests: 2, Channel: fakeChannel{t: t, handle: func(req interface{}) interface{} {
+		r := req.(uatype.ReadRequest)
+		results := make([]uatype.DataValue, len(r.NodesToRead))
+		m.Lock()
+		if r.NodesToRead[0].NodeId.NamespaceIndex() == 0 {
+			// Operation limits; only MaxNodesPerRead is published.
+			limitReads++
+			for i := range results {
+				results[i].StatusCodeSpecified = true
+				results[i].StatusCode = uatype.StatusBadNodeIdUnknown
+			}
+			results[0] = uint32Value(t, 3)
+		} else {
+			sizes = append(sizes, len(r.NodesToRead))
+			for i, id := range r.NodesToRead {
+				results[i] = uint32Value(t, id.NodeId.Numeric.Identifier)
+			}
+		}
+		m.Unlock()
+		return uatype.ReadResponse{
+			ResponseHeader: responseHeader(),
+			NoOfResults:    int32(len(results)),
+			Results:        results,
+		}
+	}}}
+
+	limits, err := c.OperationLimits(time.Time{})
+	require.NoError(t, err, "OperationLimits")
+	assert.Equal(t, stack.OperationLimits{MaxNodesPerRead: 3}, limits)
+
+	var nodes []uatype.ReadValueId
+	for i := 0; i < 10; i++ {
+		nodes = append(nodes, uatype.ReadValueId{
+			NodeId:      uatype.NewNumericNodeID(2, uint32(i)),
+			AttributeId: uint32(uatype.AttrTypeValue),
+		})
+	}
+	resp, err := c.ReadBatched(uatype.ReadRequest{
+		NoOfNodesToRead: int32(len(nodes)),
+		NodesToRead:     nodes,
+	}, time.Time{})
+	require.NoError(t, err, "ReadBatched")
+	require.Len(t, resp.Results, len(nodes), "results")
+	for i, dv := range resp.Results {
+		v, err := dv.Value.Value()
+		require.NoError(t, err, "Value()")
+		assert.Equal(t, uint32(i), v, "result %d", i)
+	}
+	assert.Equal(t, 1, limitReads, "limit reads")
+	sort.Ints(sizes)
+	assert.Equal(t, []int{1, 3, 3, 3}, sizes, "request sizes")
+}
+
+// operationLimits returns the response to the read of the operation limits
+// in req, where only the limit at index i of stack.OperationLimits is
+// published as n.
+func operationLimits(t *testing.T, req uatype.ReadRequest, i int, n uint32) uatype.ReadResponse {
+	results := make([]uatype.DataValue, len(req.NodesToRead))
+	for i := range results {
+		results[i].StatusCodeSpecified = true
+		results[i].StatusCode = uatype.StatusBadNodeIdUnknown
+	}
+	results[i] = uint32Value(t, n)
+	return uatype.ReadResponse{
+		ResponseHeader: responseHeader(),
+		NoOfResults:    int32(len(results)),
+		Results:        results,
+	}
+}
+
+func TestWriteBatched(t *testing.T) {
+	var m sync.Mutex
+	var sizes []int
+	handles := make(map[uint32]bool)
+	c := &stack.Client{Channel: fakeChannel{t: t, handle: func(req interface{}) interface{} {
+		if r, ok := req.(uatype.ReadRequest); ok {
+			return operationLimits(t, r, 3, 4) // MaxNodesPerWrite
+		}
+		r := req.(uatype.WriteRequest)
+		m.Lock()
+		sizes = append(sizes, len(r.NodesToWrite))
+		handles[r.RequestHeader.RequestHandle] = true
+		m.Unlock()
+		results := make([]uatype.StatusCode, len(r.NodesToWrite))
+		for i, w := range r.NodesToWrite {
+			if w.NodeId.Numeric.Identifier%2 == 1 {
+				results[i] = uatype.StatusBadNotWritable
+			}
+		}
+		return uatype.WriteResponse{
+			ResponseHeader: responseHeader(),
+			NoOfResults:    int32(len(results)),
+			Results:        results,
+		}
+	}}}
+
+	var nodes []uatype.WriteValue
+	for i := 0; i < 10; i++ {
+		nodes = append(nodes, uatype.WriteValue{
+			NodeId:      uatype.NewNumericNodeID(2, uint32(i)),
+			AttributeId: uint32(uatype.AttrTypeValue),
+		})
+	}
+	resp, err := c.WriteBatched(uatype.WriteRequest{
+		NoOfNodesToWrite: int32(len(nodes)),
+		NodesToWrite:     nodes,
+	}, time.Time{})
+	require.NoError(t, err, "WriteBatched")
+	require.Len(t, resp.Results, len(nodes), "results")
+	for i, code := range resp.Results {
+		if i%2 == 1 {
+			assert.Equal(t, uatype.StatusBadNotWritable, code, "result %d", i)
+		} else {
+			assert.Equal(t, uatype.StatusCode(0), code, "result %d", i)
+		}
+	}
+	sort.Ints(sizes)
+	assert.Equal(t, []int{2, 4, 4}, sizes, "request sizes")
+	assert.Len(t, handles, 3, "request handles")
+	assert.False(t, handles[0], "zero request handle")
+}
+
+func TestCreateMonitoredItemsBatched(t *testing.T) {
+	var m sync.Mutex
+	var sizes []int
+	c := &stack.Client{Channel: fakeChannel{t: t, handle: func(req interface{}) interface{} {
+		if r, ok := req.(uatype.ReadRequest); ok {
+			return operationLimits(t, r, 11, 2) // MaxMonitoredItemsPerCall
+		}
+		r := req.(uatype.CreateMonitoredItemsRequest)
+		m.Lock()
+		sizes = append(sizes, len(r.ItemsToCreate))
+		m.Unlock()
+		assert.Equal(t, uint32(7), r.SubscriptionId, "subscription ID")
+		assert.Equal(t, uatype.TimestampsToReturnSource, r.TimestampsToReturn, "TimestampsToReturn")
+		results := make([]uatype.MonitoredItemCreateResult, len(r.ItemsToCreate))
+		for i, item := range r.ItemsToCreate {
+			results[i].MonitoredItemId = item.RequestedParameters.ClientHandle + 100
+		}
+		return uatype.CreateMonitoredItemsResponse{
+			ResponseHeader: responseHeader(),
+			NoOfResults:    int32(len(results)),
+			Results:        results,
+		}
+	}}}
+
+	var items []uatype.MonitoredItemCreateRequest
+	for i := 0; i < 5; i++ {
+		items = append(items, uatype.MonitoredItemCreateRequest{
+			ItemToMonitor:       uatype.ReadValueId{NodeId: uatype.NewNumericNodeID(2, uint32(i))},
+			RequestedParameters: uatype.MonitoringParameters{ClientHandle: uint32(i)},
+		})
+	}
+	resp, err := c.CreateMonitoredItemsBatched(uatype.CreateMonitoredItemsRequest{
+		SubscriptionId:     7,
+		TimestampsToReturn: uatype.TimestampsToReturnSource,
+		NoOfItemsToCreate:  int32(len(items)),
+		ItemsToCreate:      items,
+	}, time.Time{})
+	require.NoError(t, err, "CreateMonitoredItemsBatched")
+	require.Len(t, resp.Results, len(items), "results")
+	for i, r := range resp.Results {
+		assert.Equal(t, uint32(i+100), r.MonitoredItemId, "result %d", i)
+	}
+	sort.Ints(sizes)
+	assert.Equal(t, []int{1, 2, 2}, sizes, "request sizes")
+}
+
+func TestWriteBatchedError(t *testing.T) {
+	var requests []int
+	c := &stack.Client{MaxConcurrentRequests: 1, Channel: fakeChannel{t: t, handle: func(req interface{}) interface{} {
+		if r, ok := req.(uatype.ReadRequest); ok {
+			return operationLimits(t, r, 3, 2) // MaxNodesPerWrite
+		}
+		r := req.(uatype.WriteRequest)
+		first := int(r.NodesToWrite[0].NodeId.Numeric.Identifier)
+		requests = append(requests, first)
+		header := responseHeader()
+		header.RequestHandle = r.RequestHeader.RequestHandle
+		if first == 2 {
+			// The second request fails as a whole.
+			header.ServiceResult = uatype.StatusBadTooManyOperations
+			return uatype.WriteResponse{ResponseHeader: header}
+		}
+		return uatype.WriteResponse{
+			ResponseHeader: header,
+			NoOfResults:    int32(len(r.NodesToWrite)),
+			Results:        make([]uatype.StatusCode, len(r.NodesToWrite)),
+		}
+	}}}
+
+	var nodes []uatype.WriteValue
+	for i := 0; i < 7; i++ {
+		nodes = append(nodes, uatype.WriteValue{
+			NodeId:      uatype.NewNumericNodeID(2, uint32(i)),
+			AttributeId: uint32(uatype.AttrTypeValue),
+		})
+	}
+	resp, err := c.WriteBatched(uatype.WriteRequest{
+		NoOfNodesToWrite: int32(len(nodes)),
+		NodesToWrite:     nodes,
+	}, time.Time{})
+
+	// No requests are sent after the failed one, but the results of the
+	// first request are returned.
+	assert.Equal(t, []int{0, 2}, requests, "requests")
+	require.IsType(t, stack.BatchError{}, err, "error")
+	assert.Equal(t, []stack.BatchRequestError{
+		{Start: 2, End: 4, Err: uatype.StatusBadTooManyOperations},
+		{Start: 4, End: 6, Err: uatype.StatusBadOperationAbandoned},
+		{Start: 6, End: 7, Err: uatype.StatusBadOperationAbandoned},
+	}, err.(stack.BatchError).Requests, "failed requests")
+	require.NotNil(t, resp, "response")
+	assert.Equal(t, []uatype.StatusCode{
+		uatype.StatusCode(0),
+		uatype.StatusCode(0),
+		uatype.StatusBadTooManyOperations,
+		uatype.StatusBadTooManyOperations,
+		uatype.StatusBadOperationAbandoned,
+		uatype.StatusBadOperationAbandoned,
+		uatype.StatusBadOperationAbandoned,
+	}, resp.Results, "results")
+	assert.Equal(t, uatype.StatusCode(0), resp.ResponseHeader.ServiceResult, "service result")
+}
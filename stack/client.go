@@ -1,8 +1,180 @@
 package stack
 
-import "github.com/searis/guma/stack/transport"
+import (
+	"io"
+	"sync"
+	"time"
+
+	"github.com/searis/guma/stack/encoding/binary"
+	_ "github.com/searis/guma/stack/encoding/xml" // decodes XML bodies of ExtensionObjects
+	"github.com/searis/guma/stack/transport"
+	"github.com/searis/guma/stack/uatype"
+)
 
 // A Client is an OPCUA client.
 type Client struct {
 	Channel transport.SecureChannel
+
+	// MaxConcurrentRequests limits the number of requests sent concurrently
+	// when batched service calls such as ReadBatched are split according to
+	// the server's operation limits. If zero, DefaultMaxConcurrentRequests is
+	// used.
+	MaxConcurrentRequests int
+
+	// DecoderLimits limits the resources spent on decoding responses. If nil,
+	// limits are derived from the max message size negotiated for the
+	// channel through binary.DefaultDecoderLimits.
+	DecoderLimits *binary.DecoderLimits
+
+	// token is the authentication token of the session; see
+	// AuthenticationToken.
+	tokenM sync.RWMutex
+	token  uatype.NodeId
+
+	// types holds the ExtensionObject types of the server, as loaded by
+	// LoadTypeDictionaries or LoadDataTypeDefinitions.
+	typesM sync.RWMutex
+	types  *uatype.ExtensionObjectTypes
+
+	// requestHandle is the last request handle assigned by a context-aware
+	// service method.
+	requestHandle uint32
+
+	methods      methodCache
+	historyLimit historyLimit
+	limits       operationLimits
+	namespaces   namespaceTable
+}
+
+// AuthenticationToken returns the token used in the request header of
+// requests sent by helper methods such as LoadTypeDictionaries.
+func (c *Client) AuthenticationToken() uatype.NodeId {
+	c.tokenM.RLock()
+	defer c.tokenM.RUnlock()
+	return c.token
+}
+
+// SetAuthenticationToken sets the token used in the request header of
+// requests sent by helper methods. It should be set to the token returned by
+// CreateSession; OpenSession and Session.Restore set it. It's safe to call
+// SetAuthenticationToken while requests are sent.
+func (c *Client) SetAuthenticationToken(token uatype.NodeId) {
+	c.tokenM.Lock()
+	defer c.tokenM.Unlock()
+	c.token = token
+}
+
+// requestHeader returns a request header for helper methods.
+func (c *Client) requestHeader() uatype.RequestHeader {
+	return uatype.RequestHeader{
+		AuthenticationToken: c.AuthenticationToken(),
+		Timestamp:           time.Now(),
+	}
+}
+
+// extensionObjectTypes returns the ExtensionObject types of the server, or
+// nil if none are loaded.
+func (c *Client) extensionObjectTypes() *uatype.ExtensionObjectTypes {
+	c.typesM.RLock()
+	defer c.typesM.RUnlock()
+	return c.types
+}
+
+// setExtensionObjectTypes sets the ExtensionObject types used when encoding
+// requests and decoding responses.
+func (c *Client) setExtensionObjectTypes(types *uatype.ExtensionObjectTypes) {
+	c.typesM.Lock()
+	defer c.typesM.Unlock()
+	c.types = types
+}
+
+// newEncoder returns an encoder for a request body that writes to w, and
+// knows the ExtensionObject types of the server.
+func (c *Client) newEncoder(w io.Writer) *binary.Encoder {
+	enc := binary.NewEncoder(w)
+	enc.SetExtensionObjectTypes(c.extensionObjectTypes())
+	return enc
+}
+
+// newDecoder returns a decoder for the body of resp that applies the decoder
+// limits of c, and knows the ExtensionObject types of the server.
+func (c *Client) newDecoder(resp *transport.Response) *binary.Decoder {
+	dec := binary.NewDecoder(resp.Body)
+	if c.DecoderLimits != nil {
+		dec.SetLimits(*c.DecoderLimits)
+	} else {
+		dec.SetLimits(binary.DefaultDecoderLimits(int(resp.MaxMessageSize)))
+	}
+	dec.SetExtensionObjectTypes(c.extensionObjectTypes())
+	return dec
+}
+
+// browseAll browses the nodes described by nodes, following continuation
+// points until all references are returned. The result for each node is
+// returned in the same order as nodes.
+func (c *Client) browseAll(nodes []uatype.BrowseDescription, deadline time.Time) ([][]uatype.ReferenceDescription, error) {
+	resp, err := c.BrowseBatched(uatype.BrowseRequest{
+		RequestHeader:     c.requestHeader(),
+		NoOfNodesToBrowse: int32(len(nodes)),
+		NodesToBrowse:     nodes,
+	}, deadline)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Results) != len(nodes) {
+		return nil, uatype.StatusBadUnexpectedError
+	}
+
+	refs := make([][]uatype.ReferenceDescription, len(nodes))
+	for i, r := range resp.Results {
+		if r.StatusCode.IsBad() {
+			return nil, r.StatusCode
+		}
+		refs[i] = r.References
+		for cp := r.ContinuationPoint; len(cp) > 0; {
+			next, err := c.BrowseNext(uatype.BrowseNextRequest{
+				RequestHeader:          c.requestHeader(),
+				NoOfContinuationPoints: 1,
+				ContinuationPoints:     []uatype.ByteString{cp},
+			}, deadline)
+			if err != nil {
+				return nil, err
+			}
+			if len(next.Results) != 1 {
+				return nil, uatype.StatusBadUnexpectedError
+			}
+			if next.Results[0].StatusCode.IsBad() {
+				return nil, next.Results[0].StatusCode
+			}
+			refs[i] = append(refs[i], next.Results[0].References...)
+			cp = next.Results[0].ContinuationPoint
+		}
+	}
+	return refs, nil
+}
+
+// readValues reads the Value attribute of the given nodes.
+func (c *Client) readValues(nodes []uatype.NodeId, deadline time.Time) ([]uatype.DataValue, error) {
+	ids := make([]uatype.ReadValueId, len(nodes))
+	for i, n := range nodes {
+		ids[i] = uatype.ReadValueId{NodeId: n, AttributeId: uint32(uatype.AttrTypeValue)}
+	}
+	return c.read(ids, deadline)
+}
+
+// read reads the given attributes, and returns a result for each of them.
+func (c *Client) read(ids []uatype.ReadValueId, deadline time.Time) ([]uatype.DataValue, error) {
+	resp, err := c.ReadBatched(uatype.ReadRequest{
+		RequestHeader:      c.requestHeader(),
+		TimestampsToReturn: uatype.TimestampsToReturnNeither,
+		NoOfNodesToRead:    int32(len(ids)),
+		NodesToRead:        ids,
+	}, deadline)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Results) != len(ids) {
+		return nil, uatype.StatusBadUnexpectedError
+	}
+	return resp.Results, nil
 }
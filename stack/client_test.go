@@ -0,0 +1,152 @@
+package stack_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/searis/guma/stack"
+	"github.com/searis/guma/stack/encoding/binary"
+	"github.com/searis/guma/stack/uatype"
+	"github.com/searis/guma/stack/uatype/typedict"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientDecoderLimits(t *testing.T) {
+	handle := func(req interface{}) interface{} {
+		r := req.(uatype.ReadRequest)
+		results := make([]uatype.DataValue, len(r.NodesToRead))
+		return uatype.ReadResponse{
+			ResponseHeader: responseHeader(),
+			NoOfResults:    int32(len(results)),
+			Results:        results,
+		}
+	}
+	req := uatype.ReadRequest{
+		NoOfNodesToRead: 3,
+		NodesToRead:     make([]uatype.ReadValueId, 3),
+	}
+
+	cases := []struct {
+		Name           string
+		MaxMessageSize uint32
+		Limits         *binary.DecoderLimits
+		Error          string
+	}{
+		{Name: "Unlimited"},
+		{Name: "MaxMessageSize", MaxMessageSize: 64},
+		{
+			Name:           "MaxMessageSizeExceeded",
+			MaxMessageSize: 2,
+			Error:          "DecoderError .Results: decoder limit exceeded: array length 3 > 2",
+		},
+		{
+			Name:           "DecoderLimits",
+			MaxMessageSize: 64,
+			Limits:         &binary.DecoderLimits{MaxArrayLength: 1},
+			Error:          "DecoderError .Results: decoder limit exceeded: array length 3 > 1",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			c := &stack.Client{
+				Channel:       fakeChannel{t: t, handle: handle, maxMessageSize: tc.MaxMessageSize},
+				DecoderLimits: tc.Limits,
+			}
+			_, err := c.Read(req, time.Time{})
+			if tc.Error == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.Error)
+			}
+		})
+	}
+}
+
+const pointDict = `<opc:TypeDictionary xmlns:opc="http://opcfoundation.org/BinarySchema/" TargetNamespace="urn:vendor:types">
+  <opc:StructuredType Name="Point">
+    <opc:Field Name="x" TypeName="opc:Double" />
+    <opc:Field Name="y" TypeName="opc:Double" />
+  </opc:StructuredType>
+</opc:TypeDictionary>`
+
+func TestClientExtensionObjectTypes(t *testing.T) {
+	set := typedict.NewSet()
+	d, err := set.Parse(strings.NewReader(pointDict))
+	require.NoError(t, err, "set.Parse")
+	point, err := d.Type("Point")
+	require.NoError(t, err, `d.Type("Point")`)
+	point.Register(uatype.NewNumericNodeID(2, 5001))
+	body, err := point.Encode(map[string]interface{}{"x": 3, "y": 4})
+	require.NoError(t, err, "point.Encode")
+
+	value, err := uatype.NewVariant(uatype.ExtensionObject{
+		TypeId:     uatype.NewNumericNodeID(2, 5001).Expanded(),
+		Encoding:   1,
+		BodyLength: int32(len(body)),
+		Body:       body,
+	})
+	require.NoError(t, err, "uatype.NewVariant")
+	handle := func(req interface{}) interface{} {
+		switch r := req.(type) {
+		case uatype.BrowseRequest:
+			// No type dictionaries.
+			return uatype.BrowseResponse{
+				ResponseHeader: responseHeader(),
+				NoOfResults:    int32(len(r.NodesToBrowse)),
+				Results:        make([]uatype.BrowseResult, len(r.NodesToBrowse)),
+			}
+		case uatype.ReadRequest:
+			if r.NodesToRead[0].NodeId.NamespaceIndex() == 0 {
+				return operationLimits(t, r, 0, 0)
+			}
+			return uatype.ReadResponse{
+				ResponseHeader: responseHeader(),
+				NoOfResults:    1,
+				Results:        []uatype.DataValue{{ValueSpecified: true, Value: value}},
+			}
+		case uatype.WriteRequest:
+			eo := r.NodesToWrite[0].Value.Value.ExtensionObject[0]
+			assert.Equal(t, uint32(5001), eo.TypeId.Numeric.Identifier, "written TypeId")
+			assert.Equal(t, body, eo.Body, "written Body")
+			return uatype.WriteResponse{
+				ResponseHeader: responseHeader(),
+				NoOfResults:    1,
+				Results:        []uatype.StatusCode{0},
+			}
+		}
+		t.Fatalf("unexpected request %T", req)
+		return nil
+	}
+	read := uatype.ReadRequest{
+		NoOfNodesToRead: 1,
+		NodesToRead:     []uatype.ReadValueId{{NodeId: uatype.NewNumericNodeID(2, 1)}},
+	}
+
+	// Other clients don't decode the types of the set.
+	other := &stack.Client{Channel: fakeChannel{t: t, handle: handle}}
+	resp, err := other.Read(read, time.Time{})
+	require.NoError(t, err, "other.Read")
+	assert.Nil(t, resp.Results[0].Value.ExtensionObject[0].Value, "other.Read: Value")
+
+	c := &stack.Client{Channel: fakeChannel{t: t, handle: handle}}
+	require.NoError(t, c.LoadTypeDictionaries(set, time.Time{}), "LoadTypeDictionaries")
+	resp, err = c.Read(read, time.Time{})
+	require.NoError(t, err, "Read")
+	v := resp.Results[0].Value.ExtensionObject[0].Value
+	m, err := set.Map(v)
+	require.NoError(t, err, "set.Map")
+	assert.Equal(t, map[string]interface{}{"x": 3.0, "y": 4.0}, m, "Read: Value")
+
+	written, err := uatype.NewVariant(uatype.ExtensionObject{Value: v})
+	require.NoError(t, err, "uatype.NewVariant")
+	_, err = c.Write(uatype.WriteRequest{
+		NoOfNodesToWrite: 1,
+		NodesToWrite: []uatype.WriteValue{{
+			NodeId: uatype.NewNumericNodeID(2, 1),
+			Value:  uatype.DataValue{ValueSpecified: true, Value: written},
+		}},
+	}, time.Time{})
+	require.NoError(t, err, "Write")
+}
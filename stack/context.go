@@ -0,0 +1,113 @@
+package stack
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/searis/guma/stack/encoding/binary"
+	"github.com/searis/guma/stack/transport"
+	"github.com/searis/guma/stack/uatype"
+)
+
+// cancelTimeout limits the time spent sending a Cancel request for a request
+// whose context is done.
+const cancelTimeout = 5 * time.Second
+
+// contextHeader prepares h for a request sent with ctx. A request handle is
+// assigned if h has none, and the deadline of ctx, if any, is set as the
+// timeout hint.
+func (c *Client) contextHeader(ctx context.Context, h *uatype.RequestHeader) {
+	if h.RequestHandle == 0 {
+		h.RequestHandle = c.nextRequestHandle()
+	}
+	if h.Timestamp.IsZero() {
+		h.Timestamp = time.Now()
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		ms := time.Until(deadline) / time.Millisecond
+		switch {
+		case ms < 1:
+			// A timeout hint of zero means no timeout.
+			h.TimeoutHint = 1
+		case ms > 0xffffffff:
+			h.TimeoutHint = 0
+		default:
+			h.TimeoutHint = uint32(ms)
+		}
+	}
+}
+
+// nextRequestHandle returns a new request handle, which is never zero.
+func (c *Client) nextRequestHandle() uint32 {
+	for {
+		if handle := atomic.AddUint32(&c.requestHandle, 1); handle != 0 {
+			return handle
+		}
+	}
+}
+
+// sendContext sends r, the request with header h, and waits for a response or
+// for ctx to be done. If ctx is done first, a Cancel request for the request
+// is sent in the background. If
+// the channel doesn't implement transport.ContextSender, only the deadline of
+// ctx is used.
+func (c *Client) sendContext(ctx context.Context, h uatype.RequestHeader, r transport.Request) (*transport.Response, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	cs, ok := c.Channel.(transport.ContextSender)
+	if !ok {
+		deadline, _ := ctx.Deadline()
+		return c.Channel.Send(r, deadline)
+	}
+	resp, err := cs.SendContext(ctx, r)
+	if err != nil && ctx.Err() != nil {
+		go c.cancel(h)
+	}
+	return resp, err
+}
+
+// contextError returns ctx.Err() if err is caused by ctx being done while the
+// body of the response to the request with header h was read, and sends a
+// Cancel request for the request in the background. Otherwise err is
+// returned.
+func (c *Client) contextError(ctx context.Context, h uatype.RequestHeader, err error) error {
+	if ctx.Err() == nil || !localAbort(err) {
+		return err
+	}
+	go c.cancel(h)
+	return ctx.Err()
+}
+
+// localAbort returns true if err, or the cause of a DecoderError, is a local
+// transport error for a request that is canceled or timed out.
+func localAbort(err error) bool {
+	if e, ok := err.(binary.DecoderError); ok {
+		err = e.Cause()
+	}
+	e, ok := err.(*transport.Error)
+	if !ok || e.Origin() != "local" {
+		return false
+	}
+	switch e.StatusCode() {
+	case uatype.StatusBadRequestCancelledByClient, uatype.StatusBadTimeout:
+		return true
+	}
+	return false
+}
+
+// cancel asks the server to cancel outstanding requests with the handle of
+// h. The Cancel request is sent with the authentication token of h, as the
+// server only cancels requests of the same session.
+func (c *Client) cancel(h uatype.RequestHeader) {
+	// The request may already be completed or abandoned by the server, so
+	// the result is ignored.
+	c.Cancel(uatype.CancelRequest{
+		RequestHeader: uatype.RequestHeader{
+			AuthenticationToken: h.AuthenticationToken,
+			Timestamp:           time.Now(),
+		},
+		RequestHandle: h.RequestHandle,
+	}, time.Now().Add(cancelTimeout))
+}
@@ -0,0 +1,173 @@
+package stack_test
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/searis/guma/stack"
+	"github.com/searis/guma/stack/transport"
+	"github.com/searis/guma/stack/uatype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// contextChannel is a fakeChannel that implements transport.ContextSender.
+// Requests are passed to handle; a nil response blocks until ctx is done.
+type contextChannel struct {
+	fakeChannel
+}
+
+func (ch contextChannel) SendContext(ctx context.Context, req transport.Request) (*transport.Response, error) {
+	blocked := false
+	inner := ch.fakeChannel
+	inner.handle = func(req interface{}) interface{} {
+		if resp := ch.handle(req); resp != nil {
+			return resp
+		}
+		blocked = true
+		return uatype.ServiceFault{ResponseHeader: responseHeader()}
+	}
+	resp, err := inner.Send(req, time.Time{})
+	if blocked {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	return resp, err
+}
+
+func TestReadContextCancel(t *testing.T) {
+	reads := make(chan uatype.RequestHeader, 1)
+	cancels := make(chan uatype.CancelRequest, 1)
+	c := &stack.Client{Channel: contextChannel{fakeChannel{t: t, handle: func(req interface{}) interface{} {
+		switch r := req.(type) {
+		case uatype.ReadRequest:
+			reads <- r.RequestHeader
+			return nil
+		case uatype.CancelRequest:
+			cancels <- r
+			return uatype.CancelResponse{ResponseHeader: responseHeader()}
+		}
+		t.Fatalf("unexpected request %T", req)
+		return nil
+	}}}}
+	// The Cancel request is sent with the token of the canceled request,
+	// which may differ from the token of the client.
+	c.SetAuthenticationToken(uatype.NewNumericNodeID(1, 1))
+	token := uatype.NewNumericNodeID(1, 2)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	errc := make(chan error, 1)
+	go func() {
+		_, err := c.ReadContext(ctx, uatype.ReadRequest{
+			RequestHeader: uatype.RequestHeader{AuthenticationToken: token},
+		})
+		errc <- err
+	}()
+
+	header := <-reads
+	assert.NotZero(t, header.RequestHandle, "RequestHandle")
+	assert.InDelta(t, uint32(time.Minute/time.Millisecond), header.TimeoutHint, 5000, "TimeoutHint")
+	cancel()
+	require.Equal(t, context.Canceled, <-errc, "ReadContext error")
+
+	select {
+	case r := <-cancels:
+		assert.Equal(t, header.RequestHandle, r.RequestHandle, "canceled request handle")
+		assert.Equal(t, token, r.RequestHeader.AuthenticationToken, "Cancel AuthenticationToken")
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout waiting for Cancel request")
+	}
+}
+
+// stalledChannel is a fakeChannel that implements transport.ContextSender,
+// and returns responses with a body that stalls after half of the data.
+type stalledChannel struct {
+	fakeChannel
+}
+
+func (ch stalledChannel) SendContext(ctx context.Context, req transport.Request) (*transport.Response, error) {
+	resp, err := ch.Send(req, time.Time{})
+	if err != nil {
+		return nil, err
+	}
+	data, err := ioutil.ReadAll(resp.Body)
+	require.NoError(ch.t, err, "read body")
+	resp.Body = &stalledBody{ctx: ctx, data: data[:len(data)/2]}
+	return resp, nil
+}
+
+// stalledBody returns data, and then blocks until ctx is done, as the body of
+// a response that is canceled while it's received.
+type stalledBody struct {
+	ctx  context.Context
+	data []byte
+}
+
+func (b *stalledBody) Read(p []byte) (int, error) {
+	if len(b.data) > 0 {
+		n := copy(p, b.data)
+		b.data = b.data[n:]
+		return n, nil
+	}
+	<-b.ctx.Done()
+	return 0, transport.LocalError(uatype.StatusBadRequestCancelledByClient, errors.New("request canceled"))
+}
+
+func TestReadContextCancelBody(t *testing.T) {
+	reads := make(chan uatype.RequestHeader, 1)
+	cancels := make(chan uint32, 1)
+	c := &stack.Client{Channel: stalledChannel{fakeChannel{t: t, handle: func(req interface{}) interface{} {
+		switch r := req.(type) {
+		case uatype.ReadRequest:
+			reads <- r.RequestHeader
+			results := make([]uatype.DataValue, 10)
+			for i := range results {
+				results[i] = uint32Value(t, uint32(i))
+			}
+			return uatype.ReadResponse{
+				ResponseHeader: responseHeader(),
+				NoOfResults:    int32(len(results)),
+				Results:        results,
+			}
+		case uatype.CancelRequest:
+			cancels <- r.RequestHandle
+			return uatype.CancelResponse{ResponseHeader: responseHeader()}
+		}
+		t.Fatalf("unexpected request %T", req)
+		return nil
+	}}}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	errc := make(chan error, 1)
+	go func() {
+		_, err := c.ReadContext(ctx, uatype.ReadRequest{})
+		errc <- err
+	}()
+
+	header := <-reads
+	cancel()
+	require.Equal(t, context.Canceled, <-errc, "ReadContext error")
+
+	select {
+	case handle := <-cancels:
+		assert.Equal(t, header.RequestHandle, handle, "canceled request handle")
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout waiting for Cancel request")
+	}
+}
+
+func TestReadContextDone(t *testing.T) {
+	c := &stack.Client{Channel: fakeChannel{t: t, handle: func(req interface{}) interface{} {
+		t.Fatalf("unexpected request %T", req)
+		return nil
+	}}}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := c.ReadContext(ctx, uatype.ReadRequest{})
+	assert.Equal(t, context.Canceled, err, "ReadContext error")
+}
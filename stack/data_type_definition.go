@@ -0,0 +1,126 @@
+package stack
+
+import (
+	"time"
+
+	"github.com/searis/guma/stack/uatype"
+	"github.com/searis/guma/stack/uatype/typedict"
+)
+
+// LoadDataTypeDefinitions reads the DataTypeDefinition attribute of all
+// structured and enumerated DataTypes defined by the server outside of the
+// OPC UA namespace, and adds them to set, together with the supertype of
+// other DataTypes, such as Enumerations in the OPC UA namespace and vendor
+// specific subtypes of built-in types. The DataTypeDefinition attribute was
+// introduced in OPC UA 1.04; DataTypes without it are skipped, and may be
+// described by a type dictionary instead. See LoadTypeDictionaries.
+//
+// Each structured type with a default binary encoding is registered for
+// automatic encoding and decoding of ExtensionObjects in requests and
+// responses of c, which uses the types of the set it last loaded. If some
+// types can not be built, the remaining types are still registered, and an
+// error describing the first failure is returned.
+func (c *Client) LoadDataTypeDefinitions(set *typedict.Set, deadline time.Time) error {
+	c.setExtensionObjectTypes(set.ExtensionObjectTypes())
+
+	// Find all DataTypes. DataTypes have a single supertype, so the
+	// hierarchy is browsed as a tree. All but the structured DataTypes are
+	// encoded like their supertype unless they have a DataTypeDefinition.
+	type dataType struct {
+		id         uatype.NodeId
+		structured bool
+	}
+	var custom []uatype.ReferenceDescription
+	level := []dataType{{id: uatype.NewFourByteNodeID(0, uatype.NodeIdBaseDataType)}}
+	for len(level) > 0 {
+		browse := make([]uatype.BrowseDescription, len(level))
+		for i, dt := range level {
+			browse[i] = uatype.BrowseDescription{
+				NodeId:          dt.id,
+				BrowseDirection: uatype.BrowseDirectionForward,
+				ReferenceTypeId: uatype.NewFourByteNodeID(0, uatype.NodeIdHasSubtype),
+				NodeClassMask:   uint32(uatype.NodeClassDataType),
+				ResultMask:      uint32(uatype.BrowseResultMaskAll),
+			}
+		}
+		refs, err := c.browseAll(browse, deadline)
+		if err != nil {
+			return err
+		}
+		parents := level
+		level = nil
+		for i := range refs {
+			for _, ref := range refs[i] {
+				nid, ok := ref.NodeId.NodeID()
+				if !ok {
+					continue
+				}
+				structured := parents[i].structured || nid.NamespaceIndex() == 0 && nid.Uint() == uatype.NodeIdStructure
+				level = append(level, dataType{id: nid, structured: structured})
+				if !structured {
+					set.DefineSubtype(nid, parents[i].id)
+				}
+				if nid.NamespaceIndex() != 0 {
+					custom = append(custom, ref)
+				}
+			}
+		}
+	}
+	if len(custom) == 0 {
+		return nil
+	}
+
+	ids := make([]uatype.ReadValueId, len(custom))
+	for i, ref := range custom {
+		nid, _ := ref.NodeId.NodeID()
+		ids[i] = uatype.ReadValueId{NodeId: nid, AttributeId: uint32(uatype.AttrTypeDataTypeDefinition)}
+	}
+	values, err := c.read(ids, deadline)
+	if err != nil {
+		return err
+	}
+
+	// Define all types before building any of them, as fields may refer to
+	// other custom types.
+	var structs []int
+	encodingIDs := make(map[int]uatype.NodeId)
+	for i, dv := range values {
+		if dv.StatusCode.IsBad() {
+			// The server does not support the attribute for this node.
+			continue
+		}
+		v, err := dv.Value.Value()
+		if err != nil {
+			return err
+		}
+		eo, ok := v.(uatype.ExtensionObject)
+		if !ok || eo.Value == nil {
+			continue
+		}
+		if err := set.Define(ids[i].NodeId, custom[i].BrowseName.Name, eo.Value); err != nil {
+			return err
+		}
+		if sd, ok := eo.Value.(uatype.StructureDefinition); ok {
+			structs = append(structs, i)
+			encodingIDs[i] = sd.DefaultEncodingId
+		}
+	}
+
+	var firstErr error
+	for _, i := range structs {
+		t, err := set.TypeByID(ids[i].NodeId)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		encodingID := encodingIDs[i]
+		if encodingID.NamespaceIndex() == 0 && encodingID.Uint() == 0 {
+			// Null node ID; the type has no binary encoding.
+			continue
+		}
+		t.Register(encodingID)
+	}
+	return firstErr
+}
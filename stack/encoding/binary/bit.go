@@ -4,75 +4,105 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"reflect"
 )
 
-// bitCacheMarshaler allows encoding (compression) of bit sequences and small
-// byte values into a single byte. To achieve this, the set bits are cached
-// until full byte is ready for writing/releasing.
+// bitCacheMarshaler allows encoding (compression) of bit sequences of 1-64
+// bits into bytes. Bits are packed in little-endian order, starting at the
+// least significant bit of each byte, and may cross byte boundaries. To achieve
+// this, the set bits are cached until full bytes are ready for
+// writing/releasing.
 type bitCacheMarshaler struct {
 	cache  byte
 	cursor byte
+	full   []byte // full bytes not yet released by MarshalBinary
 }
 
 // SetBits encodes the n least significant bits from data into b's cache and
-// increments the cursor. An error is returned if n is not in range 1-8, or if
+// increments the cursor. An error is returned if n is not in range 1-64, or if
 // data can not be encoded into n bits.
-func (m *bitCacheMarshaler) SetBits(data, n byte) error {
-	if n < 1 || n > 8 {
+func (m *bitCacheMarshaler) SetBits(data uint64, n byte) error {
+	if n < 1 || n > 64 {
 		return ErrInvalidBitLength
 	}
 
-	var max byte = 0xFF >> (8 - n)
-	if data > max {
+	if n < 64 && data>>n != 0 {
+		var max uint64 = 1<<n - 1
 		return fmt.Errorf("can't encode 0x%.2X > 0x%.2X into %d bits", data, max, n)
 	}
 
-	m.cache |= data << m.cursor
-	m.cursor += n
-
+	for n > 0 {
+		k := 8 - m.cursor
+		if k > n {
+			k = n
+		}
+		m.cache |= byte(data&(1<<k-1)) << m.cursor
+		m.cursor += k
+		data >>= k
+		n -= k
+		if m.cursor == 8 {
+			m.full = append(m.full, m.cache)
+			m.cache = 0
+			m.cursor = 0
+		}
+	}
 	return nil
 }
 
-// MarshalBinary clears m's bit cache and return a singe byte if the bit cache
-// is full. Otherwise, nil is returned.
+// MarshalBinary releases and returns the full bytes of m's bit cache. If no
+// byte is full, nil is returned. The returned slice is only valid until the
+// next call to SetBits.
 func (m *bitCacheMarshaler) MarshalBinary() ([]byte, error) {
-	if m.cursor < 8 {
+	if len(m.full) == 0 {
 		return nil, nil
 	}
-	if m.cursor > 8 {
-		return nil, fmt.Errorf("bitCacheMarshaler: MarshalBinary: cursor position %d > 8", m.cursor)
-	}
-	ret := []byte{m.cache}
+	ret := m.full
+	m.full = m.full[:0]
+	return ret, nil
+}
+
+// Aligned returns true if there are no bits in m's cache that are waiting for
+// a byte to be filled.
+func (m *bitCacheMarshaler) Aligned() bool {
+	return m.cursor == 0
+}
+
+// Reset clears m's bit cache.
+func (m *bitCacheMarshaler) Reset() {
 	m.cache = 0
 	m.cursor = 0
-	return ret, nil
+	m.full = m.full[:0]
 }
 
-// bitCacheUnmarshaler allows unmarshaling (decompressing) bit sequences and
-// small byte values from a single byte. To achieve this, a cache is used to
-// extract values from. Only when the cache is empty will a byte be consumed
-// by UnmarshalBinary (and reported by BitLength).
+// bitCacheUnmarshaler allows unmarshaling (decompressing) bit sequences of
+// 1-64 bits from bytes, in the same order as bitCacheMarshaler. To achieve
+// this, a cache is used to extract values from. Only when the cache is empty
+// will bytes be consumed by UnmarshalBinary (and reported by BitLength).
 type bitCacheUnmarshaler struct {
 	cache      byte
 	cursor     byte
-	byteTarget *byte
+	target     reflect.Value
 	boolTarget *bool
 	nBits      byte
-	byteRead   bool
+	bytesRead  int
 }
 
 // SetTarget lets you set where the next UnmarshalBinary call will write it's
-// results. An error is returned if n is not in range 1-8 or if v is nil.
-func (u *bitCacheUnmarshaler) SetTarget(v *byte, n byte) error {
-	if v == nil {
-		return errors.New("target may not be nil")
+// results. An error is returned if n is not in range 1-64, or if v is not a
+// settable unsigned integer of at least n bits.
+func (u *bitCacheUnmarshaler) SetTarget(v reflect.Value, n byte) error {
+	if !v.CanSet() {
+		return errors.New("target must be settable")
 	}
-	if n < 1 || n > 8 {
+	if n < 1 || n > 64 {
 		return ErrInvalidBitLength
 	}
+	if !isUint(v.Kind()) || v.Type().Bits() < int(n) {
+		return fmt.Errorf("can't decode %d bits into %s", n, v.Type())
+	}
 
 	u.boolTarget = nil
-	u.byteTarget = v
+	u.target = v
 	u.nBits = n
 	return nil
 }
@@ -81,31 +111,38 @@ func (u *bitCacheUnmarshaler) SetTarget(v *byte, n byte) error {
 // call will write it's result.
 func (u *bitCacheUnmarshaler) SetBoolTarget(v *bool) {
 	u.boolTarget = v
-	u.byteTarget = nil
+	u.target = reflect.Value{}
 	u.nBits = 1
 }
 
+// BytesNeeded returns the number of bytes that the next call to
+// UnmarshalBinary will read in addition to the bits in the cache.
+func (u *bitCacheUnmarshaler) BytesNeeded() int {
+	n := int(u.nBits)
+	if u.cursor > 0 {
+		n -= 8 - int(u.cursor)
+	}
+	if n <= 0 {
+		return 0
+	}
+	return (n + 7) / 8
+}
+
 // UnmarshalBinary sets the target value from the bit cache, and then forgets
-// the previously set target. If the bit cache is empty, a byte is first read
-// from data. If no target is set, a panic is raised.
+// the previously set target. Bytes are read from data as the bit cache is
+// emptied. If no target is set, a panic is raised.
 func (u *bitCacheUnmarshaler) UnmarshalBinary(data []byte) error {
-	// read one byte to cache if needed.
-	if u.cursor == 0 {
-		if len(data) < 1 {
-			return io.ErrShortBuffer
-		}
-		u.cache = data[0]
-		u.byteRead = true
-	} else {
-		u.byteRead = false
+	if len(data) < u.BytesNeeded() {
+		return io.ErrShortBuffer
 	}
 
-	// read bits from cache.
-	val := u.readBits()
+	// read bits from cache, filling it from data as needed.
+	u.bytesRead = 0
+	val := u.readBits(data)
 	if u.boolTarget != nil {
 		*u.boolTarget = (val == 1)
-	} else if u.byteTarget != nil {
-		*u.byteTarget = val
+	} else if u.target.IsValid() {
+		u.target.SetUint(val)
 	} else {
 		// May only be caused by a programming error within the guma package.
 		panic("decode target not set")
@@ -113,36 +150,48 @@ func (u *bitCacheUnmarshaler) UnmarshalBinary(data []byte) error {
 
 	// Clear target.
 	u.boolTarget = nil
-	u.byteTarget = nil
+	u.target = reflect.Value{}
 	u.nBits = 0
 
 	return nil
-
 }
 
-func (u *bitCacheUnmarshaler) readBits() byte {
-	var mask byte = 0xFF >> (8 - u.nBits)
-
-	ret := (u.cache >> u.cursor) & mask
-	u.cursor += u.nBits
-	if u.cursor > 8 {
-		// FIXME: If OPC UA bug 3252 is fixed, we should be able to raise an
-		// error here.
-		// - https://opcfoundation-onlineapplications.org/mantis/view.php?id=3252
-		debugLogger.Printf("bitCacheUnmarshaler: UnmarshalBinary: ignoring cursor position > 8; cursor position is %d", u.cursor)
-	}
-	if u.cursor >= 8 {
-		u.cache = 0
-		u.cursor = 0
+func (u *bitCacheUnmarshaler) readBits(data []byte) uint64 {
+	var ret uint64
+	var n byte
+	for n < u.nBits {
+		if u.cursor == 0 {
+			u.cache = data[u.bytesRead]
+			u.bytesRead++
+		}
+		k := 8 - u.cursor
+		if k > u.nBits-n {
+			k = u.nBits - n
+		}
+		ret |= uint64(u.cache>>u.cursor&byte(1<<k-1)) << n
+		u.cursor += k
+		n += k
+		if u.cursor == 8 {
+			u.cache = 0
+			u.cursor = 0
+		}
 	}
 	return ret
 }
 
-// BitLength returns 8 if the last call to UnmarshalBinary resulted in a byte
-// being read. 0 is returned otherwise.
+// Aligned returns true if there are no bits left in u's cache.
+func (u *bitCacheUnmarshaler) Aligned() bool {
+	return u.cursor == 0
+}
+
+// Reset clears u's bit cache.
+func (u *bitCacheUnmarshaler) Reset() {
+	u.cache = 0
+	u.cursor = 0
+}
+
+// BitLength returns the number of bits of the bytes read by the last call to
+// UnmarshalBinary.
 func (u *bitCacheUnmarshaler) BitLength() int {
-	if u.byteRead {
-		return 8
-	}
-	return 0
+	return 8 * u.bytesRead
 }
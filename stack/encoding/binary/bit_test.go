@@ -7,6 +7,13 @@ import (
 	"github.com/searis/guma/stack/uatype"
 )
 
+// twelveBitEnum is an enumerated type that is encoded into 12 bits.
+type twelveBitEnum uint16
+
+func (twelveBitEnum) BitLength() int {
+	return 12
+}
+
 func TestBitTranscoder(t *testing.T) {
 	type eightBits struct{ Bit0, Bit1, Bit2, Bit3, Bit4, Bit5, Bit6, Bit7 uatype.Bit }
 	type sixOneOne struct {
@@ -32,11 +39,36 @@ func TestBitTranscoder(t *testing.T) {
 		Data2 byte `opcua:"bits=6"`
 		Data3 byte `opcua:"bits=2"`
 	}
-	type bitOverflow struct {
+	type crossByte struct {
 		Bit0, Bit1, Bit2 uatype.Bit
-		Data1            byte `opcua:"bits=6"` // overflows by 1 here.
-		Data2            byte `opcua:"bits=6"` // Should realign at cursor 0.
-		Data3            byte `opcua:"bits=2"`
+		Data1            byte `opcua:"bits=6"` // crosses a byte boundary.
+		Data2            byte `opcua:"bits=6"`
+		Data3            byte `opcua:"bits=1"`
+	}
+	type twelveTwelve struct {
+		Data0 uint16 `opcua:"bits=12"`
+		Data1 uint16 `opcua:"bits=12"`
+	}
+	type oneTwentyFourSeven struct {
+		Bit0  uatype.Bit
+		Data  uint32 `opcua:"bits=24"`
+		Data1 byte   `opcua:"bits=7"`
+	}
+	type oneSixtyFourSeven struct {
+		Bit0  uatype.Bit
+		Data  uint64 `opcua:"bits=64"`
+		Data1 byte   `opcua:"bits=7"`
+	}
+	type enumFour struct {
+		Data0 twelveBitEnum
+		Data1 byte `opcua:"bits=4"`
+	}
+	type unfilled struct {
+		Data byte `opcua:"bits=4"`
+	}
+	type misaligned struct {
+		Data byte `opcua:"bits=4"`
+		Next uint32
 	}
 
 	type invalidByte struct {
@@ -145,11 +177,61 @@ func TestBitTranscoder(t *testing.T) {
 			DecodeTarget: new(sixTwoSixTwo),
 			Marshaled:    []byte{0x3F, 0xC0},
 		},
+		{
+			SubTests:     testutil.TestEncode | testutil.TestDecode,
+			Name:         "crossByte{false,true,true,0x3F,0x00,0x01}",
+			Unmarshaled:  crossByte{O, I, I, 0x3F, 0x00, 0x01},
+			DecodeTarget: new(crossByte),
+			Marshaled:    []byte{0xFE, 0x81},
+		},
+		{
+			SubTests:     testutil.TestEncode | testutil.TestDecode,
+			Name:         "twelveTwelve{0xABC,0x123}",
+			Unmarshaled:  twelveTwelve{0xABC, 0x123},
+			DecodeTarget: new(twelveTwelve),
+			Marshaled:    []byte{0xBC, 0x3A, 0x12},
+		},
 		{
 			SubTests:    testutil.TestEncode,
-			Name:        "bitOverflow{0x3F,0x01,0x00,0x3}",
-			Unmarshaled: bitOverflow{O, I, I, 0x3F, 0x00, 0x02},
-			EncodeError: "EncoderError bitOverflow.Data1: bitCacheMarshaler: MarshalBinary: cursor position 9 > 8",
+			Name:        "twelveTwelve{0x1000,0x00}",
+			Unmarshaled: twelveTwelve{0x1000, 0x000},
+			EncodeError: "EncoderError twelveTwelve.Data0: can't encode 0x1000 > 0xFFF into 12 bits",
+		},
+		{
+			SubTests:     testutil.TestEncode | testutil.TestDecode,
+			Name:         "oneTwentyFourSeven{true,0xABCDEF,0x55}",
+			Unmarshaled:  oneTwentyFourSeven{I, 0xABCDEF, 0x55},
+			DecodeTarget: new(oneTwentyFourSeven),
+			Marshaled:    []byte{0xDF, 0x9B, 0x57, 0xAB},
+		},
+		{
+			SubTests:     testutil.TestEncode | testutil.TestDecode,
+			Name:         "oneSixtyFourSeven{true,0x8000000000000001,0x55}",
+			Unmarshaled:  oneSixtyFourSeven{I, 0x8000000000000001, 0x55},
+			DecodeTarget: new(oneSixtyFourSeven),
+			Marshaled:    []byte{0x03, 0, 0, 0, 0, 0, 0, 0, 0xAB},
+		},
+		{
+			SubTests:     testutil.TestEncode | testutil.TestDecode,
+			Name:         "enumFour{0xABC,0x5}",
+			Unmarshaled:  enumFour{0xABC, 0x5},
+			DecodeTarget: new(enumFour),
+			Marshaled:    []byte{0xBC, 0x5A},
+		},
+		{
+			SubTests:    testutil.TestEncode,
+			Name:        "unfilled{0x0F}",
+			Unmarshaled: unfilled{0x0F},
+			EncodeError: "bit fields do not fill a byte",
+		},
+		{
+			SubTests:     testutil.TestEncode | testutil.TestDecode,
+			Name:         "misaligned{0x0F,0x01}",
+			Unmarshaled:  misaligned{0x0F, 0x01},
+			DecodeTarget: new(misaligned),
+			Marshaled:    []byte{0x0F, 0x01, 0x00, 0x00, 0x00},
+			EncodeError:  "EncoderError misaligned.Next: bit fields do not fill a byte",
+			DecodeError:  "DecoderError .Next: bit fields do not fill a byte",
 		},
 		{
 			// Documenting current behavior: we can't distinguish between bit
@@ -136,20 +136,18 @@ func TestByteTranscoder(t *testing.T) {
 			Marshaled:    []byte{0xFE, 0xCA, 0x37, 0x13},
 		},
 		{
-			// Documenting current behavior where non-aligned bit-lengths >8 are
-			// not supported. In this sexample, for struct-field tags.
+			// Bit fields must be unsigned.
 			SubTests:    testutil.TestEncode,
 			Name:        "invalidInt32{0x01FF}",
 			Unmarshaled: invalidInt32{0x01FF},
-			EncodeError: "EncoderError invalidInt32.Data: bit length not in range 1-8",
+			EncodeError: "EncoderError invalidInt32.Data: invalid struct tag: bits=9 requires an unsigned integer field of at least 9 bits, not int32",
 		},
 		{
-			// Documenting current behavior where >8 bit-lengths are not
-			// supported for struct field tags.
-			SubTests:    testutil.TestEncode,
-			Name:        "shrinkUint32{0x0000FFFF}",
-			Unmarshaled: shrinkUint32{0x0000FFFF},
-			EncodeError: "EncoderError shrinkUint32.Data: bit length not in range 1-8",
+			SubTests:     testutil.TestEncode | testutil.TestDecode,
+			Name:         "shrinkUint32{0x0000FFFF}",
+			Unmarshaled:  shrinkUint32{0x0000FFFF},
+			DecodeTarget: new(shrinkUint32),
+			Marshaled:    []byte{0xFF, 0xFF},
 		},
 		{
 			SubTests:     testutil.TestEncode | testutil.TestDecode,
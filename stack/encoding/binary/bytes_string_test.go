@@ -37,8 +37,17 @@ func TestByteString(t *testing.T) {
 			},
 		},
 		{
-			// At the moment we don't distinguish between null and empty values
-			// for ByteString, so testing Encode only.
+			SubTests:     testutil.TestDecode,
+			Name:         `ByteString(nil) with negative size`,
+			Unmarshaled:  uatype.ByteString(nil),
+			DecodeTarget: &uatype.ByteString{'o', 'l', 'd'},
+			Marshaled: []byte{
+				0x00, 0x00, 0x00, 0x80,
+			},
+		},
+		{
+			// By default, we don't distinguish between null and empty values
+			// for ByteString, so testing Encode only. See TestPreserveNull.
 			SubTests:     testutil.TestEncode,
 			Name:         `ByteString("")`,
 			Unmarshaled:  uatype.ByteString(""),
@@ -0,0 +1,281 @@
+//go:generate opcua-xml2code -t=codecs -o codec_auto.go ../../../schemas/1.03/Opc.Ua.Types.bsd.xml
+//go:generate gofmt -w codec_auto.go
+
+package binary
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"time"
+
+	"github.com/searis/guma/stack/uatype"
+)
+
+// generated decides if the generated encoders and decoders in codec_auto.go
+// are used for the structured types of package uatype. Other types, and all
+// types when generated is false, are encoded and decoded through reflection.
+var generated = true
+
+// packBits returns c with the n least significant bits of v set at offset off.
+// An error is returned if v can not be encoded into n bits.
+func packBits(c, v byte, off, n uint) (byte, error) {
+	if max := byte(0xFF >> (8 - n)); v > max {
+		return c, fmt.Errorf("can't encode 0x%.2X > 0x%.2X into %d bits", v, max, n)
+	}
+	return c | v<<off, nil
+}
+
+// bit returns a byte with only the bit at offset off set if v is set.
+func bit(v uatype.Bit, off uint) byte {
+	if v {
+		return 1 << off
+	}
+	return 0
+}
+
+func appendBool(b []byte, v bool) []byte {
+	if v {
+		return append(b, 1)
+	}
+	return append(b, 0)
+}
+
+func appendUint8(b []byte, v uint8) []byte {
+	return append(b, v)
+}
+
+func appendUint16(b []byte, v uint16) []byte {
+	return append(b, byte(v), byte(v>>8))
+}
+
+func appendUint32(b []byte, v uint32) []byte {
+	return append(b, byte(v), byte(v>>8), byte(v>>16), byte(v>>24))
+}
+
+func appendUint64(b []byte, v uint64) []byte {
+	return append(b, byte(v), byte(v>>8), byte(v>>16), byte(v>>24),
+		byte(v>>32), byte(v>>40), byte(v>>48), byte(v>>56))
+}
+
+func appendFloat32(b []byte, v float32) []byte {
+	return appendUint32(b, math.Float32bits(v))
+}
+
+func appendFloat64(b []byte, v float64) []byte {
+	return appendUint64(b, math.Float64bits(v))
+}
+
+// appendString appends s prefixed by its size in bytes as int32. A length of
+// -1 is encoded for empty strings.
+func appendString(b []byte, s string) []byte {
+	if len(s) == 0 {
+		return appendUint32(b, math.MaxUint32)
+	}
+	b = appendUint32(b, uint32(len(s)))
+	return append(b, s...)
+}
+
+// appendByteString appends bs prefixed by its size in bytes as int32. A length
+// of -1 is encoded for empty byte strings.
+func appendByteString(b []byte, bs uatype.ByteString) []byte {
+	if len(bs) == 0 {
+		return appendUint32(b, math.MaxUint32)
+	}
+	b = appendUint32(b, uint32(len(bs)))
+	return append(b, bs...)
+}
+
+func appendDateTime(b []byte, t time.Time) []byte {
+	return appendUint64(b, uint64(dateTime(t).ticks()))
+}
+
+func appendGuid(b []byte, g uatype.Guid) []byte {
+	return append(b, g[:]...)
+}
+
+// next returns the next n bytes of dec's input stream, and marks them as read.
+// The returned slice is only valid until the next read.
+func (dec *Decoder) next(n int) ([]byte, error) {
+	if err := dec.fill(n); err != nil {
+		return nil, err
+	}
+	if len(dec.data) < n {
+		return nil, io.ErrShortBuffer
+	}
+	b := dec.data[:n]
+	dec.data = dec.data[n:]
+	dec.n += n
+	return b, nil
+}
+
+func (dec *Decoder) readBool(p *bool) error {
+	b, err := dec.next(1)
+	if err != nil {
+		return err
+	}
+	*p = b[0] != 0
+	return nil
+}
+
+func (dec *Decoder) readUint8(p *uint8) error {
+	b, err := dec.next(1)
+	if err != nil {
+		return err
+	}
+	*p = b[0]
+	return nil
+}
+
+func (dec *Decoder) readInt8(p *int8) error {
+	var u uint8
+	if err := dec.readUint8(&u); err != nil {
+		return err
+	}
+	*p = int8(u)
+	return nil
+}
+
+func (dec *Decoder) readUint16(p *uint16) error {
+	b, err := dec.next(2)
+	if err != nil {
+		return err
+	}
+	*p = uint16(b[0]) | uint16(b[1])<<8
+	return nil
+}
+
+func (dec *Decoder) readInt16(p *int16) error {
+	var u uint16
+	if err := dec.readUint16(&u); err != nil {
+		return err
+	}
+	*p = int16(u)
+	return nil
+}
+
+func (dec *Decoder) readUint32(p *uint32) error {
+	b, err := dec.next(4)
+	if err != nil {
+		return err
+	}
+	*p = uint32(b[0]) | uint32(b[1])<<8 | uint32(b[2])<<16 | uint32(b[3])<<24
+	return nil
+}
+
+func (dec *Decoder) readInt32(p *int32) error {
+	var u uint32
+	if err := dec.readUint32(&u); err != nil {
+		return err
+	}
+	*p = int32(u)
+	return nil
+}
+
+func (dec *Decoder) readFloat32(p *float32) error {
+	var u uint32
+	if err := dec.readUint32(&u); err != nil {
+		return err
+	}
+	*p = math.Float32frombits(u)
+	return nil
+}
+
+func (dec *Decoder) readUint64(p *uint64) error {
+	b, err := dec.next(8)
+	if err != nil {
+		return err
+	}
+	*p = uint64(b[0]) | uint64(b[1])<<8 | uint64(b[2])<<16 | uint64(b[3])<<24 |
+		uint64(b[4])<<32 | uint64(b[5])<<40 | uint64(b[6])<<48 | uint64(b[7])<<56
+	return nil
+}
+
+func (dec *Decoder) readInt64(p *int64) error {
+	var u uint64
+	if err := dec.readUint64(&u); err != nil {
+		return err
+	}
+	*p = int64(u)
+	return nil
+}
+
+func (dec *Decoder) readFloat64(p *float64) error {
+	var u uint64
+	if err := dec.readUint64(&u); err != nil {
+		return err
+	}
+	*p = math.Float64frombits(u)
+	return nil
+}
+
+// readString reads a string prefixed by its size in bytes as int32. Negative
+// sizes describe null strings, which are decoded as empty strings.
+func (dec *Decoder) readString(p *string) error {
+	var size int32
+	if err := dec.readInt32(&size); err != nil {
+		return err
+	}
+	if size <= 0 {
+		*p = ""
+		return nil
+	}
+	if err := dec.allocString(int(size)); err != nil {
+		return err
+	}
+	b, err := dec.next(int(size))
+	if err != nil {
+		return err
+	}
+	*p = string(b)
+	return nil
+}
+
+// readByteString reads a byte string prefixed by its size in bytes as int32.
+// Negative sizes describe null byte strings, which are decoded as nil.
+func (dec *Decoder) readByteString(p *uatype.ByteString) error {
+	var size int32
+	if err := dec.readInt32(&size); err != nil {
+		return err
+	}
+	if size <= 0 {
+		*p = nil
+		return nil
+	}
+	if err := dec.allocByteString(int(size)); err != nil {
+		return err
+	}
+	b, err := dec.next(int(size))
+	if err != nil {
+		return err
+	}
+	*p = append(uatype.ByteString(nil), b...)
+	return nil
+}
+
+func (dec *Decoder) readDateTime(p *time.Time) error {
+	b, err := dec.next(8)
+	if err != nil {
+		return err
+	}
+	return (*dateTime)(p).UnmarshalBinary(b)
+}
+
+func (dec *Decoder) readGuid(p *uatype.Guid) error {
+	b, err := dec.next(len(p))
+	if err != nil {
+		return err
+	}
+	copy(p[:], b)
+	return nil
+}
+
+// readBytes fills p with the next len(p) bytes of dec's input stream.
+func (dec *Decoder) readBytes(p []byte) error {
+	b, err := dec.next(len(p))
+	if err != nil {
+		return err
+	}
+	copy(p, b)
+	return nil
+}
@@ -0,0 +1,272 @@
+package binary_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/searis/guma/stack/encoding/binary"
+	"github.com/searis/guma/stack/uatype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// marshal encodes v with or without the generated encoders.
+func marshal(v interface{}, generated bool) ([]byte, error) {
+	defer binary.SetGenerated(binary.SetGenerated(generated))
+	return binary.Marshal(v)
+}
+
+// unmarshal decodes data into v with or without the generated decoders.
+func unmarshal(data []byte, v interface{}, generated bool) error {
+	defer binary.SetGenerated(binary.SetGenerated(generated))
+	return binary.Unmarshal(data, v)
+}
+
+func readRequest() uatype.ReadRequest {
+	ids := []uatype.NodeId{
+		uatype.NewTwoByteNodeID(85),
+		uatype.NewFourByteNodeID(1, 2253),
+		uatype.NewNumericNodeID(2, 100000),
+		uatype.NewStringNodeID(3, "Demo.Static.Scalar.Double"),
+		uatype.NewGuidNodeID(4, uatype.Guid{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}),
+		uatype.NewByteStringNodeID(5, uatype.ByteString("opaque")),
+	}
+	nodes := make([]uatype.ReadValueId, 0, 10*len(ids))
+	for i := 0; i < 10; i++ {
+		for _, id := range ids {
+			nodes = append(nodes, uatype.ReadValueId{
+				NodeId:       id,
+				AttributeId:  uint32(uatype.AttrTypeValue),
+				DataEncoding: uatype.QualifiedName{Name: "Default Binary"},
+			})
+		}
+	}
+	return uatype.ReadRequest{
+		RequestHeader: uatype.RequestHeader{
+			AuthenticationToken: uatype.NewByteStringNodeID(0, uatype.ByteString{0xde, 0xad, 0xbe, 0xef}),
+			Timestamp:           time.Date(2018, 3, 14, 15, 9, 26, 535897900, time.UTC),
+			RequestHandle:       42,
+			TimeoutHint:         10000,
+		},
+		MaxAge:             500,
+		TimestampsToReturn: uatype.TimestampsToReturnBoth,
+		NoOfNodesToRead:    int32(len(nodes)),
+		NodesToRead:        nodes,
+	}
+}
+
+func readResponse(t testing.TB) uatype.ReadResponse {
+	values := []interface{}{
+		true,
+		int8(-8),
+		uint8(8),
+		int16(-16),
+		uint16(16),
+		int32(-32),
+		uint32(32),
+		int64(-64),
+		uint64(64),
+		float32(3.25),
+		float64(6.5),
+		"text",
+		time.Date(2018, 3, 14, 15, 9, 26, 0, time.UTC),
+		uatype.Guid{0xff},
+		uatype.ByteString("bytes"),
+		uatype.NewStringNodeID(1, "node"),
+		uatype.ExpandedNodeId{
+			NodeIdType:            uatype.NodeIdTypeNumeric,
+			NamespaceURISpecified: true,
+			ServerIndexSpecified:  true,
+			Numeric:               uatype.NumericNodeId{Identifier: 7},
+			NamespaceURI:          "urn:test",
+			ServerIndex:           2,
+		},
+		uatype.StatusBadNodeIdUnknown,
+		uatype.QualifiedName{NamespaceIndex: 1, Name: "name"},
+		uatype.LocalizedText{LocaleSpecified: true, TextSpecified: true, Locale: "en", Text: "text"},
+		uatype.ExtensionObject{Value: uatype.Argument{Name: "arg", DataType: uatype.NewTwoByteNodeID(6), ValueRank: -1}},
+		[]float64{1, 2, 3},
+		[][]int32{{1, 2, 3}, {4, 5, 6}},
+		[]string{"a", "", "c"},
+	}
+	results := make([]uatype.DataValue, 0, 4*len(values))
+	for i := 0; i < 4; i++ {
+		for _, v := range values {
+			variant, err := uatype.NewVariant(v)
+			require.NoError(t, err, "NewVariant(%#v)", v)
+			results = append(results, uatype.DataValue{
+				ValueSpecified:           true,
+				Value:                    variant,
+				SourceTimestampSpecified: true,
+				SourceTimestamp:          time.Date(2018, 3, 14, 15, 9, 26, 0, time.UTC),
+			})
+		}
+	}
+	results = append(results, uatype.DataValue{StatusCodeSpecified: true, StatusCode: uatype.StatusBadNotReadable})
+
+	diag := uatype.DiagnosticInfo{
+		SymbolicIdSpecified:          true,
+		SymbolicId:                   1,
+		AdditionalInfoSpecified:      true,
+		AdditionalInfo:               "outer",
+		InnerDiagnosticInfoSpecified: true,
+		InnerDiagnosticInfo: &uatype.DiagnosticInfo{
+			InnerStatusCodeSpecified: true,
+			InnerStatusCode:          uatype.StatusBadInternalError,
+		},
+	}
+	return uatype.ReadResponse{
+		ResponseHeader: uatype.ResponseHeader{
+			Timestamp:          time.Date(2018, 3, 14, 15, 9, 26, 0, time.UTC),
+			RequestHandle:      42,
+			ServiceDiagnostics: &diag,
+			NoOfStringTable:    2,
+			StringTable:        []string{"one", "two"},
+		},
+		NoOfResults:         int32(len(results)),
+		Results:             results,
+		NoOfDiagnosticInfos: 1,
+		DiagnosticInfos:     []uatype.DiagnosticInfo{diag},
+	}
+}
+
+func TestGeneratedCodec(t *testing.T) {
+	cases := []struct {
+		name   string
+		value  interface{}
+		target func() interface{}
+	}{
+		{"ReadRequest", readRequest(), func() interface{} { return new(uatype.ReadRequest) }},
+		{"ReadResponse", readResponse(t), func() interface{} { return new(uatype.ReadResponse) }},
+		{"CreateSessionResponse", uatype.CreateSessionResponse{
+			ResponseHeader:                 uatype.ResponseHeader{ServiceResult: uatype.StatusBadTooManySessions},
+			NoOfServerEndpoints:            1,
+			ServerEndpoints:                []uatype.EndpointDescription{{EndpointUrl: "opc.tcp://localhost:4840", SecurityMode: uatype.MessageSecurityModeNone}},
+			NoOfServerSoftwareCertificates: -1,
+		}, func() interface{} { return new(uatype.CreateSessionResponse) }},
+	}
+	for _, tc := range cases {
+		generated, err := marshal(tc.value, true)
+		require.NoError(t, err, "%s: generated encoder", tc.name)
+		reflected, err := marshal(tc.value, false)
+		require.NoError(t, err, "%s: reflection encoder", tc.name)
+		require.Equal(t, reflected, generated, "%s: encoding", tc.name)
+
+		fromGenerated := tc.target()
+		require.NoError(t, unmarshal(generated, fromGenerated, true), "%s: generated decoder", tc.name)
+		fromReflected := tc.target()
+		require.NoError(t, unmarshal(generated, fromReflected, false), "%s: reflection decoder", tc.name)
+		assert.Equal(t, fromReflected, fromGenerated, "%s: decoded value", tc.name)
+	}
+}
+
+func TestGeneratedCodecErrors(t *testing.T) {
+	req := readRequest()
+	req.NoOfNodesToRead++
+	_, err := marshal(req, false)
+	require.Error(t, err, "reflection encoder")
+	_, genErr := marshal(req, true)
+	assert.EqualError(t, genErr, err.Error(), "generated encoder")
+
+	data, err := marshal(readRequest(), true)
+	require.NoError(t, err, "Marshal")
+	data = data[:len(data)-3]
+	err = unmarshal(data, new(uatype.ReadRequest), false)
+	require.Error(t, err, "reflection decoder")
+	genErr = unmarshal(data, new(uatype.ReadRequest), true)
+	assert.EqualError(t, genErr, err.Error(), "generated decoder")
+}
+
+func benchmarkMarshal(b *testing.B, v interface{}) {
+	for _, generated := range []bool{true, false} {
+		name := "Reflection"
+		if generated {
+			name = "Generated"
+		}
+		b.Run(name, func(b *testing.B) {
+			defer binary.SetGenerated(binary.SetGenerated(generated))
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := binary.Marshal(v); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func benchmarkUnmarshal(b *testing.B, v interface{}, target func() interface{}) {
+	data, err := binary.Marshal(v)
+	if err != nil {
+		b.Fatal(err)
+	}
+	for _, generated := range []bool{true, false} {
+		name := "Reflection"
+		if generated {
+			name = "Generated"
+		}
+		b.Run(name, func(b *testing.B) {
+			defer binary.SetGenerated(binary.SetGenerated(generated))
+			b.ReportAllocs()
+			b.SetBytes(int64(len(data)))
+			for i := 0; i < b.N; i++ {
+				if err := binary.Unmarshal(data, target()); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkMarshalReadRequest(b *testing.B) {
+	benchmarkMarshal(b, readRequest())
+}
+
+func BenchmarkUnmarshalReadRequest(b *testing.B) {
+	benchmarkUnmarshal(b, readRequest(), func() interface{} { return new(uatype.ReadRequest) })
+}
+
+func BenchmarkMarshalReadResponse(b *testing.B) {
+	benchmarkMarshal(b, readResponse(b))
+}
+
+func BenchmarkUnmarshalReadResponse(b *testing.B) {
+	benchmarkUnmarshal(b, readResponse(b), func() interface{} { return new(uatype.ReadResponse) })
+}
+
+func publishResponse(t testing.TB) uatype.PublishResponse {
+	items := make([]uatype.MonitoredItemNotification, 50)
+	for i := range items {
+		variant, err := uatype.NewVariant(float64(i))
+		require.NoError(t, err, "NewVariant")
+		items[i] = uatype.MonitoredItemNotification{
+			ClientHandle: uint32(i),
+			Value: uatype.DataValue{
+				ValueSpecified:           true,
+				Value:                    variant,
+				SourceTimestampSpecified: true,
+				SourceTimestamp:          time.Date(2018, 3, 14, 15, 9, 26, 0, time.UTC),
+			},
+		}
+	}
+	return uatype.PublishResponse{
+		ResponseHeader:               uatype.ResponseHeader{RequestHandle: 42},
+		SubscriptionId:               1,
+		NoOfAvailableSequenceNumbers: 2,
+		AvailableSequenceNumbers:     []uint32{1, 2},
+		NotificationMessage: uatype.NotificationMessage{
+			SequenceNumber:       2,
+			PublishTime:          time.Date(2018, 3, 14, 15, 9, 26, 0, time.UTC),
+			NoOfNotificationData: 1,
+			NotificationData: []uatype.ExtensionObject{{Value: uatype.DataChangeNotification{
+				NoOfMonitoredItems:  int32(len(items)),
+				MonitoredItems:      items,
+				NoOfDiagnosticInfos: -1,
+			}}},
+		},
+	}
+}
+
+func BenchmarkUnmarshalPublishResponse(b *testing.B) {
+	benchmarkUnmarshal(b, publishResponse(b), func() interface{} { return new(uatype.PublishResponse) })
+}
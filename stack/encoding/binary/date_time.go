@@ -1,8 +1,13 @@
 package binary
 
-import "time"
+import (
+	"encoding/binary"
+	"io"
+	"math"
+	"time"
 
-import "encoding/binary"
+	"github.com/searis/guma/stack/uatype"
+)
 
 // secondsToUnixEpoch counts the number of seconds since the Windows NT time
 // epoch (January 1 1601) to the Unix/POSIX time epoch.
@@ -21,23 +26,45 @@ type dateTime time.Time
 // representation.
 func (t dateTime) MarshalBinary() ([]byte, error) {
 	b := make([]byte, 8)
+	binary.LittleEndian.PutUint64(b, uint64(t.ticks()))
+	return b, nil
+}
 
+// ticks returns t as the number of 100 nanosecond intervals since the Windows
+// NT time epoch. Times at or before uatype.MinDateTime, including the zero
+// time, give 0, and times at or after uatype.MaxDateTime give math.MaxInt64.
+func (t dateTime) ticks() int64 {
+	switch {
+	case !time.Time(t).After(uatype.MinDateTime):
+		return 0
+	case !time.Time(t).Before(uatype.MaxDateTime):
+		return math.MaxInt64
+	}
 	sec := time.Time(t).Unix()
 	nsec := time.Time(t).Nanosecond()
 
 	// We do all calculations as int64 to ensure the sign is correct during
 	// addition and integer scaling. We then cast to uint64 for the final
 	// put-operation only.
-	i := sec*1e7 + hundredNanoSecondsToUnixEpoch + int64(nsec/100)
-	binary.LittleEndian.PutUint64(b, uint64(i))
-
-	return b, nil
+	return sec*1e7 + hundredNanoSecondsToUnixEpoch + int64(nsec/100)
 }
 
 // UnmarshalBinary decodes a 64-bit Windows NT timestamp into a Go time.Time
-// struct.
+// struct. 0 and negative values give the zero time, and math.MaxInt64 gives
+// uatype.MaxDateTime.
 func (t *dateTime) UnmarshalBinary(data []byte) error {
+	if len(data) < 8 {
+		return io.ErrShortBuffer
+	}
 	i := int64(binary.LittleEndian.Uint64(data[0:8]))
+	switch {
+	case i <= 0:
+		*t = dateTime{}
+		return nil
+	case i == math.MaxInt64:
+		*t = dateTime(uatype.MaxDateTime)
+		return nil
+	}
 	i -= hundredNanoSecondsToUnixEpoch
 
 	sec := i / 1e7
@@ -2,27 +2,71 @@ package binary_test
 
 import (
 	b "encoding/binary"
+	"math"
 	"testing"
 	"time"
 
 	"github.com/searis/guma/internal/testutil"
+	"github.com/searis/guma/stack/encoding/binary"
+	"github.com/searis/guma/stack/uatype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestTime(t *testing.T) {
 	const hundredNanoSecondsToUnixEpoch = 11644473600 * 1e7
 	unixEpochBytes := make([]byte, 8, 8)
 	b.LittleEndian.PutUint64(unixEpochBytes, uint64(hundredNanoSecondsToUnixEpoch))
+	unixEpochPlusTickBytes := make([]byte, 8, 8)
+	b.LittleEndian.PutUint64(unixEpochPlusTickBytes, uint64(hundredNanoSecondsToUnixEpoch+1))
+	maxInt64Bytes := []byte{0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0x7F}
 
 	cases := []testutil.TranscoderTest{
 		{
 			SubTests:     testutil.TestEncode | testutil.TestDecode,
-			Name:         `1601-01-01T00:00:01.00`,
+			Name:         `zero`,
+			Unmarshaled:  time.Time{},
+			DecodeTarget: new(time.Time),
+			Marshaled: []byte{
+				0, 0, 0, 0, 0, 0, 0, 0,
+			},
+		},
+		{
+			SubTests:     testutil.TestEncode,
+			Name:         `1601-01-01T00:00:00.00`,
 			Unmarshaled:  time.Date(1601, 1, 1, 0, 0, 0, 0, time.UTC),
 			DecodeTarget: new(time.Time),
 			Marshaled: []byte{
 				0, 0, 0, 0, 0, 0, 0, 0,
 			},
 		},
+		{
+			SubTests:     testutil.TestEncode,
+			Name:         `1600-12-31T23:59:59.00`,
+			Unmarshaled:  time.Date(1600, 12, 31, 23, 59, 59, 0, time.UTC),
+			DecodeTarget: new(time.Time),
+			Marshaled: []byte{
+				0, 0, 0, 0, 0, 0, 0, 0,
+			},
+		},
+		{
+			SubTests:     testutil.TestDecode,
+			Name:         `negative`,
+			Unmarshaled:  time.Time{},
+			DecodeTarget: new(time.Time),
+			Marshaled: []byte{
+				0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF,
+			},
+		},
+		{
+			SubTests:     testutil.TestEncode | testutil.TestDecode,
+			Name:         `1601-01-01T00:00:00.0000001`,
+			Unmarshaled:  time.Date(1601, 1, 1, 0, 0, 0, 100, time.UTC),
+			DecodeTarget: new(time.Time),
+			Marshaled: []byte{
+				1, 0, 0, 0, 0, 0, 0, 0,
+			},
+		},
 		{
 			SubTests:     testutil.TestEncode | testutil.TestDecode,
 			Name:         `1970-01-01T00:00:00.00`,
@@ -30,9 +74,81 @@ func TestTime(t *testing.T) {
 			DecodeTarget: new(time.Time),
 			Marshaled:    unixEpochBytes,
 		},
+		{
+			SubTests:     testutil.TestEncode,
+			Name:         `1970-01-01T00:00:00.000000199`,
+			Unmarshaled:  time.Date(1970, 1, 1, 0, 0, 0, 199, time.UTC),
+			DecodeTarget: new(time.Time),
+			Marshaled:    unixEpochPlusTickBytes,
+		},
+		{
+			SubTests:     testutil.TestEncode | testutil.TestDecode,
+			Name:         `9999-12-31T23:59:59.00`,
+			Unmarshaled:  uatype.MaxDateTime,
+			DecodeTarget: new(time.Time),
+			Marshaled:    maxInt64Bytes,
+		},
+		{
+			SubTests:     testutil.TestEncode,
+			Name:         `10000-01-01T00:00:00.00`,
+			Unmarshaled:  time.Date(10000, 1, 1, 0, 0, 0, 0, time.UTC),
+			DecodeTarget: new(time.Time),
+			Marshaled:    maxInt64Bytes,
+		},
 	}
 
 	for i := range cases {
 		cases[i].Run(t)
 	}
 }
+
+func TestTimeBounds(t *testing.T) {
+	// Times just within the bounds are encoded as they are.
+	min := uatype.MinDateTime.Add(100 * time.Nanosecond)
+	max := uatype.MaxDateTime.Add(-100 * time.Nanosecond)
+	for _, v := range []time.Time{min, max} {
+		data, err := binary.Marshal(v)
+		require.NoError(t, err, "binary.Marshal(%s)", v)
+		ticks := int64(b.LittleEndian.Uint64(data))
+		assert.True(t, ticks > 0 && ticks < math.MaxInt64, "binary.Marshal(%s) ticks", v)
+
+		var decoded time.Time
+		require.NoError(t, binary.Unmarshal(data, &decoded), "binary.Unmarshal")
+		assert.Equal(t, v, decoded, "binary.Unmarshal")
+	}
+}
+
+func TestDataValuePicoseconds(t *testing.T) {
+	source := time.Date(2018, 3, 14, 15, 9, 26, 535897932, time.UTC)
+	server := time.Date(2018, 3, 14, 15, 9, 27, 100, time.UTC)
+
+	var dv uatype.DataValue
+	dv.SetSourceTime(source)
+	dv.SetServerTime(server)
+	assert.Equal(t, uatype.Bit(true), dv.SourcePicosecondsSpecified, "SourcePicosecondsSpecified")
+	assert.Equal(t, uint16(3200), dv.SourcePicoseconds, "SourcePicoseconds")
+	assert.Equal(t, uatype.Bit(false), dv.ServerPicosecondsSpecified, "ServerPicosecondsSpecified")
+
+	data, err := binary.Marshal(dv)
+	require.NoError(t, err, "binary.Marshal")
+	var decoded uatype.DataValue
+	require.NoError(t, binary.Unmarshal(data, &decoded), "binary.Unmarshal")
+	assert.Equal(t, dv, decoded, "binary.Unmarshal")
+	assert.Equal(t, source, decoded.SourceTime().Time(), "SourceTime")
+	assert.Equal(t, server, decoded.ServerTime().Time(), "ServerTime")
+
+	var unset uatype.DataValue
+	unset.SetSourceTime(time.Time{})
+	assert.Equal(t, uatype.DataValue{}, unset, "SetSourceTime(time.Time{})")
+	assert.True(t, unset.SourceTime().Time().IsZero(), "SourceTime")
+}
+
+func TestNewTimestamp(t *testing.T) {
+	ts := uatype.NewTimestamp(time.Date(2018, 3, 14, 15, 9, 26, 99, time.UTC))
+	assert.Equal(t, time.Date(2018, 3, 14, 15, 9, 26, 0, time.UTC), ts.DateTime, "DateTime")
+	assert.Equal(t, uint16(9900), ts.Picoseconds, "Picoseconds")
+
+	// Picoseconds below a nanosecond are truncated.
+	ts.Picoseconds = uatype.MaxPicoseconds
+	assert.Equal(t, time.Date(2018, 3, 14, 15, 9, 26, 99, time.UTC), ts.Time(), "Time")
+}
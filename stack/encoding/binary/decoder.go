@@ -2,23 +2,25 @@ package binary
 
 import (
 	"encoding"
+	"encoding/binary"
 	"fmt"
 	"io"
 	"reflect"
 	"time"
-	"unsafe"
 
 	"github.com/searis/guma/stack/uatype"
 
-	"io/ioutil"
-
 	"runtime/debug"
 )
 
-// bitExtractor is a helper struct that can be used to unmarshal slices of 1-8
-// bits.
+// minReadSize is the minimum number of bytes a Decoder attempts to read from
+// its input stream at a time.
+const minReadSize = 4096
+
+// bitExtractor is a helper struct that can be used to unmarshal slices of 1-64
+// bits into a settable unsigned integer.
 type bitExtractor struct {
-	Target    *byte
+	Target    reflect.Value
 	BitLength byte
 }
 
@@ -36,12 +38,22 @@ func Unmarshal(data []byte, v interface{}) error {
 	return dec.Decode(v)
 }
 
-// A Decoder reads and decodes OPC UA Binary content from an input stream.
+// A Decoder reads and decodes OPC UA Binary content from an input stream. Data
+// is read on demand; the Decoder buffers at most the larger of minReadSize and
+// the encoded size of a single String, ByteString or fixed-size value.
 type Decoder struct {
 	r              io.Reader
+	buf            []byte // read buffer; data is the unread part of it
 	data           []byte
 	n              int
 	bitUnmarshaler bitCacheUnmarshaler
+
+	limits    DecoderLimits
+	depth     int // nesting depth of structured values
+	allocated int // bytes allocated by the current call to Decode
+
+	preserveNull bool
+	types        *uatype.ExtensionObjectTypes
 }
 
 // NewDecoder initializes a Decoder for r.
@@ -64,24 +76,12 @@ func (dec *Decoder) Decode(v interface{}) (err error) {
 		}
 	}()
 
-	// Check if dec.r is (still) set to allow multiple calls to Decode, as well
-	// as an optimized path for the Unmarshal function.
-	if dec.r != nil {
-		// FIXME: As an initial implementation we read all binary data directly
-		// into memory without any steaming. We should improve on this later
-		// to prevent consuming data that we do not need.
-		if dec.data, err = ioutil.ReadAll(dec.r); err != nil {
-			return err
-		}
-
-		// get rid of the reader to symbolize that it's been exhausted.
-		dec.r = nil
-	}
-
 	rv := reflect.ValueOf(v)
 	if rv.Kind() != reflect.Ptr || rv.IsNil() {
 		return ErrNotSetable
 	}
+	dec.depth, dec.allocated = 0, 0
+	dec.bitUnmarshaler.Reset()
 
 	switch err := dec.decode(rv).(type) {
 	case transcoderError:
@@ -92,9 +92,70 @@ func (dec *Decoder) Decode(v interface{}) (err error) {
 	}
 }
 
+// fill reads from the input stream until at least n bytes are buffered, or
+// the input stream is exhausted.
+func (dec *Decoder) fill(n int) error {
+	if len(dec.data) >= n || dec.r == nil {
+		return nil
+	}
+
+	// Move unread data to the start of the buffer, growing it if needed.
+	size := n
+	if size < minReadSize {
+		size = minReadSize
+	}
+	if cap(dec.buf) < size {
+		dec.buf = make([]byte, size)
+	}
+	dec.buf = dec.buf[:cap(dec.buf)]
+	m := copy(dec.buf, dec.data)
+
+	for m < n {
+		k, err := dec.r.Read(dec.buf[m:])
+		m += k
+		if err == io.EOF {
+			// get rid of the reader to symbolize that it's been exhausted.
+			dec.r = nil
+			break
+		} else if err != nil {
+			dec.data = dec.buf[:m]
+			return err
+		}
+	}
+	dec.data = dec.buf[:m]
+	return nil
+}
+
+// fillAll reads the remainder of the input stream into the buffer.
+func (dec *Decoder) fillAll() error {
+	for dec.r != nil {
+		if err := dec.fill(len(dec.data) + minReadSize); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fillPrefixed buffers a value that is prefixed by its length as an int32,
+// such as a String or ByteString. The length is checked by alloc before any
+// data is buffered.
+func (dec *Decoder) fillPrefixed(alloc func(size int) error) error {
+	if err := dec.fill(4); err != nil || len(dec.data) < 4 {
+		return err
+	}
+	if l := int32(binary.LittleEndian.Uint32(dec.data)); l > 0 {
+		if err := alloc(int(l)); err != nil {
+			return err
+		}
+		return dec.fill(4 + int(l))
+	}
+	return nil
+}
+
 func (dec *Decoder) decode(rv reflect.Value) error {
 	var u encoding.BinaryUnmarshaler
 	var size, maxSize int
+	var alloc func(size int) error // set for length prefixed values
 	var data []byte
 
 	// Pick binary marshaler.
@@ -151,34 +212,42 @@ func (dec *Decoder) decode(rv reflect.Value) error {
 		size = 8
 	case *time.Duration:
 		u = (*duration)(iv)
+		size = 4
 	case *uatype.Bit:
 		dec.bitUnmarshaler.SetBoolTarget((*bool)(iv))
 		u = &dec.bitUnmarshaler
-		maxSize = 1
 	case *string:
 		u = (*uaString)(iv)
+		alloc = dec.allocString
+	case *uatype.ByteString:
+		if dec.preserveNull {
+			u = (*nullByteString)(iv)
+		} else {
+			u = iv
+		}
+		alloc = dec.allocByteString
+	case *uatype.NullString:
+		u = iv
+		alloc = dec.allocString
 	case bitExtractor:
 		if err := dec.bitUnmarshaler.SetTarget(iv.Target, iv.BitLength); err != nil {
 			return err
 		}
 		u = &dec.bitUnmarshaler
-		maxSize = 1
 	case encoding.BinaryUnmarshaler:
 		// Prefer BinaryUnmarshaler over BitLengther, if implemented.
 		u = iv
 	case BitLengther:
 		nBits := iv.BitLength()
-		if nBits < 8 {
-			if rv.Elem().Kind() != reflect.Uint8 {
-				return fmt.Errorf("Not uint8")
-			}
-			if err := dec.bitUnmarshaler.SetTarget((*byte)(unsafe.Pointer(rv.Pointer())), byte(nBits)); err != nil {
+		if nBits < 1 || nBits > 64 {
+			return ErrInvalidBitLength
+		}
+		if nBits%8 != 0 || !dec.bitUnmarshaler.Aligned() {
+			// Values that are not byte aligned are packed as bits.
+			if err := dec.bitUnmarshaler.SetTarget(rv.Elem(), byte(nBits)); err != nil {
 				return err
 			}
 			u = &dec.bitUnmarshaler
-			maxSize = 1
-		} else if nBits%8 != 0 {
-			return fmt.Errorf("bit length above 8 must be aligned to 8 bits; bit length was %d", nBits)
 		} else {
 			size = nBits / 8
 			u = byteUnmarshaler{iv}
@@ -192,6 +261,12 @@ func (dec *Decoder) decode(rv reflect.Value) error {
 				return dec.decodeList(re)
 			}
 		case reflect.Struct:
+			// Generated code does not preserve null values.
+			if generated && !dec.preserveNull && dec.bitUnmarshaler.Aligned() {
+				if ok, err := decodeGenerated(dec, rv.Interface()); ok {
+					return err
+				}
+			}
 			return dec.decodeStruct(re)
 		case reflect.Int8, reflect.Uint8:
 			u = byteUnmarshaler{re}
@@ -212,6 +287,35 @@ func (dec *Decoder) decode(rv reflect.Value) error {
 
 	}
 
+	// Bits are read from the bit cache, and from as many bytes as needed to
+	// fill the target. Other values must start at a byte boundary.
+	if u == &dec.bitUnmarshaler {
+		maxSize = dec.bitUnmarshaler.BytesNeeded()
+		if maxSize == 0 {
+			maxSize = 1
+		}
+	} else if _, nop := u.(nopUnmarshaler); !nop && !dec.bitUnmarshaler.Aligned() {
+		return ErrBitAlignment
+	}
+
+	// Make sure the value is buffered. The size of values that are not
+	// length prefixed is unknown, so the rest of the input is read.
+	var err error
+	switch _, nop := u.(nopUnmarshaler); {
+	case nop:
+	case size != 0:
+		err = dec.fill(size)
+	case maxSize != 0:
+		err = dec.fill(maxSize)
+	case alloc != nil:
+		err = dec.fillPrefixed(alloc)
+	default:
+		err = dec.fillAll()
+	}
+	if err != nil {
+		return err
+	}
+
 	// Limit input data if the (max) size is known.
 	if size != 0 {
 		if size > len(dec.data) {
@@ -233,6 +337,7 @@ func (dec *Decoder) decode(rv reflect.Value) error {
 		size = marshaledSize(u)
 	}
 	dec.data = dec.data[size:]
+	dec.n += size
 
 	return nil
 }
@@ -248,106 +353,88 @@ func (dec *Decoder) decodeList(rv reflect.Value) error {
 }
 
 func (dec *Decoder) decodeStruct(rv reflect.Value) error {
-	fields, err := gatherFields(nil, rv)
+	plan, err := planFor(rv.Type())
 	if err != nil {
 		return err
 	}
+	if err := dec.enter(); err != nil {
+		return err
+	}
+	defer dec.leave()
 
-	prevIndices := map[string]int{}
-
-	for i, f := range fields {
-
-		// Continue if switch field value does not match.
-		if f.SwitchValue != -1 {
-			if f.SwitchField == "" {
-				return wrapError(ErrInvalidTag, f.Name)
+	for i := range plan {
+		if !plan.active(rv, i) {
+			continue
+		}
+		f := &plan[i]
+		fv := rv.Field(f.Index)
+
+		// Allocate space for slices. A length field that has been switched off
+		// means that exactly one element should be decoded, which is how scalar
+		// Variant values are described. Negative lengths describe null arrays,
+		// which are decoded as nil if null values are preserved.
+		if f.LengthField >= 0 {
+			l := plan.length(rv, f)
+			if l < 0 && dec.preserveNull {
+				fv.Set(reflect.Zero(fv.Type()))
+				continue
 			}
-			var v int64
-			switch fields[prevIndices[f.SwitchField]].Value.Kind() {
-			case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Int:
-				v = fields[prevIndices[f.SwitchField]].Value.Int()
-			case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uint:
-				v = int64(fields[prevIndices[f.SwitchField]].Value.Uint())
-			default:
-				return wrapError(ErrInvalidTag, fmt.Errorf("Invalid SwitchValue [%s] for field [%s]", fields[prevIndices[f.SwitchField]].Value.Kind(), f.Name))
+			if l < 0 {
+				l = 0
 			}
-
-			if f.SwitchOperand != "" {
-				switch f.SwitchOperand {
-				case "Equals":
-					if v == f.SwitchValue {
-						goto process
-					}
-				case "GreaterThan":
-					if v > f.SwitchValue {
-						goto process
-					}
-				case "LessThan":
-					if v < f.SwitchValue {
-						goto process
-					}
-				case "GreaterThanOrEqual":
-					if v >= f.SwitchValue {
-						goto process
-					}
-				case "LessThanOrEqual":
-					if v <= f.SwitchValue {
-						goto process
-					}
-				case "NotEqual":
-					if v != f.SwitchValue {
-						goto process
-					}
-				default:
-					return wrapError(ErrInvalidTag, fmt.Errorf("Invalid SwithcOperand [%s] for field [%s]", f.SwitchOperand, f.Name))
+			if _, ok := rv.Addr().Interface().(*uatype.Variant); ok {
+				if err := dec.checkVariantArrayLength(l); err != nil {
+					return wrapError(err, "ArrayLength")
 				}
-				continue
 			}
-			// There might be switchValiues without any defined Operator, this means that we should
-			// continue if the value and field does not match
-			if v != f.SwitchValue {
-				continue
+			if err := dec.allocArray(l, int(fv.Type().Elem().Size())); err != nil {
+				return wrapError(err, f.Name)
 			}
-		} else if f.SwitchField != "" {
-			if !fields[prevIndices[f.SwitchField]].Value.Bool() {
-				continue
-			}
-		}
-	process:
-		// Allocate space for slices.
-		if f.LengthField != "" {
-			l := int(fields[prevIndices[f.LengthField]].Value.Int())
-
-			et := f.Value.Type().Elem()
-
-			f.Value.Set(reflect.MakeSlice(reflect.SliceOf(et), l, l))
+			fv.Set(reflect.MakeSlice(fv.Type(), l, l))
 		}
 
 		// Wrap or reference field value before decoding.
 		var decodeValue reflect.Value
-		if f.BitSize > 0 && f.BitSize <= 8 {
+		if f.BitSize > 0 {
 			decodeValue = reflect.ValueOf(bitExtractor{
-				Target:    f.Value.Addr().Interface().(*byte),
+				Target:    fv,
 				BitLength: f.BitSize,
 			})
-		} else if f.BitSize > 8 {
-			return wrapError(ErrInvalidBitLength, f.Name)
-		} else if f.Value.Kind() == reflect.Ptr {
-			f.Value.Set(reflect.New(f.Value.Type().Elem()))
-			decodeValue = f.Value
+		} else if fv.Kind() == reflect.Ptr {
+			fv.Set(reflect.New(fv.Type().Elem()))
+			decodeValue = fv
 		} else {
-			decodeValue = f.Value.Addr()
+			decodeValue = fv.Addr()
+		}
+
+		// Decode String values of Variants as NullStrings to tell null
+		// values apart.
+		if v, ok := rv.Addr().Interface().(*uatype.Variant); ok && dec.preserveNull && f.Name == "String" {
+			ns := make([]uatype.NullString, fv.Len())
+			if err := dec.decode(reflect.ValueOf(&ns)); err != nil {
+				return wrapError(err, f.Name)
+			}
+			setVariantNullStrings(v, ns)
+			continue
 		}
 
 		// Decode value.
-		err := dec.decode(decodeValue)
-		if err != nil {
+		if err := dec.decode(decodeValue); err != nil {
 			return wrapError(err, f.Name)
 		}
-		prevIndices[f.Name] = i
 	}
-	return nil
 
+	switch v := rv.Addr().Interface().(type) {
+	case *uatype.Variant:
+		if _, err := v.Dimensions(); err != nil {
+			return wrapError(err, "ArrayDimensions")
+		}
+	case *uatype.ExtensionObject:
+		if err := unwrapExtensionObject(dec, v); err != nil {
+			return wrapError(err, "Body")
+		}
+	}
+	return nil
 }
 
 // listUnmarshaler is intended to return a marshaler that would run slightly
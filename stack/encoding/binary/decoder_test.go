@@ -0,0 +1,63 @@
+package binary_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+	"testing/iotest"
+
+	"github.com/searis/guma/stack/encoding/binary"
+	"github.com/searis/guma/stack/uatype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// errReader returns err on all reads.
+type errReader struct {
+	err error
+}
+
+func (r errReader) Read([]byte) (int, error) {
+	return 0, r.err
+}
+
+func TestDecoderStreaming(t *testing.T) {
+	type streamStruct struct {
+		Name       string
+		NoOfValues int32
+		Values     []int32 `opcua:"lengthField=NoOfValues"`
+		Data       uatype.ByteString
+	}
+	first := streamStruct{
+		Name:       "first",
+		NoOfValues: 3,
+		Values:     []int32{1, 2, 3},
+		Data:       uatype.ByteString(bytes.Repeat([]byte{0xAB}, 10000)),
+	}
+	second := streamStruct{Name: "second"}
+
+	var data []byte
+	for _, v := range []streamStruct{first, second} {
+		p, err := binary.Marshal(v)
+		require.NoError(t, err, "Marshal")
+		data = append(data, p...)
+	}
+
+	// Values are decoded in sequence from a reader that returns one byte at
+	// a time, and no data is read past the last value.
+	readErr := errors.New("read past end")
+	r := io.MultiReader(iotest.OneByteReader(bytes.NewReader(data)), errReader{readErr})
+	dec := binary.NewDecoder(r)
+
+	var v streamStruct
+	require.NoError(t, dec.Decode(&v), "Decode first")
+	assert.Equal(t, first, v, "first value")
+	v = streamStruct{}
+	require.NoError(t, dec.Decode(&v), "Decode second")
+	assert.Equal(t, second.Name, v.Name, "second value")
+	assert.Equal(t, len(data), dec.BytesRead(), "BytesRead")
+
+	var extra uint32
+	assert.Equal(t, readErr, dec.Decode(&extra), "Decode past end")
+}
@@ -5,4 +5,19 @@
 // are defined in the uatype package, and can not be expected to handle all
 // Go types. As an example, int and uint types without a bit size suffix are
 // deliberately not handled.
+//
+// Structured types from the uatype package are encoded and decoded by
+// generated code, while other types are handled through reflection. Both
+// produce the same wire format.
+//
+// By default, null and empty ByteStrings and arrays are not told apart. Some
+// servers treat them differently, e.g. when writing a null rather than an
+// empty array, so Encoder.SetPreserveNull and Decoder.SetPreserveNull opt in
+// to encoding and decoding them exactly, as nil and empty, non-nil slices.
+// Such values are always handled through reflection. Strings can't be nil in
+// Go, so uatype.NullString is provided to tell null and empty strings apart.
+//
+// A Decoder trusts the length prefixes of its input unless limits are set
+// through Decoder.SetLimits. Set DecoderLimits when decoding untrusted input,
+// such as responses from a server.
 package binary
@@ -13,9 +13,10 @@ import (
 	"github.com/searis/guma/stack/uatype"
 )
 
-// bitSlice is a helper struct that can be used to marshal slices of 1-8 bits.
+// bitSlice is a helper struct that can be used to marshal slices of 1-64
+// bits.
 type bitSlice struct {
-	Data      byte
+	Data      uint64
 	BitLength byte
 }
 
@@ -33,8 +34,11 @@ func Marshal(v interface{}) ([]byte, error) {
 type Encoder struct {
 	w             io.Writer
 	n             int64
+	buf           []byte // reused by generated encoders
 	bitMarshaler  bitCacheMarshaler
 	byteMarshaler byteMarshaler
+	preserveNull  bool
+	types         *uatype.ExtensionObjectTypes
 }
 
 // NewEncoder takes a writer object where OPC UA data will be written on calls
@@ -59,8 +63,14 @@ func (enc *Encoder) Encode(v interface{}) (err error) {
 	}()
 
 	rv := reflect.ValueOf(v)
+	enc.bitMarshaler.Reset()
 
-	switch err := enc.encode(rv).(type) {
+	err = enc.encode(rv)
+	if err == nil && !enc.bitMarshaler.Aligned() {
+		// Bits that don't fill a byte can't be written.
+		err = ErrBitAlignment
+	}
+	switch err := err.(type) {
 	case transcoderError:
 		typeName := rv.Type().Name()
 		return EncoderError{err, typeName}
@@ -116,22 +126,29 @@ func (enc *Encoder) encode(rv reflect.Value) error {
 			return err
 		}
 		m = &enc.bitMarshaler
+	case uatype.ByteString:
+		if enc.preserveNull {
+			m = nullByteString(iv)
+		} else {
+			m = iv
+		}
 	case encoding.BinaryMarshaler:
 		// Prefer BinaryMarshaler over BitLengther, if implemented.
 		m = iv
 	case BitLengther:
 		nBits := iv.BitLength()
-		if nBits < 8 {
-			// If the underlying type is not a byte, we wil panic.
-			if rv.Kind() != reflect.Uint8 {
-				return fmt.Errorf("bit lenght below 8 must have an underlying byte type, type was %s", rv.Type().Name())
+		if nBits < 1 || nBits > 64 {
+			return ErrInvalidBitLength
+		}
+		if nBits%8 != 0 || !enc.bitMarshaler.Aligned() {
+			// Values that are not byte aligned are packed as bits.
+			if !isUint(rv.Kind()) {
+				return fmt.Errorf("bit length %d must have an underlying unsigned integer type, type was %s", nBits, rv.Type().Name())
 			}
-			if err := enc.bitMarshaler.SetBits(uint8(rv.Uint()), byte(nBits)); err != nil {
+			if err := enc.bitMarshaler.SetBits(rv.Uint(), byte(nBits)); err != nil {
 				return err
 			}
 			m = &enc.bitMarshaler
-		} else if nBits%8 != 0 {
-			return fmt.Errorf("bit length above 8 must be aligned to 8 bits; bit length was %d", nBits)
 		} else {
 			enc.byteMarshaler.SetData(iv)
 			enc.byteMarshaler.SetSlice(0, uint(nBits/8))
@@ -145,7 +162,21 @@ func (enc *Encoder) encode(rv reflect.Value) error {
 				return enc.encodeList(rv)
 			}
 		case reflect.Struct:
+			// Generated code does not preserve null values, and only
+			// knows the globally registered ExtensionObject types.
+			if generated && !enc.preserveNull && enc.types == nil {
+				if ok, err := enc.encodeGenerated(rv); ok {
+					return err
+				}
+			}
 			return enc.encodeStruct(rv)
+		case reflect.Ptr:
+			// Encode nil pointers as the zero value, like the decoder
+			// allocates them.
+			if rv.IsNil() {
+				return enc.encode(reflect.Zero(rv.Type().Elem()))
+			}
+			return enc.encode(rv.Elem())
 		case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Int, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uint:
 			enc.byteMarshaler.SetData(iv)
 			m = &enc.byteMarshaler
@@ -155,10 +186,13 @@ func (enc *Encoder) encode(rv reflect.Value) error {
 	}
 
 	data, err := m.MarshalBinary()
-	enc.n += int64(len(data))
 	if err != nil || len(data) == 0 {
 		return err
 	}
+	if m != &enc.bitMarshaler && !enc.bitMarshaler.Aligned() {
+		return ErrBitAlignment
+	}
+	enc.n += int64(len(data))
 
 	err = binary.Write(enc.w, binary.LittleEndian, data)
 	if err != nil {
@@ -176,103 +210,83 @@ func (enc *Encoder) encodeList(rv reflect.Value) error {
 	return nil
 }
 
-func (enc *Encoder) encodeStruct(rv reflect.Value) error {
-	fields, err := gatherFields(nil, rv)
-	if err != nil {
-		return err
+// encodeGenerated encodes rv using generated code. ok is false if there is no
+// generated code for the type of rv.
+func (enc *Encoder) encodeGenerated(rv reflect.Value) (ok bool, err error) {
+	b, ok, err := appendGenerated(enc.buf[:0], rv.Interface())
+	if !ok || err != nil {
+		return ok, err
 	}
+	if len(b) > 0 && !enc.bitMarshaler.Aligned() {
+		return true, ErrBitAlignment
+	}
+	enc.buf = b
+	enc.n += int64(len(b))
+	_, err = enc.w.Write(b)
+	return true, err
+}
 
-	prevIndices := map[string]int{}
+func (enc *Encoder) encodeStruct(rv reflect.Value) error {
+	// Encode the Value of ExtensionObjects into the Body.
+	if eo, ok := rv.Interface().(uatype.ExtensionObject); ok && eo.Value != nil {
+		eo, err := wrapExtensionObject(enc, eo)
+		if err != nil {
+			return wrapError(err, "Value")
+		}
+		rv = reflect.ValueOf(eo)
+	}
 
-	for i, f := range fields {
+	// Refuse to encode Variant arrays with inconsistent dimensions.
+	var nullStrings []uatype.NullString
+	if v, ok := rv.Interface().(uatype.Variant); ok {
+		if _, err := v.Dimensions(); err != nil {
+			return wrapError(err, "ArrayDimensions")
+		}
+		if enc.preserveNull && v.VariantType == 12 {
+			nullStrings = variantNullStrings(v)
+		}
+	}
 
-		// Continue if switch field value does not match.
-		if f.SwitchValue != -1 {
-			if f.SwitchField == "" {
-				return wrapError(ErrInvalidTag, f.Name)
-			}
-			var v int64
-			switch fields[prevIndices[f.SwitchField]].Value.Kind() {
-			case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Int:
-				v = fields[prevIndices[f.SwitchField]].Value.Int()
-			case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uint:
-				v = int64(fields[prevIndices[f.SwitchField]].Value.Uint())
-			default:
-				return wrapError(ErrInvalidTag, fmt.Errorf("Invalid SwitchValue [%s] for field [%s]", fields[prevIndices[f.SwitchField]].Value.Kind(), f.Name))
-			}
+	plan, err := planFor(rv.Type())
+	if err != nil {
+		return err
+	}
 
-			if f.SwitchOperand != "" {
-				switch f.SwitchOperand {
-				case "Equals":
-					if v == f.SwitchValue {
-						goto process
-					}
-				case "GreaterThan":
-					if v > f.SwitchValue {
-						goto process
-					}
-				case "LessThan":
-					if v < f.SwitchValue {
-						goto process
-					}
-				case "GreaterThanOrEqual":
-					if v >= f.SwitchValue {
-						goto process
-					}
-				case "LessThanOrEqual":
-					if v <= f.SwitchValue {
-						goto process
-					}
-				case "NotEqual":
-					if v != f.SwitchValue {
-						goto process
-					}
-				default:
-					return wrapError(ErrInvalidTag, fmt.Errorf("Invalid SwithcOperand [%s] for field [%s]", f.SwitchOperand, f.Name))
-				}
-				continue
-			}
-			// There might be switchValiues without any defined Operator, this means that we should
-			// continue if the value and field does not match
-			if v != f.SwitchValue {
-				continue
-			}
-		} else if f.SwitchField != "" {
-			if !fields[prevIndices[f.SwitchField]].Value.Bool() {
-				continue
-			}
+	for i := range plan {
+		if !plan.active(rv, i) {
+			continue
 		}
-	process:
+		f := &plan[i]
+		fv := rv.Field(f.Index)
 
-		// Assert that length field is set correctly.
-		if f.LengthField != "" {
-			e := int(fields[prevIndices[f.LengthField]].Value.Int())
-			l := f.Value.Len()
-			if l != e {
+		// Assert that length field is set correctly. A length field that has
+		// been switched off means that exactly one element should be encoded,
+		// which is how scalar Variant values are described.
+		if f.LengthField >= 0 {
+			e := plan.length(rv, f)
+			l := fv.Len()
+			if l != e && !(l == 0 && e < 0) {
 				debugLogger.Printf("length (%d) != expected length (%d)", l, e)
 				return wrapError(ErrInvalidLength, f.Name)
 			}
 		}
 
 		// Get/wrap value to encode.
-		var re reflect.Value
-		if f.BitSize > 0 && f.BitSize <= 8 {
+		re := fv
+		if f.BitSize > 0 {
 			re = reflect.ValueOf(bitSlice{
-				Data:      f.Value.Interface().(byte),
+				Data:      fv.Uint(),
 				BitLength: f.BitSize,
 			})
-		} else if f.BitSize > 8 {
-			return wrapError(ErrInvalidBitLength, f.Name)
-		} else {
-			re = f.Value
+		}
+		if nullStrings != nil && f.Name == "String" {
+			re = reflect.ValueOf(nullStrings)
 		}
 
 		// Encode value.
-		err := enc.encode(re)
-		if err != nil {
+		if err := enc.encode(re); err != nil {
 			return wrapError(err, f.Name)
 		}
-		prevIndices[f.Name] = i
 	}
 	return nil
 }
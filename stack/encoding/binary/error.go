@@ -7,11 +7,14 @@ import (
 )
 
 // Common errors that may be returned as the cause for EncoderError and
-// DecoderError.
+// DecoderError. Decoder limits are reported with a LimitError cause, which
+// describes itself as ErrLimitExceeded.
 var (
-	ErrInvalidBitLength = errors.New("bit length not in range 1-8")
+	ErrBitAlignment     = errors.New("bit fields do not fill a byte")
+	ErrInvalidBitLength = errors.New("bit length not in range 1-64")
 	ErrInvalidLength    = errors.New("length don't match length field value")
 	ErrInvalidTag       = errors.New("invalid struct tag")
+	ErrLimitExceeded    = errors.New("decoder limit exceeded")
 	ErrNotSetable       = errors.New("value must be a pointer")
 	ErrUnknownType      = errors.New("can not handle type")
 )
@@ -0,0 +1,9 @@
+package binary
+
+// SetGenerated decides if generated encoders and decoders are used, and
+// returns the previous setting. Tests that call SetGenerated must not run in
+// parallel with other tests.
+func SetGenerated(on bool) (prev bool) {
+	prev, generated = generated, on
+	return prev
+}
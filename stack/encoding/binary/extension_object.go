@@ -0,0 +1,176 @@
+package binary
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/searis/guma/stack/uatype"
+)
+
+// ExtensionObject Encoding values used when the Body holds an OPC UA binary or
+// XML encoded structure.
+const (
+	extensionObjectBinaryBody uint8 = 1
+	extensionObjectXMLBody    uint8 = 2
+)
+
+// XMLBodyDecoder decodes body, the XML encoding of a structured value, into
+// v, which points to a value of a type registered through
+// uatype.RegisterExtensionObjectXML. It must not exceed limits.
+type XMLBodyDecoder func(body []byte, v interface{}, limits DecoderLimits) error
+
+// xmlBodyDecoder holds the registered XMLBodyDecoder.
+var xmlBodyDecoder struct {
+	sync.RWMutex
+	f XMLBodyDecoder
+}
+
+// RegisterXMLBodyDecoder sets the function used by Decoders to decode XML
+// bodies of ExtensionObjects. Until one is registered, XML bodies are not
+// decoded. The xml package registers its decoder on initialization.
+func RegisterXMLBodyDecoder(f XMLBodyDecoder) {
+	xmlBodyDecoder.Lock()
+	defer xmlBodyDecoder.Unlock()
+	xmlBodyDecoder.f = f
+}
+
+// SetExtensionObjectTypes sets the ExtensionObject types of a server to use
+// for subsequent calls to Encode, in addition to the types registered through
+// uatype.RegisterExtensionObject. If types is nil, only the latter are used.
+func (enc *Encoder) SetExtensionObjectTypes(types *uatype.ExtensionObjectTypes) {
+	enc.types = types
+}
+
+// SetExtensionObjectTypes sets the ExtensionObject types of a server to use
+// for subsequent calls to Decode, in addition to the types registered through
+// uatype.RegisterExtensionObject. If types is nil, only the latter are used.
+func (dec *Decoder) SetExtensionObjectTypes(types *uatype.ExtensionObjectTypes) {
+	dec.types = types
+}
+
+// extensionObjectEncodingID returns the binary encoding node ID of the type of
+// v from types, if not nil, or from the global registry.
+func extensionObjectEncodingID(types *uatype.ExtensionObjectTypes, v interface{}) (uatype.NodeId, bool) {
+	if types != nil {
+		if id, ok := types.EncodingID(v); ok {
+			return id, true
+		}
+	}
+	return uatype.ExtensionObjectEncodingID(v)
+}
+
+// extensionObjectType returns the Go type for the binary encoding node ID
+// encodingID from types, if not nil, or from the global registry.
+func extensionObjectType(types *uatype.ExtensionObjectTypes, encodingID uatype.ExpandedNodeId) (reflect.Type, bool) {
+	if types != nil {
+		if rt, ok := types.Type(encodingID); ok {
+			return rt, true
+		}
+	}
+	return uatype.ExtensionObjectType(encodingID)
+}
+
+// wrapExtensionObject returns a copy of eo where TypeId, Encoding and Body is
+// set from eo.Value, which must be of a type registered through
+// uatype.RegisterExtensionObject or in the ExtensionObject types of enc. The
+// Body preserves null values if enc does. enc may be nil.
+func wrapExtensionObject(enc *Encoder, eo uatype.ExtensionObject) (uatype.ExtensionObject, error) {
+	var types *uatype.ExtensionObjectTypes
+	var preserveNull bool
+	if enc != nil {
+		types, preserveNull = enc.types, enc.preserveNull
+	}
+	id, ok := extensionObjectEncodingID(types, eo.Value)
+	if !ok {
+		return eo, fmt.Errorf("%s: %T is not a registered ExtensionObject type", ErrUnknownType, eo.Value)
+	}
+	v := reflect.ValueOf(eo.Value)
+	for v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+	var buf bytes.Buffer
+	body := NewEncoder(&buf)
+	body.SetPreserveNull(preserveNull)
+	body.SetExtensionObjectTypes(types)
+	if err := body.Encode(v.Interface()); err != nil {
+		return eo, err
+	}
+	eo.TypeId = id.Expanded()
+	eo.Encoding = extensionObjectBinaryBody
+	eo.BodyLength = int32(buf.Len())
+	eo.Body = buf.Bytes()
+	return eo, nil
+}
+
+// unwrapExtensionObject decodes eo.Body into eo.Value if eo has a binary body
+// and a TypeId that is registered through uatype.RegisterExtensionObject or in
+// the ExtensionObject types of dec, or an
+// XML body and a TypeId that is registered through
+// uatype.RegisterExtensionObjectXML and an XMLBodyDecoder is registered.
+// Otherwise eo is left untouched. Bodies are decoded within the limits of dec,
+// and binary bodies preserve null values if dec does. If a body can not be
+// decoded, eo.ValueError is set and Value is left nil; only exceeded limits
+// are returned as errors.
+func unwrapExtensionObject(dec *Decoder, eo *uatype.ExtensionObject) error {
+	switch eo.Encoding {
+	case extensionObjectBinaryBody:
+		rt, ok := extensionObjectType(dec.types, eo.TypeId)
+		if !ok {
+			return nil
+		}
+		rv := reflect.New(rt)
+		body := &Decoder{
+			data:      eo.Body,
+			limits:    dec.limits,
+			depth:     dec.depth,
+			allocated: dec.allocated,
+
+			preserveNull: dec.preserveNull,
+			types:        dec.types,
+		}
+		err := body.decode(rv)
+		dec.allocated = body.allocated
+		if err != nil {
+			return valueError(eo, err, rt)
+		}
+		eo.Value = rv.Elem().Interface()
+	case extensionObjectXMLBody:
+		xmlBodyDecoder.RLock()
+		decode := xmlBodyDecoder.f
+		xmlBodyDecoder.RUnlock()
+		rt, ok := uatype.ExtensionObjectXMLType(eo.TypeId)
+		if !ok || decode == nil {
+			return nil
+		}
+		limits, err := dec.remainingLimits()
+		if err != nil {
+			return err
+		}
+		rv := reflect.New(rt)
+		if err := decode(eo.Body, rv.Interface(), limits); err != nil {
+			return valueError(eo, err, rt)
+		}
+		eo.Value = rv.Elem().Interface()
+	}
+	return nil
+}
+
+// valueError sets eo.ValueError to err, the error of decoding the body of eo
+// into a value of type rt, unless it's caused by an exceeded limit, which is
+// returned instead, as the limits apply to the response as a whole.
+func valueError(eo *uatype.ExtensionObject, err error, rt reflect.Type) error {
+	cause := err
+	if e, ok := err.(transcoderError); ok {
+		cause = e.cause
+	}
+	if _, ok := cause.(LimitError); ok {
+		return err
+	}
+	if e, ok := err.(transcoderError); ok {
+		err = DecoderError{e, rt.Name()}
+	}
+	eo.ValueError = err
+	return nil
+}
@@ -0,0 +1,201 @@
+package binary_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/searis/guma/internal/testutil"
+	"github.com/searis/guma/stack/encoding/binary"
+	_ "github.com/searis/guma/stack/encoding/xml" // decodes XML bodies
+	"github.com/searis/guma/stack/uatype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type vendorStruct struct {
+	Speed float32
+	Name  string
+}
+
+func init() {
+	uatype.RegisterExtensionObject(uatype.NewStringNodeID(2, "VendorStruct"), vendorStruct{})
+}
+
+func TestExtensionObject(t *testing.T) {
+	anonymousBody := []byte{
+		9, 0, 0, 0, 'a', 'n', 'o', 'n', 'y', 'm', 'o', 'u', 's',
+	}
+	anonymousTypeID := uatype.NewFourByteNodeID(0, uatype.NodeIdAnonymousIdentityToken_Encoding_DefaultBinary).Expanded()
+	anonymousXMLBody := []byte("<AnonymousIdentityToken><PolicyId>anonymous</PolicyId></AnonymousIdentityToken>")
+	anonymousXMLTypeID := uatype.NewFourByteNodeID(0, uatype.NodeIdAnonymousIdentityToken_Encoding_DefaultXml).Expanded()
+
+	cases := []testutil.TranscoderTest{
+		{
+			SubTests: testutil.TestEncode,
+			Name:     "AnonymousIdentityToken",
+			Unmarshaled: uatype.ExtensionObject{
+				Value: uatype.AnonymousIdentityToken{PolicyId: "anonymous"},
+			},
+			Marshaled: append([]byte{
+				0x01, 0x00, 0x41, 0x01, // TypeId
+				0x01,        // Encoding
+				13, 0, 0, 0, // BodyLength
+			}, anonymousBody...),
+		},
+		{
+			SubTests: testutil.TestEncode | testutil.TestDecode,
+			Name:     "AnonymousIdentityToken/Decode",
+			Unmarshaled: uatype.ExtensionObject{
+				TypeId:     anonymousTypeID,
+				Encoding:   1,
+				BodyLength: 13,
+				Body:       anonymousBody,
+				Value:      uatype.AnonymousIdentityToken{PolicyId: "anonymous"},
+			},
+			DecodeTarget: new(uatype.ExtensionObject),
+			Marshaled: append([]byte{
+				0x01, 0x00, 0x41, 0x01,
+				0x01,
+				13, 0, 0, 0,
+			}, anonymousBody...),
+		},
+		{
+			SubTests: testutil.TestDecode,
+			Name:     "AnonymousIdentityToken/DecodeXML",
+			Unmarshaled: uatype.ExtensionObject{
+				TypeId:     anonymousXMLTypeID,
+				Encoding:   2,
+				BodyLength: int32(len(anonymousXMLBody)),
+				Body:       anonymousXMLBody,
+				Value:      uatype.AnonymousIdentityToken{PolicyId: "anonymous"},
+			},
+			DecodeTarget: new(uatype.ExtensionObject),
+			Marshaled: append([]byte{
+				0x01, 0x00, 0x40, 0x01,
+				0x02,
+				byte(len(anonymousXMLBody)), 0, 0, 0,
+			}, anonymousXMLBody...),
+		},
+		{
+			SubTests: testutil.TestEncode | testutil.TestDecode,
+			Name:     "unregistered",
+			Unmarshaled: uatype.ExtensionObject{
+				TypeId:     uatype.NewNumericNodeID(3, 0x01020304).Expanded(),
+				Encoding:   1,
+				BodyLength: 2,
+				Body:       []byte{0xCA, 0xFE},
+			},
+			DecodeTarget: new(uatype.ExtensionObject),
+			Marshaled: []byte{
+				0x02, 0x03, 0x00, 0x04, 0x03, 0x02, 0x01,
+				0x01,
+				2, 0, 0, 0,
+				0xCA, 0xFE,
+			},
+		},
+		{
+			SubTests:    testutil.TestEncode,
+			Name:        "unregisteredValue",
+			Unmarshaled: uatype.ExtensionObject{Value: int32(42)},
+			EncodeError: "EncoderError ExtensionObject.Value: can not handle type: int32 is not a registered ExtensionObject type",
+		},
+	}
+	for i := range cases {
+		cases[i].Run(t)
+	}
+}
+
+func TestExtensionObjectVendorType(t *testing.T) {
+	v := vendorStruct{Speed: 1.5, Name: "pump"}
+	data, err := binary.Marshal(uatype.ExtensionObject{Value: &v})
+	require.NoError(t, err, "binary.Marshal")
+
+	var eo uatype.ExtensionObject
+	require.NoError(t, binary.Unmarshal(data, &eo), "binary.Unmarshal")
+	assert.Equal(t, uatype.NodeIdTypeString, eo.TypeId.NodeIdType, "eo.TypeId.NodeIdType")
+	assert.Equal(t, "VendorStruct", eo.TypeId.StringID.Identifier, "eo.TypeId.StringID.Identifier")
+	assert.Equal(t, v, eo.Value, "eo.Value")
+
+	// Values are also unwrapped when nested in a Variant.
+	variant, err := uatype.NewVariant(eo)
+	require.NoError(t, err, "uatype.NewVariant")
+	data, err = binary.Marshal(variant)
+	require.NoError(t, err, "binary.Marshal(variant)")
+	var decoded uatype.Variant
+	require.NoError(t, binary.Unmarshal(data, &decoded), "binary.Unmarshal(variant)")
+	r, err := decoded.Value()
+	require.NoError(t, err, "decoded.Value()")
+	assert.Equal(t, v, r.(uatype.ExtensionObject).Value, "decoded.Value().Value")
+}
+
+func TestExtensionObjectValueError(t *testing.T) {
+	good, err := binary.Marshal(vendorStruct{Speed: 1.5, Name: "pump"})
+	require.NoError(t, err, "binary.Marshal")
+	bad := []byte{0, 0, 0xc0, 0x3f, 0xff, 0, 0, 0} // Name is longer than the body
+	typeID := uatype.NewStringNodeID(2, "VendorStruct").Expanded()
+	variant, err := uatype.NewVariant([]uatype.ExtensionObject{
+		{TypeId: typeID, Encoding: 1, BodyLength: int32(len(bad)), Body: bad},
+		{TypeId: typeID, Encoding: 1, BodyLength: int32(len(good)), Body: good},
+	})
+	require.NoError(t, err, "uatype.NewVariant")
+	data, err := binary.Marshal(variant)
+	require.NoError(t, err, "binary.Marshal(variant)")
+
+	// A body that can't be decoded doesn't fail the other values.
+	var decoded uatype.Variant
+	require.NoError(t, binary.Unmarshal(data, &decoded), "binary.Unmarshal")
+	require.Len(t, decoded.ExtensionObject, 2, "decoded.ExtensionObject")
+	eo := decoded.ExtensionObject[0]
+	assert.Nil(t, eo.Value, "[0].Value")
+	assert.Equal(t, bad, eo.Body, "[0].Body")
+	assert.IsType(t, binary.DecoderError{}, eo.ValueError, "[0].ValueError")
+	eo = decoded.ExtensionObject[1]
+	assert.Equal(t, vendorStruct{Speed: 1.5, Name: "pump"}, eo.Value, "[1].Value")
+	assert.NoError(t, eo.ValueError, "[1].ValueError")
+}
+
+func TestExtensionObjectXMLBodyLimits(t *testing.T) {
+	body := []byte("<AnonymousIdentityToken><PolicyId>anonymous</PolicyId></AnonymousIdentityToken>")
+	data, err := binary.Marshal(uatype.ExtensionObject{
+		TypeId:     uatype.NewFourByteNodeID(0, uatype.NodeIdAnonymousIdentityToken_Encoding_DefaultXml).Expanded(),
+		Encoding:   2,
+		BodyLength: int32(len(body)),
+		Body:       body,
+	})
+	require.NoError(t, err, "binary.Marshal")
+
+	cases := []struct {
+		Name   string
+		Limits binary.DecoderLimits
+		Limit  string
+	}{
+		{Name: "MaxStringLength", Limits: binary.DecoderLimits{MaxStringLength: 8}, Limit: "MaxStringLength"},
+		{Name: "MaxDepth", Limits: binary.DecoderLimits{MaxDepth: 2}, Limit: "MaxDepth"},
+		{Name: "MaxAlloc", Limits: binary.DecoderLimits{MaxAlloc: 200}, Limit: "MaxAlloc"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			dec := binary.NewDecoder(bytes.NewReader(data))
+			dec.SetLimits(tc.Limits)
+			var eo uatype.ExtensionObject
+			err := dec.Decode(&eo)
+			require.IsType(t, binary.DecoderError{}, err, "Decode")
+			cause, ok := err.(binary.DecoderError).Cause().(binary.LimitError)
+			require.True(t, ok, "cause is %T", err.(binary.DecoderError).Cause())
+			assert.Equal(t, tc.Limit, cause.Limit, "cause.Limit")
+		})
+	}
+}
+
+func TestExtensionObjectRegistry(t *testing.T) {
+	rt, ok := uatype.ExtensionObjectType(uatype.NewNumericNodeID(0, uint32(uatype.NodeIdReadRequest_Encoding_DefaultBinary)).Expanded())
+	assert.True(t, ok, "ExtensionObjectType(ReadRequest) ok")
+	assert.Equal(t, "ReadRequest", rt.Name(), "ExtensionObjectType(ReadRequest)")
+
+	id, ok := uatype.ExtensionObjectEncodingID(&uatype.DataChangeNotification{})
+	assert.True(t, ok, "ExtensionObjectEncodingID(DataChangeNotification) ok")
+	assert.Equal(t, uatype.NodeIdDataChangeNotification_Encoding_DefaultBinary, id.Uint(), "ExtensionObjectEncodingID(DataChangeNotification)")
+
+	_, ok = uatype.ExtensionObjectType(uatype.NewTwoByteNodeID(0).Expanded())
+	assert.False(t, ok, "ExtensionObjectType(0) ok")
+}
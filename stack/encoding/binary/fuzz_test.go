@@ -0,0 +1,84 @@
+package binary_test
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/searis/guma/internal/testutil"
+	"github.com/searis/guma/stack/encoding/binary"
+	"github.com/searis/guma/stack/uatype"
+)
+
+// responseTypes returns the registered response types, including
+// ServiceFault.
+func responseTypes() []reflect.Type {
+	var types []reflect.Type
+	for _, rt := range testutil.RegisteredTypes() {
+		if strings.HasSuffix(rt.Name(), "Response") || rt.Name() == "ServiceFault" {
+			types = append(types, rt)
+		}
+	}
+	return types
+}
+
+// FuzzUnmarshalResponse decodes the input into the response type selected by
+// its first byte, with both the generated and the reflection based decoders.
+// Neither may panic, and both must agree on the result.
+func FuzzUnmarshalResponse(f *testing.F) {
+	types := responseTypes()
+	index := make(map[reflect.Type]byte, len(types))
+	for i, rt := range types {
+		index[rt] = byte(i)
+	}
+	for _, v := range []interface{}{
+		readResponse(f),
+		publishResponse(f),
+		uatype.ServiceFault{ResponseHeader: uatype.ResponseHeader{ServiceDiagnostics: &uatype.DiagnosticInfo{}}},
+	} {
+		data, err := binary.Marshal(v)
+		if err != nil {
+			f.Fatal(err)
+		}
+		f.Add(append([]byte{index[reflect.TypeOf(v)]}, data...))
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		if len(data) == 0 {
+			return
+		}
+		rt := types[int(data[0])%len(types)]
+		data = data[1:]
+
+		var values [2]reflect.Value
+		var errs [2]error
+		for i, generated := range []bool{true, false} {
+			values[i] = reflect.New(rt)
+			errs[i] = decodeLimited(data, values[i].Interface(), binary.DefaultDecoderLimits(len(data)), generated)
+			if errs[i] != nil && strings.Contains(errs[i].Error(), "recovered from panic") {
+				t.Fatalf("%s generated=%t: %s", rt.Name(), generated, errs[i])
+			}
+		}
+		if (errs[0] == nil) != (errs[1] == nil) {
+			t.Fatalf("%s: generated error %v, reflection error %v", rt.Name(), errs[0], errs[1])
+		}
+		if errs[0] != nil {
+			return
+		}
+
+		// Compare the values by their encoding, as reflect.DeepEqual never
+		// considers NaN floats equal.
+		var encoded [2][]byte
+		for i := range values {
+			b, err := binary.Marshal(values[i].Interface())
+			if err != nil {
+				t.Fatalf("%s: Marshal decoded value: %s", rt.Name(), err)
+			}
+			encoded[i] = b
+		}
+		if !bytes.Equal(encoded[0], encoded[1]) {
+			t.Fatalf("%s: generated and reflection decoders disagree", rt.Name())
+		}
+	})
+}
@@ -0,0 +1,168 @@
+package binary
+
+import (
+	"fmt"
+
+	"github.com/searis/guma/stack/uatype"
+)
+
+// DefaultMaxDepth is the maximum nesting depth of structured values used by
+// DefaultDecoderLimits.
+const DefaultMaxDepth = 100
+
+// defaultAllocFactor is the number of bytes DefaultDecoderLimits allows to be
+// allocated per byte of the max message size. Decoded values take up more
+// memory than their encoding, e.g. a DataValue occupies well over a hundred
+// bytes in memory, but may be encoded as a single byte.
+const defaultAllocFactor = 64
+
+// DecoderLimits limits the resources a Decoder may spend on a single call to
+// Decode, so that malicious or corrupt input can't exhaust memory or stack.
+// A zero value for any of the limits means that there is no limit.
+type DecoderLimits struct {
+	// MaxStringLength is the maximum size in bytes of a String.
+	MaxStringLength int
+
+	// MaxByteStringLength is the maximum size in bytes of a ByteString.
+	MaxByteStringLength int
+
+	// MaxArrayLength is the maximum number of elements of an array.
+	MaxArrayLength int
+
+	// MaxDepth is the maximum nesting depth of structured values, such as a
+	// DiagnosticInfo with inner diagnostic infos.
+	MaxDepth int
+
+	// MaxAlloc is the maximum total number of bytes allocated for Strings,
+	// ByteStrings and arrays.
+	MaxAlloc int
+}
+
+// DefaultDecoderLimits returns limits suitable for decoding a message of at
+// most maxMessageSize bytes, as negotiated for a secure channel. Strings,
+// ByteStrings and arrays are limited to maxMessageSize, and the total
+// allocation to a multiple of it. If maxMessageSize is zero, only the nesting
+// depth is limited.
+func DefaultDecoderLimits(maxMessageSize int) DecoderLimits {
+	if maxMessageSize <= 0 {
+		return DecoderLimits{MaxDepth: DefaultMaxDepth}
+	}
+	return DecoderLimits{
+		MaxStringLength:     maxMessageSize,
+		MaxByteStringLength: maxMessageSize,
+		MaxArrayLength:      maxMessageSize,
+		MaxDepth:            DefaultMaxDepth,
+		MaxAlloc:            defaultAllocFactor * maxMessageSize,
+	}
+}
+
+// LimitError is the cause of a DecoderError when decoding exceeds one of the
+// DecoderLimits.
+type LimitError struct {
+	// Limit is the name of the exceeded DecoderLimits field, e.g.
+	// "MaxArrayLength".
+	Limit string
+
+	// Value is the length, depth or number of bytes that exceeded Max.
+	Value int
+	Max   int
+}
+
+// limitDescriptions describes what each of the DecoderLimits limits.
+var limitDescriptions = map[string]string{
+	"MaxStringLength":     "string length",
+	"MaxByteStringLength": "byte string length",
+	"MaxArrayLength":      "array length",
+	"MaxDepth":            "nesting depth",
+	"MaxAlloc":            "allocated bytes",
+}
+
+// Error returns a human readable description of the error.
+func (err LimitError) Error() string {
+	return fmt.Sprintf("%s: %s %d > %d", ErrLimitExceeded, limitDescriptions[err.Limit], err.Value, err.Max)
+}
+
+// SetLimits sets the limits used by subsequent calls to Decode. A Decoder
+// returned by NewDecoder has no limits.
+func (dec *Decoder) SetLimits(l DecoderLimits) {
+	dec.limits = l
+}
+
+// remainingLimits returns the limits of dec, with MaxDepth and MaxAlloc
+// reduced by what the current call to Decode has used. A LimitError is
+// returned if nothing remains.
+func (dec *Decoder) remainingLimits() (DecoderLimits, error) {
+	l := dec.limits
+	if max := l.MaxDepth; max > 0 {
+		if l.MaxDepth -= dec.depth; l.MaxDepth <= 0 {
+			return l, LimitError{Limit: "MaxDepth", Value: dec.depth + 1, Max: max}
+		}
+	}
+	if max := l.MaxAlloc; max > 0 {
+		if l.MaxAlloc -= dec.allocated; l.MaxAlloc <= 0 {
+			return l, LimitError{Limit: "MaxAlloc", Value: dec.allocated + 1, Max: max}
+		}
+	}
+	return l, nil
+}
+
+// allocString checks that a String of size bytes may be decoded.
+func (dec *Decoder) allocString(size int) error {
+	if max := dec.limits.MaxStringLength; max > 0 && size > max {
+		return LimitError{Limit: "MaxStringLength", Value: size, Max: max}
+	}
+	return dec.alloc(size)
+}
+
+// allocByteString checks that a ByteString of size bytes may be decoded.
+func (dec *Decoder) allocByteString(size int) error {
+	if max := dec.limits.MaxByteStringLength; max > 0 && size > max {
+		return LimitError{Limit: "MaxByteStringLength", Value: size, Max: max}
+	}
+	return dec.alloc(size)
+}
+
+// allocArray checks that an array of n elements of elemSize bytes each may be
+// allocated.
+func (dec *Decoder) allocArray(n, elemSize int) error {
+	if max := dec.limits.MaxArrayLength; max > 0 && n > max {
+		return LimitError{Limit: "MaxArrayLength", Value: n, Max: max}
+	}
+	return dec.alloc(n * elemSize)
+}
+
+// checkVariantArrayLength checks that a Variant array of n elements may be
+// decoded, before anything is allocated for it. Without a MaxArrayLength
+// limit, uatype.MaxVariantArrayLength applies.
+func (dec *Decoder) checkVariantArrayLength(n int) error {
+	if max := dec.limits.MaxArrayLength; max > 0 && n > max {
+		return LimitError{Limit: "MaxArrayLength", Value: n, Max: max}
+	}
+	if n > uatype.MaxVariantArrayLength {
+		return uatype.ErrVariantArrayTooLong
+	}
+	return nil
+}
+
+// alloc accounts for size bytes being allocated by the current call to Decode.
+func (dec *Decoder) alloc(size int) error {
+	dec.allocated += size
+	if max := dec.limits.MaxAlloc; max > 0 && dec.allocated > max {
+		return LimitError{Limit: "MaxAlloc", Value: dec.allocated, Max: max}
+	}
+	return nil
+}
+
+// enter must be called before decoding the fields of a structured value, and
+// leave after.
+func (dec *Decoder) enter() error {
+	dec.depth++
+	if max := dec.limits.MaxDepth; max > 0 && dec.depth > max {
+		return LimitError{Limit: "MaxDepth", Value: dec.depth, Max: max}
+	}
+	return nil
+}
+
+func (dec *Decoder) leave() {
+	dec.depth--
+}
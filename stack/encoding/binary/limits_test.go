@@ -0,0 +1,141 @@
+package binary_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/searis/guma/stack/encoding/binary"
+	"github.com/searis/guma/stack/uatype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// decodeLimited decodes data into v within l, with or without the generated
+// decoders.
+func decodeLimited(data []byte, v interface{}, l binary.DecoderLimits, generated bool) error {
+	defer binary.SetGenerated(binary.SetGenerated(generated))
+	dec := binary.NewDecoder(bytes.NewReader(data))
+	dec.SetLimits(l)
+	return dec.Decode(v)
+}
+
+func TestDecoderLimits(t *testing.T) {
+	request, err := binary.Marshal(readRequest())
+	require.NoError(t, err, "Marshal ReadRequest")
+
+	// A response that claims to hold 2^31-1 results.
+	header, err := binary.Marshal(uatype.ResponseHeader{ServiceDiagnostics: &uatype.DiagnosticInfo{}})
+	require.NoError(t, err, "Marshal ResponseHeader")
+	hostile := append(header, 0xff, 0xff, 0xff, 0x7f)
+
+	// A DiagnosticInfo with 200 nested inner diagnostic infos.
+	nested := append(bytes.Repeat([]byte{0x40}, 200), 0x00)
+
+	cases := []struct {
+		Name   string
+		Data   []byte
+		Value  func() interface{}
+		Limits binary.DecoderLimits
+		Cause  binary.LimitError
+		Error  string
+	}{
+		{
+			Name:   "NoLimits",
+			Data:   nested,
+			Value:  func() interface{} { return new(uatype.DiagnosticInfo) },
+			Limits: binary.DecoderLimits{},
+		},
+		{
+			Name:   "WithinLimits",
+			Data:   request,
+			Value:  func() interface{} { return new(uatype.ReadRequest) },
+			Limits: binary.DefaultDecoderLimits(len(request)),
+		},
+		{
+			Name:   "MaxStringLength",
+			Data:   request,
+			Value:  func() interface{} { return new(uatype.ReadRequest) },
+			Limits: binary.DecoderLimits{MaxStringLength: 10},
+			Cause:  binary.LimitError{Limit: "MaxStringLength", Value: 14, Max: 10},
+			Error:  "DecoderError .NodesToRead[0].DataEncoding.Name: decoder limit exceeded: string length 14 > 10",
+		},
+		{
+			Name:   "MaxByteStringLength",
+			Data:   request,
+			Value:  func() interface{} { return new(uatype.ReadRequest) },
+			Limits: binary.DecoderLimits{MaxByteStringLength: 4},
+			Cause:  binary.LimitError{Limit: "MaxByteStringLength", Value: 6, Max: 4},
+			Error:  "decoder limit exceeded: byte string length 6 > 4",
+		},
+		{
+			Name:   "MaxArrayLength",
+			Data:   request,
+			Value:  func() interface{} { return new(uatype.ReadRequest) },
+			Limits: binary.DecoderLimits{MaxArrayLength: 10},
+			Cause:  binary.LimitError{Limit: "MaxArrayLength", Value: 60, Max: 10},
+			Error:  "DecoderError .NodesToRead: decoder limit exceeded: array length 60 > 10",
+		},
+		{
+			Name:   "HostileArrayLength",
+			Data:   hostile,
+			Value:  func() interface{} { return new(uatype.ReadResponse) },
+			Limits: binary.DefaultDecoderLimits(1024),
+			Cause:  binary.LimitError{Limit: "MaxArrayLength", Value: 2147483647, Max: 1024},
+			Error:  "DecoderError .Results: decoder limit exceeded: array length 2147483647 > 1024",
+		},
+		{
+			Name:   "MaxAlloc",
+			Data:   request,
+			Value:  func() interface{} { return new(uatype.ReadRequest) },
+			Limits: binary.DecoderLimits{MaxAlloc: 1000},
+			Cause:  binary.LimitError{Limit: "MaxAlloc", Max: 1000},
+			Error:  "decoder limit exceeded: allocated bytes",
+		},
+		{
+			Name:   "MaxDepth",
+			Data:   nested,
+			Value:  func() interface{} { return new(uatype.DiagnosticInfo) },
+			Limits: binary.DefaultDecoderLimits(0),
+			Cause:  binary.LimitError{Limit: "MaxDepth", Value: 101, Max: 100},
+			Error:  "decoder limit exceeded: nesting depth 101 > 100",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			var errs [2]error
+			for i, generated := range []bool{true, false} {
+				errs[i] = decodeLimited(tc.Data, tc.Value(), tc.Limits, generated)
+				if tc.Error == "" {
+					assert.NoError(t, errs[i], "generated=%t", generated)
+					continue
+				}
+				require.Error(t, errs[i], "generated=%t", generated)
+				require.IsType(t, binary.DecoderError{}, errs[i], "generated=%t", generated)
+				cause, ok := errs[i].(binary.DecoderError).Cause().(binary.LimitError)
+				require.True(t, ok, "generated=%t: cause is %T", generated, errs[i].(binary.DecoderError).Cause())
+				if tc.Cause.Value == 0 {
+					// The number of allocated bytes depends on the value.
+					cause.Value = 0
+				}
+				assert.Equal(t, tc.Cause, cause, "generated=%t", generated)
+				assert.Contains(t, errs[i].Error(), tc.Error, "generated=%t", generated)
+			}
+			assert.Equal(t, errs[0], errs[1], "generated and reflection errors")
+		})
+	}
+}
+
+func TestDecoderLimitsPerDecode(t *testing.T) {
+	data, err := binary.Marshal(readRequest())
+	require.NoError(t, err, "Marshal ReadRequest")
+
+	// Allocations are counted per call to Decode.
+	dec := binary.NewDecoder(bytes.NewReader(append(data, data...)))
+	dec.SetLimits(binary.DefaultDecoderLimits(len(data)))
+	for i := 0; i < 2; i++ {
+		var req uatype.ReadRequest
+		require.NoError(t, dec.Decode(&req), "Decode #%d", i)
+		assert.Equal(t, readRequest(), req, "Decode #%d", i)
+	}
+}
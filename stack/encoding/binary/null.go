@@ -0,0 +1,105 @@
+package binary
+
+import (
+	"encoding/binary"
+	"io"
+
+	"github.com/searis/guma/stack/uatype"
+)
+
+// SetPreserveNull sets whether subsequent calls to Encode tell null values
+// apart from empty ones. By default, nil and empty ByteStrings are both
+// encoded as null. When preserveNull is set, only nil ByteStrings are encoded
+// as null, and empty ones are encoded with a length of 0. Arrays are always
+// encoded according to their length fields, where -1 describes a null array.
+//
+// Strings can't be null in Go; use uatype.NullString where the difference
+// matters, which always preserves null values. When preserveNull is set,
+// String values of Variants are encoded like NullStrings, with the values
+// marked in StringNull as null.
+func (enc *Encoder) SetPreserveNull(preserveNull bool) {
+	enc.preserveNull = preserveNull
+}
+
+// SetPreserveNull sets whether subsequent calls to Decode tell null values
+// apart from empty ones. By default, null and empty ByteStrings are both
+// decoded as nil, and null and empty arrays as empty slices. When
+// preserveNull is set, only null values are decoded as nil, and empty values
+// as empty, non-nil slices, so that they encode the same way with an Encoder
+// that preserves null values. Null String values of Variants are marked in
+// StringNull only when preserveNull is set.
+func (dec *Decoder) SetPreserveNull(preserveNull bool) {
+	dec.preserveNull = preserveNull
+}
+
+// variantNullStrings returns the String values of v as NullStrings, which
+// are null if marked in StringNull.
+func variantNullStrings(v uatype.Variant) []uatype.NullString {
+	ns := make([]uatype.NullString, len(v.String))
+	for i, s := range v.String {
+		if i >= len(v.StringNull) || !v.StringNull[i] {
+			ns[i] = uatype.NewNullString(s)
+		}
+	}
+	return ns
+}
+
+// setVariantNullStrings sets the String values of v from ns, and marks the
+// null values in StringNull.
+func setVariantNullStrings(v *uatype.Variant, ns []uatype.NullString) {
+	v.String = make([]string, len(ns))
+	v.StringNull = nil
+	for i, s := range ns {
+		v.String[i] = s.String
+		if !s.Valid {
+			if v.StringNull == nil {
+				v.StringNull = make([]bool, len(ns))
+			}
+			v.StringNull[i] = true
+		}
+	}
+}
+
+// nullByteString handles encoding and decoding of a ByteString where nil
+// and empty values are told apart.
+type nullByteString uatype.ByteString
+
+// MarshalBinary prefixes bs with it's size in bytes as int32. A length of -1
+// is encoded for nil values.
+func (bs nullByteString) MarshalBinary() ([]byte, error) {
+	if bs == nil {
+		return []byte{0xff, 0xff, 0xff, 0xff}, nil
+	}
+	target := make([]byte, 4+len(bs))
+	binary.LittleEndian.PutUint32(target, uint32(len(bs)))
+	copy(target[4:], bs)
+	return target, nil
+}
+
+// UnmarshalBinary first reads the data size in bytes as int32, and then reads
+// the value according to the size into bs. Negative sizes give nil.
+func (bs *nullByteString) UnmarshalBinary(data []byte) error {
+	l := len(data)
+	if l < 4 {
+		return io.ErrShortBuffer
+	}
+	size := int32(binary.LittleEndian.Uint32(data[0:4]))
+	if size < 0 {
+		*bs = nil
+		return nil
+	}
+	stop := int(size) + 4
+	if stop > l {
+		return io.ErrShortBuffer
+	}
+
+	*bs = make([]byte, size)
+	copy(*bs, data[4:stop])
+	return nil
+}
+
+// BitLength returns the size in bits of bs when encoded to binary. The number
+// is at least 32, and always a multiplum of 8.
+func (bs nullByteString) BitLength() int {
+	return 32 + 8*len(bs)
+}
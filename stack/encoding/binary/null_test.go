@@ -0,0 +1,208 @@
+package binary_test
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/searis/guma/stack/encoding/binary"
+	"github.com/searis/guma/stack/uatype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPreserveNull(t *testing.T) {
+	nullArray, err := uatype.NewNullArrayVariant(6)
+	require.NoError(t, err, "uatype.NewNullArrayVariant(6)")
+
+	cases := []struct {
+		Name      string
+		Value     interface{}
+		Marshaled []byte // nil to only check that Value round-trips
+	}{
+		{
+			Name:      `ByteString(nil)`,
+			Value:     uatype.ByteString(nil),
+			Marshaled: []byte{0xFF, 0xFF, 0xFF, 0xFF},
+		},
+		{
+			Name:      `ByteString("")`,
+			Value:     uatype.ByteString{},
+			Marshaled: []byte{0x00, 0x00, 0x00, 0x00},
+		},
+		{
+			Name:      `NullString{}`,
+			Value:     uatype.NullString{},
+			Marshaled: []byte{0xFF, 0xFF, 0xFF, 0xFF},
+		},
+		{
+			Name:      `NewNullString("")`,
+			Value:     uatype.NewNullString(""),
+			Marshaled: []byte{0x00, 0x00, 0x00, 0x00},
+		},
+		{
+			Name:      `NewNullString("foo")`,
+			Value:     uatype.NewNullString("foo"),
+			Marshaled: []byte{0x03, 0x00, 0x00, 0x00, 'f', 'o', 'o'},
+		},
+		{
+			Name:      `NewNullArrayVariant(Int32)`,
+			Value:     nullArray,
+			Marshaled: []byte{0x86, 0xFF, 0xFF, 0xFF, 0xFF},
+		},
+		{
+			Name:      `Variant([]int32{})`,
+			Value:     mustVariant(t, []int32{}),
+			Marshaled: []byte{0x86, 0x00, 0x00, 0x00, 0x00},
+		},
+		{
+			Name:  `Variant([]ByteString{nil, ""})`,
+			Value: mustVariant(t, []uatype.ByteString{nil, {}}),
+			Marshaled: []byte{
+				0x8F, 0x02, 0x00, 0x00, 0x00,
+				0xFF, 0xFF, 0xFF, 0xFF,
+				0x00, 0x00, 0x00, 0x00,
+			},
+		},
+		{
+			Name:  `Variant([]NullString{null, ""})`,
+			Value: mustVariant(t, []uatype.NullString{{}, uatype.NewNullString("")}),
+			Marshaled: []byte{
+				0x8C, 0x02, 0x00, 0x00, 0x00,
+				0xFF, 0xFF, 0xFF, 0xFF,
+				0x00, 0x00, 0x00, 0x00,
+			},
+		},
+		{
+			Name:      `Variant("")`,
+			Value:     mustVariant(t, ""),
+			Marshaled: []byte{0x0C, 0x00, 0x00, 0x00, 0x00},
+		},
+		{
+			Name: `WriteRequest with null array`,
+			Value: uatype.WriteRequest{
+				NoOfNodesToWrite: -1,
+			},
+		},
+		{
+			Name: `WriteRequest with empty arrays`,
+			Value: uatype.WriteRequest{
+				NoOfNodesToWrite: 1,
+				NodesToWrite: []uatype.WriteValue{{
+					NodeId:     uatype.NewFourByteNodeID(0, 42),
+					IndexRange: "",
+					Value: uatype.DataValue{
+						ValueSpecified: true,
+						Value:          mustVariant(t, []float64{}),
+					},
+				}},
+			},
+		},
+		{
+			Name: `ExtensionObject with null array`,
+			Value: uatype.ExtensionObject{
+				Value: uatype.ReadRequest{NoOfNodesToRead: -1},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			var buf bytes.Buffer
+			enc := binary.NewEncoder(&buf)
+			enc.SetPreserveNull(true)
+			require.NoError(t, enc.Encode(tc.Value), "Encode")
+			if tc.Marshaled != nil {
+				assert.Equal(t, tc.Marshaled, buf.Bytes(), "Encode")
+			}
+
+			target := reflect.New(reflect.TypeOf(tc.Value))
+			dec := binary.NewDecoder(&buf)
+			dec.SetPreserveNull(true)
+			require.NoError(t, dec.Decode(target.Interface()), "Decode")
+			decoded := target.Elem().Interface()
+			if eo, ok := decoded.(uatype.ExtensionObject); ok {
+				decoded = uatype.ExtensionObject{Value: eo.Value}
+			}
+			assert.Equal(t, tc.Value, decoded, "Decode")
+		})
+	}
+}
+
+func TestPreserveNullDefault(t *testing.T) {
+	data, err := binary.Marshal(uatype.ByteString{})
+	require.NoError(t, err, "binary.Marshal")
+	assert.Equal(t, []byte{0xFF, 0xFF, 0xFF, 0xFF}, data, "empty ByteString")
+
+	var rr uatype.ReadRequest
+	data, err = binary.Marshal(uatype.ReadRequest{NoOfNodesToRead: -1})
+	require.NoError(t, err, "binary.Marshal")
+	require.NoError(t, binary.Unmarshal(data, &rr), "binary.Unmarshal")
+	assert.Equal(t, []uatype.ReadValueId{}, rr.NodesToRead, "null array")
+}
+
+func TestPreserveNullDefaultVariant(t *testing.T) {
+	data, err := binary.Marshal(mustVariant(t, []string(nil)))
+	require.NoError(t, err, "binary.Marshal")
+	assert.Equal(t, []byte{0x8C, 0x00, 0x00, 0x00, 0x00}, data, "nil slice")
+
+	data, err = binary.Marshal(mustVariant(t, []uatype.NullString{{}, uatype.NewNullString("a")}))
+	require.NoError(t, err, "binary.Marshal")
+	assert.Equal(t, []byte{
+		0x8C, 0x02, 0x00, 0x00, 0x00,
+		0xFF, 0xFF, 0xFF, 0xFF,
+		0x01, 0x00, 0x00, 0x00, 'a',
+	}, data, "null string")
+
+	var v uatype.Variant
+	require.NoError(t, binary.Unmarshal(data, &v), "binary.Unmarshal")
+	assert.Equal(t, []string{"", "a"}, v.String, "v.String")
+	assert.Nil(t, v.StringNull, "v.StringNull")
+}
+
+func TestNullStringVariant(t *testing.T) {
+	value := [][]uatype.NullString{
+		{uatype.NewNullString("a"), {}},
+		{uatype.NewNullString(""), uatype.NewNullString("b")},
+	}
+	v := mustVariant(t, value)
+	assert.Equal(t, []bool{false, true, false, false}, v.StringNull, "v.StringNull")
+
+	var buf bytes.Buffer
+	enc := binary.NewEncoder(&buf)
+	enc.SetPreserveNull(true)
+	require.NoError(t, enc.Encode(v), "Encode")
+	var decoded uatype.Variant
+	dec := binary.NewDecoder(&buf)
+	dec.SetPreserveNull(true)
+	require.NoError(t, dec.Decode(&decoded), "Decode")
+
+	r, err := decoded.NullStringValue()
+	require.NoError(t, err, "decoded.NullStringValue()")
+	assert.Equal(t, value, r, "decoded.NullStringValue()")
+	r, err = decoded.Value()
+	require.NoError(t, err, "decoded.Value()")
+	assert.Equal(t, [][]string{{"a", ""}, {"", "b"}}, r, "decoded.Value()")
+
+	r, err = mustVariant(t, "a").NullStringValue()
+	require.NoError(t, err, "NullStringValue() of string")
+	assert.Equal(t, uatype.NewNullString("a"), r, "NullStringValue() of string")
+
+	nv, err := uatype.NewVariantAs(12, []uatype.NullString{{}})
+	require.NoError(t, err, "NewVariantAs(String, []NullString)")
+	assert.Equal(t, []bool{true}, nv.StringNull, "NewVariantAs(String, []NullString)")
+
+	_, err = mustVariant(t, int32(1)).NullStringValue()
+	assert.Error(t, err, "NullStringValue() of Int32")
+}
+
+func TestNullArrayVariantValue(t *testing.T) {
+	v, err := uatype.NewNullArrayVariant(12)
+	require.NoError(t, err, "uatype.NewNullArrayVariant(12)")
+	r, err := v.Value()
+	assert.NoError(t, err, "v.Value()")
+	assert.Equal(t, []string(nil), r, "v.Value()")
+
+	_, err = uatype.NewNullArrayVariant(0)
+	assert.EqualError(t, err, "type can not be stored in a Variant: VariantType 0", "VariantType 0")
+}
@@ -0,0 +1,42 @@
+package binary_test
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/searis/guma/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoundTrip(t *testing.T) {
+	const iterations = 20
+	seed := time.Now().UnixNano()
+	t.Logf("seed %d", seed)
+	g := testutil.NewValueGen(rand.New(rand.NewSource(seed)))
+	types := testutil.RegisteredTypes()
+	require.NotEmpty(t, types, "registered types")
+
+	for _, rt := range types {
+		t.Run(rt.Name(), func(t *testing.T) {
+			for i := 0; i < iterations; i++ {
+				v := g.Value(rt)
+				data, err := marshal(v, true)
+				require.NoError(t, err, "generated Marshal")
+				reflected, err := marshal(v, false)
+				require.NoError(t, err, "reflection Marshal")
+				require.Equal(t, data, reflected, "generated and reflection encoding")
+
+				for _, generated := range []bool{true, false} {
+					rv := reflect.New(rt)
+					require.NoError(t, unmarshal(data, rv.Interface(), generated), "Unmarshal generated=%t", generated)
+					if !assert.Equal(t, v, rv.Elem().Interface(), "generated=%t", generated) {
+						return
+					}
+				}
+			}
+		})
+	}
+}
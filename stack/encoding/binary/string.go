@@ -37,7 +37,8 @@ func (s *uaString) UnmarshalBinary(data []byte) error {
 		return io.ErrShortBuffer
 	}
 	size := int32(binary.LittleEndian.Uint32(data[0:4]))
-	if size == -1 || size == 0 {
+	if size <= 0 {
+		// Negative sizes describe null strings.
 		*s = ""
 		return nil
 	}
@@ -21,6 +21,15 @@ func TestString(t *testing.T) {
 				0xFF, 0xFF, 0xFF, 0xFF, // -1
 			},
 		},
+		{
+			SubTests:     testutil.TestDecode,
+			Name:         `"" with negative size`,
+			Unmarshaled:  "",
+			DecodeTarget: new(string),
+			Marshaled: []byte{
+				0x00, 0x00, 0x00, 0x80,
+			},
+		},
 		{
 			SubTests:     testutil.TestDecode,
 			Name:         "io.ErrNotEnoughData",
@@ -1,66 +1,165 @@
 package binary
 
 import (
+	"fmt"
 	"reflect"
 	"strconv"
 	"strings"
+	"sync"
 )
 
+// Switch operands read from `opcua:"switchOperand=x"`.
+const (
+	switchEquals switchOperand = iota
+	switchGreaterThan
+	switchLessThan
+	switchGreaterThanOrEqual
+	switchLessThanOrEqual
+	switchNotEqual
+)
+
+type switchOperand uint8
+
+var switchOperands = map[string]switchOperand{
+	"Equals":             switchEquals,
+	"GreaterThan":        switchGreaterThan,
+	"LessThan":           switchLessThan,
+	"GreaterThanOrEqual": switchGreaterThanOrEqual,
+	"LessThanOrEqual":    switchLessThanOrEqual,
+	"NotEqual":           switchNotEqual,
+}
+
+// structField describes how to encode and decode a field of a struct type.
+// Switch and length fields are resolved to indices into the fields of the
+// struct plan.
 type structField struct {
 	Name          string
-	Value         reflect.Value
-	BitSize       byte   // read from `opcua:"bits=x"`
-	SwitchValue   int64  // read from `opcua:"switchValue=x"`
-	SwitchField   string // retrieved based on `opcua:"switchField=x"`
-	LengthField   string // retrieved based on `opcua:"lengthField=x"`
-	SwitchOperand string // retrieved based on `opcua:"switchOperand=x"`
+	Index         int           // index of the field in the struct type
+	BitSize       byte          // read from `opcua:"bits=x"`
+	SwitchValue   int64         // read from `opcua:"switchValue=x"`
+	HasValue      bool          // true if SwitchValue is set
+	SwitchField   int           // resolved from `opcua:"switchField=x"`; -1 if not set
+	SwitchOperand switchOperand // read from `opcua:"switchOperand=x"`
+	LengthField   int           // resolved from `opcua:"lengthField=x"`; -1 if not set
+}
+
+// structPlan lists the encoded fields of a struct type in order.
+type structPlan []structField
+
+// cachedPlan holds the result of compiling the plan for a struct type.
+type cachedPlan struct {
+	plan structPlan
+	err  error
 }
 
-func gatherFields(fields []structField, rv reflect.Value) ([]structField, error) {
-	var err error
+// structPlans caches compiled plans by reflect.Type.
+var structPlans sync.Map
+
+// planFor returns the plan for the struct type rt. Plans are compiled and
+// validated once per type.
+func planFor(rt reflect.Type) (structPlan, error) {
+	if c, ok := structPlans.Load(rt); ok {
+		return c.(*cachedPlan).plan, c.(*cachedPlan).err
+	}
+	plan, err := compilePlan(rt)
+	c, _ := structPlans.LoadOrStore(rt, &cachedPlan{plan, err})
+	return c.(*cachedPlan).plan, c.(*cachedPlan).err
+}
 
-	rt := rv.Type()
-	l := rt.NumField()
+func compilePlan(rt reflect.Type) (structPlan, error) {
+	var plan structPlan
+	indices := map[string]int{}
 
-	for i := 0; i < l; i++ {
+	for i := 0; i < rt.NumField(); i++ {
 		rf := rt.Field(i)
 		if rf.PkgPath != "" {
 			// Skip unexported field.
 		} else if rf.Anonymous {
-			// Recursively gather inherited fields.
-			fields, err = gatherFields(fields, rv.Field(i))
-			if err != nil {
-				return nil, err
-			}
+			// Skip embedded base types. Structured types in the OPC UA type
+			// dictionary repeat all inherited fields, so the generated types
+			// already declare them.
+		} else if rf.Tag.Get("opcua") == "-" {
+			// Skip field that is explicitly excluded from the encoding.
 		} else {
-			// Gather information about field.
-			field, err := readStructField(rv, rf)
+			f, err := compileField(rt, plan, indices, i)
 			if err != nil {
-				return nil, err
+				return nil, wrapError(err, rf.Name)
 			}
-			fields = append(fields, *field)
+			indices[f.Name] = len(plan)
+			plan = append(plan, f)
 		}
-
 	}
-	return fields, nil
+	return plan, nil
 }
 
-func readStructField(parent reflect.Value, rf reflect.StructField) (*structField, error) {
+// compileField reads the struct tag of the index'th field of rt, and validates
+// it against the previous fields of the plan.
+func compileField(rt reflect.Type, plan structPlan, indices map[string]int, index int) (structField, error) {
+	rf := rt.Field(index)
 	sf := structField{
 		Name:        rf.Name,
-		Value:       parent.FieldByIndex(rf.Index),
-		SwitchValue: -1,
+		Index:       index,
+		SwitchField: -1,
+		LengthField: -1,
 	}
 	tag, ok := rf.Tag.Lookup("opcua")
-	if ok && tag != "" {
-		if err := readTag(&sf, tag); err != nil {
-			return nil, wrapError(err, sf.Name)
+	if !ok || tag == "" {
+		return sf, nil
+	}
+	var switchField, lengthField, operand string
+	if err := readTag(&sf, tag, &switchField, &lengthField, &operand); err != nil {
+		return sf, err
+	}
+
+	if sf.BitSize > 0 && (!isUint(rf.Type.Kind()) || rf.Type.Bits() < int(sf.BitSize)) {
+		return sf, fmt.Errorf("%s: bits=%d requires an unsigned integer field of at least %d bits, not %s", ErrInvalidTag, sf.BitSize, sf.BitSize, rf.Type)
+	}
+
+	if switchField == "" && (sf.HasValue || operand != "") {
+		return sf, fmt.Errorf("%s: switchValue and switchOperand require switchField", ErrInvalidTag)
+	}
+	if operand != "" {
+		op, ok := switchOperands[operand]
+		if !ok {
+			return sf, fmt.Errorf("%s: unknown switchOperand %q", ErrInvalidTag, operand)
+		}
+		if !sf.HasValue {
+			return sf, fmt.Errorf("%s: switchOperand requires switchValue", ErrInvalidTag)
+		}
+		sf.SwitchOperand = op
+	}
+	if switchField != "" {
+		j, ok := indices[switchField]
+		if !ok {
+			return sf, fmt.Errorf("%s: switchField %q is not a previous field", ErrInvalidTag, switchField)
+		}
+		kind := rt.Field(plan[j].Index).Type.Kind()
+		switch {
+		case sf.HasValue && !isInt(kind) && !isUint(kind):
+			return sf, fmt.Errorf("%s: switchField %q must be an integer when switchValue is set, not %s", ErrInvalidTag, switchField, kind)
+		case !sf.HasValue && kind != reflect.Bool:
+			return sf, fmt.Errorf("%s: switchField %q must be a boolean when switchValue is not set, not %s", ErrInvalidTag, switchField, kind)
 		}
+		sf.SwitchField = j
 	}
-	return &sf, nil
+
+	if lengthField != "" {
+		j, ok := indices[lengthField]
+		if !ok {
+			return sf, fmt.Errorf("%s: lengthField %q is not a previous field", ErrInvalidTag, lengthField)
+		}
+		if kind := rt.Field(plan[j].Index).Type.Kind(); !isInt(kind) {
+			return sf, fmt.Errorf("%s: lengthField %q must be a signed integer, not %s", ErrInvalidTag, lengthField, kind)
+		}
+		if rf.Type.Kind() != reflect.Slice {
+			return sf, fmt.Errorf("%s: lengthField requires a slice field, not %s", ErrInvalidTag, rf.Type)
+		}
+		sf.LengthField = j
+	}
+	return sf, nil
 }
 
-func readTag(sf *structField, tag string) error {
+func readTag(sf *structField, tag string, switchField, lengthField, operand *string) error {
 	const (
 		bitsPrefix          = "bits="
 		lengthFieldPrefix   = "lengthField="
@@ -72,25 +171,88 @@ func readTag(sf *structField, tag string) error {
 	for _, s := range strings.Split(tag, ",") {
 		if strings.HasPrefix(s, bitsPrefix) {
 			i, err := strconv.Atoi(s[len(bitsPrefix):])
-			if err != nil {
-				return ErrInvalidTag
+			if err != nil || i < 0 {
+				return fmt.Errorf("%s: bits must be a positive integer", ErrInvalidTag)
+			}
+			if i > 64 {
+				return ErrInvalidBitLength
 			}
 			sf.BitSize = byte(i)
 		} else if strings.HasPrefix(s, lengthFieldPrefix) {
-			sf.LengthField = s[len(lengthFieldPrefix):]
+			*lengthField = s[len(lengthFieldPrefix):]
 		} else if strings.HasPrefix(s, switchFieldPrefix) {
-			sf.SwitchField = s[len(switchFieldPrefix):]
+			*switchField = s[len(switchFieldPrefix):]
 		} else if strings.HasPrefix(s, switchValuePrefix) {
-			i, err := strconv.Atoi(s[len(switchValuePrefix):])
+			i, err := strconv.ParseInt(s[len(switchValuePrefix):], 10, 64)
 			if err != nil {
-				return ErrInvalidTag
+				return fmt.Errorf("%s: switchValue must be an integer", ErrInvalidTag)
 			}
-			sf.SwitchValue = int64(i)
+			sf.SwitchValue = i
+			sf.HasValue = true
 		} else if strings.HasPrefix(s, switchOperandPrefix) {
-			sf.SwitchOperand = s[len(switchOperandPrefix):]
+			*operand = s[len(switchOperandPrefix):]
 		} else {
-			return ErrInvalidTag
+			return fmt.Errorf("%s: unknown option %q", ErrInvalidTag, s)
 		}
 	}
 	return nil
 }
+
+// active returns true if the i'th field of p should be encoded or decoded for
+// the struct value rv.
+func (p structPlan) active(rv reflect.Value, i int) bool {
+	f := &p[i]
+	if f.SwitchField < 0 {
+		return true
+	}
+	sv := rv.Field(p[f.SwitchField].Index)
+	if !f.HasValue {
+		return sv.Bool()
+	}
+
+	var v int64
+	if isInt(sv.Kind()) {
+		v = sv.Int()
+	} else {
+		v = int64(sv.Uint())
+	}
+	switch f.SwitchOperand {
+	case switchGreaterThan:
+		return v > f.SwitchValue
+	case switchLessThan:
+		return v < f.SwitchValue
+	case switchGreaterThanOrEqual:
+		return v >= f.SwitchValue
+	case switchLessThanOrEqual:
+		return v <= f.SwitchValue
+	case switchNotEqual:
+		return v != f.SwitchValue
+	}
+	return v == f.SwitchValue
+}
+
+// length returns the number of elements of the slice field f of rv. A length
+// field that has been switched off means that exactly one element should be
+// encoded, which is how scalar Variant values are described.
+func (p structPlan) length(rv reflect.Value, f *structField) int {
+	if !p.active(rv, f.LengthField) {
+		return 1
+	}
+	return int(rv.Field(p[f.LengthField].Index).Int())
+}
+
+func isInt(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Int:
+		return true
+	}
+	return false
+}
+
+func isUint(k reflect.Kind) bool {
+	switch k {
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uint:
+		return true
+	}
+	return false
+}
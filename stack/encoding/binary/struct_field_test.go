@@ -0,0 +1,120 @@
+package binary_test
+
+import (
+	"testing"
+
+	"github.com/searis/guma/internal/testutil"
+	"github.com/searis/guma/stack/uatype"
+)
+
+func TestStructTags(t *testing.T) {
+	type switched struct {
+		Kind     uint8
+		HasValue uatype.Bit
+		Flags    byte   `opcua:"bits=7"`
+		Small    uint16 `opcua:"switchField=Kind,switchValue=1,switchOperand=LessThanOrEqual"`
+		Large    uint32 `opcua:"switchField=Kind,switchValue=1,switchOperand=GreaterThan"`
+		Value    int8   `opcua:"switchField=HasValue"`
+	}
+	type unknownOption struct {
+		Data int32 `opcua:"length=1"`
+	}
+	type unknownSwitchField struct {
+		Data int32 `opcua:"switchField=Missing"`
+	}
+	type laterSwitchField struct {
+		Data    int32 `opcua:"switchField=Enabled"`
+		Enabled bool
+	}
+	type boolSwitchValue struct {
+		Enabled bool
+		Data    int32 `opcua:"switchField=Enabled,switchValue=1"`
+	}
+	type unknownOperand struct {
+		Kind uint8
+		Data int32 `opcua:"switchField=Kind,switchValue=1,switchOperand=Between"`
+	}
+	type unsignedLengthField struct {
+		Length uint32
+		Data   []int32 `opcua:"lengthField=Length"`
+	}
+	type scalarLengthField struct {
+		Length int32
+		Data   int32 `opcua:"lengthField=Length"`
+	}
+	type narrowBits struct {
+		Data uint8 `opcua:"bits=12"`
+	}
+
+	cases := []testutil.TranscoderTest{
+		{
+			SubTests:     testutil.TestEncode | testutil.TestDecode,
+			Name:         "switched{Kind:1}",
+			Unmarshaled:  switched{Kind: 1, HasValue: true, Flags: 0x7f, Small: 0xCAFE, Value: -1},
+			DecodeTarget: new(switched),
+			Marshaled:    []byte{0x01, 0xff, 0xfe, 0xca, 0xff},
+		},
+		{
+			SubTests:     testutil.TestEncode | testutil.TestDecode,
+			Name:         "switched{Kind:2}",
+			Unmarshaled:  switched{Kind: 2, Large: 0xCAFEBABE},
+			DecodeTarget: new(switched),
+			Marshaled:    []byte{0x02, 0x00, 0xbe, 0xba, 0xfe, 0xca},
+		},
+		{
+			SubTests:    testutil.TestEncode | testutil.TestDecode,
+			Name:        "unknownOption",
+			Unmarshaled: unknownOption{},
+			EncodeError: `EncoderError unknownOption.Data: invalid struct tag: unknown option "length=1"`,
+			// The type name of the decode target pointer is empty.
+			DecodeTarget: new(unknownOption),
+			Marshaled:    []byte{0x00, 0x00, 0x00, 0x00},
+			DecodeError:  `DecoderError .Data: invalid struct tag: unknown option "length=1"`,
+		},
+		{
+			SubTests:    testutil.TestEncode,
+			Name:        "unknownSwitchField",
+			Unmarshaled: unknownSwitchField{},
+			EncodeError: `EncoderError unknownSwitchField.Data: invalid struct tag: switchField "Missing" is not a previous field`,
+		},
+		{
+			SubTests:    testutil.TestEncode,
+			Name:        "laterSwitchField",
+			Unmarshaled: laterSwitchField{},
+			EncodeError: `EncoderError laterSwitchField.Data: invalid struct tag: switchField "Enabled" is not a previous field`,
+		},
+		{
+			SubTests:    testutil.TestEncode,
+			Name:        "boolSwitchValue",
+			Unmarshaled: boolSwitchValue{},
+			EncodeError: `EncoderError boolSwitchValue.Data: invalid struct tag: switchField "Enabled" must be an integer when switchValue is set, not bool`,
+		},
+		{
+			SubTests:    testutil.TestEncode,
+			Name:        "unknownOperand",
+			Unmarshaled: unknownOperand{},
+			EncodeError: `EncoderError unknownOperand.Data: invalid struct tag: unknown switchOperand "Between"`,
+		},
+		{
+			SubTests:    testutil.TestEncode,
+			Name:        "unsignedLengthField",
+			Unmarshaled: unsignedLengthField{},
+			EncodeError: `EncoderError unsignedLengthField.Data: invalid struct tag: lengthField "Length" must be a signed integer, not uint32`,
+		},
+		{
+			SubTests:    testutil.TestEncode,
+			Name:        "scalarLengthField",
+			Unmarshaled: scalarLengthField{},
+			EncodeError: `EncoderError scalarLengthField.Data: invalid struct tag: lengthField requires a slice field, not int32`,
+		},
+		{
+			SubTests:    testutil.TestEncode,
+			Name:        "narrowBits",
+			Unmarshaled: narrowBits{},
+			EncodeError: `EncoderError narrowBits.Data: invalid struct tag: bits=12 requires an unsigned integer field of at least 12 bits, not uint8`,
+		},
+	}
+	for i := range cases {
+		cases[i].Run(t)
+	}
+}
@@ -0,0 +1,190 @@
+package binary_test
+
+import (
+	"testing"
+
+	"github.com/searis/guma/internal/testutil"
+	"github.com/searis/guma/stack/encoding/binary"
+	"github.com/searis/guma/stack/uatype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mustVariant(t *testing.T, v interface{}) uatype.Variant {
+	variant, err := uatype.NewVariant(v)
+	require.NoError(t, err, "uatype.NewVariant(%#v)", v)
+	return variant
+}
+
+func TestVariant(t *testing.T) {
+	cases := []testutil.TranscoderTest{
+		{
+			SubTests:     testutil.TestEncode | testutil.TestDecode,
+			Name:         "null",
+			Unmarshaled:  mustVariant(t, nil),
+			DecodeTarget: new(uatype.Variant),
+			Marshaled:    []byte{0x00},
+		},
+		{
+			SubTests:     testutil.TestEncode | testutil.TestDecode,
+			Name:         "int32(5)",
+			Unmarshaled:  mustVariant(t, int32(5)),
+			DecodeTarget: new(uatype.Variant),
+			Marshaled:    []byte{0x06, 0x05, 0x00, 0x00, 0x00},
+		},
+		{
+			SubTests:     testutil.TestEncode | testutil.TestDecode,
+			Name:         "[]uint16{1,2}",
+			Unmarshaled:  mustVariant(t, []uint16{1, 2}),
+			DecodeTarget: new(uatype.Variant),
+			Marshaled: []byte{
+				0x85,
+				0x02, 0x00, 0x00, 0x00,
+				0x01, 0x00, 0x02, 0x00,
+			},
+		},
+		{
+			SubTests:     testutil.TestEncode | testutil.TestDecode,
+			Name:         "[][]byte{{1,2,3},{4,5,6}}",
+			Unmarshaled:  mustVariant(t, [][]byte{{1, 2, 3}, {4, 5, 6}}),
+			DecodeTarget: new(uatype.Variant),
+			Marshaled: []byte{
+				0xC3,
+				0x06, 0x00, 0x00, 0x00,
+				0x01, 0x02, 0x03, 0x04, 0x05, 0x06,
+				0x02, 0x00, 0x00, 0x00,
+				0x02, 0x00, 0x00, 0x00, 0x03, 0x00, 0x00, 0x00,
+			},
+		},
+		{
+			SubTests: testutil.TestEncode,
+			Name:     "inconsistentDimensions",
+			Unmarshaled: uatype.Variant{
+				VariantType:              3,
+				ArrayLengthSpecified:     true,
+				ArrayDimensionsSpecified: true,
+				ArrayLength:              2,
+				Byte:                     []byte{1, 2},
+				NoOfArrayDimensions:      2,
+				ArrayDimensions:          []int32{2, 2},
+			},
+			EncodeError: "EncoderError Variant.ArrayDimensions: " + uatype.ErrVariantDimensions.Error(),
+		},
+		{
+			SubTests:     testutil.TestDecode,
+			Name:         "inconsistentDimensions",
+			DecodeTarget: new(uatype.Variant),
+			Marshaled: []byte{
+				0xC3,
+				0x02, 0x00, 0x00, 0x00,
+				0x01, 0x02,
+				0x02, 0x00, 0x00, 0x00,
+				0x02, 0x00, 0x00, 0x00, 0x02, 0x00, 0x00, 0x00,
+			},
+			DecodeError: "DecoderError .ArrayDimensions: " + uatype.ErrVariantDimensions.Error(),
+		},
+		{
+			SubTests:     testutil.TestDecode,
+			Name:         "negativeDimension",
+			DecodeTarget: new(uatype.Variant),
+			Marshaled: []byte{
+				0xC3,
+				0x00, 0x00, 0x00, 0x00,
+				0x02, 0x00, 0x00, 0x00,
+				0xFF, 0xFF, 0xFF, 0xFF, 0x00, 0x00, 0x00, 0x00,
+			},
+			DecodeError: "DecoderError .ArrayDimensions: " + uatype.ErrVariantDimensions.Error(),
+		},
+	}
+	for i := range cases {
+		cases[i].Run(t)
+	}
+}
+
+func TestVariantArrayTooLong(t *testing.T) {
+	// A Boolean array that claims to hold 2^31-1 elements must be refused
+	// before it's allocated, also when decoding without limits.
+	data := []byte{0x81, 0xff, 0xff, 0xff, 0x7f}
+	for _, generated := range []bool{false, true} {
+		err := decodeLimited(data, new(uatype.Variant), binary.DecoderLimits{}, generated)
+		assert.EqualError(t, err, "DecoderError .ArrayLength: "+uatype.ErrVariantArrayTooLong.Error(), "generated=%t", generated)
+	}
+
+	// The MaxArrayLength limit of the decoder applies to the array as a
+	// whole, which is refused before its ArrayDimensions are decoded.
+	data = []byte{
+		0xC3,
+		0x06, 0x00, 0x00, 0x00,
+		0x02, 0x00, 0x00, 0x00,
+		0x02, 0x00, 0x00, 0x00, 0x03, 0x00, 0x00, 0x00,
+	}
+	for _, generated := range []bool{false, true} {
+		err := decodeLimited(data, new(uatype.Variant), binary.DecoderLimits{MaxArrayLength: 4}, generated)
+		assert.EqualError(t, err, "DecoderError .ArrayLength: decoder limit exceeded: array length 6 > 4", "generated=%t", generated)
+	}
+}
+
+func TestVariantValue(t *testing.T) {
+	values := []interface{}{
+		true,
+		float64(3.14),
+		"foo",
+		[]string{"a", "b"},
+		[][]float32{{1, 2, 3}, {4, 5, 6}},
+		[][][]int16{{{1, 2}, {3, 4}, {5, 6}}, {{7, 8}, {9, 10}, {11, 12}}},
+		[][]float32{{}, {}},
+		[][]float32{},
+		uatype.ByteString("bar"),
+		[]uatype.ByteString{uatype.ByteString("bar")},
+	}
+	for _, v := range values {
+		data, err := binary.Marshal(mustVariant(t, v))
+		require.NoError(t, err, "binary.Marshal(%#v)", v)
+
+		var decoded uatype.Variant
+		require.NoError(t, binary.Unmarshal(data, &decoded), "binary.Unmarshal(%#v)", data)
+		r, err := decoded.Value()
+		assert.NoError(t, err, "decoded.Value()")
+		assert.Equal(t, v, r, "decoded.Value()")
+	}
+}
+
+func TestNewVariantErrors(t *testing.T) {
+	_, err := uatype.NewVariant([][]float32{{1, 2}, {3}})
+	assert.Equal(t, uatype.ErrVariantRagged, err, "ragged slice")
+
+	_, err = uatype.NewVariant(int(42))
+	assert.EqualError(t, err, "type can not be stored in a Variant: int", "int")
+
+	v := uatype.Variant{
+		VariantType:              3,
+		ArrayLengthSpecified:     true,
+		ArrayDimensionsSpecified: true,
+		ArrayLength:              0x7FFFFFFF,
+		NoOfArrayDimensions:      2,
+		ArrayDimensions:          []int32{0x7FFFFFFF, 1},
+	}
+	_, err = v.Value()
+	assert.Equal(t, uatype.ErrVariantArrayTooLong, err, "decode bomb")
+}
+
+func TestNewVariantAs(t *testing.T) {
+	v, err := uatype.NewVariantAs(11, [][]int{{1, 2}, {3, 4}})
+	require.NoError(t, err, "NewVariantAs(Double, [][]int)")
+	r, err := v.Value()
+	require.NoError(t, err, "v.Value()")
+	assert.Equal(t, [][]float64{{1, 2}, {3, 4}}, r, "v.Value()")
+
+	v, err = uatype.NewVariantAs(15, []byte("raw"))
+	require.NoError(t, err, "NewVariantAs(ByteString, []byte)")
+	assert.False(t, v.IsArray(), "v.IsArray()")
+
+	_, err = uatype.NewVariantAs(3, []int{1, 256})
+	assert.Equal(t, uatype.ErrVariantRange, err, "NewVariantAs(Byte, 256)")
+	_, err = uatype.NewVariantAs(7, -1)
+	assert.Equal(t, uatype.ErrVariantRange, err, "NewVariantAs(UInt32, -1)")
+	_, err = uatype.NewVariantAs(6, 1.5)
+	assert.Equal(t, uatype.ErrVariantRange, err, "NewVariantAs(Int32, 1.5)")
+	_, err = uatype.NewVariantAs(12, 42)
+	assert.EqualError(t, err, "type can not be stored in a Variant: can not convert int to string", "NewVariantAs(String, 42)")
+}
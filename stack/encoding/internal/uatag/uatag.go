@@ -0,0 +1,137 @@
+// Package uatag interprets the opcua struct tags of the uatype package for
+// the encodings that represent structures by field name.
+package uatag
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Tag holds the parts of an opcua struct tag that affect encodings by field
+// name.
+type Tag struct {
+	LengthField    string
+	SwitchField    string
+	SwitchValue    int64
+	HasSwitchValue bool
+	SwitchOperand  string
+	Bits           bool
+}
+
+// ParseTag parses an opcua struct tag.
+func ParseTag(tag string) Tag {
+	var t Tag
+	for _, s := range strings.Split(tag, ",") {
+		kv := strings.SplitN(s, "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		switch kv[0] {
+		case "lengthField":
+			t.LengthField = kv[1]
+		case "switchField":
+			t.SwitchField = kv[1]
+		case "switchValue":
+			t.SwitchValue, _ = strconv.ParseInt(kv[1], 10, 64)
+			t.HasSwitchValue = true
+		case "switchOperand":
+			t.SwitchOperand = kv[1]
+		case "bits":
+			t.Bits = true
+		}
+	}
+	return t
+}
+
+// Field describes a struct field that is part of the encoding.
+type Field struct {
+	Index int
+	Name  string
+	Tag   Tag
+}
+
+// structFields caches the result of Fields per type.
+var structFields sync.Map
+
+// Fields returns the fields of the struct type rt that are part of the
+// encoding. Length fields and boolean switch fields are implied by the fields
+// they describe, and reserved bits only pad bit fields to whole bytes, so they
+// are left out. So are unexported, embedded and ignored fields. Other bit
+// fields are part of the encoding.
+func Fields(rt reflect.Type) []Field {
+	if fields, ok := structFields.Load(rt); ok {
+		return fields.([]Field)
+	}
+
+	implied := make(map[string]bool)
+	for i := 0; i < rt.NumField(); i++ {
+		tag := ParseTag(rt.Field(i).Tag.Get("opcua"))
+		if tag.LengthField != "" {
+			implied[tag.LengthField] = true
+		}
+		if sf, ok := rt.FieldByName(tag.SwitchField); ok && !tag.HasSwitchValue && sf.Type.Kind() == reflect.Bool {
+			implied[tag.SwitchField] = true
+		}
+	}
+
+	var fields []Field
+	for i := 0; i < rt.NumField(); i++ {
+		f := rt.Field(i)
+		opcua := f.Tag.Get("opcua")
+		tag := ParseTag(opcua)
+		if f.PkgPath != "" || f.Anonymous || opcua == "-" || reserved(f, tag) || implied[f.Name] {
+			continue
+		}
+		fields = append(fields, Field{Index: i, Name: f.Name, Tag: tag})
+	}
+	structFields.Store(rt, fields)
+	return fields
+}
+
+// reserved returns true if f holds reserved bits, which are named Reserved
+// followed by a number in the type dictionaries.
+func reserved(f reflect.StructField, tag Tag) bool {
+	if !tag.Bits && f.Type.Kind() != reflect.Bool {
+		return false
+	}
+	n := strings.TrimPrefix(f.Name, "Reserved")
+	if n == f.Name {
+		return false
+	}
+	_, err := strconv.Atoi(n)
+	return n == "" || err == nil
+}
+
+// SwitchActive returns true if a field with the given tag is part of the
+// encoding of the struct value rv.
+func SwitchActive(rv reflect.Value, tag Tag) bool {
+	if tag.SwitchField == "" {
+		return true
+	}
+	sv := rv.FieldByName(tag.SwitchField)
+	if !tag.HasSwitchValue {
+		return sv.Bool()
+	}
+	var v int64
+	switch sv.Kind() {
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v = sv.Int()
+	default:
+		v = int64(sv.Uint())
+	}
+	switch tag.SwitchOperand {
+	case "GreaterThan":
+		return v > tag.SwitchValue
+	case "LessThan":
+		return v < tag.SwitchValue
+	case "GreaterThanOrEqual":
+		return v >= tag.SwitchValue
+	case "LessThanOrEqual":
+		return v <= tag.SwitchValue
+	case "NotEqual":
+		return v != tag.SwitchValue
+	}
+	return v == tag.SwitchValue
+}
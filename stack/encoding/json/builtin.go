@@ -0,0 +1,311 @@
+package json
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"github.com/searis/guma/stack/uatype"
+)
+
+// IdType values of the JSON encoding of NodeIds. Numeric node IDs omit the
+// IdType.
+const (
+	idTypeNumeric    uint8 = 0
+	idTypeString     uint8 = 1
+	idTypeGuid       uint8 = 2
+	idTypeByteString uint8 = 3
+)
+
+// nodeOf returns the node ID part of nid, ignoring any namespace URI and
+// server index.
+func nodeOf(nid uatype.ExpandedNodeId) uatype.NodeId {
+	return uatype.NodeId{
+		NodeIdType: nid.NodeIdType,
+		TwoByte:    nid.TwoByte,
+		FourByte:   nid.FourByte,
+		Numeric:    nid.Numeric,
+		StringID:   nid.StringID,
+		Guid:       nid.Guid,
+		ByteString: nid.ByteString,
+	}
+}
+
+// writeIdentifier writes the IdType and Id members of nid.
+func (enc *Encoder) writeIdentifier(o *objectWriter, nid uatype.NodeId) error {
+	switch nid.NodeIdType {
+	case uatype.NodeIdTypeTwoByte:
+		return o.value("Id", uint32(nid.TwoByte.Identifier))
+	case uatype.NodeIdTypeFourByte:
+		return o.value("Id", uint32(nid.FourByte.Identifier))
+	case uatype.NodeIdTypeNumeric:
+		return o.value("Id", nid.Numeric.Identifier)
+	case uatype.NodeIdTypeString:
+		o.value("IdType", idTypeString)
+		return o.value("Id", nid.StringID.Identifier)
+	case uatype.NodeIdTypeGuid:
+		o.value("IdType", idTypeGuid)
+		return o.value("Id", nid.Guid.Identifier)
+	case uatype.NodeIdTypeByteString:
+		o.value("IdType", idTypeByteString)
+		return o.value("Id", nid.ByteString.Identifier)
+	}
+	return fmt.Errorf("%s: NodeIdType %d", ErrInvalidValue, nid.NodeIdType)
+}
+
+// writeNamespace writes a namespace index member, unless it is 0. The
+// non-reversible encoding replaces indexes above 1 by the namespace URI, if
+// it is known.
+func (enc *Encoder) writeNamespace(o *objectWriter, name string, ns uint16) {
+	if ns == 0 {
+		return
+	}
+	if !enc.reversible && ns > 1 && enc.namespaces != nil {
+		if uri, ok := enc.namespaces.URI(ns); ok {
+			o.value(name, uri)
+			return
+		}
+	}
+	o.value(name, ns)
+}
+
+func (enc *Encoder) writeNodeID(nid uatype.NodeId) error {
+	o := enc.object()
+	if err := enc.writeIdentifier(o, nid); err != nil {
+		return err
+	}
+	enc.writeNamespace(o, "Namespace", nid.NamespaceIndex())
+	o.end()
+	return nil
+}
+
+func (enc *Encoder) writeExpandedNodeID(nid uatype.ExpandedNodeId) error {
+	o := enc.object()
+	if err := enc.writeIdentifier(o, nodeOf(nid)); err != nil {
+		return err
+	}
+	if ns, ok := nid.NamespaceIndex(); ok {
+		enc.writeNamespace(o, "Namespace", ns)
+	} else if nid.NamespaceURISpecified {
+		o.value("Namespace", nid.NamespaceURI)
+	}
+	if nid.ServerIndexSpecified && nid.ServerIndex != 0 {
+		o.value("ServerUri", nid.ServerIndex)
+	}
+	o.end()
+	return nil
+}
+
+func (enc *Encoder) writeStatusCode(code uatype.StatusCode) {
+	if enc.reversible {
+		enc.buf.WriteString(strconv.FormatUint(uint64(code), 10))
+		return
+	}
+	o := enc.object()
+	o.value("Code", uint32(code))
+	if symbol := uatype.StatusSymbol(code); symbol != "" {
+		o.value("Symbol", symbol)
+	}
+	o.end()
+}
+
+func (enc *Encoder) writeQualifiedName(qn uatype.QualifiedName) error {
+	o := enc.object()
+	o.value("Name", qn.Name)
+	enc.writeNamespace(o, "Uri", qn.NamespaceIndex)
+	o.end()
+	return nil
+}
+
+// optionalMember describes a member of a built-in type that is omitted
+// unless its switch is set.
+type optionalMember struct {
+	name      string
+	specified *uatype.Bit
+	value     interface{} // pointer to the field
+}
+
+// members writes the members that have their switch set.
+func (o *objectWriter) members(fields []optionalMember) error {
+	for _, f := range fields {
+		if !*f.specified {
+			continue
+		}
+		if err := o.field(f.name, reflect.ValueOf(f.value).Elem()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func localizedTextMembers(lt *uatype.LocalizedText) []optionalMember {
+	return []optionalMember{
+		{"Locale", &lt.LocaleSpecified, &lt.Locale},
+		{"Text", &lt.TextSpecified, &lt.Text},
+	}
+}
+
+func dataValueMembers(dv *uatype.DataValue) []optionalMember {
+	return []optionalMember{
+		{"Value", &dv.ValueSpecified, &dv.Value},
+		{"Status", &dv.StatusCodeSpecified, &dv.StatusCode},
+		{"SourceTimestamp", &dv.SourceTimestampSpecified, &dv.SourceTimestamp},
+		{"SourcePicoseconds", &dv.SourcePicosecondsSpecified, &dv.SourcePicoseconds},
+		{"ServerTimestamp", &dv.ServerTimestampSpecified, &dv.ServerTimestamp},
+		{"ServerPicoseconds", &dv.ServerPicosecondsSpecified, &dv.ServerPicoseconds},
+	}
+}
+
+func diagnosticInfoMembers(di *uatype.DiagnosticInfo) []optionalMember {
+	return []optionalMember{
+		{"SymbolicId", &di.SymbolicIdSpecified, &di.SymbolicId},
+		{"NamespaceUri", &di.NamespaceURISpecified, &di.NamespaceURI},
+		{"Locale", &di.LocaleSpecified, &di.Locale},
+		{"LocalizedText", &di.LocalizedTextSpecified, &di.LocalizedText},
+		{"AdditionalInfo", &di.AdditionalInfoSpecified, &di.AdditionalInfo},
+		{"InnerStatusCode", &di.InnerStatusCodeSpecified, &di.InnerStatusCode},
+		{"InnerDiagnosticInfo", &di.InnerDiagnosticInfoSpecified, &di.InnerDiagnosticInfo},
+	}
+}
+
+// writeLocalizedText writes lt as an object with Locale and Text members, or
+// as the text only in the non-reversible encoding.
+func (enc *Encoder) writeLocalizedText(lt uatype.LocalizedText) error {
+	if !enc.reversible {
+		enc.writeString(lt.Text)
+		return nil
+	}
+	return enc.writeMembers(localizedTextMembers(&lt))
+}
+
+// writeMembers writes an object with the members that have their switch set.
+func (enc *Encoder) writeMembers(fields []optionalMember) error {
+	o := enc.object()
+	if err := o.members(fields); err != nil {
+		return err
+	}
+	o.end()
+	return nil
+}
+
+// decodeNamespace decodes a namespace index, or a namespace URI that is looked
+// up in the namespace table of dec.
+func (dec *Decoder) decodeNamespace(data interface{}) (uint16, error) {
+	if uri, ok := data.(string); ok {
+		if dec.namespaces != nil {
+			if ns, ok := dec.namespaces.Index(uri); ok {
+				return ns, nil
+			}
+		}
+		return 0, fmt.Errorf("%s: %q", uatype.ErrUnknownNamespace, uri)
+	}
+	var ns uint16
+	err := dec.decode(data, reflect.ValueOf(&ns).Elem())
+	return ns, err
+}
+
+// decodeIdentifier decodes the IdType and Id members of m into a node ID in
+// namespace ns. Numeric node IDs use the most compact encoding.
+func (dec *Decoder) decodeIdentifier(m map[string]interface{}, ns uint16) (uatype.NodeId, error) {
+	var idType uint8
+	if _, err := dec.member(m, "IdType", &idType); err != nil {
+		return uatype.NodeId{}, err
+	}
+	switch idType {
+	case idTypeNumeric:
+		var id uint32
+		_, err := dec.member(m, "Id", &id)
+		return uatype.NewNodeID(ns, id), err
+	case idTypeString:
+		var id string
+		_, err := dec.member(m, "Id", &id)
+		return uatype.NewStringNodeID(ns, id), err
+	case idTypeGuid:
+		var id uatype.Guid
+		_, err := dec.member(m, "Id", &id)
+		return uatype.NewGuidNodeID(ns, id), err
+	case idTypeByteString:
+		var id uatype.ByteString
+		_, err := dec.member(m, "Id", &id)
+		return uatype.NewByteStringNodeID(ns, id), err
+	}
+	return uatype.NodeId{}, wrapError(fmt.Errorf("%s: IdType %d", ErrInvalidValue, idType), "IdType")
+}
+
+func (dec *Decoder) decodeNodeID(data interface{}, nid *uatype.NodeId) error {
+	m, err := asObject(data)
+	if err != nil {
+		return err
+	}
+	var ns uint16
+	if v := m["Namespace"]; v != nil {
+		if ns, err = dec.decodeNamespace(v); err != nil {
+			return wrapError(err, "Namespace")
+		}
+	}
+	*nid, err = dec.decodeIdentifier(m, ns)
+	return err
+}
+
+func (dec *Decoder) decodeExpandedNodeID(data interface{}, nid *uatype.ExpandedNodeId) error {
+	m, err := asObject(data)
+	if err != nil {
+		return err
+	}
+	var ns uint16
+	uri, isURI := m["Namespace"].(string)
+	if v := m["Namespace"]; v != nil && !isURI {
+		if ns, err = dec.decodeNamespace(v); err != nil {
+			return wrapError(err, "Namespace")
+		}
+	}
+	id, err := dec.decodeIdentifier(m, ns)
+	if err != nil {
+		return err
+	}
+	*nid = id.Expanded()
+	if isURI {
+		nid.NamespaceURISpecified = true
+		nid.NamespaceURI = uri
+	}
+	ok, err := dec.member(m, "ServerUri", &nid.ServerIndex)
+	nid.ServerIndexSpecified = uatype.Bit(ok)
+	return err
+}
+
+func (dec *Decoder) decodeQualifiedName(data interface{}, qn *uatype.QualifiedName) error {
+	m, err := asObject(data)
+	if err != nil {
+		return err
+	}
+	*qn = uatype.QualifiedName{}
+	if _, err := dec.member(m, "Name", &qn.Name); err != nil {
+		return err
+	}
+	if v := m["Uri"]; v != nil {
+		if qn.NamespaceIndex, err = dec.decodeNamespace(v); err != nil {
+			return wrapError(err, "Uri")
+		}
+	}
+	return nil
+}
+
+// decodeMembers decodes the members of an object into the fields described by
+// fields, and sets the switch of each member that is present, even if null.
+func (dec *Decoder) decodeMembers(data interface{}, fields []optionalMember) error {
+	m, err := asObject(data)
+	if err != nil {
+		return err
+	}
+	for _, f := range fields {
+		data, ok := m[f.name]
+		if !ok {
+			continue
+		}
+		if err := dec.decode(data, reflect.ValueOf(f.value).Elem()); err != nil {
+			return wrapError(err, f.name)
+		}
+		*f.specified = true
+	}
+	return nil
+}
@@ -0,0 +1,327 @@
+package json
+
+import (
+	"bytes"
+	"encoding/base64"
+	stdjson "encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"runtime/debug"
+	"strconv"
+	"time"
+
+	"github.com/searis/guma/stack/encoding/internal/uatag"
+	"github.com/searis/guma/stack/uatype"
+)
+
+// Unmarshal decodes the reversible OPC UA JSON encoding in data into v, which
+// must be a pointer.
+func Unmarshal(data []byte, v interface{}) error {
+	return NewDecoder(bytes.NewReader(data)).Decode(v)
+}
+
+// A Decoder reads OPC UA JSON content from an input stream. Only the
+// reversible encoding can be decoded.
+type Decoder struct {
+	r          *stdjson.Decoder
+	namespaces *uatype.NamespaceTable
+}
+
+// NewDecoder returns a decoder that reads from r.
+func NewDecoder(r io.Reader) *Decoder {
+	dec := &Decoder{r: stdjson.NewDecoder(r)}
+	dec.r.UseNumber()
+	return dec
+}
+
+// SetNamespaces sets the namespace table used to look up the namespace index
+// of NodeIds and QualifiedNames that refer to their namespace by URI. Without
+// a table, such values give an error.
+func (dec *Decoder) SetNamespaces(t *uatype.NamespaceTable) {
+	dec.namespaces = t
+}
+
+// Decode reads the next JSON value from the stream and stores it in v, which
+// must be a pointer.
+func (dec *Decoder) Decode(v interface{}) (err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			debugLogger.Printf("recovered from panic: %s:\n%s", e, debug.Stack())
+			err = fmt.Errorf("recovered from panic: %s", e)
+		}
+	}()
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return ErrNotSetable
+	}
+	var data interface{}
+	if err := dec.r.Decode(&data); err != nil {
+		return err
+	}
+	if err := dec.decode(data, rv.Elem()); err != nil {
+		return DecoderError{transcoderErr(err)}
+	}
+	return nil
+}
+
+// decode stores data, as parsed by the standard library decoder, in the
+// addressable value rv. JSON null gives the zero value.
+func (dec *Decoder) decode(data interface{}, rv reflect.Value) error {
+	if data == nil {
+		rv.Set(reflect.Zero(rv.Type()))
+		return nil
+	}
+
+	switch p := rv.Addr().Interface().(type) {
+	case *time.Time:
+		s, err := asString(data)
+		if err != nil {
+			return err
+		}
+		t, err := time.Parse(time.RFC3339Nano, s)
+		if err != nil {
+			return fmt.Errorf("%s: %s", ErrInvalidValue, err)
+		}
+		*p = t.UTC()
+		return nil
+	case *uatype.Guid:
+		s, err := asString(data)
+		if err != nil {
+			return err
+		}
+		*p, err = uatype.ParseGuid(s)
+		return err
+	case *uatype.ByteString:
+		return decodeByteString(data, (*[]byte)(p))
+	case *uatype.NullString:
+		s, err := asString(data)
+		if err != nil {
+			return err
+		}
+		*p = uatype.NewNullString(s)
+		return nil
+	case *uatype.XmlElement:
+		s, err := asString(data)
+		if err != nil {
+			return err
+		}
+		p.Value = []rune(s)
+		p.Length = int32(len(p.Value))
+		return nil
+	case *uatype.NodeId:
+		return dec.decodeNodeID(data, p)
+	case *uatype.ExpandedNodeId:
+		return dec.decodeExpandedNodeID(data, p)
+	case *uatype.QualifiedName:
+		return dec.decodeQualifiedName(data, p)
+	case *uatype.LocalizedText:
+		*p = uatype.LocalizedText{}
+		return dec.decodeMembers(data, localizedTextMembers(p))
+	case *uatype.DataValue:
+		*p = uatype.DataValue{}
+		return dec.decodeMembers(data, dataValueMembers(p))
+	case *uatype.DiagnosticInfo:
+		*p = uatype.DiagnosticInfo{}
+		return dec.decodeMembers(data, diagnosticInfoMembers(p))
+	case *uatype.Variant:
+		return dec.decodeVariant(data, p)
+	case *uatype.ExtensionObject:
+		return dec.decodeExtensionObject(data, p)
+	}
+
+	switch rv.Kind() {
+	case reflect.Bool:
+		b, ok := data.(bool)
+		if !ok {
+			return typeError("boolean", data)
+		}
+		rv.SetBool(b)
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		s, err := asNumber(data)
+		if err != nil {
+			return err
+		}
+		i, err := strconv.ParseInt(s, 10, rv.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("%s: %s", ErrInvalidValue, err)
+		}
+		rv.SetInt(i)
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		s, err := asNumber(data)
+		if err != nil {
+			return err
+		}
+		u, err := strconv.ParseUint(s, 10, rv.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("%s: %s", ErrInvalidValue, err)
+		}
+		rv.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		s, err := asNumber(data)
+		if err != nil {
+			return err
+		}
+		f, err := parseFloat(s, rv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		rv.SetFloat(f)
+	case reflect.String:
+		s, err := asString(data)
+		if err != nil {
+			return err
+		}
+		rv.SetString(s)
+	case reflect.Ptr:
+		rv.Set(reflect.New(rv.Type().Elem()))
+		return dec.decode(data, rv.Elem())
+	case reflect.Slice:
+		a, ok := data.([]interface{})
+		if !ok {
+			return typeError("array", data)
+		}
+		rv.Set(reflect.MakeSlice(rv.Type(), len(a), len(a)))
+		return dec.decodeArray(a, rv)
+	case reflect.Array:
+		a, ok := data.([]interface{})
+		if !ok {
+			return typeError("array", data)
+		}
+		if len(a) != rv.Len() {
+			return fmt.Errorf("%s: expected array of length %d, got %d", ErrInvalidValue, rv.Len(), len(a))
+		}
+		return dec.decodeArray(a, rv)
+	case reflect.Struct:
+		return dec.decodeStruct(data, rv)
+	default:
+		return fmt.Errorf("%s: %s", ErrUnknownType, rv.Type())
+	}
+	return nil
+}
+
+func (dec *Decoder) decodeArray(a []interface{}, rv reflect.Value) error {
+	for i, data := range a {
+		if err := dec.decode(data, rv.Index(i)); err != nil {
+			return wrapError(err, i)
+		}
+	}
+	return nil
+}
+
+// decodeStruct decodes a JSON object into the fields of a structured type.
+// Length fields and boolean switch fields are set from the fields they
+// describe.
+func (dec *Decoder) decodeStruct(data interface{}, rv reflect.Value) error {
+	m, err := asObject(data)
+	if err != nil {
+		return err
+	}
+	for _, f := range uatag.Fields(rv.Type()) {
+		v, ok := m[f.Name]
+		if !ok {
+			continue
+		}
+		fv := rv.Field(f.Index)
+		if err := dec.decode(v, fv); err != nil {
+			return wrapError(err, f.Name)
+		}
+		if f.Tag.LengthField != "" {
+			rv.FieldByName(f.Tag.LengthField).SetInt(int64(fv.Len()))
+		}
+		if f.Tag.SwitchField != "" && !f.Tag.HasSwitchValue {
+			rv.FieldByName(f.Tag.SwitchField).SetBool(true)
+		}
+	}
+	return nil
+}
+
+// member decodes the member name of m into the value pointed to by v, and
+// returns true. If the member is missing or null, v is left untouched, and
+// false is returned.
+func (dec *Decoder) member(m map[string]interface{}, name string, v interface{}) (bool, error) {
+	data := m[name]
+	if data == nil {
+		return false, nil
+	}
+	return true, wrapError(dec.decode(data, reflect.ValueOf(v).Elem()), name)
+}
+
+func decodeByteString(data interface{}, b *[]byte) error {
+	s, err := asString(data)
+	if err != nil {
+		return err
+	}
+	if *b, err = base64.StdEncoding.DecodeString(s); err != nil {
+		return fmt.Errorf("%s: %s", ErrInvalidValue, err)
+	}
+	return nil
+}
+
+// parseFloat parses s as a number, or as one of the strings "NaN",
+// "Infinity" and "-Infinity".
+func parseFloat(s string, bitSize int) (float64, error) {
+	switch s {
+	case "NaN":
+		return math.NaN(), nil
+	case "Infinity":
+		return math.Inf(1), nil
+	case "-Infinity":
+		return math.Inf(-1), nil
+	}
+	f, err := strconv.ParseFloat(s, bitSize)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %s", ErrInvalidValue, err)
+	}
+	return f, nil
+}
+
+// asNumber returns the text of a JSON number, or of a JSON string as used for
+// 64-bit integers and special floating point values.
+func asNumber(data interface{}) (string, error) {
+	switch v := data.(type) {
+	case stdjson.Number:
+		return string(v), nil
+	case string:
+		return v, nil
+	}
+	return "", typeError("number", data)
+}
+
+func asString(data interface{}) (string, error) {
+	s, ok := data.(string)
+	if !ok {
+		return "", typeError("string", data)
+	}
+	return s, nil
+}
+
+func asObject(data interface{}) (map[string]interface{}, error) {
+	m, ok := data.(map[string]interface{})
+	if !ok {
+		return nil, typeError("object", data)
+	}
+	return m, nil
+}
+
+// typeError returns an error for a JSON value of the wrong type.
+func typeError(expected string, data interface{}) error {
+	var got string
+	switch data.(type) {
+	case nil:
+		got = "null"
+	case bool:
+		got = "boolean"
+	case stdjson.Number:
+		got = "number"
+	case string:
+		got = "string"
+	case []interface{}:
+		got = "array"
+	default:
+		got = "object"
+	}
+	return fmt.Errorf("%s: expected %s, got %s", ErrInvalidValue, expected, got)
+}
@@ -0,0 +1,30 @@
+// Package json provides functionality for encoding Go types into the OPC UA
+// JSON representation as defined by
+// [IEC-62541](https://webstore.iec.ch/webstore/webstore.nsf/mysearchajax?Openform&key=62541)
+// part 6, and for decoding it. Like the binary package, it only aims to fully
+// handle types that are defined in the uatype package.
+//
+// Two encodings are provided. The reversible encoding keeps all information
+// needed to decode a value back into its Go type, e.g. the built-in type of
+// Variant values, and is what Marshal and Unmarshal use. The non-reversible
+// encoding, as returned by MarshalNonReversible, is meant for consumers that
+// have no knowledge of OPC UA. It writes Variants and ExtensionObjects as
+// their value only, LocalizedTexts as their text, StatusCodes with their
+// symbolic name, and namespace URIs instead of namespace indexes where a
+// namespace table is available. It can not be decoded.
+//
+// Structured types are encoded as JSON objects keyed by field name, driven by
+// the same opcua struct tags as the binary encoding: length fields and
+// optional field switches are implied by the fields they describe, and
+// fields that are switched off are omitted. Int64 and UInt64 values are
+// encoded as strings, and the floating point values NaN, Infinity and
+// -Infinity as the strings of the same name. Enumerations are encoded as
+// numbers. Nil ByteStrings and slices, and null uatype.NullStrings, are
+// encoded as null, so they are told apart from empty values.
+//
+// ExtensionObjects with a registered Value are encoded with the JSON encoding
+// of the Value as Body, and the registered binary encoding node ID as TypeId,
+// such that they can be decoded by this package. When decoded, the Value is
+// also binary encoded into the Body, so that the result is the same as when
+// decoding the binary encoding of the original value.
+package json
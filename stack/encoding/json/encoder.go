@@ -0,0 +1,276 @@
+package json
+
+import (
+	"bytes"
+	"encoding/base64"
+	stdjson "encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"runtime/debug"
+	"strconv"
+	"time"
+
+	"github.com/searis/guma/stack/encoding/internal/uatag"
+	"github.com/searis/guma/stack/uatype"
+)
+
+// dateTimeFormat is the format of DateTime values. OPC UA DateTime values have
+// a resolution of 100 nanoseconds.
+const dateTimeFormat = "2006-01-02T15:04:05.9999999Z07:00"
+
+// Marshal encodes v into the reversible OPC UA JSON encoding, and returns it as
+// a slice of bytes.
+func Marshal(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	err := NewEncoder(&buf).Encode(v)
+	return bytes.TrimSuffix(buf.Bytes(), []byte{'\n'}), err
+}
+
+// MarshalNonReversible encodes v into the non-reversible OPC UA JSON encoding,
+// and returns it as a slice of bytes.
+func MarshalNonReversible(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := NewEncoder(&buf)
+	enc.SetReversible(false)
+	err := enc.Encode(v)
+	return bytes.TrimSuffix(buf.Bytes(), []byte{'\n'}), err
+}
+
+// An Encoder writes OPC UA JSON content to an output stream.
+type Encoder struct {
+	w          io.Writer
+	buf        bytes.Buffer
+	str        *stdjson.Encoder
+	reversible bool
+	namespaces *uatype.NamespaceTable
+}
+
+// NewEncoder returns an encoder that writes the reversible encoding of values
+// to w.
+func NewEncoder(w io.Writer) *Encoder {
+	enc := &Encoder{w: w, reversible: true}
+	enc.str = stdjson.NewEncoder(&enc.buf)
+	enc.str.SetEscapeHTML(false)
+	return enc
+}
+
+// SetReversible selects between the reversible encoding, which can be decoded
+// back into the original value, and the non-reversible encoding, which is
+// meant for consumers that have no knowledge of OPC UA. The default is
+// reversible.
+func (enc *Encoder) SetReversible(reversible bool) {
+	enc.reversible = reversible
+}
+
+// SetNamespaces sets the namespace table used to replace namespace indexes
+// above 1 with namespace URIs in the non-reversible encoding. Without a table,
+// namespace indexes are kept.
+func (enc *Encoder) SetNamespaces(t *uatype.NamespaceTable) {
+	enc.namespaces = t
+}
+
+// Encode writes the JSON encoding of v to the stream, followed by a newline.
+func (enc *Encoder) Encode(v interface{}) (err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			debugLogger.Printf("recovered from panic: %s:\n%s", e, debug.Stack())
+			err = fmt.Errorf("recovered from panic: %s", e)
+		}
+	}()
+
+	enc.buf.Reset()
+	if err := enc.encode(reflect.ValueOf(v)); err != nil {
+		return EncoderError{transcoderErr(err)}
+	}
+	enc.buf.WriteByte('\n')
+	_, err = enc.w.Write(enc.buf.Bytes())
+	return err
+}
+
+func (enc *Encoder) encode(rv reflect.Value) error {
+	if !rv.IsValid() {
+		enc.buf.WriteString("null")
+		return nil
+	}
+
+	switch v := rv.Interface().(type) {
+	case time.Time:
+		enc.writeString(v.UTC().Format(dateTimeFormat))
+		return nil
+	case uatype.Guid:
+		enc.writeString(v.String())
+		return nil
+	case uatype.ByteString:
+		enc.writeByteString(v)
+		return nil
+	case uatype.NullString:
+		if !v.Valid {
+			enc.buf.WriteString("null")
+			return nil
+		}
+		enc.writeString(v.String)
+		return nil
+	case uatype.XmlElement:
+		enc.writeString(string(v.Value))
+		return nil
+	case uatype.StatusCode:
+		enc.writeStatusCode(v)
+		return nil
+	case uatype.NodeId:
+		return enc.writeNodeID(v)
+	case uatype.ExpandedNodeId:
+		return enc.writeExpandedNodeID(v)
+	case uatype.QualifiedName:
+		return enc.writeQualifiedName(v)
+	case uatype.LocalizedText:
+		return enc.writeLocalizedText(v)
+	case uatype.DataValue:
+		return enc.writeMembers(dataValueMembers(&v))
+	case uatype.DiagnosticInfo:
+		return enc.writeMembers(diagnosticInfoMembers(&v))
+	case uatype.Variant:
+		return enc.writeVariant(v)
+	case uatype.ExtensionObject:
+		return enc.writeExtensionObject(v)
+	}
+
+	switch rv.Kind() {
+	case reflect.Bool:
+		enc.buf.WriteString(strconv.FormatBool(rv.Bool()))
+	case reflect.Int8, reflect.Int16, reflect.Int32:
+		enc.buf.WriteString(strconv.FormatInt(rv.Int(), 10))
+	case reflect.Int64:
+		// 64-bit integers are encoded as strings, as many JSON parsers
+		// can't represent them as numbers.
+		enc.writeString(strconv.FormatInt(rv.Int(), 10))
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		enc.buf.WriteString(strconv.FormatUint(rv.Uint(), 10))
+	case reflect.Uint64:
+		enc.writeString(strconv.FormatUint(rv.Uint(), 10))
+	case reflect.Float32:
+		enc.writeFloat(rv.Float(), 32)
+	case reflect.Float64:
+		enc.writeFloat(rv.Float(), 64)
+	case reflect.String:
+		enc.writeString(rv.String())
+	case reflect.Ptr, reflect.Interface:
+		if rv.IsNil() {
+			enc.buf.WriteString("null")
+			return nil
+		}
+		return enc.encode(rv.Elem())
+	case reflect.Slice:
+		if rv.IsNil() {
+			enc.buf.WriteString("null")
+			return nil
+		}
+		return enc.writeArray(rv)
+	case reflect.Array:
+		return enc.writeArray(rv)
+	case reflect.Struct:
+		return enc.writeStruct(rv)
+	default:
+		return fmt.Errorf("%s: %s", ErrUnknownType, rv.Type())
+	}
+	return nil
+}
+
+func (enc *Encoder) writeString(s string) {
+	enc.str.Encode(s)
+	// Drop the newline added by the standard library encoder.
+	enc.buf.Truncate(enc.buf.Len() - 1)
+}
+
+func (enc *Encoder) writeByteString(b []byte) {
+	if b == nil {
+		enc.buf.WriteString("null")
+		return
+	}
+	enc.writeString(base64.StdEncoding.EncodeToString(b))
+}
+
+// writeFloat writes f as a number, or as one of the strings "NaN",
+// "Infinity" and "-Infinity", which JSON numbers can't represent.
+func (enc *Encoder) writeFloat(f float64, bitSize int) {
+	switch {
+	case math.IsNaN(f):
+		enc.writeString("NaN")
+	case math.IsInf(f, 1):
+		enc.writeString("Infinity")
+	case math.IsInf(f, -1):
+		enc.writeString("-Infinity")
+	default:
+		enc.buf.WriteString(strconv.FormatFloat(f, 'g', -1, bitSize))
+	}
+}
+
+func (enc *Encoder) writeArray(rv reflect.Value) error {
+	enc.buf.WriteByte('[')
+	for i := 0; i < rv.Len(); i++ {
+		if i > 0 {
+			enc.buf.WriteByte(',')
+		}
+		if err := enc.encode(rv.Index(i)); err != nil {
+			return wrapError(err, i)
+		}
+	}
+	enc.buf.WriteByte(']')
+	return nil
+}
+
+// writeStruct writes the fields of a structured type as a JSON object keyed
+// by field name. Fields that are switched off by their opcua struct tag are
+// omitted.
+func (enc *Encoder) writeStruct(rv reflect.Value) error {
+	o := enc.object()
+	for _, f := range uatag.Fields(rv.Type()) {
+		if !uatag.SwitchActive(rv, f.Tag) {
+			continue
+		}
+		if err := o.field(f.Name, rv.Field(f.Index)); err != nil {
+			return err
+		}
+	}
+	o.end()
+	return nil
+}
+
+// objectWriter writes the members of a JSON object.
+type objectWriter struct {
+	enc *Encoder
+	n   int
+}
+
+// object starts writing a JSON object.
+func (enc *Encoder) object() *objectWriter {
+	enc.buf.WriteByte('{')
+	return &objectWriter{enc: enc}
+}
+
+// key writes the name of the next member.
+func (o *objectWriter) key(name string) {
+	if o.n > 0 {
+		o.enc.buf.WriteByte(',')
+	}
+	o.n++
+	o.enc.writeString(name)
+	o.enc.buf.WriteByte(':')
+}
+
+// field writes a member with the encoding of rv as value.
+func (o *objectWriter) field(name string, rv reflect.Value) error {
+	o.key(name)
+	return wrapError(o.enc.encode(rv), name)
+}
+
+// value writes a member with the encoding of v as value.
+func (o *objectWriter) value(name string, v interface{}) error {
+	return o.field(name, reflect.ValueOf(v))
+}
+
+// end ends the JSON object.
+func (o *objectWriter) end() {
+	o.enc.buf.WriteByte('}')
+}
@@ -0,0 +1,96 @@
+package json
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+)
+
+// Common errors that may be returned as the cause for EncoderError and
+// DecoderError.
+var (
+	ErrInvalidValue = errors.New("invalid value")
+	ErrNotSetable   = errors.New("value must be a pointer")
+	ErrUnknownType  = errors.New("can not handle type")
+)
+
+// EncoderError provides a way of getting the logical path to where in a nested
+// data structure an encoder error occurred.
+type EncoderError struct {
+	transcoderError
+}
+
+// Error returns a human readable description of the error.
+func (err EncoderError) Error() string {
+	return fmt.Sprintf("EncoderError %s", err.transcoderError)
+}
+
+// DecoderError provides a way of getting the logical path to where in a nested
+// data structure a decoder error occurred.
+type DecoderError struct {
+	transcoderError
+}
+
+// Error returns a human readable description of the error.
+func (err DecoderError) Error() string {
+	return fmt.Sprintf("DecoderError %s", err.transcoderError)
+}
+
+type transcoderError struct {
+	path  []interface{}
+	cause error
+}
+
+// wrapError wraps a transcoderError or other error with field prepended to
+// its path.
+func wrapError(cause error, field interface{}) error {
+	if cause == nil {
+		return nil
+	}
+	err := transcoderError{
+		path: []interface{}{field},
+	}
+	if trErr, ok := cause.(transcoderError); ok {
+		err.path = append(err.path, trErr.path...)
+		err.cause = trErr.cause
+	} else {
+		err.cause = cause
+	}
+	return err
+}
+
+// Path returns the error path.
+func (err transcoderError) Path() []interface{} {
+	return err.path
+}
+
+// Cause returns the original error.
+func (err transcoderError) Cause() error {
+	return err.cause
+}
+
+// Error returns a nicely formatted error string.
+func (err transcoderError) Error() string {
+	var buf bytes.Buffer
+
+	for _, field := range err.path {
+		switch ft := field.(type) {
+		case int:
+			fmt.Fprintf(&buf, "[%d]", ft)
+		default:
+			fmt.Fprint(&buf, ".")
+			fmt.Fprint(&buf, ft)
+		}
+	}
+	fmt.Fprint(&buf, ": ", err.cause)
+	return buf.String()
+}
+
+// transcoderErr returns err as a transcoderError with an empty path, unless it
+// already is one.
+func transcoderErr(err error) transcoderError {
+	if trErr, ok := err.(transcoderError); ok {
+		return trErr
+	}
+	return transcoderError{cause: err}
+}
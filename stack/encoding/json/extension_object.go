@@ -0,0 +1,131 @@
+package json
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/searis/guma/stack/encoding/binary"
+	"github.com/searis/guma/stack/uatype"
+)
+
+// ExtensionObject Encoding values. A JSON body is the default, and is not
+// written.
+const (
+	extensionObjectJSONBody   uint8 = 0
+	extensionObjectBinaryBody uint8 = 1
+	extensionObjectXMLBody    uint8 = 2
+)
+
+// writeExtensionObject writes eo as an object with TypeId, Encoding and Body
+// members. If eo.Value is set, the TypeId is its registered binary encoding
+// node ID, and the Body holds its JSON encoding. Otherwise binary bodies are
+// written as base64 strings, and XML bodies as strings. The non-reversible
+// encoding holds the Body only. ExtensionObjects without TypeId and body are
+// written as null.
+func (enc *Encoder) writeExtensionObject(eo uatype.ExtensionObject) error {
+	var body reflect.Value
+	encoding := eo.Encoding
+	switch {
+	case eo.Value != nil:
+		id, ok := uatype.ExtensionObjectEncodingID(eo.Value)
+		if !ok {
+			return fmt.Errorf("%s: %T is not a registered ExtensionObject type", ErrUnknownType, eo.Value)
+		}
+		eo.TypeId = id.Expanded()
+		encoding = extensionObjectJSONBody
+		body = reflect.ValueOf(eo.Value)
+	case eo.Encoding == extensionObjectBinaryBody:
+		body = reflect.ValueOf(uatype.ByteString(eo.Body))
+	case eo.Encoding == extensionObjectXMLBody:
+		body = reflect.ValueOf(string(eo.Body))
+	case eo.Encoding != 0:
+		return fmt.Errorf("%s: ExtensionObject Encoding %d", ErrInvalidValue, eo.Encoding)
+	case reflect.DeepEqual(eo.TypeId, uatype.ExpandedNodeId{}):
+		enc.buf.WriteString("null")
+		return nil
+	}
+
+	if !enc.reversible {
+		return enc.encode(body)
+	}
+	o := enc.object()
+	if err := o.value("TypeId", eo.TypeId); err != nil {
+		return err
+	}
+	if encoding != extensionObjectJSONBody {
+		o.value("Encoding", encoding)
+	}
+	if body.IsValid() {
+		if err := o.field("Body", body); err != nil {
+			return err
+		}
+	}
+	o.end()
+	return nil
+}
+
+// decodeExtensionObject decodes eo. JSON bodies are decoded into eo.Value,
+// which requires the TypeId to be registered, and are then binary encoded
+// into eo.Body, such that eo is equal to the result of decoding its binary
+// encoding. Binary bodies of registered types are decoded into eo.Value as
+// well.
+func (dec *Decoder) decodeExtensionObject(data interface{}, eo *uatype.ExtensionObject) error {
+	m, err := asObject(data)
+	if err != nil {
+		return err
+	}
+	*eo = uatype.ExtensionObject{}
+	if _, err := dec.member(m, "TypeId", &eo.TypeId); err != nil {
+		return err
+	}
+	var encoding uint8
+	if _, err := dec.member(m, "Encoding", &encoding); err != nil {
+		return err
+	}
+
+	switch encoding {
+	case extensionObjectJSONBody:
+		if m["Body"] == nil {
+			return nil
+		}
+		rt, ok := uatype.ExtensionObjectType(eo.TypeId)
+		if !ok {
+			return wrapError(fmt.Errorf("%s: no type registered for %s", ErrUnknownType, eo.TypeId), "TypeId")
+		}
+		rv := reflect.New(rt)
+		if _, err := dec.member(m, "Body", rv.Interface()); err != nil {
+			return err
+		}
+		body, err := binary.Marshal(rv.Elem().Interface())
+		if err != nil {
+			return wrapError(err, "Body")
+		}
+		eo.Body = body
+		eo.Value = rv.Elem().Interface()
+	case extensionObjectBinaryBody:
+		if _, err := dec.member(m, "Body", (*uatype.ByteString)(&eo.Body)); err != nil {
+			return err
+		}
+		if rt, ok := uatype.ExtensionObjectType(eo.TypeId); ok {
+			rv := reflect.New(rt)
+			if err := binary.Unmarshal(eo.Body, rv.Interface()); err != nil {
+				return wrapError(err, "Body")
+			}
+			eo.Value = rv.Elem().Interface()
+		}
+	case extensionObjectXMLBody:
+		var body string
+		if _, err := dec.member(m, "Body", &body); err != nil {
+			return err
+		}
+		eo.Body = []byte(body)
+	default:
+		return wrapError(fmt.Errorf("%s: Encoding %d", ErrInvalidValue, encoding), "Encoding")
+	}
+	eo.Encoding = encoding
+	if encoding == extensionObjectJSONBody {
+		eo.Encoding = extensionObjectBinaryBody
+	}
+	eo.BodyLength = int32(len(eo.Body))
+	return nil
+}
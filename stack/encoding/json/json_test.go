@@ -0,0 +1,434 @@
+package json_test
+
+import (
+	"bytes"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/searis/guma/stack/encoding/json"
+	"github.com/searis/guma/stack/uatype"
+	"github.com/searis/guma/stack/uatype/typedict"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testGuid = uatype.Guid{0x91, 0x2B, 0x96, 0x72, 0x75, 0xFA, 0xE6, 0x4A, 0x8D, 0x28, 0xB4, 0x04, 0xDC, 0x7D, 0xAF, 0x63}
+
+func mustVariant(t *testing.T, v interface{}) uatype.Variant {
+	variant, err := uatype.NewVariant(v)
+	require.NoError(t, err, "NewVariant")
+	return variant
+}
+
+type marshalTest struct {
+	Name          string
+	Value         interface{}
+	Reversible    string
+	NonReversible string
+
+	// Decoded is the expected result of decoding Reversible, if it is not
+	// equal to Value.
+	Decoded interface{}
+}
+
+func marshalTests(t *testing.T) []marshalTest {
+	ts := time.Date(2018, 3, 14, 15, 9, 26, 535897900, time.UTC)
+	return []marshalTest{
+		{
+			Name:          "Int32",
+			Value:         int32(-42),
+			Reversible:    `-42`,
+			NonReversible: `-42`,
+		},
+		{
+			Name:          "Int64",
+			Value:         int64(-1) << 62,
+			Reversible:    `"-4611686018427387904"`,
+			NonReversible: `"-4611686018427387904"`,
+		},
+		{
+			Name:          "UInt64",
+			Value:         uint64(math.MaxUint64),
+			Reversible:    `"18446744073709551615"`,
+			NonReversible: `"18446744073709551615"`,
+		},
+		{
+			Name:          "Float",
+			Value:         float32(0.1),
+			Reversible:    `0.1`,
+			NonReversible: `0.1`,
+		},
+		{
+			Name:          "DoubleInfinity",
+			Value:         math.Inf(-1),
+			Reversible:    `"-Infinity"`,
+			NonReversible: `"-Infinity"`,
+		},
+		{
+			Name:          "String",
+			Value:         "<æøå>",
+			Reversible:    `"<æøå>"`,
+			NonReversible: `"<æøå>"`,
+		},
+		{
+			Name:          "DateTime",
+			Value:         ts,
+			Reversible:    `"2018-03-14T15:09:26.5358979Z"`,
+			NonReversible: `"2018-03-14T15:09:26.5358979Z"`,
+		},
+		{
+			Name:          "Guid",
+			Value:         testGuid,
+			Reversible:    `"72962B91-FA75-4AE6-8D28-B404DC7DAF63"`,
+			NonReversible: `"72962B91-FA75-4AE6-8D28-B404DC7DAF63"`,
+		},
+		{
+			Name:          "ByteString",
+			Value:         uatype.ByteString("guma"),
+			Reversible:    `"Z3VtYQ=="`,
+			NonReversible: `"Z3VtYQ=="`,
+		},
+		{
+			Name:          "NullStringNull",
+			Value:         uatype.NullString{},
+			Reversible:    `null`,
+			NonReversible: `null`,
+		},
+		{
+			Name:          "NullStringEmpty",
+			Value:         uatype.NewNullString(""),
+			Reversible:    `""`,
+			NonReversible: `""`,
+		},
+		{
+			Name:          "XmlElement",
+			Value:         uatype.XmlElement{Length: 8, Value: []rune("<a>b</a>")},
+			Reversible:    `"<a>b</a>"`,
+			NonReversible: `"<a>b</a>"`,
+		},
+		{
+			Name:          "NodeIdNumeric",
+			Value:         uatype.NewFourByteNodeID(1, 1000),
+			Reversible:    `{"Id":1000,"Namespace":1}`,
+			NonReversible: `{"Id":1000,"Namespace":1}`,
+		},
+		{
+			Name:          "NodeIdString",
+			Value:         uatype.NewStringNodeID(2, "Demo.Static"),
+			Reversible:    `{"IdType":1,"Id":"Demo.Static","Namespace":2}`,
+			NonReversible: `{"IdType":1,"Id":"Demo.Static","Namespace":2}`,
+		},
+		{
+			Name:          "NodeIdGuid",
+			Value:         uatype.NewGuidNodeID(0, testGuid),
+			Reversible:    `{"IdType":2,"Id":"72962B91-FA75-4AE6-8D28-B404DC7DAF63"}`,
+			NonReversible: `{"IdType":2,"Id":"72962B91-FA75-4AE6-8D28-B404DC7DAF63"}`,
+		},
+		{
+			Name:          "NodeIdByteString",
+			Value:         uatype.NewByteStringNodeID(3, uatype.ByteString("guma")),
+			Reversible:    `{"IdType":3,"Id":"Z3VtYQ==","Namespace":3}`,
+			NonReversible: `{"IdType":3,"Id":"Z3VtYQ==","Namespace":3}`,
+		},
+		{
+			Name: "ExpandedNodeId",
+			Value: uatype.ExpandedNodeId{
+				NodeIdType:            uatype.NodeIdTypeString,
+				StringID:              uatype.StringNodeId{Identifier: "Demo"},
+				NamespaceURISpecified: true,
+				NamespaceURI:          "urn:guma",
+				ServerIndexSpecified:  true,
+				ServerIndex:           2,
+			},
+			Reversible:    `{"IdType":1,"Id":"Demo","Namespace":"urn:guma","ServerUri":2}`,
+			NonReversible: `{"IdType":1,"Id":"Demo","Namespace":"urn:guma","ServerUri":2}`,
+		},
+		{
+			Name:          "StatusCode",
+			Value:         uatype.StatusBadTimeout,
+			Reversible:    `2148139008`,
+			NonReversible: `{"Code":2148139008,"Symbol":"BadTimeout"}`,
+		},
+		{
+			Name:          "QualifiedName",
+			Value:         uatype.QualifiedName{NamespaceIndex: 2, Name: "Temperature"},
+			Reversible:    `{"Name":"Temperature","Uri":2}`,
+			NonReversible: `{"Name":"Temperature","Uri":2}`,
+		},
+		{
+			Name:          "LocalizedText",
+			Value:         uatype.LocalizedText{LocaleSpecified: true, Locale: "en-US", TextSpecified: true, Text: "Hello"},
+			Reversible:    `{"Locale":"en-US","Text":"Hello"}`,
+			NonReversible: `"Hello"`,
+		},
+		{
+			Name:          "LocalizedTextNull",
+			Value:         uatype.LocalizedText{},
+			Reversible:    `{}`,
+			NonReversible: `""`,
+		},
+		{
+			Name:          "VariantNull",
+			Value:         uatype.Variant{},
+			Reversible:    `null`,
+			NonReversible: `null`,
+		},
+		{
+			Name:          "VariantScalar",
+			Value:         mustVariant(t, uint64(7)),
+			Reversible:    `{"Type":9,"Body":"7"}`,
+			NonReversible: `"7"`,
+		},
+		{
+			Name:          "VariantArray",
+			Value:         mustVariant(t, []string{"a", "b"}),
+			Reversible:    `{"Type":12,"Body":["a","b"]}`,
+			NonReversible: `["a","b"]`,
+		},
+		{
+			Name:          "VariantMatrix",
+			Value:         mustVariant(t, [][]float64{{1, 2, 3}, {4, 5, 6}}),
+			Reversible:    `{"Type":11,"Body":[1,2,3,4,5,6],"Dimensions":[2,3]}`,
+			NonReversible: `[[1,2,3],[4,5,6]]`,
+		},
+		{
+			Name:          "VariantLocalizedText",
+			Value:         mustVariant(t, uatype.LocalizedText{TextSpecified: true, Text: "Hello"}),
+			Reversible:    `{"Type":21,"Body":{"Text":"Hello"}}`,
+			NonReversible: `"Hello"`,
+		},
+		{
+			Name: "DataValue",
+			Value: uatype.DataValue{
+				ValueSpecified:             true,
+				Value:                      mustVariant(t, int16(-3)),
+				StatusCodeSpecified:        true,
+				StatusCode:                 uatype.StatusBadTimeout,
+				SourceTimestampSpecified:   true,
+				SourceTimestamp:            ts,
+				SourcePicosecondsSpecified: true,
+				SourcePicoseconds:          10,
+			},
+			Reversible:    `{"Value":{"Type":4,"Body":-3},"Status":2148139008,"SourceTimestamp":"2018-03-14T15:09:26.5358979Z","SourcePicoseconds":10}`,
+			NonReversible: `{"Value":-3,"Status":{"Code":2148139008,"Symbol":"BadTimeout"},"SourceTimestamp":"2018-03-14T15:09:26.5358979Z","SourcePicoseconds":10}`,
+		},
+		{
+			Name: "DiagnosticInfo",
+			Value: &uatype.DiagnosticInfo{
+				SymbolicIdSpecified:          true,
+				SymbolicId:                   1,
+				AdditionalInfoSpecified:      true,
+				AdditionalInfo:               "details",
+				InnerDiagnosticInfoSpecified: true,
+				InnerDiagnosticInfo: &uatype.DiagnosticInfo{
+					InnerStatusCodeSpecified: true,
+					InnerStatusCode:          uatype.StatusBadTimeout,
+				},
+			},
+			Reversible:    `{"SymbolicId":1,"AdditionalInfo":"details","InnerDiagnosticInfo":{"InnerStatusCode":2148139008}}`,
+			NonReversible: `{"SymbolicId":1,"AdditionalInfo":"details","InnerDiagnosticInfo":{"InnerStatusCode":{"Code":2148139008,"Symbol":"BadTimeout"}}}`,
+		},
+		{
+			Name: "ExtensionObject",
+			Value: uatype.ExtensionObject{
+				Value: uatype.AnonymousIdentityToken{PolicyId: "anonymous"},
+			},
+			Reversible:    `{"TypeId":{"Id":321},"Body":{"PolicyId":"anonymous"}}`,
+			NonReversible: `{"PolicyId":"anonymous"}`,
+			Decoded: uatype.ExtensionObject{
+				TypeId:     uatype.NewNodeID(0, 321).Expanded(),
+				Encoding:   1,
+				BodyLength: 13,
+				Body:       []byte("\x09\x00\x00\x00anonymous"),
+				Value:      uatype.AnonymousIdentityToken{PolicyId: "anonymous"},
+			},
+		},
+		{
+			Name: "ExtensionObjectBinary",
+			Value: uatype.ExtensionObject{
+				TypeId:     uatype.NewStringNodeID(2, "Vendor").Expanded(),
+				Encoding:   1,
+				BodyLength: 4,
+				Body:       []byte("guma"),
+			},
+			Reversible:    `{"TypeId":{"IdType":1,"Id":"Vendor","Namespace":2},"Encoding":1,"Body":"Z3VtYQ=="}`,
+			NonReversible: `"Z3VtYQ=="`,
+		},
+		{
+			Name: "ExtensionObjectXML",
+			Value: uatype.ExtensionObject{
+				TypeId:     uatype.NewStringNodeID(2, "Vendor").Expanded(),
+				Encoding:   2,
+				BodyLength: 8,
+				Body:       []byte("<a>b</a>"),
+			},
+			Reversible:    `{"TypeId":{"IdType":1,"Id":"Vendor","Namespace":2},"Encoding":2,"Body":"<a>b</a>"}`,
+			NonReversible: `"<a>b</a>"`,
+		},
+		{
+			Name:          "ExtensionObjectNull",
+			Value:         uatype.ExtensionObject{},
+			Reversible:    `null`,
+			NonReversible: `null`,
+		},
+		{
+			Name: "Structure",
+			Value: uatype.ReadRequest{
+				MaxAge:             100,
+				TimestampsToReturn: uatype.TimestampsToReturnBoth,
+				NoOfNodesToRead:    1,
+				NodesToRead: []uatype.ReadValueId{{
+					NodeId:      uatype.NewTwoByteNodeID(85),
+					AttributeId: 13,
+				}},
+			},
+			Reversible: `{"RequestHeader":{"AuthenticationToken":{"Id":0},"Timestamp":"0001-01-01T00:00:00Z","RequestHandle":0,"ReturnDiagnostics":0,"AuditEntryId":"","TimeoutHint":0,"AdditionalHeader":null},` +
+				`"MaxAge":100,"TimestampsToReturn":2,"NodesToRead":[{"NodeId":{"Id":85},"AttributeId":13,"IndexRange":"","DataEncoding":{"Name":""}}]}`,
+			NonReversible: `{"RequestHeader":{"AuthenticationToken":{"Id":0},"Timestamp":"0001-01-01T00:00:00Z","RequestHandle":0,"ReturnDiagnostics":0,"AuditEntryId":"","TimeoutHint":0,"AdditionalHeader":null},` +
+				`"MaxAge":100,"TimestampsToReturn":2,"NodesToRead":[{"NodeId":{"Id":85},"AttributeId":13,"IndexRange":"","DataEncoding":{"Name":""}}]}`,
+		},
+	}
+}
+
+func TestMarshal(t *testing.T) {
+	for _, tc := range marshalTests(t) {
+		t.Run(tc.Name, func(t *testing.T) {
+			data, err := json.Marshal(tc.Value)
+			require.NoError(t, err, "Marshal")
+			assert.Equal(t, tc.Reversible, string(data), "Marshal")
+
+			data, err = json.MarshalNonReversible(tc.Value)
+			require.NoError(t, err, "MarshalNonReversible")
+			assert.Equal(t, tc.NonReversible, string(data), "MarshalNonReversible")
+		})
+	}
+}
+
+func TestUnmarshal(t *testing.T) {
+	for _, tc := range marshalTests(t) {
+		t.Run(tc.Name, func(t *testing.T) {
+			expected := tc.Decoded
+			if expected == nil {
+				expected = tc.Value
+			}
+			rv := reflect.New(reflect.TypeOf(expected))
+			require.NoError(t, json.Unmarshal([]byte(tc.Reversible), rv.Interface()), "Unmarshal")
+			assert.Equal(t, expected, rv.Elem().Interface())
+		})
+	}
+}
+
+func TestNamespaces(t *testing.T) {
+	namespaces := uatype.NewNamespaceTable([]string{uatype.DefaultNamespaceURI, "urn:local", "urn:guma"})
+	v := uatype.ReadValueId{
+		NodeId:       uatype.NewStringNodeID(2, "Demo"),
+		DataEncoding: uatype.QualifiedName{NamespaceIndex: 2, Name: "Default"},
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetReversible(false)
+	enc.SetNamespaces(namespaces)
+	require.NoError(t, enc.Encode(v), "Encode")
+	data := `{"NodeId":{"IdType":1,"Id":"Demo","Namespace":"urn:guma"},"AttributeId":0,"IndexRange":"","DataEncoding":{"Name":"Default","Uri":"urn:guma"}}`
+	assert.Equal(t, data+"\n", buf.String(), "non-reversible encoding")
+
+	var decoded uatype.ReadValueId
+	err := json.Unmarshal([]byte(data), &decoded)
+	require.Error(t, err, "Unmarshal without namespace table")
+	assert.Equal(t, `DecoderError .NodeId.Namespace: unknown namespace: "urn:guma"`, err.Error())
+
+	dec := json.NewDecoder(strings.NewReader(data))
+	dec.SetNamespaces(namespaces)
+	require.NoError(t, dec.Decode(&decoded), "Decode")
+	assert.Equal(t, v, decoded)
+}
+
+func TestUnmarshalErrors(t *testing.T) {
+	cases := []struct {
+		Name  string
+		Data  string
+		Value interface{}
+		Error string
+	}{
+		{
+			Name:  "NotSetable",
+			Data:  `1`,
+			Value: int32(0),
+			Error: "value must be a pointer",
+		},
+		{
+			Name:  "Type",
+			Data:  `{"NodesToRead":[{"AttributeId":"x"}]}`,
+			Value: new(uatype.ReadRequest),
+			Error: `DecoderError .NodesToRead[0].AttributeId: invalid value: strconv.ParseUint: parsing "x": invalid syntax`,
+		},
+		{
+			Name:  "Range",
+			Data:  `{"Type":3,"Body":256}`,
+			Value: new(uatype.Variant),
+			Error: `DecoderError .Body: invalid value: strconv.ParseUint: parsing "256": value out of range`,
+		},
+		{
+			Name:  "VariantType",
+			Data:  `{"Type":26,"Body":1}`,
+			Value: new(uatype.Variant),
+			Error: "DecoderError .Type: invalid value: VariantType 26",
+		},
+		{
+			Name:  "IdType",
+			Data:  `{"IdType":4,"Id":1}`,
+			Value: new(uatype.NodeId),
+			Error: "DecoderError .IdType: invalid value: IdType 4",
+		},
+		{
+			Name:  "UnregisteredBody",
+			Data:  `{"TypeId":{"IdType":1,"Id":"Vendor","Namespace":2},"Body":{}}`,
+			Value: new(uatype.ExtensionObject),
+			Error: "DecoderError .TypeId: can not handle type: no type registered for ns=2;s=Vendor",
+		},
+		{
+			Name:  "Object",
+			Data:  `[]`,
+			Value: new(uatype.LocalizedText),
+			Error: "DecoderError : invalid value: expected object, got array",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			err := json.Unmarshal([]byte(tc.Data), tc.Value)
+			require.Error(t, err)
+			assert.Equal(t, tc.Error, err.Error())
+		})
+	}
+}
+
+const bitFieldDict = `<opc:TypeDictionary xmlns:opc="http://opcfoundation.org/BinarySchema/" TargetNamespace="urn:bits">
+  <opc:StructuredType Name="Packed">
+    <opc:Field Name="Flags" TypeName="opc:Bit" Length="4" />
+    <opc:Field Name="Counter" TypeName="opc:Bit" Length="12" />
+    <opc:Field Name="Enabled" TypeName="opc:Bit" />
+    <opc:Field Name="Reserved1" TypeName="opc:Bit" Length="7" />
+    <opc:Field Name="Value" TypeName="opc:Int32" />
+  </opc:StructuredType>
+</opc:TypeDictionary>`
+
+func TestBitFields(t *testing.T) {
+	d, err := typedict.NewSet().Parse(strings.NewReader(bitFieldDict))
+	require.NoError(t, err, "Parse")
+	packed, err := d.Type("Packed")
+	require.NoError(t, err, `d.Type("Packed")`)
+	v, err := packed.New(map[string]interface{}{"Flags": 5, "Counter": 0xABC, "Enabled": true, "Value": 7})
+	require.NoError(t, err, "packed.New")
+
+	data, err := json.Marshal(v)
+	require.NoError(t, err, "Marshal")
+	assert.Equal(t, `{"Flags":5,"Counter":2748,"Enabled":true,"Value":7}`, string(data), "Marshal")
+
+	rv := reflect.New(packed.GoType())
+	require.NoError(t, json.Unmarshal(data, rv.Interface()), "Unmarshal")
+	assert.Equal(t, v, rv.Elem().Interface(), "Unmarshal")
+}
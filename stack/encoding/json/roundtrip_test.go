@@ -0,0 +1,50 @@
+package json_test
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/searis/guma/internal/testutil"
+	"github.com/searis/guma/stack/encoding/binary"
+	"github.com/searis/guma/stack/encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRoundTrip checks that decoding the reversible encoding of random values
+// gives a value with the same JSON and binary encoding. The values are not
+// compared directly, as numeric NodeIds are decoded with the most compact
+// encoding.
+func TestRoundTrip(t *testing.T) {
+	const iterations = 20
+	seed := time.Now().UnixNano()
+	t.Logf("seed %d", seed)
+	g := testutil.NewValueGen(rand.New(rand.NewSource(seed)))
+	types := testutil.RegisteredTypes()
+	require.NotEmpty(t, types, "registered types")
+
+	for _, rt := range types {
+		t.Run(rt.Name(), func(t *testing.T) {
+			for i := 0; i < iterations; i++ {
+				v := g.Value(rt)
+				data, err := json.Marshal(v)
+				require.NoError(t, err, "Marshal")
+				_, err = json.MarshalNonReversible(v)
+				require.NoError(t, err, "MarshalNonReversible")
+
+				rv := reflect.New(rt)
+				require.NoError(t, json.Unmarshal(data, rv.Interface()), "Unmarshal %s", data)
+				decoded, err := json.Marshal(rv.Elem().Interface())
+				require.NoError(t, err, "Marshal decoded")
+				if !assert.Equal(t, string(data), string(decoded), "JSON encoding") {
+					return
+				}
+
+				_, err = binary.Marshal(rv.Elem().Interface())
+				require.NoError(t, err, "binary Marshal decoded")
+			}
+		})
+	}
+}
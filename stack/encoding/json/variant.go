@@ -0,0 +1,107 @@
+package json
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/searis/guma/stack/encoding/internal/uatag"
+	"github.com/searis/guma/stack/uatype"
+)
+
+// variantFields maps VariantType values to the index of the matching slice
+// field in the Variant struct, as given by its opcua struct tags.
+var variantFields = map[byte]int{}
+
+func init() {
+	rt := reflect.TypeOf(uatype.Variant{})
+	for i := 0; i < rt.NumField(); i++ {
+		tag := uatag.ParseTag(rt.Field(i).Tag.Get("opcua"))
+		if tag.SwitchField == "VariantType" && tag.HasSwitchValue {
+			variantFields[byte(tag.SwitchValue)] = i
+		}
+	}
+}
+
+// writeVariant writes v as an object with the built-in type ID as Type, the
+// value as Body, and the dimensions of multi-dimensional arrays as
+// Dimensions. Arrays are flattened in the Body. The non-reversible encoding
+// holds the value only, with multi-dimensional arrays as nested arrays. Null
+// Variants are written as null.
+func (enc *Encoder) writeVariant(v uatype.Variant) error {
+	if v.VariantType == 0 {
+		enc.buf.WriteString("null")
+		return nil
+	}
+	if !enc.reversible {
+		value, err := v.Value()
+		if err != nil {
+			return err
+		}
+		return enc.encode(reflect.ValueOf(value))
+	}
+
+	fi, ok := variantFields[v.VariantType]
+	if !ok {
+		return fmt.Errorf("%s: VariantType %d", ErrInvalidValue, v.VariantType)
+	}
+	body := reflect.ValueOf(v).Field(fi)
+	o := enc.object()
+	o.value("Type", v.VariantType)
+	switch {
+	case bool(v.ArrayLengthSpecified):
+		if body.IsNil() {
+			// Null arrays are written as empty arrays, to keep them apart
+			// from scalars.
+			body = reflect.MakeSlice(body.Type(), 0, 0)
+		}
+	case body.Len() != 1:
+		return fmt.Errorf("%s: scalar Variant with %d values", ErrInvalidValue, body.Len())
+	default:
+		body = body.Index(0)
+	}
+	if err := o.field("Body", body); err != nil {
+		return err
+	}
+	if v.ArrayDimensionsSpecified {
+		o.value("Dimensions", v.ArrayDimensions)
+	}
+	o.end()
+	return nil
+}
+
+func (dec *Decoder) decodeVariant(data interface{}, v *uatype.Variant) error {
+	m, err := asObject(data)
+	if err != nil {
+		return err
+	}
+	*v = uatype.Variant{}
+	if ok, err := dec.member(m, "Type", &v.VariantType); !ok || err != nil {
+		return err
+	}
+	fi, ok := variantFields[v.VariantType]
+	if !ok {
+		return wrapError(fmt.Errorf("%s: VariantType %d", ErrInvalidValue, v.VariantType), "Type")
+	}
+
+	field := reflect.ValueOf(v).Elem().Field(fi)
+	if body, ok := m["Body"].([]interface{}); ok {
+		field.Set(reflect.MakeSlice(field.Type(), len(body), len(body)))
+		if err := dec.decodeArray(body, field); err != nil {
+			return wrapError(err, "Body")
+		}
+		v.ArrayLengthSpecified = true
+		v.ArrayLength = int32(len(body))
+	} else {
+		field.Set(reflect.MakeSlice(field.Type(), 1, 1))
+		if err := dec.decode(m["Body"], field.Index(0)); err != nil {
+			return wrapError(err, "Body")
+		}
+	}
+
+	ok, err = dec.member(m, "Dimensions", &v.ArrayDimensions)
+	if ok {
+		v.ArrayDimensionsSpecified = true
+		v.NoOfArrayDimensions = int32(len(v.ArrayDimensions))
+	}
+	return err
+}
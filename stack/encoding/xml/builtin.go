@@ -0,0 +1,75 @@
+package xml
+
+import (
+	"reflect"
+
+	"github.com/searis/guma/stack/uatype"
+)
+
+// optionalMember describes a child element of a built-in type that is omitted
+// unless its switch is set.
+type optionalMember struct {
+	name      string
+	specified *uatype.Bit
+	value     interface{} // pointer to the field
+}
+
+func localizedTextMembers(lt *uatype.LocalizedText) []optionalMember {
+	return []optionalMember{
+		{"Locale", &lt.LocaleSpecified, &lt.Locale},
+		{"Text", &lt.TextSpecified, &lt.Text},
+	}
+}
+
+func dataValueMembers(dv *uatype.DataValue) []optionalMember {
+	return []optionalMember{
+		{"Value", &dv.ValueSpecified, &dv.Value},
+		{"StatusCode", &dv.StatusCodeSpecified, &dv.StatusCode},
+		{"SourceTimestamp", &dv.SourceTimestampSpecified, &dv.SourceTimestamp},
+		{"SourcePicoseconds", &dv.SourcePicosecondsSpecified, &dv.SourcePicoseconds},
+		{"ServerTimestamp", &dv.ServerTimestampSpecified, &dv.ServerTimestamp},
+		{"ServerPicoseconds", &dv.ServerPicosecondsSpecified, &dv.ServerPicoseconds},
+	}
+}
+
+func diagnosticInfoMembers(di *uatype.DiagnosticInfo) []optionalMember {
+	return []optionalMember{
+		{"SymbolicId", &di.SymbolicIdSpecified, &di.SymbolicId},
+		{"NamespaceUri", &di.NamespaceURISpecified, &di.NamespaceURI},
+		{"Locale", &di.LocaleSpecified, &di.Locale},
+		{"LocalizedText", &di.LocalizedTextSpecified, &di.LocalizedText},
+		{"AdditionalInfo", &di.AdditionalInfoSpecified, &di.AdditionalInfo},
+		{"InnerStatusCode", &di.InnerStatusCodeSpecified, &di.InnerStatusCode},
+		{"InnerDiagnosticInfo", &di.InnerDiagnosticInfoSpecified, &di.InnerDiagnosticInfo},
+	}
+}
+
+// writeMembers writes the members that have their switch set as child
+// elements.
+func (enc *Encoder) writeMembers(fields []optionalMember) error {
+	for _, f := range fields {
+		if !*f.specified {
+			continue
+		}
+		if err := enc.writeElement(f.name, reflect.ValueOf(f.value).Elem()); err != nil {
+			return wrapError(err, f.name)
+		}
+	}
+	return nil
+}
+
+// decodeMembers decodes the child elements of e into the fields described by
+// fields, and sets the switch of each member that is present.
+func (dec *Decoder) decodeMembers(e *element, fields []optionalMember) error {
+	for _, f := range fields {
+		child := e.child(f.name)
+		if child == nil {
+			continue
+		}
+		if err := dec.decode(child, reflect.ValueOf(f.value).Elem()); err != nil {
+			return wrapError(err, f.name)
+		}
+		*f.specified = true
+	}
+	return nil
+}
@@ -0,0 +1,323 @@
+package xml
+
+import (
+	"bytes"
+	"encoding/base64"
+	stdxml "encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/searis/guma/stack/encoding/binary"
+	"github.com/searis/guma/stack/encoding/internal/uatag"
+	"github.com/searis/guma/stack/uatype"
+)
+
+// Unmarshal decodes the OPC UA XML encoding in data into v, which must be a
+// pointer.
+func Unmarshal(data []byte, v interface{}) error {
+	return NewDecoder(bytes.NewReader(data)).Decode(v)
+}
+
+// UnmarshalValue decodes a Value element, as used for the values of variables
+// in NodeSet2 files, into v.
+func UnmarshalValue(data []byte, v *uatype.Variant) error {
+	return NewDecoder(bytes.NewReader(data)).DecodeValue(v)
+}
+
+// A Decoder reads OPC UA XML content from an input stream.
+type Decoder struct {
+	r *stdxml.Decoder
+
+	limits    binary.DecoderLimits
+	allocated int // bytes allocated by the current call to Decode
+}
+
+// NewDecoder returns a decoder that reads from r.
+func NewDecoder(r io.Reader) *Decoder {
+	return &Decoder{r: stdxml.NewDecoder(r)}
+}
+
+// Decode reads the next XML element from the stream and stores it in v, which
+// must be a pointer. The name of the element is not checked.
+func (dec *Decoder) Decode(v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return ErrNotSetable
+	}
+	return dec.decodeRoot(func(e *element) error {
+		return dec.decode(e, rv.Elem())
+	})
+}
+
+// DecodeValue reads the next XML element from the stream as the Value element
+// of a Variant, as used for the values of variables in NodeSet2 files, and
+// stores it in v.
+func (dec *Decoder) DecodeValue(v *uatype.Variant) error {
+	return dec.decodeRoot(func(e *element) error {
+		*v = uatype.Variant{}
+		return dec.decodeValue(e, v)
+	})
+}
+
+// decodeRoot reads the next XML element from the stream and passes it to
+// decode.
+func (dec *Decoder) decodeRoot(decode func(e *element) error) (err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			debugLogger.Printf("recovered from panic: %s:\n%s", e, debug.Stack())
+			err = fmt.Errorf("recovered from panic: %s", e)
+		}
+	}()
+
+	dec.allocated = 0
+	for {
+		tok, err := dec.r.Token()
+		if err != nil {
+			return err
+		}
+		if start, ok := tok.(stdxml.StartElement); ok {
+			e, err := dec.readElement(start, 0)
+			if _, ok := err.(binary.LimitError); ok {
+				return DecoderError{transcoderErr(err)}
+			} else if err != nil {
+				return err
+			}
+			if err := decode(e); err != nil {
+				return DecoderError{transcoderErr(err)}
+			}
+			return nil
+		}
+	}
+}
+
+// decode stores the content of e in the addressable value rv. Elements that
+// are marked as nil give the zero value.
+func (dec *Decoder) decode(e *element, rv reflect.Value) error {
+	if e.isNil() {
+		rv.Set(reflect.Zero(rv.Type()))
+		return nil
+	}
+
+	switch p := rv.Addr().Interface().(type) {
+	case *time.Time:
+		t, err := time.Parse(time.RFC3339Nano, strings.TrimSpace(e.text()))
+		if err != nil {
+			return fmt.Errorf("%s: %s", ErrInvalidValue, err)
+		}
+		*p = t.UTC()
+		return nil
+	case *uatype.Guid:
+		var s string
+		if err := dec.child(e, "String", &s); err != nil {
+			return err
+		}
+		g, err := uatype.ParseGuid(strings.TrimSpace(s))
+		if err != nil {
+			return wrapError(err, "String")
+		}
+		*p = g
+		return nil
+	case *uatype.ByteString:
+		// Base64 content may be broken into lines.
+		s := strings.Join(strings.Fields(e.text()), "")
+		if err := dec.allocByteString(base64.StdEncoding.DecodedLen(len(s))); err != nil {
+			return err
+		}
+		b, err := base64.StdEncoding.DecodeString(s)
+		if err != nil {
+			return fmt.Errorf("%s: %s", ErrInvalidValue, err)
+		}
+		*p = b
+		return nil
+	case *uatype.NullString:
+		s := e.text()
+		if err := dec.allocString(len(s)); err != nil {
+			return err
+		}
+		*p = uatype.NewNullString(s)
+		return nil
+	case *uatype.XmlElement:
+		p.Value = []rune(e.innerXML())
+		p.Length = int32(len(p.Value))
+		return nil
+	case *uatype.StatusCode:
+		*p = 0
+		return dec.child(e, "Code", (*uint32)(p))
+	case *uatype.NodeId:
+		*p = uatype.NodeId{}
+		var s string
+		if err := dec.child(e, "Identifier", &s); err != nil || s == "" {
+			return err
+		}
+		nid, err := uatype.ParseNodeID(strings.TrimSpace(s))
+		if err != nil {
+			return wrapError(err, "Identifier")
+		}
+		*p = nid
+		return nil
+	case *uatype.ExpandedNodeId:
+		*p = uatype.ExpandedNodeId{}
+		var s string
+		if err := dec.child(e, "Identifier", &s); err != nil || s == "" {
+			return err
+		}
+		nid, err := uatype.ParseExpandedNodeID(strings.TrimSpace(s))
+		if err != nil {
+			return wrapError(err, "Identifier")
+		}
+		*p = nid
+		return nil
+	case *uatype.QualifiedName:
+		*p = uatype.QualifiedName{}
+		if err := dec.child(e, "NamespaceIndex", &p.NamespaceIndex); err != nil {
+			return err
+		}
+		return dec.child(e, "Name", &p.Name)
+	case *uatype.LocalizedText:
+		*p = uatype.LocalizedText{}
+		return dec.decodeMembers(e, localizedTextMembers(p))
+	case *uatype.DataValue:
+		*p = uatype.DataValue{}
+		return dec.decodeMembers(e, dataValueMembers(p))
+	case *uatype.DiagnosticInfo:
+		*p = uatype.DiagnosticInfo{}
+		return dec.decodeMembers(e, diagnosticInfoMembers(p))
+	case *uatype.Variant:
+		return dec.decodeVariant(e, p)
+	case *uatype.ExtensionObject:
+		return dec.decodeExtensionObject(e, p)
+	}
+
+	switch rv.Kind() {
+	case reflect.Bool:
+		switch s := strings.TrimSpace(e.text()); s {
+		case "true", "1":
+			rv.SetBool(true)
+		case "false", "0":
+			rv.SetBool(false)
+		default:
+			return fmt.Errorf("%s: %q is not a boolean", ErrInvalidValue, s)
+		}
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(enumValue(e.text()), 10, rv.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("%s: %s", ErrInvalidValue, err)
+		}
+		rv.SetInt(i)
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(enumValue(e.text()), 10, rv.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("%s: %s", ErrInvalidValue, err)
+		}
+		rv.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := parseFloat(e.text(), rv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		rv.SetFloat(f)
+	case reflect.String:
+		s := e.text()
+		if err := dec.allocString(len(s)); err != nil {
+			return err
+		}
+		rv.SetString(s)
+	case reflect.Ptr:
+		if rv.IsNil() {
+			rv.Set(reflect.New(rv.Type().Elem()))
+		}
+		return dec.decode(e, rv.Elem())
+	case reflect.Slice:
+		children := e.children()
+		if err := dec.allocArray(len(children), int(rv.Type().Elem().Size())); err != nil {
+			return err
+		}
+		rv.Set(reflect.MakeSlice(rv.Type(), len(children), len(children)))
+		return dec.decodeArray(children, rv)
+	case reflect.Array:
+		children := e.children()
+		if len(children) != rv.Len() {
+			return fmt.Errorf("%s: %d elements for array of length %d", ErrInvalidValue, len(children), rv.Len())
+		}
+		return dec.decodeArray(children, rv)
+	case reflect.Struct:
+		return dec.decodeStruct(e, rv)
+	default:
+		return fmt.Errorf("%s: %s", ErrUnknownType, rv.Type())
+	}
+	return nil
+}
+
+// enumValue returns the value of s, which is either an integer or the
+// "Name_value" form of an enumerated value, with surrounding whitespace
+// removed.
+func enumValue(s string) string {
+	s = strings.TrimSpace(s)
+	return s[strings.LastIndex(s, "_")+1:]
+}
+
+// parseFloat parses an xs:float or xs:double value.
+func parseFloat(s string, bitSize int) (float64, error) {
+	switch s = strings.TrimSpace(s); s {
+	case "INF":
+		return math.Inf(1), nil
+	case "-INF":
+		return math.Inf(-1), nil
+	case "NaN":
+		return math.NaN(), nil
+	}
+	f, err := strconv.ParseFloat(s, bitSize)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %s", ErrInvalidValue, err)
+	}
+	return f, nil
+}
+
+func (dec *Decoder) decodeArray(children []*element, rv reflect.Value) error {
+	for i, child := range children {
+		if err := dec.decode(child, rv.Index(i)); err != nil {
+			return wrapError(err, i)
+		}
+	}
+	return nil
+}
+
+// decodeStruct decodes the child elements of e into the fields of a
+// structured type by name. Missing fields are left zero. Length fields and
+// optional field switches are set from the fields they describe.
+func (dec *Decoder) decodeStruct(e *element, rv reflect.Value) error {
+	rv.Set(reflect.Zero(rv.Type()))
+	for _, f := range uatag.Fields(rv.Type()) {
+		child := e.child(f.Name)
+		if child == nil {
+			continue
+		}
+		fv := rv.Field(f.Index)
+		if err := dec.decode(child, fv); err != nil {
+			return wrapError(err, f.Name)
+		}
+		if f.Tag.LengthField != "" {
+			rv.FieldByName(f.Tag.LengthField).SetInt(int64(fv.Len()))
+		}
+		if f.Tag.SwitchField != "" && !f.Tag.HasSwitchValue {
+			rv.FieldByName(f.Tag.SwitchField).SetBool(true)
+		}
+	}
+	return nil
+}
+
+// child decodes the child element of e with the given name into v, which must
+// be a pointer. A missing child leaves v untouched.
+func (dec *Decoder) child(e *element, name string, v interface{}) error {
+	child := e.child(name)
+	if child == nil {
+		return nil
+	}
+	return wrapError(dec.decode(child, reflect.ValueOf(v).Elem()), name)
+}
@@ -0,0 +1,32 @@
+// Package xml provides functionality for encoding Go types into the OPC UA
+// XML representation as defined by
+// [IEC-62541](https://webstore.iec.ch/webstore/webstore.nsf/mysearchajax?Openform&key=62541)
+// part 6, and for decoding it. Like the binary package, it only aims to fully
+// handle types that are defined in the uatype package.
+//
+// Values are encoded as elements named after their type, e.g. <Int32> or
+// <ReadRequest>, in the namespace given by TypesNamespace. Structured types
+// hold one element per field, named after the field, and arrays hold one
+// element per value, named after the type of the values. Like in the JSON
+// encoding, length fields and optional field switches are implied by the
+// fields they describe, and fields that are switched off are omitted.
+// Enumerations are encoded as "Name_value", e.g. "Both_2". Elements are
+// matched by local name when decoding, and elements marked with xsi:nil
+// decode as the zero value. Nil ByteStrings and slices, and null
+// uatype.NullStrings, are omitted from structured types, while empty values
+// are encoded as empty elements. Elsewhere, null values are encoded as empty.
+//
+// Variants are encoded as a Value element, which is also how the values of
+// variables are given in NodeSet2 files. MarshalValue and UnmarshalValue
+// encode and decode such Value elements on their own.
+//
+// ExtensionObjects with a registered Value are encoded with the XML encoding
+// of the Value as Body, and the registered XML encoding node ID as TypeId.
+// When decoded, the Body holds the XML of the Value, and Encoding says it is
+// an XML body, as if it was decoded from the binary encoding. Binary bodies
+// are encoded as a ByteString element, and are not decoded.
+//
+// Importing this package lets the binary package decode XML bodies of
+// ExtensionObjects with registered TypeIds, within the limits of the binary
+// Decoder.
+package xml
@@ -0,0 +1,170 @@
+package xml
+
+import (
+	"bytes"
+	stdxml "encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Namespaces that are given special treatment when reading and writing
+// elements.
+const (
+	xsiNamespace = "http://www.w3.org/2001/XMLSchema-instance"
+	xmlNamespace = "http://www.w3.org/XML/1998/namespace"
+)
+
+// maxDepth limits the nesting of the elements read by a Decoder.
+const maxDepth = 1000
+
+// element is an XML element as read by a Decoder. Elements are matched by
+// local name only; namespaces are kept so that the element can be written
+// back as XML.
+type element struct {
+	name    stdxml.Name
+	attr    []stdxml.Attr
+	content []interface{} // string or *element, in document order
+}
+
+// readElement reads the element started by start, including its end element,
+// from the stream of dec. The element is nested depth elements deep.
+func (dec *Decoder) readElement(start stdxml.StartElement, depth int) (*element, error) {
+	if depth > maxDepth {
+		return nil, fmt.Errorf("%s: elements nested deeper than %d", ErrInvalidValue, maxDepth)
+	}
+	if err := dec.checkDepth(depth); err != nil {
+		return nil, err
+	}
+	size := elementSize + len(start.Name.Space) + len(start.Name.Local)
+	for _, a := range start.Attr {
+		size += elementSize + len(a.Name.Space) + len(a.Name.Local) + len(a.Value)
+	}
+	if err := dec.alloc(size); err != nil {
+		return nil, err
+	}
+
+	e := &element{name: start.Name, attr: start.Attr}
+	for {
+		tok, err := dec.r.Token()
+		if err == io.EOF {
+			return nil, io.ErrUnexpectedEOF
+		} else if err != nil {
+			return nil, err
+		}
+		switch t := tok.(type) {
+		case stdxml.StartElement:
+			child, err := dec.readElement(t, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			e.content = append(e.content, child)
+		case stdxml.CharData:
+			if err := dec.alloc(len(t)); err != nil {
+				return nil, err
+			}
+			e.content = append(e.content, string(t))
+		case stdxml.EndElement:
+			return e, nil
+		}
+	}
+}
+
+// text returns the character data of e, excluding that of child elements.
+func (e *element) text() string {
+	var s []string
+	for _, c := range e.content {
+		if str, ok := c.(string); ok {
+			s = append(s, str)
+		}
+	}
+	return strings.Join(s, "")
+}
+
+// children returns the child elements of e.
+func (e *element) children() []*element {
+	var children []*element
+	for _, c := range e.content {
+		if child, ok := c.(*element); ok {
+			children = append(children, child)
+		}
+	}
+	return children
+}
+
+// child returns the first child element of e with the given local name, or
+// nil.
+func (e *element) child(name string) *element {
+	for _, c := range e.content {
+		if child, ok := c.(*element); ok && child.name.Local == name {
+			return child
+		}
+	}
+	return nil
+}
+
+// isNil returns true if e is marked as nil by the xsi:nil attribute.
+func (e *element) isNil() bool {
+	for _, a := range e.attr {
+		if a.Name.Space == xsiNamespace && a.Name.Local == "nil" {
+			return a.Value == "true" || a.Value == "1"
+		}
+	}
+	return false
+}
+
+// innerXML returns the content of e as XML, with surrounding whitespace
+// removed.
+func (e *element) innerXML() string {
+	var buf bytes.Buffer
+	e.writeContent(&buf)
+	return strings.TrimSpace(buf.String())
+}
+
+// writeContent writes the content of e as XML to buf.
+func (e *element) writeContent(buf *bytes.Buffer) {
+	for _, c := range e.content {
+		switch t := c.(type) {
+		case string:
+			stdxml.EscapeText(buf, []byte(t))
+		case *element:
+			t.writeXML(buf, e.name.Space)
+		}
+	}
+}
+
+// writeXML writes e as XML to buf. The default namespace is declared when it
+// differs from space, the namespace of the parent. Prefixed attributes are
+// written with prefixes declared on e.
+func (e *element) writeXML(buf *bytes.Buffer, space string) {
+	buf.WriteString("<" + e.name.Local)
+	if e.name.Space != space {
+		writeAttr(buf, "xmlns", e.name.Space)
+	}
+	prefixes := 0
+	for _, a := range e.attr {
+		switch {
+		case a.Name.Space == "" && a.Name.Local == "xmlns", a.Name.Space == "xmlns":
+			// Namespace declarations are written as needed instead.
+		case a.Name.Space == "":
+			writeAttr(buf, a.Name.Local, a.Value)
+		case a.Name.Space == xmlNamespace:
+			writeAttr(buf, "xml:"+a.Name.Local, a.Value)
+		default:
+			prefixes++
+			prefix := "p" + strconv.Itoa(prefixes)
+			writeAttr(buf, "xmlns:"+prefix, a.Name.Space)
+			writeAttr(buf, prefix+":"+a.Name.Local, a.Value)
+		}
+	}
+	buf.WriteByte('>')
+	e.writeContent(buf)
+	buf.WriteString("</" + e.name.Local + ">")
+}
+
+func writeAttr(buf *bytes.Buffer, name, value string) {
+	buf.WriteString(" " + name + `="`)
+	stdxml.EscapeText(buf, []byte(value))
+	buf.WriteByte('"')
+}
@@ -0,0 +1,291 @@
+package xml
+
+import (
+	"bytes"
+	"encoding/base64"
+	stdxml "encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/searis/guma/stack/encoding/internal/uatag"
+	"github.com/searis/guma/stack/uatype"
+)
+
+// TypesNamespace is the XML namespace of the OPC UA built-in and structured
+// types.
+const TypesNamespace = "http://opcfoundation.org/UA/2008/02/Types.xsd"
+
+// dateTimeFormat is the format of DateTime values. OPC UA DateTime values have
+// a resolution of 100 nanoseconds.
+const dateTimeFormat = "2006-01-02T15:04:05.9999999Z07:00"
+
+// Marshal encodes v into the OPC UA XML encoding, and returns it as a slice of
+// bytes.
+func Marshal(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	err := NewEncoder(&buf).Encode(v)
+	return buf.Bytes(), err
+}
+
+// MarshalValue returns the Value element of v, as used for the values of
+// variables in NodeSet2 files.
+func MarshalValue(v uatype.Variant) ([]byte, error) {
+	var buf bytes.Buffer
+	err := NewEncoder(&buf).EncodeValue(v)
+	return buf.Bytes(), err
+}
+
+// An Encoder writes OPC UA XML content to an output stream.
+type Encoder struct {
+	w   io.Writer
+	buf bytes.Buffer
+}
+
+// NewEncoder returns an encoder that writes to w.
+func NewEncoder(w io.Writer) *Encoder {
+	return &Encoder{w: w}
+}
+
+// Encode writes the XML encoding of v to the stream, as an element that is
+// named after the type of v and declares TypesNamespace as its namespace.
+func (enc *Encoder) Encode(v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if !rv.IsValid() {
+		return EncoderError{transcoderErr(fmt.Errorf("%s: nil", ErrUnknownType))}
+	}
+	return enc.encodeRoot(typeName(rv.Type()), func() error {
+		return enc.encode(rv)
+	})
+}
+
+// EncodeValue writes the Value element of v to the stream, as used for the
+// values of variables in NodeSet2 files. It declares TypesNamespace as its
+// namespace.
+func (enc *Encoder) EncodeValue(v uatype.Variant) error {
+	return enc.encodeRoot("Value", func() error {
+		return enc.writeVariantValue(v)
+	})
+}
+
+// encodeRoot writes an element with the given name and the content written by
+// content to the stream.
+func (enc *Encoder) encodeRoot(name string, content func() error) (err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			debugLogger.Printf("recovered from panic: %s:\n%s", e, debug.Stack())
+			err = fmt.Errorf("recovered from panic: %s", e)
+		}
+	}()
+
+	enc.buf.Reset()
+	enc.buf.WriteString("<" + name + ` xmlns="` + TypesNamespace + `">`)
+	if err := content(); err != nil {
+		return EncoderError{transcoderErr(err)}
+	}
+	enc.buf.WriteString("</" + name + ">")
+	_, err = enc.w.Write(enc.buf.Bytes())
+	return err
+}
+
+// encode writes the content of the element that holds rv.
+func (enc *Encoder) encode(rv reflect.Value) error {
+	switch v := rv.Interface().(type) {
+	case time.Time:
+		enc.writeText(v.UTC().Format(dateTimeFormat))
+		return nil
+	case uatype.Guid:
+		return enc.writeElement("String", reflect.ValueOf(v.String()))
+	case uatype.ByteString:
+		enc.writeText(base64.StdEncoding.EncodeToString(v))
+		return nil
+	case uatype.NullString:
+		enc.writeText(v.String)
+		return nil
+	case uatype.XmlElement:
+		enc.buf.WriteString(string(v.Value))
+		return nil
+	case uatype.StatusCode:
+		return enc.writeElement("Code", reflect.ValueOf(uint32(v)))
+	case uatype.NodeId:
+		return enc.writeElement("Identifier", reflect.ValueOf(v.String()))
+	case uatype.ExpandedNodeId:
+		return enc.writeElement("Identifier", reflect.ValueOf(v.String()))
+	case uatype.QualifiedName:
+		enc.writeElement("NamespaceIndex", reflect.ValueOf(v.NamespaceIndex))
+		return enc.writeElement("Name", reflect.ValueOf(v.Name))
+	case uatype.LocalizedText:
+		return enc.writeMembers(localizedTextMembers(&v))
+	case uatype.DataValue:
+		return enc.writeMembers(dataValueMembers(&v))
+	case uatype.DiagnosticInfo:
+		return enc.writeMembers(diagnosticInfoMembers(&v))
+	case uatype.Variant:
+		return enc.writeVariant(v)
+	case uatype.ExtensionObject:
+		return enc.writeExtensionObject(v)
+	}
+
+	switch rv.Kind() {
+	case reflect.Bool:
+		enc.buf.WriteString(strconv.FormatBool(rv.Bool()))
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		enc.writeEnum(rv, strconv.FormatInt(rv.Int(), 10))
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		enc.writeEnum(rv, strconv.FormatUint(rv.Uint(), 10))
+	case reflect.Float32:
+		enc.writeFloat(rv.Float(), 32)
+	case reflect.Float64:
+		enc.writeFloat(rv.Float(), 64)
+	case reflect.String:
+		enc.writeText(rv.String())
+	case reflect.Ptr, reflect.Interface:
+		if rv.IsNil() {
+			return nil
+		}
+		return enc.encode(rv.Elem())
+	case reflect.Slice, reflect.Array:
+		return enc.writeArray(rv)
+	case reflect.Struct:
+		return enc.writeStruct(rv)
+	default:
+		return fmt.Errorf("%s: %s", ErrUnknownType, rv.Type())
+	}
+	return nil
+}
+
+func (enc *Encoder) writeText(s string) {
+	stdxml.EscapeText(&enc.buf, []byte(s))
+}
+
+// writeElement writes an element with the given name that holds rv.
+func (enc *Encoder) writeElement(name string, rv reflect.Value) error {
+	enc.buf.WriteString("<" + name + ">")
+	if err := enc.encode(rv); err != nil {
+		return err
+	}
+	enc.buf.WriteString("</" + name + ">")
+	return nil
+}
+
+// writeEnum writes the integer value rv as value, or as "Name_value" if rv
+// is of an enumerated type with a name for the value.
+func (enc *Encoder) writeEnum(rv reflect.Value, value string) {
+	if s, ok := rv.Interface().(fmt.Stringer); ok {
+		if name := s.String(); name != value {
+			enc.writeText(name + "_")
+		}
+	}
+	enc.buf.WriteString(value)
+}
+
+// writeFloat writes f as an xs:float or xs:double value, which spell the
+// special values INF, -INF and NaN.
+func (enc *Encoder) writeFloat(f float64, bitSize int) {
+	switch {
+	case math.IsNaN(f):
+		enc.buf.WriteString("NaN")
+	case math.IsInf(f, 1):
+		enc.buf.WriteString("INF")
+	case math.IsInf(f, -1):
+		enc.buf.WriteString("-INF")
+	default:
+		enc.buf.WriteString(strconv.FormatFloat(f, 'G', -1, bitSize))
+	}
+}
+
+// writeArray writes each element of rv as an element named after its type.
+func (enc *Encoder) writeArray(rv reflect.Value) error {
+	name := typeName(rv.Type().Elem())
+	for i := 0; i < rv.Len(); i++ {
+		if err := enc.writeElement(name, rv.Index(i)); err != nil {
+			return wrapError(err, i)
+		}
+	}
+	return nil
+}
+
+// writeStruct writes the fields of a structured type as elements named after
+// the fields. Fields that are switched off by their opcua struct tag are
+// omitted, as are null arrays, pointers and NullStrings, unless they are
+// optional fields that are switched on.
+func (enc *Encoder) writeStruct(rv reflect.Value) error {
+	for _, f := range uatag.Fields(rv.Type()) {
+		if !uatag.SwitchActive(rv, f.Tag) {
+			continue
+		}
+		fv := rv.Field(f.Index)
+		optional := f.Tag.SwitchField != "" && !f.Tag.HasSwitchValue
+		switch fv.Kind() {
+		case reflect.Slice, reflect.Ptr:
+			if fv.IsNil() && !optional {
+				continue
+			}
+		}
+		if s, ok := fv.Interface().(uatype.NullString); ok && !s.Valid && !optional {
+			continue
+		}
+		if err := enc.writeElement(f.Name, fv); err != nil {
+			return wrapError(err, f.Name)
+		}
+	}
+	return nil
+}
+
+// typeName returns the name of the XML element of a value of type rt when no
+// field name applies, such as for array elements. Named types are written by
+// their Go name, which is the name of the OPC UA type.
+func typeName(rt reflect.Type) string {
+	for rt.Kind() == reflect.Ptr {
+		rt = rt.Elem()
+	}
+	switch rt {
+	case reflect.TypeOf(time.Time{}):
+		return "DateTime"
+	case reflect.TypeOf(uatype.NullString{}):
+		return "String"
+	}
+	if rt.PkgPath() != "" {
+		return rt.Name()
+	}
+	switch rt.Kind() {
+	case reflect.Bool:
+		return "Boolean"
+	case reflect.Int8:
+		return "SByte"
+	case reflect.Uint8:
+		return "Byte"
+	case reflect.Int16:
+		return "Int16"
+	case reflect.Uint16:
+		return "UInt16"
+	case reflect.Int32:
+		return "Int32"
+	case reflect.Uint32:
+		return "UInt32"
+	case reflect.Int64:
+		return "Int64"
+	case reflect.Uint64:
+		return "UInt64"
+	case reflect.Float32:
+		return "Float"
+	case reflect.Float64:
+		return "Double"
+	case reflect.String:
+		return "String"
+	case reflect.Struct:
+		// Types built from type dictionaries tag their first field with the
+		// qualified type name.
+		if rt.NumField() > 0 {
+			if tag := rt.Field(0).Tag.Get("typedict"); tag != "" {
+				return tag[strings.LastIndex(tag, "#")+1:]
+			}
+		}
+	}
+	return rt.String()
+}
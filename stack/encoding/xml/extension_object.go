@@ -0,0 +1,94 @@
+package xml
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/searis/guma/stack/uatype"
+)
+
+// ExtensionObject Encoding values.
+const (
+	extensionObjectBinaryBody uint8 = 1
+	extensionObjectXMLBody    uint8 = 2
+)
+
+// writeExtensionObject writes eo as TypeId and Body elements. If eo.Value is
+// set, the TypeId is its registered XML encoding node ID, and the Body holds
+// its XML encoding as an element named after its type. Otherwise binary
+// bodies are written as a ByteString element, and XML bodies as they are.
+// ExtensionObjects without TypeId and body are written without content.
+func (enc *Encoder) writeExtensionObject(eo uatype.ExtensionObject) error {
+	if eo.Value == nil && eo.Encoding == 0 && reflect.DeepEqual(eo.TypeId, uatype.ExpandedNodeId{}) {
+		return nil
+	}
+	if eo.Value != nil {
+		id, ok := uatype.ExtensionObjectXMLEncodingID(eo.Value)
+		if !ok {
+			return fmt.Errorf("%s: %T is not a registered ExtensionObject XML type", ErrUnknownType, eo.Value)
+		}
+		eo.TypeId = id.Expanded()
+	}
+	if err := enc.writeElement("TypeId", reflect.ValueOf(eo.TypeId)); err != nil {
+		return wrapError(err, "TypeId")
+	}
+
+	var err error
+	switch {
+	case eo.Value != nil:
+		enc.buf.WriteString("<Body>")
+		v := reflect.ValueOf(eo.Value)
+		err = enc.writeElement(typeName(v.Type()), v)
+		enc.buf.WriteString("</Body>")
+	case eo.Encoding == extensionObjectBinaryBody:
+		enc.buf.WriteString("<Body>")
+		err = enc.writeElement("ByteString", reflect.ValueOf(uatype.ByteString(eo.Body)))
+		enc.buf.WriteString("</Body>")
+	case eo.Encoding == extensionObjectXMLBody:
+		enc.buf.WriteString("<Body>")
+		enc.buf.Write(eo.Body)
+		enc.buf.WriteString("</Body>")
+	case eo.Encoding != 0:
+		err = fmt.Errorf("%s: ExtensionObject Encoding %d", ErrInvalidValue, eo.Encoding)
+	}
+	return wrapError(err, "Body")
+}
+
+// decodeExtensionObject decodes eo. A ByteString in the Body gives a binary
+// body, which is not decoded. Any other Body content is kept as an XML body,
+// and if the TypeId is registered through uatype.RegisterExtensionObjectXML,
+// its first element is decoded into eo.Value as well.
+func (dec *Decoder) decodeExtensionObject(e *element, eo *uatype.ExtensionObject) error {
+	*eo = uatype.ExtensionObject{}
+	if err := dec.child(e, "TypeId", &eo.TypeId); err != nil {
+		return err
+	}
+	body := e.child("Body")
+	if body == nil || body.isNil() {
+		return nil
+	}
+
+	if b := body.child("ByteString"); b != nil {
+		if err := dec.decode(b, reflect.ValueOf((*uatype.ByteString)(&eo.Body)).Elem()); err != nil {
+			return wrapError(err, "Body")
+		}
+		eo.Encoding = extensionObjectBinaryBody
+		eo.BodyLength = int32(len(eo.Body))
+		return nil
+	}
+
+	eo.Encoding = extensionObjectXMLBody
+	eo.Body = []byte(body.innerXML())
+	eo.BodyLength = int32(len(eo.Body))
+	rt, ok := uatype.ExtensionObjectXMLType(eo.TypeId)
+	children := body.children()
+	if !ok || len(children) == 0 {
+		return nil
+	}
+	rv := reflect.New(rt)
+	if err := dec.decode(children[0], rv.Elem()); err != nil {
+		return wrapError(err, "Body")
+	}
+	eo.Value = rv.Elem().Interface()
+	return nil
+}
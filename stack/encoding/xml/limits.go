@@ -0,0 +1,83 @@
+package xml
+
+import (
+	"bytes"
+
+	"github.com/searis/guma/stack/encoding/binary"
+)
+
+func init() {
+	binary.RegisterXMLBodyDecoder(decodeBody)
+}
+
+// decodeBody decodes the XML body of an ExtensionObject within limits. It's
+// used by binary Decoders, and returns exceeded limits as a plain
+// binary.LimitError so that it becomes the cause of the binary DecoderError.
+func decodeBody(body []byte, v interface{}, limits binary.DecoderLimits) error {
+	dec := NewDecoder(bytes.NewReader(body))
+	dec.SetLimits(limits)
+	err := dec.Decode(v)
+	if derr, ok := err.(DecoderError); ok {
+		if lerr, ok := derr.Cause().(binary.LimitError); ok {
+			return lerr
+		}
+	}
+	return err
+}
+
+// elementSize is the number of bytes accounted for each element read by a
+// Decoder, in addition to its name, attributes and character data.
+const elementSize = 64
+
+// SetLimits sets the limits used by subsequent calls to Decode and
+// DecodeValue. MaxDepth limits the nesting of elements, and MaxAlloc the
+// bytes held by the elements read as well as by decoded Strings, ByteStrings
+// and arrays. Exceeded limits are reported with a binary.LimitError cause. A
+// Decoder returned by NewDecoder has no limits, except that elements may not
+// be nested deeper than 1000.
+func (dec *Decoder) SetLimits(l binary.DecoderLimits) {
+	dec.limits = l
+}
+
+// allocString checks that a String of size bytes may be decoded.
+func (dec *Decoder) allocString(size int) error {
+	if max := dec.limits.MaxStringLength; max > 0 && size > max {
+		return binary.LimitError{Limit: "MaxStringLength", Value: size, Max: max}
+	}
+	return dec.alloc(size)
+}
+
+// allocByteString checks that a ByteString of size bytes may be decoded.
+func (dec *Decoder) allocByteString(size int) error {
+	if max := dec.limits.MaxByteStringLength; max > 0 && size > max {
+		return binary.LimitError{Limit: "MaxByteStringLength", Value: size, Max: max}
+	}
+	return dec.alloc(size)
+}
+
+// allocArray checks that an array of n elements of elemSize bytes each may be
+// allocated.
+func (dec *Decoder) allocArray(n, elemSize int) error {
+	if max := dec.limits.MaxArrayLength; max > 0 && n > max {
+		return binary.LimitError{Limit: "MaxArrayLength", Value: n, Max: max}
+	}
+	return dec.alloc(n * elemSize)
+}
+
+// alloc accounts for size bytes being allocated by the current call to
+// Decode or DecodeValue.
+func (dec *Decoder) alloc(size int) error {
+	dec.allocated += size
+	if max := dec.limits.MaxAlloc; max > 0 && dec.allocated > max {
+		return binary.LimitError{Limit: "MaxAlloc", Value: dec.allocated, Max: max}
+	}
+	return nil
+}
+
+// checkDepth checks that an element may be nested depth elements deep.
+func (dec *Decoder) checkDepth(depth int) error {
+	if max := dec.limits.MaxDepth; max > 0 && depth > max {
+		return binary.LimitError{Limit: "MaxDepth", Value: depth, Max: max}
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package xml_test
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/searis/guma/internal/testutil"
+	"github.com/searis/guma/stack/encoding/binary"
+	"github.com/searis/guma/stack/encoding/xml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRoundTrip checks that decoding the XML encoding of random values gives a
+// value with the same XML encoding, which can also be binary encoded. The
+// values are not compared directly, as numeric NodeIds are decoded with the
+// most compact encoding, and ExtensionObject values with an XML body.
+func TestRoundTrip(t *testing.T) {
+	const iterations = 20
+	seed := time.Now().UnixNano()
+	t.Logf("seed %d", seed)
+	g := testutil.NewValueGen(rand.New(rand.NewSource(seed)))
+	types := testutil.RegisteredTypes()
+	require.NotEmpty(t, types, "registered types")
+
+	for _, rt := range types {
+		t.Run(rt.Name(), func(t *testing.T) {
+			for i := 0; i < iterations; i++ {
+				v := g.Value(rt)
+				data, err := xml.Marshal(v)
+				require.NoError(t, err, "Marshal")
+
+				rv := reflect.New(rt)
+				require.NoError(t, xml.Unmarshal(data, rv.Interface()), "Unmarshal %s", data)
+				decoded, err := xml.Marshal(rv.Elem().Interface())
+				require.NoError(t, err, "Marshal decoded")
+				if !assert.Equal(t, string(data), string(decoded), "XML encoding") {
+					return
+				}
+
+				_, err = binary.Marshal(rv.Elem().Interface())
+				require.NoError(t, err, "binary Marshal decoded")
+			}
+		})
+	}
+}
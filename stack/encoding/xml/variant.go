@@ -0,0 +1,141 @@
+package xml
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/searis/guma/stack/encoding/internal/uatag"
+	"github.com/searis/guma/stack/uatype"
+)
+
+// variantFields maps VariantType values to the index of the matching slice
+// field in the Variant struct, as given by its opcua struct tags, and
+// variantTypes maps the XML element names of the built-in types to the
+// VariantType values.
+var (
+	variantFields = map[byte]int{}
+	variantTypes  = map[string]byte{}
+)
+
+func init() {
+	rt := reflect.TypeOf(uatype.Variant{})
+	for i := 0; i < rt.NumField(); i++ {
+		tag := uatag.ParseTag(rt.Field(i).Tag.Get("opcua"))
+		if tag.SwitchField == "VariantType" && tag.HasSwitchValue {
+			variantFields[byte(tag.SwitchValue)] = i
+			variantTypes[typeName(rt.Field(i).Type.Elem())] = byte(tag.SwitchValue)
+		}
+	}
+}
+
+// writeVariant writes v as a Value element, unless v is null.
+func (enc *Encoder) writeVariant(v uatype.Variant) error {
+	if v.VariantType == 0 {
+		return nil
+	}
+	enc.buf.WriteString("<Value>")
+	if err := enc.writeVariantValue(v); err != nil {
+		return wrapError(err, "Value")
+	}
+	enc.buf.WriteString("</Value>")
+	return nil
+}
+
+// writeVariantValue writes the content of the Value element of v: an element
+// named after the built-in type for scalar values, a ListOf element for
+// arrays, or a Matrix element with Dimensions and Elements for
+// multi-dimensional arrays. Null Variants have no content.
+func (enc *Encoder) writeVariantValue(v uatype.Variant) error {
+	if v.VariantType == 0 {
+		return nil
+	}
+	fi, ok := variantFields[v.VariantType]
+	if !ok {
+		return fmt.Errorf("%s: VariantType %d", ErrInvalidValue, v.VariantType)
+	}
+	body := reflect.ValueOf(v).Field(fi)
+	name := typeName(body.Type().Elem())
+
+	switch {
+	case bool(v.ArrayDimensionsSpecified):
+		enc.buf.WriteString("<Matrix>")
+		enc.writeElement("Dimensions", reflect.ValueOf(v.ArrayDimensions))
+		if err := enc.writeElement("Elements", body); err != nil {
+			return wrapError(err, "Elements")
+		}
+		enc.buf.WriteString("</Matrix>")
+		return nil
+	case bool(v.ArrayLengthSpecified):
+		return enc.writeElement("ListOf"+name, body)
+	case body.Len() != 1:
+		return fmt.Errorf("%s: scalar Variant with %d values", ErrInvalidValue, body.Len())
+	}
+	return enc.writeElement(name, body.Index(0))
+}
+
+// decodeVariant decodes a Variant from e, which holds its Value element.
+func (dec *Decoder) decodeVariant(e *element, v *uatype.Variant) error {
+	*v = uatype.Variant{}
+	if value := e.child("Value"); value != nil {
+		return wrapError(dec.decodeValue(value, v), "Value")
+	}
+	return nil
+}
+
+// decodeValue decodes the Value element e of a Variant into v, which must be
+// null.
+func (dec *Decoder) decodeValue(e *element, v *uatype.Variant) error {
+	if e.isNil() {
+		return nil
+	}
+	if children := e.children(); len(children) > 0 {
+		return dec.decodeVariantValue(children[0], v)
+	}
+	return nil
+}
+
+// decodeVariantValue decodes the element held by the Value element of a
+// Variant into v.
+func (dec *Decoder) decodeVariantValue(e *element, v *uatype.Variant) error {
+	name := e.name.Local
+	var elements *element
+	switch {
+	case name == "Matrix":
+		dims := e.child("Dimensions")
+		if dims == nil {
+			return fmt.Errorf("%s: Matrix without Dimensions", ErrInvalidValue)
+		}
+		if err := dec.decode(dims, reflect.ValueOf(&v.ArrayDimensions).Elem()); err != nil {
+			return wrapError(err, "Dimensions")
+		}
+		v.ArrayDimensionsSpecified = true
+		v.NoOfArrayDimensions = int32(len(v.ArrayDimensions))
+		if elements = e.child("Elements"); elements == nil {
+			return fmt.Errorf("%s: Matrix without Elements", ErrInvalidValue)
+		}
+		if children := elements.children(); len(children) > 0 {
+			name = children[0].name.Local
+		}
+	case strings.HasPrefix(name, "ListOf"):
+		elements = e
+		name = strings.TrimPrefix(name, "ListOf")
+	}
+
+	vt, ok := variantTypes[name]
+	if !ok {
+		return fmt.Errorf("%s: unknown Variant type %s", ErrInvalidValue, e.name.Local)
+	}
+	v.VariantType = vt
+	field := reflect.ValueOf(v).Elem().Field(variantFields[vt])
+	if elements == nil {
+		field.Set(reflect.MakeSlice(field.Type(), 1, 1))
+		return wrapError(dec.decode(e, field.Index(0)), name)
+	}
+	if err := dec.decode(elements, field); err != nil {
+		return wrapError(err, e.name.Local)
+	}
+	v.ArrayLengthSpecified = true
+	v.ArrayLength = int32(field.Len())
+	return nil
+}
@@ -0,0 +1,396 @@
+package xml_test
+
+import (
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/searis/guma/stack/encoding/xml"
+	"github.com/searis/guma/stack/uatype"
+	"github.com/searis/guma/stack/uatype/typedict"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testGuid = uatype.Guid{0x91, 0x2B, 0x96, 0x72, 0x75, 0xFA, 0xE6, 0x4A, 0x8D, 0x28, 0xB4, 0x04, 0xDC, 0x7D, 0xAF, 0x63}
+
+func mustVariant(t *testing.T, v interface{}) uatype.Variant {
+	variant, err := uatype.NewVariant(v)
+	require.NoError(t, err, "NewVariant")
+	return variant
+}
+
+type marshalTest struct {
+	Name  string
+	Value interface{}
+	XML   string
+
+	// Decoded is the expected result of decoding XML, if it is not equal to
+	// Value.
+	Decoded interface{}
+}
+
+// ns is the namespace declaration of root elements.
+const ns = ` xmlns="` + xml.TypesNamespace + `"`
+
+func marshalTests(t *testing.T) []marshalTest {
+	ts := time.Date(2018, 3, 14, 15, 9, 26, 535897900, time.UTC)
+	return []marshalTest{
+		{
+			Name:  "Boolean",
+			Value: true,
+			XML:   `<Boolean` + ns + `>true</Boolean>`,
+		},
+		{
+			Name:  "Int32",
+			Value: int32(-42),
+			XML:   `<Int32` + ns + `>-42</Int32>`,
+		},
+		{
+			Name:  "UInt64",
+			Value: uint64(math.MaxUint64),
+			XML:   `<UInt64` + ns + `>18446744073709551615</UInt64>`,
+		},
+		{
+			Name:  "Float",
+			Value: float32(0.1),
+			XML:   `<Float` + ns + `>0.1</Float>`,
+		},
+		{
+			Name:  "DoubleInfinity",
+			Value: math.Inf(-1),
+			XML:   `<Double` + ns + `>-INF</Double>`,
+		},
+		{
+			Name:  "String",
+			Value: "<æøå>",
+			XML:   `<String` + ns + `>&lt;æøå&gt;</String>`,
+		},
+		{
+			Name:  "DateTime",
+			Value: ts,
+			XML:   `<DateTime` + ns + `>2018-03-14T15:09:26.5358979Z</DateTime>`,
+		},
+		{
+			Name:  "Guid",
+			Value: testGuid,
+			XML:   `<Guid` + ns + `><String>72962B91-FA75-4AE6-8D28-B404DC7DAF63</String></Guid>`,
+		},
+		{
+			Name:  "ByteString",
+			Value: uatype.ByteString("guma"),
+			XML:   `<ByteString` + ns + `>Z3VtYQ==</ByteString>`,
+		},
+		{
+			Name:  "NullString",
+			Value: uatype.NewNullString(""),
+			XML:   `<String` + ns + `></String>`,
+		},
+		{
+			Name:  "XmlElement",
+			Value: uatype.XmlElement{Length: 8, Value: []rune("<a>b</a>")},
+			XML:   `<XmlElement` + ns + `><a>b</a></XmlElement>`,
+		},
+		{
+			Name:  "NodeId",
+			Value: uatype.NewStringNodeID(2, "Demo.Static"),
+			XML:   `<NodeId` + ns + `><Identifier>ns=2;s=Demo.Static</Identifier></NodeId>`,
+		},
+		{
+			Name: "ExpandedNodeId",
+			Value: uatype.ExpandedNodeId{
+				NodeIdType:            uatype.NodeIdTypeString,
+				StringID:              uatype.StringNodeId{Identifier: "Demo"},
+				NamespaceURISpecified: true,
+				NamespaceURI:          "urn:guma",
+				ServerIndexSpecified:  true,
+				ServerIndex:           2,
+			},
+			XML: `<ExpandedNodeId` + ns + `><Identifier>svr=2;nsu=urn:guma;s=Demo</Identifier></ExpandedNodeId>`,
+		},
+		{
+			Name:  "StatusCode",
+			Value: uatype.StatusBadTimeout,
+			XML:   `<StatusCode` + ns + `><Code>2148139008</Code></StatusCode>`,
+		},
+		{
+			Name:  "QualifiedName",
+			Value: uatype.QualifiedName{NamespaceIndex: 2, Name: "Temperature"},
+			XML:   `<QualifiedName` + ns + `><NamespaceIndex>2</NamespaceIndex><Name>Temperature</Name></QualifiedName>`,
+		},
+		{
+			Name:  "LocalizedText",
+			Value: uatype.LocalizedText{LocaleSpecified: true, Locale: "en-US", TextSpecified: true, Text: "Hello"},
+			XML:   `<LocalizedText` + ns + `><Locale>en-US</Locale><Text>Hello</Text></LocalizedText>`,
+		},
+		{
+			Name:  "VariantNull",
+			Value: uatype.Variant{},
+			XML:   `<Variant` + ns + `></Variant>`,
+		},
+		{
+			Name:  "VariantScalar",
+			Value: mustVariant(t, uint64(7)),
+			XML:   `<Variant` + ns + `><Value><UInt64>7</UInt64></Value></Variant>`,
+		},
+		{
+			Name:  "VariantArray",
+			Value: mustVariant(t, []string{"a", "b"}),
+			XML:   `<Variant` + ns + `><Value><ListOfString><String>a</String><String>b</String></ListOfString></Value></Variant>`,
+		},
+		{
+			Name:  "VariantMatrix",
+			Value: mustVariant(t, [][]int32{{1, 2, 3}, {4, 5, 6}}),
+			XML: `<Variant` + ns + `><Value><Matrix><Dimensions><Int32>2</Int32><Int32>3</Int32></Dimensions>` +
+				`<Elements><Int32>1</Int32><Int32>2</Int32><Int32>3</Int32><Int32>4</Int32><Int32>5</Int32><Int32>6</Int32></Elements></Matrix></Value></Variant>`,
+		},
+		{
+			Name:  "VariantLocalizedText",
+			Value: mustVariant(t, uatype.LocalizedText{TextSpecified: true, Text: "Hello"}),
+			XML:   `<Variant` + ns + `><Value><LocalizedText><Text>Hello</Text></LocalizedText></Value></Variant>`,
+		},
+		{
+			Name: "DataValue",
+			Value: uatype.DataValue{
+				ValueSpecified:             true,
+				Value:                      mustVariant(t, int16(-3)),
+				StatusCodeSpecified:        true,
+				StatusCode:                 uatype.StatusBadTimeout,
+				SourceTimestampSpecified:   true,
+				SourceTimestamp:            ts,
+				SourcePicosecondsSpecified: true,
+				SourcePicoseconds:          10,
+			},
+			XML: `<DataValue` + ns + `><Value><Value><Int16>-3</Int16></Value></Value><StatusCode><Code>2148139008</Code></StatusCode>` +
+				`<SourceTimestamp>2018-03-14T15:09:26.5358979Z</SourceTimestamp><SourcePicoseconds>10</SourcePicoseconds></DataValue>`,
+		},
+		{
+			Name: "DiagnosticInfo",
+			Value: &uatype.DiagnosticInfo{
+				SymbolicIdSpecified:          true,
+				SymbolicId:                   1,
+				AdditionalInfoSpecified:      true,
+				AdditionalInfo:               "details",
+				InnerDiagnosticInfoSpecified: true,
+				InnerDiagnosticInfo: &uatype.DiagnosticInfo{
+					InnerStatusCodeSpecified: true,
+					InnerStatusCode:          uatype.StatusBadTimeout,
+				},
+			},
+			XML: `<DiagnosticInfo` + ns + `><SymbolicId>1</SymbolicId><AdditionalInfo>details</AdditionalInfo>` +
+				`<InnerDiagnosticInfo><InnerStatusCode><Code>2148139008</Code></InnerStatusCode></InnerDiagnosticInfo></DiagnosticInfo>`,
+		},
+		{
+			Name: "ExtensionObject",
+			Value: uatype.ExtensionObject{
+				Value: uatype.AnonymousIdentityToken{PolicyId: "anonymous"},
+			},
+			XML: `<ExtensionObject` + ns + `><TypeId><Identifier>i=320</Identifier></TypeId>` +
+				`<Body><AnonymousIdentityToken><PolicyId>anonymous</PolicyId></AnonymousIdentityToken></Body></ExtensionObject>`,
+			Decoded: uatype.ExtensionObject{
+				TypeId:     uatype.NewNodeID(0, 320).Expanded(),
+				Encoding:   2,
+				BodyLength: 79,
+				Body:       []byte("<AnonymousIdentityToken><PolicyId>anonymous</PolicyId></AnonymousIdentityToken>"),
+				Value:      uatype.AnonymousIdentityToken{PolicyId: "anonymous"},
+			},
+		},
+		{
+			Name: "ExtensionObjectBinary",
+			Value: uatype.ExtensionObject{
+				TypeId:     uatype.NewStringNodeID(2, "Vendor").Expanded(),
+				Encoding:   1,
+				BodyLength: 4,
+				Body:       []byte("guma"),
+			},
+			XML: `<ExtensionObject` + ns + `><TypeId><Identifier>ns=2;s=Vendor</Identifier></TypeId>` +
+				`<Body><ByteString>Z3VtYQ==</ByteString></Body></ExtensionObject>`,
+		},
+		{
+			Name: "ExtensionObjectXML",
+			Value: uatype.ExtensionObject{
+				TypeId:     uatype.NewStringNodeID(2, "Vendor").Expanded(),
+				Encoding:   2,
+				BodyLength: 8,
+				Body:       []byte("<a>b</a>"),
+			},
+			XML: `<ExtensionObject` + ns + `><TypeId><Identifier>ns=2;s=Vendor</Identifier></TypeId>` +
+				`<Body><a>b</a></Body></ExtensionObject>`,
+		},
+		{
+			Name:  "ExtensionObjectNull",
+			Value: uatype.ExtensionObject{},
+			XML:   `<ExtensionObject` + ns + `></ExtensionObject>`,
+		},
+		{
+			Name: "Structure",
+			Value: uatype.ReadRequest{
+				MaxAge:             100,
+				TimestampsToReturn: uatype.TimestampsToReturnBoth,
+				NoOfNodesToRead:    1,
+				NodesToRead: []uatype.ReadValueId{{
+					NodeId:      uatype.NewTwoByteNodeID(85),
+					AttributeId: 13,
+				}},
+			},
+			XML: `<ReadRequest` + ns + `><RequestHeader><AuthenticationToken><Identifier>i=0</Identifier></AuthenticationToken>` +
+				`<Timestamp>0001-01-01T00:00:00Z</Timestamp><RequestHandle>0</RequestHandle><ReturnDiagnostics>0</ReturnDiagnostics>` +
+				`<AuditEntryId></AuditEntryId><TimeoutHint>0</TimeoutHint><AdditionalHeader></AdditionalHeader></RequestHeader>` +
+				`<MaxAge>100</MaxAge><TimestampsToReturn>Both_2</TimestampsToReturn><NodesToRead><ReadValueId>` +
+				`<NodeId><Identifier>i=85</Identifier></NodeId><AttributeId>13</AttributeId><IndexRange></IndexRange>` +
+				`<DataEncoding><NamespaceIndex>0</NamespaceIndex><Name></Name></DataEncoding></ReadValueId></NodesToRead></ReadRequest>`,
+		},
+	}
+}
+
+func TestMarshal(t *testing.T) {
+	for _, tc := range marshalTests(t) {
+		t.Run(tc.Name, func(t *testing.T) {
+			data, err := xml.Marshal(tc.Value)
+			require.NoError(t, err, "Marshal")
+			assert.Equal(t, tc.XML, string(data), "Marshal")
+		})
+	}
+}
+
+func TestUnmarshal(t *testing.T) {
+	for _, tc := range marshalTests(t) {
+		t.Run(tc.Name, func(t *testing.T) {
+			expected := tc.Decoded
+			if expected == nil {
+				expected = tc.Value
+			}
+			rv := reflect.New(reflect.TypeOf(expected))
+			require.NoError(t, xml.Unmarshal([]byte(tc.XML), rv.Interface()), "Unmarshal")
+			assert.Equal(t, expected, rv.Elem().Interface())
+		})
+	}
+}
+
+// nodeSetValue is the Value element of the InputArguments property of a
+// method, as found in NodeSet2 files.
+const nodeSetValue = `<Value>
+  <ListOfExtensionObject xmlns="http://opcfoundation.org/UA/2008/02/Types.xsd">
+    <ExtensionObject>
+      <TypeId>
+        <Identifier>i=297</Identifier>
+      </TypeId>
+      <Body>
+        <Argument>
+          <Name>SubscriptionId</Name>
+          <DataType>
+            <Identifier>i=7</Identifier>
+          </DataType>
+          <ValueRank>-1</ValueRank>
+          <ArrayDimensions />
+          <Description p5:nil="true" xmlns:p5="http://www.w3.org/2001/XMLSchema-instance" />
+        </Argument>
+      </Body>
+    </ExtensionObject>
+  </ListOfExtensionObject>
+</Value>`
+
+func TestUnmarshalValue(t *testing.T) {
+	var v uatype.Variant
+	require.NoError(t, xml.UnmarshalValue([]byte(nodeSetValue), &v), "UnmarshalValue")
+	value, err := v.Value()
+	require.NoError(t, err, "Value")
+	eos, ok := value.([]uatype.ExtensionObject)
+	require.True(t, ok, "Value is %T, not []ExtensionObject", value)
+	require.Len(t, eos, 1)
+	assert.Equal(t, uatype.Argument{
+		Name:                "SubscriptionId",
+		DataType:            uatype.NewNodeID(0, 7),
+		ValueRank:           -1,
+		NoOfArrayDimensions: 0,
+		ArrayDimensions:     []uint32{},
+	}, eos[0].Value)
+	assert.Equal(t, uint8(2), eos[0].Encoding, "Encoding")
+
+	data, err := xml.MarshalValue(mustVariant(t, "abc"))
+	require.NoError(t, err, "MarshalValue")
+	assert.Equal(t, `<Value`+ns+`><String>abc</String></Value>`, string(data))
+}
+
+func TestUnmarshalErrors(t *testing.T) {
+	cases := []struct {
+		Name  string
+		Data  string
+		Value interface{}
+		Error string
+	}{
+		{
+			Name:  "NotSetable",
+			Data:  `<Int32>1</Int32>`,
+			Value: int32(0),
+			Error: "value must be a pointer",
+		},
+		{
+			Name:  "Type",
+			Data:  `<ReadRequest><NodesToRead><ReadValueId><AttributeId>x</AttributeId></ReadValueId></NodesToRead></ReadRequest>`,
+			Value: new(uatype.ReadRequest),
+			Error: `DecoderError .NodesToRead[0].AttributeId: invalid value: strconv.ParseUint: parsing "x": invalid syntax`,
+		},
+		{
+			Name:  "Range",
+			Data:  `<Variant><Value><Byte>256</Byte></Value></Variant>`,
+			Value: new(uatype.Variant),
+			Error: `DecoderError .Value.Byte: invalid value: strconv.ParseUint: parsing "256": value out of range`,
+		},
+		{
+			Name:  "VariantType",
+			Data:  `<Variant><Value><Foo>1</Foo></Value></Variant>`,
+			Value: new(uatype.Variant),
+			Error: "DecoderError .Value: invalid value: unknown Variant type Foo",
+		},
+		{
+			Name:  "Boolean",
+			Data:  `<Boolean>yes</Boolean>`,
+			Value: new(bool),
+			Error: `DecoderError : invalid value: "yes" is not a boolean`,
+		},
+		{
+			Name:  "Syntax",
+			Data:  `<Int32>1</Int64>`,
+			Value: new(int32),
+			Error: "XML syntax error on line 1: element <Int32> closed by </Int64>",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			err := xml.Unmarshal([]byte(tc.Data), tc.Value)
+			require.Error(t, err)
+			assert.Equal(t, tc.Error, err.Error())
+		})
+	}
+}
+
+const bitFieldDict = `<opc:TypeDictionary xmlns:opc="http://opcfoundation.org/BinarySchema/" TargetNamespace="urn:bits">
+  <opc:StructuredType Name="Packed">
+    <opc:Field Name="Flags" TypeName="opc:Bit" Length="4" />
+    <opc:Field Name="Counter" TypeName="opc:Bit" Length="12" />
+    <opc:Field Name="Enabled" TypeName="opc:Bit" />
+    <opc:Field Name="Reserved1" TypeName="opc:Bit" Length="7" />
+    <opc:Field Name="Value" TypeName="opc:Int32" />
+  </opc:StructuredType>
+</opc:TypeDictionary>`
+
+func TestBitFields(t *testing.T) {
+	d, err := typedict.NewSet().Parse(strings.NewReader(bitFieldDict))
+	require.NoError(t, err, "Parse")
+	packed, err := d.Type("Packed")
+	require.NoError(t, err, `d.Type("Packed")`)
+	v, err := packed.New(map[string]interface{}{"Flags": 5, "Counter": 0xABC, "Enabled": true, "Value": 7})
+	require.NoError(t, err, "packed.New")
+
+	data, err := xml.Marshal(v)
+	require.NoError(t, err, "Marshal")
+	assert.Equal(t, `<Packed xmlns="http://opcfoundation.org/UA/2008/02/Types.xsd">`+
+		`<Flags>5</Flags><Counter>2748</Counter><Enabled>true</Enabled><Value>7</Value></Packed>`, string(data), "Marshal")
+
+	rv := reflect.New(packed.GoType())
+	require.NoError(t, xml.Unmarshal(data, rv.Interface()), "Unmarshal")
+	assert.Equal(t, v, rv.Elem().Interface(), "Unmarshal")
+}
@@ -0,0 +1,47 @@
+package stack_test
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/searis/guma/stack/encoding/binary"
+	"github.com/searis/guma/stack/transport"
+	"github.com/searis/guma/stack/uatype"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeChannel decodes requests and passes them to handle, and encodes the
+// returned response.
+type fakeChannel struct {
+	t              *testing.T
+	handle         func(req interface{}) interface{}
+	maxMessageSize uint32
+}
+
+func (ch fakeChannel) Send(req transport.Request, deadline time.Time) (*transport.Response, error) {
+	rt, ok := uatype.ExtensionObjectType(req.NodeID)
+	require.True(ch.t, ok, "request type %s", req.NodeID.DisplayName())
+	rv := reflect.New(rt)
+	require.NoError(ch.t, binary.NewDecoder(req.Body).Decode(rv.Interface()), "decode request")
+
+	resp := ch.handle(rv.Elem().Interface())
+	id, ok := uatype.ExtensionObjectEncodingID(resp)
+	require.True(ch.t, ok, "response type %T", resp)
+	data, err := binary.Marshal(resp)
+	require.NoError(ch.t, err, "encode response")
+	return &transport.Response{
+		NodeID:         id.Expanded(),
+		Body:           bytes.NewReader(data),
+		MaxMessageSize: ch.maxMessageSize,
+	}, nil
+}
+
+func (ch fakeChannel) Close() error {
+	return nil
+}
+
+func responseHeader() uatype.ResponseHeader {
+	return uatype.ResponseHeader{ServiceDiagnostics: &uatype.DiagnosticInfo{}}
+}
@@ -0,0 +1,455 @@
+package stack
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/searis/guma/stack/uatype"
+)
+
+// HistoryReadOptions configures history read iterators.
+type HistoryReadOptions struct {
+	// TimestampsToReturn selects the timestamps to return for each value.
+	TimestampsToReturn uatype.TimestampsToReturn
+
+	// IndexRange selects a subset of array values.
+	IndexRange string
+
+	// Timeout is the timeout of each request sent to the server. Zero means
+	// no timeout.
+	Timeout time.Duration
+
+	// MaxValuesPerRequest is the maximum number of values the server returns
+	// for a node in a single request. If zero, the server's
+	// HistoryServerCapabilities MaxReturnDataValues property is read on first
+	// use. Time ranges are split so that no request exceeds the limit, or
+	// continued after the last returned value when the server truncates a
+	// result without returning a continuation point.
+	MaxValuesPerRequest uint32
+
+	// SplitInterval, if set, splits the time range of raw reads into
+	// consecutive requests of at most this length.
+	SplitInterval time.Duration
+}
+
+// historyLimit caches the HistoryServerCapabilities MaxReturnDataValues
+// property of the server.
+type historyLimit struct {
+	sync.Mutex
+	read  bool
+	value uint32
+}
+
+// maxReturnDataValues returns the maximum number of values the server returns
+// per node in a history read, or 0 if unlimited or unknown.
+func (c *Client) maxReturnDataValues(deadline time.Time) (uint32, error) {
+	c.historyLimit.Lock()
+	defer c.historyLimit.Unlock()
+	if c.historyLimit.read {
+		return c.historyLimit.value, nil
+	}
+	values, err := c.readValues([]uatype.NodeId{
+		uatype.NewFourByteNodeID(0, uatype.NodeIdHistoryServerCapabilities_MaxReturnDataValues),
+	}, deadline)
+	if err != nil {
+		return 0, err
+	}
+	// Servers without history capabilities return a Bad status, which is
+	// treated as no limit.
+	if v, err := values[0].Value.Value(); err == nil {
+		if n, ok := v.(uint32); ok {
+			c.historyLimit.value = n
+		}
+	}
+	c.historyLimit.read = true
+	return c.historyLimit.value, nil
+}
+
+// HistoryIterator iterates over the historical values or events of a single
+// node, following continuation points and splitting requests as needed. A
+// HistoryIterator must be closed if not all values are read, so that the
+// server can release its continuation point.
+//
+//	it := client.HistoryReadRaw(nodeID, details, opts)
+//	defer it.Close()
+//	for it.Next() {
+//		dv := it.Value()
+//		...
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type HistoryIterator struct {
+	c    *Client
+	opts HistoryReadOptions
+	node uatype.NodeId
+
+	// windows holds the details of requests not yet sent. Only the first
+	// window may have a continuation point.
+	windows []interface{}
+	cp      uatype.ByteString
+	count   uint32 // number of values returned for the current window
+	limit   func() (uint32, error)
+	resume  func(last uatype.DataValue) interface{}
+
+	values []uatype.DataValue
+	mods   []uatype.ModificationInfo
+	events []uatype.HistoryEventFieldList
+	i      int
+	tail   historyBoundary // trailing values of the current window at the same timestamp
+	skip   historyBoundary // leading values of the next page already returned
+	last   uatype.DataValue
+	event  []uatype.Variant
+	err    error
+	done   bool
+}
+
+// HistoryReadRaw returns an iterator over raw or modified values of the node
+// nodeID. If both StartTime and EndTime are set, the time range is split to
+// respect MaxValuesPerRequest and SplitInterval. If StartTime is after EndTime,
+// values are returned in reverse order.
+func (c *Client) HistoryReadRaw(nodeID uatype.NodeId, details uatype.ReadRawModifiedDetails, opts HistoryReadOptions) *HistoryIterator {
+	it := c.newHistoryIterator(nodeID, opts)
+	if details.StartTime.IsZero() || details.EndTime.IsZero() {
+		it.windows = []interface{}{details}
+		return it
+	}
+
+	// Split the time range into windows of at most SplitInterval.
+	forward := !details.StartTime.After(details.EndTime)
+	for start := details.StartTime; ; {
+		w := details
+		w.StartTime = start
+		if opts.SplitInterval > 0 {
+			if forward && start.Add(opts.SplitInterval).Before(details.EndTime) {
+				w.EndTime = start.Add(opts.SplitInterval)
+			} else if !forward && start.Add(-opts.SplitInterval).After(details.EndTime) {
+				w.EndTime = start.Add(-opts.SplitInterval)
+			}
+		}
+		it.windows = append(it.windows, w)
+		if w.EndTime.Equal(details.EndTime) {
+			break
+		}
+		start = w.EndTime
+	}
+
+	// Continue after the last value if the server truncates a window.
+	it.resume = func(last uatype.DataValue) interface{} {
+		w := it.windows[0].(uatype.ReadRawModifiedDetails)
+		ts, _ := historyTimestamp(last)
+		if ts.IsZero() || ts.Equal(w.StartTime) {
+			// Can not make progress.
+			return nil
+		}
+		w.StartTime = ts
+		it.skip = it.tail
+		return w
+	}
+	return it
+}
+
+// HistoryReadProcessed returns an iterator over values of the node nodeID
+// computed by the aggregate function aggregate, such as
+// uatype.NodeIdAggregateFunction_Average or
+// uatype.NodeIdAggregateFunction_Interpolative in namespace 0. Any
+// AggregateType in details is ignored. The time range is split on multiples
+// of the ProcessingInterval to respect MaxValuesPerRequest.
+func (c *Client) HistoryReadProcessed(nodeID uatype.NodeId, aggregate uatype.NodeId, details uatype.ReadProcessedDetails, opts HistoryReadOptions) *HistoryIterator {
+	details.NoOfAggregateType = 1
+	details.AggregateType = []uatype.NodeId{aggregate}
+
+	it := c.newHistoryIterator(nodeID, opts)
+	it.windows = []interface{}{details}
+	interval := time.Duration(details.ProcessingInterval * float64(time.Millisecond))
+	if interval <= 0 || details.StartTime.IsZero() || details.EndTime.IsZero() {
+		return it
+	}
+
+	// Split the time range on first use, when the limit is known.
+	limit := it.limit
+	it.limit = func() (uint32, error) {
+		n, err := limit()
+		if err != nil || n == 0 {
+			return n, err
+		}
+		step := interval * time.Duration(n)
+		forward := !details.StartTime.After(details.EndTime)
+		if !forward {
+			step = -step
+		}
+		it.windows = it.windows[:0]
+		for start := details.StartTime; ; {
+			w := details
+			w.StartTime = start
+			if forward && start.Add(step).Before(details.EndTime) || !forward && start.Add(step).After(details.EndTime) {
+				w.EndTime = start.Add(step)
+			}
+			it.windows = append(it.windows, w)
+			if w.EndTime.Equal(details.EndTime) {
+				break
+			}
+			start = w.EndTime
+		}
+		it.limit = limit
+		return n, nil
+	}
+	return it
+}
+
+// HistoryReadAtTime returns an iterator over the values of the node nodeID at
+// the timestamps in details.ReqTimes. The timestamps are split over several
+// requests to respect MaxValuesPerRequest.
+func (c *Client) HistoryReadAtTime(nodeID uatype.NodeId, details uatype.ReadAtTimeDetails, opts HistoryReadOptions) *HistoryIterator {
+	it := c.newHistoryIterator(nodeID, opts)
+	it.windows = []interface{}{details}
+
+	limit := it.limit
+	it.limit = func() (uint32, error) {
+		n, err := limit()
+		if err != nil || n == 0 || len(details.ReqTimes) <= int(n) {
+			return n, err
+		}
+		it.windows = it.windows[:0]
+		for times := details.ReqTimes; len(times) > 0; {
+			l := len(times)
+			if l > int(n) {
+				l = int(n)
+			}
+			w := details
+			w.NoOfReqTimes = int32(l)
+			w.ReqTimes = times[:l]
+			it.windows = append(it.windows, w)
+			times = times[l:]
+		}
+		it.limit = limit
+		return n, nil
+	}
+	return it
+}
+
+// HistoryReadEvents returns an iterator over the historical events of the node
+// nodeID, typically an object with the SubscribeToEvents bit set in its
+// EventNotifier attribute. The fields of each event are selected by the
+// SelectClauses of details.Filter. The TimestampsToReturn option is ignored.
+func (c *Client) HistoryReadEvents(nodeID uatype.NodeId, details uatype.ReadEventDetails, opts HistoryReadOptions) *HistoryIterator {
+	details.Filter.NoOfSelectClauses = int32(len(details.Filter.SelectClauses))
+	opts.TimestampsToReturn = uatype.TimestampsToReturnNeither
+	it := c.newHistoryIterator(nodeID, opts)
+	it.windows = []interface{}{details}
+	return it
+}
+
+func (c *Client) newHistoryIterator(nodeID uatype.NodeId, opts HistoryReadOptions) *HistoryIterator {
+	it := &HistoryIterator{c: c, opts: opts, node: nodeID}
+	it.limit = func() (uint32, error) {
+		if opts.MaxValuesPerRequest > 0 {
+			return opts.MaxValuesPerRequest, nil
+		}
+		return c.maxReturnDataValues(it.deadline())
+	}
+	return it
+}
+
+func (it *HistoryIterator) deadline() time.Time {
+	if it.opts.Timeout <= 0 {
+		return time.Time{}
+	}
+	return time.Now().Add(it.opts.Timeout)
+}
+
+// Next advances the iterator to the next value, sending requests to the
+// server as needed. It returns false when there are no more values, or an
+// error occurred.
+func (it *HistoryIterator) Next() bool {
+	for {
+		if it.i < len(it.values) {
+			it.last = it.values[it.i]
+			it.i++
+			return true
+		}
+		if it.i < len(it.events) {
+			it.event = it.events[it.i].EventFields
+			it.i++
+			return true
+		}
+		if it.done || it.err != nil {
+			return false
+		}
+		if err := it.fetch(); err != nil {
+			it.err = err
+			it.done = true
+			return false
+		}
+	}
+}
+
+// fetch reads the next page of values.
+func (it *HistoryIterator) fetch() error {
+	limit, err := it.limit()
+	if err != nil {
+		return err
+	}
+	if len(it.windows) == 0 {
+		it.done = true
+		return nil
+	}
+
+	res, err := it.read(it.windows[0], it.cp, false)
+	if err != nil {
+		return err
+	}
+	it.cp = res.ContinuationPoint
+	it.values, it.mods, it.events, it.i = nil, nil, nil, 0
+	if eo := res.HistoryData; eo.Value != nil {
+		switch data := eo.Value.(type) {
+		case uatype.HistoryData:
+			it.values = data.DataValues
+		case uatype.HistoryModifiedData:
+			it.values = data.DataValues
+			it.mods = data.ModificationInfos
+		case uatype.HistoryEvent:
+			it.events = data.Events
+		default:
+			return fmt.Errorf("%s: unexpected history data %T", uatype.StatusBadUnexpectedError, eo.Value)
+		}
+	}
+	it.count += uint32(len(it.values) + len(it.events))
+
+	// Drop values already returned before the window was resumed.
+	if it.skip.n > 0 {
+		for it.i < len(it.values) && it.i < it.skip.n && it.skip.match(it.values[it.i]) {
+			it.i++
+		}
+		if len(it.mods) >= it.i {
+			it.mods = it.mods[it.i:]
+		}
+		it.values = it.values[it.i:]
+		it.i = 0
+		it.skip = historyBoundary{}
+	}
+	for _, dv := range it.values {
+		it.tail.add(dv)
+	}
+	if len(it.cp) > 0 {
+		return nil
+	}
+
+	// The window is complete, unless the server truncated it.
+	max := limit
+	if raw, ok := it.windows[0].(uatype.ReadRawModifiedDetails); ok && raw.NumValuesPerNode > 0 && (max == 0 || raw.NumValuesPerNode < max) {
+		max = raw.NumValuesPerNode
+	}
+	if it.resume != nil && max > 0 && it.count >= max && len(it.values) > 0 {
+		if w := it.resume(it.values[len(it.values)-1]); w != nil {
+			it.windows[0] = w
+			it.count = 0
+			return nil
+		}
+	}
+	it.windows = it.windows[1:]
+	it.count = 0
+	it.tail = historyBoundary{}
+	return nil
+}
+
+// historyBoundary counts consecutive values with the same timestamp.
+type historyBoundary struct {
+	ts     time.Time
+	server bool
+	n      int
+}
+
+// add counts dv if it has the timestamp of b, or else restarts b at the
+// timestamp of dv.
+func (b *historyBoundary) add(dv uatype.DataValue) {
+	if b.match(dv) {
+		b.n++
+		return
+	}
+	b.ts, b.server = historyTimestamp(dv)
+	b.n = 1
+}
+
+// match reports if dv has the timestamp of b.
+func (b historyBoundary) match(dv uatype.DataValue) bool {
+	ts, server := historyTimestamp(dv)
+	return b.n > 0 && server == b.server && ts.Equal(b.ts)
+}
+
+// historyTimestamp returns the timestamp used to resume a truncated read after
+// dv, which is the SourceTimestamp, or the ServerTimestamp if dv has no
+// SourceTimestamp. server reports if it is the ServerTimestamp.
+func historyTimestamp(dv uatype.DataValue) (ts time.Time, server bool) {
+	if dv.SourceTimestamp.IsZero() {
+		return dv.ServerTimestamp, true
+	}
+	return dv.SourceTimestamp, false
+}
+
+// read sends a single history read request for the node.
+func (it *HistoryIterator) read(details interface{}, cp uatype.ByteString, release bool) (*uatype.HistoryReadResult, error) {
+	resp, err := it.c.HistoryRead(uatype.HistoryReadRequest{
+		RequestHeader:             it.c.requestHeader(),
+		HistoryReadDetails:        uatype.ExtensionObject{Value: details},
+		TimestampsToReturn:        it.opts.TimestampsToReturn,
+		ReleaseContinuationPoints: release,
+		NoOfNodesToRead:           1,
+		NodesToRead: []uatype.HistoryReadValueId{{
+			NodeId:            it.node,
+			IndexRange:        it.opts.IndexRange,
+			ContinuationPoint: cp,
+		}},
+	}, it.deadline())
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Results) != 1 {
+		return nil, uatype.StatusBadUnexpectedError
+	}
+	res := &resp.Results[0]
+	if res.StatusCode.IsBad() {
+		return nil, res.StatusCode
+	}
+	return res, nil
+}
+
+// Value returns the current value when reading data values.
+func (it *HistoryIterator) Value() uatype.DataValue {
+	return it.last
+}
+
+// Event returns the fields of the current event, in the order of the select
+// clauses of the event filter. See HistoryReadEvents.
+func (it *HistoryIterator) Event() []uatype.Variant {
+	return it.event
+}
+
+// ModificationInfo returns the modification info of the current value and
+// true when reading modified values, or false if the server returned none.
+func (it *HistoryIterator) ModificationInfo() (uatype.ModificationInfo, bool) {
+	if it.i == 0 || it.i > len(it.mods) {
+		return uatype.ModificationInfo{}, false
+	}
+	return it.mods[it.i-1], true
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *HistoryIterator) Err() error {
+	return it.err
+}
+
+// Close stops the iteration, and releases the continuation point held by the
+// server, if any, also after an error. It's safe to call Close more than once.
+func (it *HistoryIterator) Close() error {
+	it.done = true
+	it.values, it.events = nil, nil
+	if len(it.cp) == 0 {
+		return nil
+	}
+	cp := it.cp
+	it.cp = nil
+	_, err := it.read(it.windows[0], cp, true)
+	return err
+}
@@ -0,0 +1,314 @@
+package stack_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/searis/guma/stack"
+	"github.com/searis/guma/stack/uatype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func historyValues(details uatype.ReadRawModifiedDetails, all []uatype.DataValue, max int) []uatype.DataValue {
+	var values []uatype.DataValue
+	for _, dv := range all {
+		ts := dv.SourceTimestamp
+		if ts.IsZero() {
+			ts = dv.ServerTimestamp
+		}
+		if ts.Before(details.StartTime) || ts.After(details.EndTime) {
+			continue
+		}
+		if len(values) == max {
+			break
+		}
+		values = append(values, dv)
+	}
+	return values
+}
+
+func TestHistoryReadRawTruncated(t *testing.T) {
+	t0 := time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+	var all []uatype.DataValue
+	for i := 0; i < 5; i++ {
+		all = append(all, uatype.DataValue{
+			SourceTimestampSpecified: true,
+			SourceTimestamp:          t0.Add(time.Duration(i) * time.Second),
+		})
+	}
+
+	// The server returns at most two values per request, and no
+	// continuation points. Each resumed request starts at the last returned
+	// value, so five requests are needed.
+	requests := 0
+	c := &stack.Client{Channel: fakeChannel{t: t, handle: func(req interface{}) interface{} {
+		requests++
+		details := req.(uatype.HistoryReadRequest).HistoryReadDetails.Value.(uatype.ReadRawModifiedDetails)
+		values := historyValues(details, all, 2)
+		return uatype.HistoryReadResponse{
+			ResponseHeader: responseHeader(),
+			NoOfResults:    1,
+			Results: []uatype.HistoryReadResult{{
+				HistoryData: uatype.ExtensionObject{Value: uatype.HistoryData{
+					NoOfDataValues: int32(len(values)),
+					DataValues:     values,
+				}},
+			}},
+		}
+	}}}
+
+	it := c.HistoryReadRaw(uatype.NewNumericNodeID(2, 1), uatype.ReadRawModifiedDetails{
+		StartTime: t0,
+		EndTime:   t0.Add(time.Minute),
+	}, stack.HistoryReadOptions{MaxValuesPerRequest: 2})
+	defer it.Close()
+
+	var got []time.Time
+	for it.Next() {
+		got = append(got, it.Value().SourceTimestamp)
+	}
+	require.NoError(t, it.Err(), "it.Err()")
+	require.Len(t, got, len(all), "values")
+	for i := range all {
+		assert.True(t, all[i].SourceTimestamp.Equal(got[i]), "value %d", i)
+	}
+	assert.Equal(t, 5, requests, "requests")
+}
+
+func TestHistoryReadRawTruncatedDuplicates(t *testing.T) {
+	t0 := time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+	offsets := []int{0, 1, 2, 2, 3}
+
+	for _, tc := range []struct {
+		Name  string
+		Value func(ts time.Time) uatype.DataValue
+		Time  func(dv uatype.DataValue) time.Time
+	}{
+		{
+			Name: "SourceTimestamp",
+			Value: func(ts time.Time) uatype.DataValue {
+				return uatype.DataValue{SourceTimestampSpecified: true, SourceTimestamp: ts}
+			},
+			Time: func(dv uatype.DataValue) time.Time { return dv.SourceTimestamp },
+		},
+		{
+			Name: "ServerTimestamp",
+			Value: func(ts time.Time) uatype.DataValue {
+				return uatype.DataValue{ServerTimestampSpecified: true, ServerTimestamp: ts}
+			},
+			Time: func(dv uatype.DataValue) time.Time { return dv.ServerTimestamp },
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			var all []uatype.DataValue
+			for i, s := range offsets {
+				dv := tc.Value(t0.Add(time.Duration(s) * time.Second))
+				v, err := uatype.NewVariant(int32(i))
+				require.NoError(t, err, "NewVariant")
+				dv.ValueSpecified, dv.Value = true, v
+				all = append(all, dv)
+			}
+
+			// The server returns at most three values per request. The second
+			// request starts at the third value, which shares its timestamp
+			// with the fourth value, so only the third must be skipped.
+			c := &stack.Client{Channel: fakeChannel{t: t, handle: func(req interface{}) interface{} {
+				details := req.(uatype.HistoryReadRequest).HistoryReadDetails.Value.(uatype.ReadRawModifiedDetails)
+				values := historyValues(details, all, 3)
+				return uatype.HistoryReadResponse{
+					ResponseHeader: responseHeader(),
+					NoOfResults:    1,
+					Results: []uatype.HistoryReadResult{{
+						HistoryData: uatype.ExtensionObject{Value: uatype.HistoryData{
+							NoOfDataValues: int32(len(values)),
+							DataValues:     values,
+						}},
+					}},
+				}
+			}}}
+
+			it := c.HistoryReadRaw(uatype.NewNumericNodeID(2, 1), uatype.ReadRawModifiedDetails{
+				StartTime: t0,
+				EndTime:   t0.Add(time.Minute),
+			}, stack.HistoryReadOptions{MaxValuesPerRequest: 3})
+			defer it.Close()
+
+			var got []uatype.DataValue
+			for it.Next() {
+				got = append(got, it.Value())
+			}
+			require.NoError(t, it.Err(), "it.Err()")
+			require.Len(t, got, len(all), "values")
+			for i := range all {
+				assert.True(t, tc.Time(all[i]).Equal(tc.Time(got[i])), "value %d timestamp", i)
+				assert.Equal(t, all[i].Value, got[i].Value, "value %d", i)
+			}
+		})
+	}
+}
+
+func TestHistoryReadRawRelease(t *testing.T) {
+	var released uatype.ByteString
+	c := &stack.Client{Channel: fakeChannel{t: t, handle: func(req interface{}) interface{} {
+		hr := req.(uatype.HistoryReadRequest)
+		if hr.ReleaseContinuationPoints {
+			released = hr.NodesToRead[0].ContinuationPoint
+			return uatype.HistoryReadResponse{ResponseHeader: responseHeader(), NoOfResults: 1, Results: []uatype.HistoryReadResult{{}}}
+		}
+		return uatype.HistoryReadResponse{
+			ResponseHeader: responseHeader(),
+			NoOfResults:    1,
+			Results: []uatype.HistoryReadResult{{
+				ContinuationPoint: uatype.ByteString("cp"),
+				HistoryData: uatype.ExtensionObject{Value: uatype.HistoryData{
+					NoOfDataValues: 2,
+					DataValues:     make([]uatype.DataValue, 2),
+				}},
+			}},
+		}
+	}}}
+
+	it := c.HistoryReadRaw(uatype.NewNumericNodeID(2, 1), uatype.ReadRawModifiedDetails{
+		NumValuesPerNode: 2,
+	}, stack.HistoryReadOptions{MaxValuesPerRequest: 100})
+	require.True(t, it.Next(), "it.Next()")
+	require.NoError(t, it.Close(), "it.Close()")
+	assert.Equal(t, uatype.ByteString("cp"), released, "released continuation point")
+	assert.False(t, it.Next(), "it.Next() after Close")
+}
+
+func TestHistoryReadRawReleaseAfterError(t *testing.T) {
+	var released uatype.ByteString
+	reads := 0
+	c := &stack.Client{Channel: fakeChannel{t: t, handle: func(req interface{}) interface{} {
+		hr := req.(uatype.HistoryReadRequest)
+		if hr.ReleaseContinuationPoints {
+			released = hr.NodesToRead[0].ContinuationPoint
+			return uatype.HistoryReadResponse{ResponseHeader: responseHeader(), NoOfResults: 1, Results: []uatype.HistoryReadResult{{}}}
+		}
+		reads++
+		if reads > 1 {
+			// The second page fails without a result.
+			return uatype.HistoryReadResponse{ResponseHeader: responseHeader()}
+		}
+		return uatype.HistoryReadResponse{
+			ResponseHeader: responseHeader(),
+			NoOfResults:    1,
+			Results: []uatype.HistoryReadResult{{
+				ContinuationPoint: uatype.ByteString("cp"),
+				HistoryData: uatype.ExtensionObject{Value: uatype.HistoryData{
+					NoOfDataValues: 1,
+					DataValues:     make([]uatype.DataValue, 1),
+				}},
+			}},
+		}
+	}}}
+
+	it := c.HistoryReadRaw(uatype.NewNumericNodeID(2, 1), uatype.ReadRawModifiedDetails{
+		NumValuesPerNode: 2,
+	}, stack.HistoryReadOptions{MaxValuesPerRequest: 100})
+	require.True(t, it.Next(), "it.Next()")
+	require.False(t, it.Next(), "it.Next()")
+	assert.Equal(t, uatype.StatusBadUnexpectedError, it.Err(), "it.Err()")
+	require.NoError(t, it.Close(), "it.Close()")
+	assert.Equal(t, uatype.ByteString("cp"), released, "released continuation point")
+}
+
+func TestHistoryReadEvents(t *testing.T) {
+	c := &stack.Client{Channel: fakeChannel{t: t, handle: func(req interface{}) interface{} {
+		hr := req.(uatype.HistoryReadRequest)
+		details := hr.HistoryReadDetails.Value.(uatype.ReadEventDetails)
+		require.Len(t, details.Filter.SelectClauses, 1, "select clauses")
+		var cp uatype.ByteString
+		message := "first"
+		if len(hr.NodesToRead[0].ContinuationPoint) == 0 {
+			cp = uatype.ByteString("cp")
+		} else {
+			message = "second"
+		}
+		field, err := uatype.NewVariant(message)
+		require.NoError(t, err, "NewVariant")
+		return uatype.HistoryReadResponse{
+			ResponseHeader: responseHeader(),
+			NoOfResults:    1,
+			Results: []uatype.HistoryReadResult{{
+				ContinuationPoint: cp,
+				HistoryData: uatype.ExtensionObject{Value: uatype.HistoryEvent{
+					NoOfEvents: 1,
+					Events: []uatype.HistoryEventFieldList{{
+						NoOfEventFields: 1,
+						EventFields:     []uatype.Variant{field},
+					}},
+				}},
+			}},
+		}
+	}}}
+
+	it := c.HistoryReadEvents(uatype.NewNumericNodeID(2, 1), uatype.ReadEventDetails{
+		Filter: uatype.EventFilter{SelectClauses: []uatype.SimpleAttributeOperand{{
+			TypeDefinitionId: uatype.NewFourByteNodeID(0, uatype.NodeIdBaseEventType),
+			NoOfBrowsePath:   1,
+			BrowsePath:       []uatype.QualifiedName{{Name: "Message"}},
+			AttributeId:      uint32(uatype.AttrTypeValue),
+		}}},
+	}, stack.HistoryReadOptions{MaxValuesPerRequest: 100})
+	defer it.Close()
+
+	var got []interface{}
+	for it.Next() {
+		v, err := it.Event()[0].Value()
+		require.NoError(t, err, "Value()")
+		got = append(got, v)
+	}
+	require.NoError(t, it.Err(), "it.Err()")
+	assert.Equal(t, []interface{}{"first", "second"}, got, "events")
+}
+
+func TestUpdateHistory(t *testing.T) {
+	t0 := time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := &stack.Client{Channel: fakeChannel{t: t, handle: func(req interface{}) interface{} {
+		hu := req.(uatype.HistoryUpdateRequest)
+		require.Len(t, hu.HistoryUpdateDetails, 3, "details")
+		update := hu.HistoryUpdateDetails[0].Value.(uatype.UpdateDataDetails)
+		assert.Len(t, update.UpdateValues, 2, "update values")
+		return uatype.HistoryUpdateResponse{
+			ResponseHeader: responseHeader(),
+			NoOfResults:    3,
+			Results: []uatype.HistoryUpdateResult{{
+				NoOfOperationResults: 2,
+				OperationResults:     []uatype.StatusCode{uatype.StatusGoodEntryInserted, uatype.StatusBadEntryExists},
+			}, {
+				StatusCode: uatype.StatusBadNoData,
+			}, {}},
+		}
+	}}}
+
+	results, err := c.UpdateHistory([]interface{}{
+		uatype.UpdateDataDetails{
+			NodeId:               uatype.NewNumericNodeID(2, 1),
+			PerformInsertReplace: uatype.PerformUpdateTypeInsert,
+			UpdateValues:         []uatype.DataValue{{}, {}},
+		},
+		&uatype.DeleteAtTimeDetails{
+			NodeId:   uatype.NewNumericNodeID(2, 1),
+			ReqTimes: []time.Time{t0},
+		},
+		uatype.DeleteRawModifiedDetails{
+			NodeId:    uatype.NewNumericNodeID(2, 1),
+			StartTime: t0,
+			EndTime:   t0.Add(time.Hour),
+		},
+	}, time.Time{})
+	require.NoError(t, err, "UpdateHistory")
+	require.Len(t, results, 3, "results")
+
+	assert.Equal(t, []uatype.StatusCode{uatype.StatusGoodEntryInserted, uatype.StatusBadEntryExists}, results[0].OperationResults)
+	assert.Equal(t, stack.HistoryUpdateError{Index: 1, StatusCode: uatype.StatusBadEntryExists}, results[0].Err())
+	assert.Equal(t, []uatype.StatusCode{uatype.StatusBadNoData}, results[1].OperationResults)
+	assert.Len(t, results[2].OperationResults, 0)
+	assert.NoError(t, results[2].Err())
+
+	_, err = c.UpdateHistory([]interface{}{uatype.ReadRawModifiedDetails{}}, time.Time{})
+	assert.Error(t, err, "unsupported details")
+}
@@ -0,0 +1,146 @@
+package stack
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/searis/guma/stack/uatype"
+)
+
+// HistoryUpdateResult is the result of a single history update operation.
+type HistoryUpdateResult struct {
+	// StatusCode is the status of the operation as a whole.
+	StatusCode uatype.StatusCode
+
+	// OperationResults holds the status of each input of the operation, in
+	// the same order: the UpdateValues of UpdateDataDetails and
+	// UpdateStructureDataDetails, the EventData of UpdateEventDetails, the
+	// ReqTimes of DeleteAtTimeDetails, or the EventIds of DeleteEventDetails.
+	// If the server returns no operation results, each input gets the status
+	// of the operation. DeleteRawModifiedDetails has no inputs, and no
+	// operation results.
+	OperationResults []uatype.StatusCode
+}
+
+// Err returns nil if the operation and all its inputs succeeded. Otherwise a
+// HistoryUpdateError for the first failure is returned.
+func (r HistoryUpdateResult) Err() error {
+	if r.StatusCode.IsBad() {
+		return HistoryUpdateError{Index: -1, StatusCode: r.StatusCode}
+	}
+	for i, code := range r.OperationResults {
+		if code.IsBad() {
+			return HistoryUpdateError{Index: i, StatusCode: code}
+		}
+	}
+	return nil
+}
+
+// HistoryUpdateError describes a failed history update operation, or a failed
+// input of an operation.
+type HistoryUpdateError struct {
+	// Index is the index of the failed input, or -1 if the operation as a
+	// whole failed.
+	Index      int
+	StatusCode uatype.StatusCode
+}
+
+func (e HistoryUpdateError) Error() string {
+	if e.Index < 0 {
+		return e.StatusCode.Error()
+	}
+	return fmt.Sprintf("input %d: %s", e.Index, e.StatusCode)
+}
+
+// UpdateHistory inserts, replaces or deletes historical data or events. Each
+// of details must be one of uatype.UpdateDataDetails,
+// uatype.UpdateStructureDataDetails, uatype.UpdateEventDetails,
+// uatype.DeleteRawModifiedDetails, uatype.DeleteAtTimeDetails or
+// uatype.DeleteEventDetails, or a pointer to one. The NoOf length fields are
+// set from the lengths of the slices they describe.
+//
+// One result is returned per operation, with the status of each input mapped
+// to its index. An error is only returned if the request as a whole fails; see
+// HistoryUpdateResult.Err for the status of each operation.
+func (c *Client) UpdateHistory(details []interface{}, deadline time.Time) ([]HistoryUpdateResult, error) {
+	eos := make([]uatype.ExtensionObject, len(details))
+	inputs := make([]int, len(details))
+	for i, d := range details {
+		v, n, err := historyUpdateDetails(d)
+		if err != nil {
+			return nil, fmt.Errorf("details %d: %s", i, err)
+		}
+		eos[i] = uatype.ExtensionObject{Value: v}
+		inputs[i] = n
+	}
+
+	resp, err := c.HistoryUpdate(uatype.HistoryUpdateRequest{
+		RequestHeader:            c.requestHeader(),
+		NoOfHistoryUpdateDetails: int32(len(eos)),
+		HistoryUpdateDetails:     eos,
+	}, deadline)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Results) != len(details) {
+		return nil, uatype.StatusBadUnexpectedError
+	}
+
+	results := make([]HistoryUpdateResult, len(resp.Results))
+	for i, r := range resp.Results {
+		results[i].StatusCode = r.StatusCode
+		switch {
+		case len(r.OperationResults) == inputs[i]:
+			results[i].OperationResults = r.OperationResults
+		case len(r.OperationResults) == 0:
+			results[i].OperationResults = make([]uatype.StatusCode, inputs[i])
+			for j := range results[i].OperationResults {
+				results[i].OperationResults[j] = r.StatusCode
+			}
+		default:
+			return nil, fmt.Errorf("%s: details %d: expected %d operation results, got %d", uatype.StatusBadUnexpectedError, i, inputs[i], len(r.OperationResults))
+		}
+	}
+	return results, nil
+}
+
+// historyUpdateDetails returns d with its length fields set, and the number of
+// inputs of the operation.
+func historyUpdateDetails(d interface{}) (interface{}, int, error) {
+	switch v := d.(type) {
+	case *uatype.UpdateDataDetails:
+		return historyUpdateDetails(*v)
+	case *uatype.UpdateStructureDataDetails:
+		return historyUpdateDetails(*v)
+	case *uatype.UpdateEventDetails:
+		return historyUpdateDetails(*v)
+	case *uatype.DeleteRawModifiedDetails:
+		return historyUpdateDetails(*v)
+	case *uatype.DeleteAtTimeDetails:
+		return historyUpdateDetails(*v)
+	case *uatype.DeleteEventDetails:
+		return historyUpdateDetails(*v)
+	case uatype.UpdateDataDetails:
+		v.NoOfUpdateValues = int32(len(v.UpdateValues))
+		return v, len(v.UpdateValues), nil
+	case uatype.UpdateStructureDataDetails:
+		v.NoOfUpdateValues = int32(len(v.UpdateValues))
+		return v, len(v.UpdateValues), nil
+	case uatype.UpdateEventDetails:
+		v.Filter.NoOfSelectClauses = int32(len(v.Filter.SelectClauses))
+		v.NoOfEventData = int32(len(v.EventData))
+		for i := range v.EventData {
+			v.EventData[i].NoOfEventFields = int32(len(v.EventData[i].EventFields))
+		}
+		return v, len(v.EventData), nil
+	case uatype.DeleteRawModifiedDetails:
+		return v, 0, nil
+	case uatype.DeleteAtTimeDetails:
+		v.NoOfReqTimes = int32(len(v.ReqTimes))
+		return v, len(v.ReqTimes), nil
+	case uatype.DeleteEventDetails:
+		v.NoOfEventIds = int32(len(v.EventIds))
+		return v, len(v.EventIds), nil
+	}
+	return nil, 0, fmt.Errorf("%s: unsupported history update details %T", uatype.StatusBadHistoryOperationUnsupported, d)
+}
@@ -0,0 +1,367 @@
+package stack
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/searis/guma/stack/uatype"
+)
+
+// ErrMethodNotFound is returned by FindMethod if the object has no method with
+// the given browse name.
+var ErrMethodNotFound = errors.New("method not found")
+
+// methodCache holds method IDs and arguments that have been read from the
+// server.
+type methodCache struct {
+	sync.Mutex
+	ids  map[methodKey]uatype.NodeId
+	args map[uatype.NodeKey]methodArguments
+}
+
+// methodKey identifies a method by its object and browse name.
+type methodKey struct {
+	object     uatype.NodeKey
+	browseName uatype.QualifiedName
+}
+
+type methodArguments struct {
+	in, out []uatype.Argument
+}
+
+// MethodResult is the result of a successful method call.
+type MethodResult struct {
+	// StatusCode is the status of the call, which may be Good or Uncertain.
+	StatusCode uatype.StatusCode
+
+	// InputArgumentResults holds the status of each input argument, if
+	// returned by the server.
+	InputArgumentResults []uatype.StatusCode
+
+	// OutputArguments holds the output arguments converted to Go values. See
+	// uatype.Variant.Value.
+	OutputArguments []interface{}
+}
+
+// ArgumentError is returned when an input argument can not be converted to
+// the declared DataType and ValueRank of the method argument.
+type ArgumentError struct {
+	Index int
+	Name  string
+	Err   error
+}
+
+func (e ArgumentError) Error() string {
+	return fmt.Sprintf("input argument %d (%s): %s", e.Index, e.Name, e.Err)
+}
+
+// MethodError is returned when the server responds with a Bad status code for
+// a method call.
+type MethodError struct {
+	StatusCode uatype.StatusCode
+
+	// InputArgumentResults holds the status of each input argument, if
+	// returned by the server.
+	InputArgumentResults []uatype.StatusCode
+
+	// InputArguments holds the declared input arguments of the method.
+	InputArguments []uatype.Argument
+}
+
+func (e MethodError) Error() string {
+	var buf bytes.Buffer
+	buf.WriteString(e.StatusCode.Error())
+	for i, code := range e.InputArgumentResults {
+		if !code.IsBad() {
+			continue
+		}
+		name := ""
+		if i < len(e.InputArguments) {
+			name = e.InputArguments[i].Name
+		}
+		fmt.Fprintf(&buf, "; input argument %d (%s): %s", i, name, code)
+	}
+	return buf.String()
+}
+
+// FindMethod returns the node ID of the method of the object objectID with
+// the given browse name. The result is cached for the lifetime of c.
+func (c *Client) FindMethod(objectID uatype.NodeId, browseName uatype.QualifiedName, deadline time.Time) (uatype.NodeId, error) {
+	key := methodKey{object: objectID.Key(), browseName: browseName}
+	c.methods.Lock()
+	id, ok := c.methods.ids[key]
+	c.methods.Unlock()
+	if ok {
+		return id, nil
+	}
+
+	refs, err := c.browseAll([]uatype.BrowseDescription{{
+		NodeId:          objectID,
+		BrowseDirection: uatype.BrowseDirectionForward,
+		ReferenceTypeId: uatype.NewFourByteNodeID(0, uatype.NodeIdHasComponent),
+		IncludeSubtypes: true,
+		NodeClassMask:   uint32(uatype.NodeClassMethod),
+		ResultMask:      uint32(uatype.BrowseResultMaskAll),
+	}}, deadline)
+	if err != nil {
+		return uatype.NodeId{}, err
+	}
+	for _, ref := range refs[0] {
+		if ref.BrowseName != browseName {
+			continue
+		}
+		if id, ok = ref.NodeId.NodeID(); ok {
+			break
+		}
+	}
+	if !ok {
+		return uatype.NodeId{}, fmt.Errorf("%s: %d:%s", ErrMethodNotFound, browseName.NamespaceIndex, browseName.Name)
+	}
+
+	c.methods.Lock()
+	if c.methods.ids == nil {
+		c.methods.ids = make(map[methodKey]uatype.NodeId)
+	}
+	c.methods.ids[key] = id
+	c.methods.Unlock()
+	return id, nil
+}
+
+// MethodArguments returns the declared input and output arguments of the
+// method methodID, read from its InputArguments and OutputArguments
+// properties. A missing property means the method has no such arguments. The
+// result is cached for the lifetime of c.
+func (c *Client) MethodArguments(methodID uatype.NodeId, deadline time.Time) (in, out []uatype.Argument, err error) {
+	key := methodID.Key()
+	c.methods.Lock()
+	args, ok := c.methods.args[key]
+	c.methods.Unlock()
+	if ok {
+		return args.in, args.out, nil
+	}
+
+	refs, err := c.browseAll([]uatype.BrowseDescription{{
+		NodeId:          methodID,
+		BrowseDirection: uatype.BrowseDirectionForward,
+		ReferenceTypeId: uatype.NewFourByteNodeID(0, uatype.NodeIdHasProperty),
+		NodeClassMask:   uint32(uatype.NodeClassVariable),
+		ResultMask:      uint32(uatype.BrowseResultMaskAll),
+	}}, deadline)
+	if err != nil {
+		return nil, nil, err
+	}
+	var nodes []uatype.NodeId
+	var targets []*[]uatype.Argument
+	for _, ref := range refs[0] {
+		nid, ok := ref.NodeId.NodeID()
+		if !ok || ref.BrowseName.NamespaceIndex != 0 {
+			continue
+		}
+		switch ref.BrowseName.Name {
+		case "InputArguments":
+			targets = append(targets, &args.in)
+		case "OutputArguments":
+			targets = append(targets, &args.out)
+		default:
+			continue
+		}
+		nodes = append(nodes, nid)
+	}
+	if len(nodes) > 0 {
+		values, err := c.readValues(nodes, deadline)
+		if err != nil {
+			return nil, nil, err
+		}
+		for i, dv := range values {
+			if dv.StatusCode.IsBad() {
+				return nil, nil, dv.StatusCode
+			}
+			if *targets[i], err = arguments(dv.Value); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+
+	c.methods.Lock()
+	if c.methods.args == nil {
+		c.methods.args = make(map[uatype.NodeKey]methodArguments)
+	}
+	c.methods.args[key] = args
+	c.methods.Unlock()
+	return args.in, args.out, nil
+}
+
+// arguments returns the Argument structures held by v.
+func arguments(v uatype.Variant) ([]uatype.Argument, error) {
+	if v.VariantType == 0 {
+		return nil, nil
+	}
+	if v.VariantType != 22 {
+		return nil, fmt.Errorf("%s: arguments must be ExtensionObjects, got VariantType %d", uatype.ErrVariantType, v.VariantType)
+	}
+	args := make([]uatype.Argument, len(v.ExtensionObject))
+	for i, eo := range v.ExtensionObject {
+		arg, ok := eo.Value.(uatype.Argument)
+		if !ok {
+			return nil, fmt.Errorf("%s: argument %d is not an Argument structure", uatype.ErrVariantType, i)
+		}
+		args[i] = arg
+	}
+	return args, nil
+}
+
+// CallMethod calls the method methodID of the object objectID. The inputs are
+// validated and converted to Variants of the DataType and ValueRank declared
+// by the method's input arguments; see MethodArguments. An input may also be
+// given as a uatype.Variant, which is then only checked against the ValueRank.
+// Inputs of structured DataTypes must be ExtensionObject types of the server,
+// as loaded by LoadTypeDictionaries or LoadDataTypeDefinitions, or registered
+// with uatype.RegisterExtensionObject.
+//
+// If an input can not be converted, an ArgumentError is returned without
+// calling the method. If the server responds with a Bad status code for the
+// call, a MethodError is returned.
+func (c *Client) CallMethod(objectID, methodID uatype.NodeId, inputs []interface{}, deadline time.Time) (*MethodResult, error) {
+	in, _, err := c.MethodArguments(methodID, deadline)
+	if err != nil {
+		return nil, err
+	}
+	if len(inputs) != len(in) {
+		return nil, fmt.Errorf("%s: expected %d input arguments, got %d", uatype.StatusBadArgumentsMissing, len(in), len(inputs))
+	}
+	types := c.extensionObjectTypes()
+	variants := make([]uatype.Variant, len(inputs))
+	for i, arg := range in {
+		if variants[i], err = argumentVariant(types, arg, inputs[i]); err != nil {
+			return nil, ArgumentError{Index: i, Name: arg.Name, Err: err}
+		}
+	}
+
+	resp, err := c.Call(uatype.CallRequest{
+		RequestHeader:     c.requestHeader(),
+		NoOfMethodsToCall: 1,
+		MethodsToCall: []uatype.CallMethodRequest{{
+			ObjectId:           objectID,
+			MethodId:           methodID,
+			NoOfInputArguments: int32(len(variants)),
+			InputArguments:     variants,
+		}},
+	}, deadline)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Results) != 1 {
+		return nil, uatype.StatusBadUnexpectedError
+	}
+	r := resp.Results[0]
+	if r.StatusCode.IsBad() {
+		return nil, MethodError{
+			StatusCode:           r.StatusCode,
+			InputArgumentResults: r.InputArgumentResults,
+			InputArguments:       in,
+		}
+	}
+
+	res := &MethodResult{
+		StatusCode:           r.StatusCode,
+		InputArgumentResults: r.InputArgumentResults,
+		OutputArguments:      make([]interface{}, len(r.OutputArguments)),
+	}
+	for i, v := range r.OutputArguments {
+		if res.OutputArguments[i], err = v.Value(); err != nil {
+			return nil, fmt.Errorf("output argument %d: %s", i, err)
+		}
+	}
+	return res, nil
+}
+
+// argumentVariant converts v to a Variant of the DataType and ValueRank of arg.
+// Values of the ExtensionObject types in types, or of registered types, are
+// wrapped in ExtensionObjects.
+func argumentVariant(types *uatype.ExtensionObjectTypes, arg uatype.Argument, v interface{}) (uatype.Variant, error) {
+	variant, ok := v.(uatype.Variant)
+	if !ok {
+		var err error
+		bt, ok := uatype.BuiltinType(arg.DataType)
+		if !ok || bt == 22 {
+			v = wrapExtensionObjects(types, v)
+		}
+		if ok {
+			variant, err = uatype.NewVariantAs(bt, v)
+		} else {
+			variant, err = uatype.NewVariant(v)
+		}
+		if err != nil {
+			return variant, err
+		}
+	}
+
+	dims, err := variant.Dimensions()
+	if err != nil {
+		return variant, err
+	}
+	n := len(dims)
+	switch rank := int(arg.ValueRank); {
+	case rank == -1 && n != 0:
+		return variant, errors.New("expected a scalar value")
+	case rank == -3 && n > 1:
+		return variant, errors.New("expected a scalar or one-dimensional array")
+	case rank == 0 && n == 0:
+		return variant, errors.New("expected an array")
+	case rank > 0 && n != rank:
+		return variant, fmt.Errorf("expected an array with %d dimensions", rank)
+	}
+	for i, d := range arg.ArrayDimensions {
+		if d != 0 && i < n && dims[i] != int(d) {
+			return variant, fmt.Errorf("expected length %d of dimension %d, got %d", d, i, dims[i])
+		}
+	}
+	return variant, nil
+}
+
+// wrapExtensionObjects returns v with values of the ExtensionObject types in
+// types or of registered types, also in (nested) slices, wrapped in
+// ExtensionObjects. Other values are returned unchanged.
+func wrapExtensionObjects(types *uatype.ExtensionObjectTypes, v interface{}) interface{} {
+	if v == nil {
+		return nil
+	}
+	rv := reflect.ValueOf(v)
+	et := rv.Type()
+	depth := 0
+	for et.Kind() == reflect.Slice {
+		et = et.Elem()
+		depth++
+	}
+	zero := reflect.Zero(et).Interface()
+	ok := false
+	if types != nil {
+		_, ok = types.EncodingID(zero)
+	}
+	if !ok {
+		_, ok = uatype.ExtensionObjectEncodingID(zero)
+	}
+	if !ok {
+		return v
+	}
+	return wrapExtensionObjectValue(rv, depth).Interface()
+}
+
+func wrapExtensionObjectValue(rv reflect.Value, depth int) reflect.Value {
+	if depth == 0 {
+		return reflect.ValueOf(uatype.ExtensionObject{Value: rv.Interface()})
+	}
+	tt := reflect.TypeOf(uatype.ExtensionObject{})
+	for i := 0; i < depth; i++ {
+		tt = reflect.SliceOf(tt)
+	}
+	ret := reflect.MakeSlice(tt, rv.Len(), rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		ret.Index(i).Set(wrapExtensionObjectValue(rv.Index(i), depth-1))
+	}
+	return ret
+}
@@ -0,0 +1,190 @@
+package stack_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/searis/guma/stack"
+	"github.com/searis/guma/stack/uatype"
+	"github.com/searis/guma/stack/uatype/typedict"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	methodObjectID = uatype.NewNumericNodeID(2, 1)
+	methodID       = uatype.NewNumericNodeID(2, 2)
+	inputArgsID    = uatype.NewNumericNodeID(2, 3)
+)
+
+// methodServer serves a method methodID of the object methodObjectID with the
+// input arguments in, and answers calls with call.
+type methodServer struct {
+	t      *testing.T
+	in     []uatype.Argument
+	call   func(r uatype.CallMethodRequest) uatype.CallMethodResult
+	browse int
+	reads  int
+}
+
+func (s *methodServer) handle(req interface{}) interface{} {
+	switch r := req.(type) {
+	case uatype.BrowseRequest:
+		results := make([]uatype.BrowseResult, len(r.NodesToBrowse))
+		for i, desc := range r.NodesToBrowse {
+			var ref uatype.ReferenceDescription
+			switch desc.NodeId.Key() {
+			case methodObjectID.Key():
+				ref = uatype.ReferenceDescription{
+					NodeId:     methodID.Expanded(),
+					BrowseName: uatype.QualifiedName{NamespaceIndex: 2, Name: "Start"},
+				}
+			case methodID.Key():
+				ref = uatype.ReferenceDescription{
+					NodeId:     inputArgsID.Expanded(),
+					BrowseName: uatype.QualifiedName{Name: "InputArguments"},
+				}
+			default:
+				continue
+			}
+			s.browse++
+			results[i] = uatype.BrowseResult{NoOfReferences: 1, References: []uatype.ReferenceDescription{ref}}
+		}
+		return uatype.BrowseResponse{
+			ResponseHeader: responseHeader(),
+			NoOfResults:    int32(len(results)),
+			Results:        results,
+		}
+	case uatype.ReadRequest:
+		if r.NodesToRead[0].NodeId.NamespaceIndex() == 0 {
+			return operationLimits(s.t, r, 0, 0)
+		}
+		s.reads++
+		eos := make([]uatype.ExtensionObject, len(s.in))
+		for i, arg := range s.in {
+			eos[i] = uatype.ExtensionObject{Value: arg}
+		}
+		value, err := uatype.NewVariant(eos)
+		require.NoError(s.t, err, "uatype.NewVariant")
+		return uatype.ReadResponse{
+			ResponseHeader: responseHeader(),
+			NoOfResults:    1,
+			Results:        []uatype.DataValue{{ValueSpecified: true, Value: value}},
+		}
+	case uatype.CallRequest:
+		return uatype.CallResponse{
+			ResponseHeader: responseHeader(),
+			NoOfResults:    1,
+			Results:        []uatype.CallMethodResult{s.call(r.MethodsToCall[0])},
+		}
+	}
+	s.t.Fatalf("unexpected request %T", req)
+	return nil
+}
+
+func TestCallMethod(t *testing.T) {
+	s := &methodServer{t: t, in: []uatype.Argument{
+		{Name: "speed", DataType: uatype.NewTwoByteNodeID(uatype.NodeIdDouble), ValueRank: -1, ArrayDimensions: []uint32{}},
+		{
+			Name:                "values",
+			DataType:            uatype.NewTwoByteNodeID(uatype.NodeIdInt32),
+			ValueRank:           1,
+			NoOfArrayDimensions: 1,
+			ArrayDimensions:     []uint32{3},
+		},
+	}}
+	s.call = func(r uatype.CallMethodRequest) uatype.CallMethodResult {
+		speed, err := r.InputArguments[0].Value()
+		require.NoError(t, err, "InputArguments[0].Value()")
+		if speed.(float64) < 0 {
+			return uatype.CallMethodResult{
+				StatusCode:               uatype.StatusBadInvalidArgument,
+				NoOfInputArgumentResults: 2,
+				InputArgumentResults:     []uatype.StatusCode{uatype.StatusBadOutOfRange, 0},
+			}
+		}
+		out, err := uatype.NewVariant(speed.(float64) * 2)
+		require.NoError(t, err, "uatype.NewVariant")
+		return uatype.CallMethodResult{NoOfOutputArguments: 1, OutputArguments: []uatype.Variant{out}}
+	}
+	c := &stack.Client{Channel: fakeChannel{t: t, handle: s.handle}}
+
+	for i := 0; i < 2; i++ {
+		id, err := c.FindMethod(methodObjectID, uatype.QualifiedName{NamespaceIndex: 2, Name: "Start"}, time.Time{})
+		require.NoError(t, err, "FindMethod")
+		assert.Equal(t, methodID, id, "FindMethod")
+		res, err := c.CallMethod(methodObjectID, id, []interface{}{1.5, []int32{1, 2, 3}}, time.Time{})
+		require.NoError(t, err, "CallMethod")
+		assert.Equal(t, []interface{}{3.0}, res.OutputArguments, "res.OutputArguments")
+	}
+	// The method and its arguments are only looked up once.
+	assert.Equal(t, 2, s.browse, "browsed nodes")
+	assert.Equal(t, 1, s.reads, "argument reads")
+
+	cases := []struct {
+		Name   string
+		Inputs []interface{}
+		Error  string
+	}{
+		{
+			Name:   "Scalar",
+			Inputs: []interface{}{[]float64{1.5}, []int32{1, 2, 3}},
+			Error:  "input argument 0 (speed): expected a scalar value",
+		},
+		{
+			Name:   "Array",
+			Inputs: []interface{}{1.5, int32(1)},
+			Error:  "input argument 1 (values): expected an array with 1 dimensions",
+		},
+		{
+			Name:   "ArrayDimensions",
+			Inputs: []interface{}{1.5, []int32{1, 2}},
+			Error:  "input argument 1 (values): expected length 3 of dimension 0, got 2",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			_, err := c.CallMethod(methodObjectID, methodID, tc.Inputs, time.Time{})
+			require.IsType(t, stack.ArgumentError{}, err, "CallMethod")
+			assert.EqualError(t, err, tc.Error, "CallMethod")
+		})
+	}
+
+	_, err := c.CallMethod(methodObjectID, methodID, []interface{}{-1.5, []int32{1, 2, 3}}, time.Time{})
+	require.IsType(t, stack.MethodError{}, err, "CallMethod(-1.5)")
+	merr := err.(stack.MethodError)
+	assert.Equal(t, uatype.StatusBadInvalidArgument, merr.StatusCode, "merr.StatusCode")
+	assert.Equal(t, []uatype.StatusCode{uatype.StatusBadOutOfRange, 0}, merr.InputArgumentResults, "merr.InputArgumentResults")
+	assert.Equal(t, s.in, merr.InputArguments, "merr.InputArguments")
+}
+
+func TestCallMethodExtensionObject(t *testing.T) {
+	set := typedict.NewSet()
+	d, err := set.Parse(strings.NewReader(pointDict))
+	require.NoError(t, err, "set.Parse")
+	point, err := d.Type("Point")
+	require.NoError(t, err, `d.Type("Point")`)
+	point.Register(uatype.NewNumericNodeID(2, 5001))
+	v, err := point.New(map[string]interface{}{"x": 3, "y": 4})
+	require.NoError(t, err, "point.New")
+	body, err := point.Encode(map[string]interface{}{"x": 3, "y": 4})
+	require.NoError(t, err, "point.Encode")
+
+	s := &methodServer{t: t, in: []uatype.Argument{
+		{Name: "point", DataType: uatype.NewNumericNodeID(2, 5000), ValueRank: -1},
+	}}
+	s.call = func(r uatype.CallMethodRequest) uatype.CallMethodResult {
+		arg := r.InputArguments[0]
+		require.Len(t, arg.ExtensionObject, 1, "InputArguments[0].ExtensionObject")
+		eo := arg.ExtensionObject[0]
+		assert.Equal(t, uint32(5001), eo.TypeId.Numeric.Identifier, "eo.TypeId")
+		assert.Equal(t, body, eo.Body, "eo.Body")
+		return uatype.CallMethodResult{}
+	}
+	c := &stack.Client{Channel: fakeChannel{t: t, handle: s.handle}}
+	require.NoError(t, c.LoadTypeDictionaries(set, time.Time{}), "LoadTypeDictionaries")
+
+	_, err = c.CallMethod(methodObjectID, methodID, []interface{}{v}, time.Time{})
+	require.NoError(t, err, "CallMethod")
+}
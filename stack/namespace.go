@@ -0,0 +1,82 @@
+package stack
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/searis/guma/stack/uatype"
+)
+
+// namespaceTable caches the namespace table of the server for the session
+// identified by token.
+type namespaceTable struct {
+	sync.Mutex
+	token uatype.NodeKey
+	table *uatype.NamespaceTable
+}
+
+// NamespaceTable returns the namespace table of the server, read from the
+// Server NamespaceArray property. The table is read once per session; it is
+// read again if AuthenticationToken changes. Use RefreshNamespaceTable to read
+// it again after reconnecting to a server that may have restarted.
+func (c *Client) NamespaceTable(deadline time.Time) (*uatype.NamespaceTable, error) {
+	c.namespaces.Lock()
+	defer c.namespaces.Unlock()
+	if c.namespaces.table != nil && c.namespaces.token == c.AuthenticationToken().Key() {
+		return c.namespaces.table, nil
+	}
+	return c.readNamespaceTable(deadline)
+}
+
+// RefreshNamespaceTable reads the namespace table of the server, replacing
+// any cached table. See NamespaceTable.
+func (c *Client) RefreshNamespaceTable(deadline time.Time) (*uatype.NamespaceTable, error) {
+	c.namespaces.Lock()
+	defer c.namespaces.Unlock()
+	return c.readNamespaceTable(deadline)
+}
+
+// readNamespaceTable reads and caches the namespace table. The caller must
+// hold the c.namespaces lock.
+func (c *Client) readNamespaceTable(deadline time.Time) (*uatype.NamespaceTable, error) {
+	values, err := c.readValues([]uatype.NodeId{
+		uatype.NewFourByteNodeID(0, uatype.NodeIdServer_NamespaceArray),
+	}, deadline)
+	if err != nil {
+		return nil, err
+	}
+	if values[0].StatusCode.IsBad() {
+		return nil, values[0].StatusCode
+	}
+	v, err := values[0].Value.Value()
+	if err != nil {
+		return nil, err
+	}
+	uris, ok := v.([]string)
+	if !ok {
+		return nil, fmt.Errorf("%s: NamespaceArray is %T, not []string", uatype.StatusBadTypeMismatch, v)
+	}
+
+	c.namespaces.token = c.AuthenticationToken().Key()
+	c.namespaces.table = uatype.NewNamespaceTable(uris)
+	return c.namespaces.table, nil
+}
+
+// ResolveNodeID parses s as an expanded node ID, such as
+// "nsu=http://vendor/;s=Tag1", and returns it as a NodeId with the namespace
+// index used by the server. See uatype.ParseExpandedNodeID.
+func (c *Client) ResolveNodeID(s string, deadline time.Time) (uatype.NodeId, error) {
+	nid, err := uatype.ParseExpandedNodeID(s)
+	if err != nil {
+		return uatype.NodeId{}, err
+	}
+	if !nid.NamespaceURISpecified {
+		return uatype.NewNamespaceTable(nil).NodeID(nid)
+	}
+	t, err := c.NamespaceTable(deadline)
+	if err != nil {
+		return uatype.NodeId{}, err
+	}
+	return t.NodeID(nid)
+}
@@ -0,0 +1,57 @@
+package stack_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/searis/guma/stack"
+	"github.com/searis/guma/stack/uatype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNamespaceTable(t *testing.T) {
+	uris := []string{uatype.DefaultNamespaceURI, "urn:server", "http://vendor/"}
+	reads := 0
+	c := &stack.Client{Channel: fakeChannel{t: t, handle: func(req interface{}) interface{} {
+		r := req.(uatype.ReadRequest)
+		results := make([]uatype.DataValue, len(r.NodesToRead))
+		if r.NodesToRead[0].NodeId.Uint() == uatype.NodeIdServer_NamespaceArray {
+			reads++
+			variant, err := uatype.NewVariant(uris)
+			require.NoError(t, err, "NewVariant")
+			results[0] = uatype.DataValue{ValueSpecified: true, Value: variant}
+		} else {
+			// Operation limits are not published.
+			for i := range results {
+				results[i].StatusCodeSpecified = true
+				results[i].StatusCode = uatype.StatusBadNodeIdUnknown
+			}
+		}
+		return uatype.ReadResponse{
+			ResponseHeader: responseHeader(),
+			NoOfResults:    int32(len(results)),
+			Results:        results,
+		}
+	}}}
+
+	nid, err := c.ResolveNodeID("nsu=http://vendor/;s=Tag1", time.Time{})
+	require.NoError(t, err, "ResolveNodeID")
+	assert.Equal(t, uatype.NewStringNodeID(2, "Tag1"), nid)
+
+	_, err = c.ResolveNodeID("nsu=http://unknown/;s=Tag1", time.Time{})
+	assert.Error(t, err, "unknown namespace")
+	assert.Equal(t, 1, reads, "namespace table reads")
+
+	table, err := c.NamespaceTable(time.Time{})
+	require.NoError(t, err, "NamespaceTable")
+	assert.Equal(t, uris, table.URIs(), "NamespaceTable")
+
+	// A new session reads the table again.
+	c.SetAuthenticationToken(uatype.NewNumericNodeID(0, 1))
+	uris = []string{uatype.DefaultNamespaceURI, "http://vendor/"}
+	nid, err = c.ResolveNodeID("nsu=http://vendor/;s=Tag1", time.Time{})
+	require.NoError(t, err, "ResolveNodeID")
+	assert.Equal(t, uatype.NewStringNodeID(1, "Tag1"), nid)
+	assert.Equal(t, 2, reads, "namespace table reads")
+}
@@ -0,0 +1,170 @@
+package stack
+
+import (
+	"sync"
+	"time"
+
+	"github.com/searis/guma/stack/uatype"
+)
+
+// NodeHandleCache registers nodes with the server using the RegisterNodes
+// service, and replaces their node IDs with the aliases returned by the
+// server in Read and Write requests. Servers may use the aliases to access
+// frequently used nodes more efficiently.
+//
+// Nodes are registered on first use, or in advance with Register. If the
+// session changes, detected by a change of Client.AuthenticationToken, all
+// nodes are registered again with the new session. A NodeHandleCache is safe
+// for concurrent use.
+type NodeHandleCache struct {
+	c *Client
+
+	mu      sync.Mutex
+	token   uatype.NodeKey
+	aliases map[uatype.NodeKey]uatype.NodeId
+	nodes   []uatype.NodeId // registered nodes, in registration order
+}
+
+// NewNodeHandleCache returns an empty node handle cache for c.
+func (c *Client) NewNodeHandleCache() *NodeHandleCache {
+	return &NodeHandleCache{
+		c:       c,
+		token:   c.AuthenticationToken().Key(),
+		aliases: make(map[uatype.NodeKey]uatype.NodeId),
+	}
+}
+
+// Register registers the nodes that are not already registered.
+func (h *NodeHandleCache) Register(nodes []uatype.NodeId, deadline time.Time) error {
+	_, err := h.lookup(nodes, deadline)
+	return err
+}
+
+// lookup returns the aliases of nodes, registering nodes as needed.
+func (h *NodeHandleCache) lookup(nodes []uatype.NodeId, deadline time.Time) ([]uatype.NodeId, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	// Register all nodes again for a new session.
+	if token := h.c.AuthenticationToken().Key(); token != h.token {
+		h.token = token
+		h.aliases = make(map[uatype.NodeKey]uatype.NodeId, len(h.nodes))
+		if err := h.register(h.nodes, deadline); err != nil {
+			h.nodes = nil
+			return nil, err
+		}
+	}
+
+	var missing []uatype.NodeId
+	seen := make(map[uatype.NodeKey]bool)
+	for _, nid := range nodes {
+		key := nid.Key()
+		if _, ok := h.aliases[key]; !ok && !seen[key] {
+			seen[key] = true
+			missing = append(missing, nid)
+		}
+	}
+	if len(missing) > 0 {
+		if err := h.register(missing, deadline); err != nil {
+			return nil, err
+		}
+		h.nodes = append(h.nodes, missing...)
+	}
+
+	aliases := make([]uatype.NodeId, len(nodes))
+	for i, nid := range nodes {
+		aliases[i] = h.aliases[nid.Key()]
+	}
+	return aliases, nil
+}
+
+// register registers nodes with the server, and adds their aliases. The caller
+// must hold h.mu.
+func (h *NodeHandleCache) register(nodes []uatype.NodeId, deadline time.Time) error {
+	if len(nodes) == 0 {
+		return nil
+	}
+	resp, err := h.c.RegisterNodesBatched(uatype.RegisterNodesRequest{
+		RequestHeader:       h.c.requestHeader(),
+		NoOfNodesToRegister: int32(len(nodes)),
+		NodesToRegister:     nodes,
+	}, deadline)
+	if err != nil {
+		return err
+	}
+	for i, nid := range nodes {
+		h.aliases[nid.Key()] = resp.RegisteredNodeIds[i]
+	}
+	return nil
+}
+
+// Read sends req with the node IDs replaced by their aliases, registering
+// nodes as needed. The request is batched according to the operation limits
+// of the server; see ReadBatched.
+func (h *NodeHandleCache) Read(req uatype.ReadRequest, deadline time.Time) (*uatype.ReadResponse, error) {
+	nodes := make([]uatype.NodeId, len(req.NodesToRead))
+	for i, r := range req.NodesToRead {
+		nodes[i] = r.NodeId
+	}
+	aliases, err := h.lookup(nodes, deadline)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]uatype.ReadValueId, len(req.NodesToRead))
+	copy(ids, req.NodesToRead)
+	for i := range ids {
+		ids[i].NodeId = aliases[i]
+	}
+	req.NoOfNodesToRead = int32(len(ids))
+	req.NodesToRead = ids
+	return h.c.ReadBatched(req, deadline)
+}
+
+// Write sends req with the node IDs replaced by their aliases, registering
+// nodes as needed. The request is batched according to the operation limits
+// of the server; see WriteBatched.
+func (h *NodeHandleCache) Write(req uatype.WriteRequest, deadline time.Time) (*uatype.WriteResponse, error) {
+	nodes := make([]uatype.NodeId, len(req.NodesToWrite))
+	for i, w := range req.NodesToWrite {
+		nodes[i] = w.NodeId
+	}
+	aliases, err := h.lookup(nodes, deadline)
+	if err != nil {
+		return nil, err
+	}
+	values := make([]uatype.WriteValue, len(req.NodesToWrite))
+	copy(values, req.NodesToWrite)
+	for i := range values {
+		values[i].NodeId = aliases[i]
+	}
+	req.NoOfNodesToWrite = int32(len(values))
+	req.NodesToWrite = values
+	return h.c.WriteBatched(req, deadline)
+}
+
+// Close unregisters all nodes registered with the current session, and
+// empties the cache. The cache may still be used after Close, registering
+// nodes again as needed.
+func (h *NodeHandleCache) Close(deadline time.Time) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	var aliases []uatype.NodeId
+	if h.token == h.c.AuthenticationToken().Key() {
+		aliases = make([]uatype.NodeId, 0, len(h.aliases))
+		for _, nid := range h.nodes {
+			aliases = append(aliases, h.aliases[nid.Key()])
+		}
+	}
+	h.aliases = make(map[uatype.NodeKey]uatype.NodeId)
+	h.nodes = nil
+	if len(aliases) == 0 {
+		return nil
+	}
+	_, err := h.c.UnregisterNodesBatched(uatype.UnregisterNodesRequest{
+		RequestHeader:         h.c.requestHeader(),
+		NoOfNodesToUnregister: int32(len(aliases)),
+		NodesToUnregister:     aliases,
+	}, deadline)
+	return err
+}
@@ -0,0 +1,94 @@
+package stack_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/searis/guma/stack"
+	"github.com/searis/guma/stack/uatype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNodeHandleCache(t *testing.T) {
+	const aliasNamespace = 9
+	var registered, unregistered []uatype.NodeId
+	var read []uatype.NodeId
+	c := &stack.Client{Channel: fakeChannel{t: t, handle: func(req interface{}) interface{} {
+		switch r := req.(type) {
+		case uatype.RegisterNodesRequest:
+			aliases := make([]uatype.NodeId, len(r.NodesToRegister))
+			for i, nid := range r.NodesToRegister {
+				registered = append(registered, nid)
+				aliases[i] = uatype.NewNumericNodeID(aliasNamespace, uint32(len(registered)))
+			}
+			return uatype.RegisterNodesResponse{
+				ResponseHeader:        responseHeader(),
+				NoOfRegisteredNodeIds: int32(len(aliases)),
+				RegisteredNodeIds:     aliases,
+			}
+		case uatype.UnregisterNodesRequest:
+			unregistered = append(unregistered, r.NodesToUnregister...)
+			return uatype.UnregisterNodesResponse{ResponseHeader: responseHeader()}
+		case uatype.ReadRequest:
+			results := make([]uatype.DataValue, len(r.NodesToRead))
+			for i, id := range r.NodesToRead {
+				if id.NodeId.NamespaceIndex() == 0 {
+					// Operation limits are not published.
+					results[i].StatusCodeSpecified = true
+					results[i].StatusCode = uatype.StatusBadNodeIdUnknown
+					continue
+				}
+				read = append(read, id.NodeId)
+			}
+			return uatype.ReadResponse{
+				ResponseHeader: responseHeader(),
+				NoOfResults:    int32(len(results)),
+				Results:        results,
+			}
+		}
+		t.Fatalf("unexpected request %T", req)
+		return nil
+	}}}
+
+	speed := uatype.NewStringNodeID(2, "Pump.Speed")
+	level := uatype.NewStringNodeID(2, "Tank.Level")
+	cache := c.NewNodeHandleCache()
+	readNodes := func() {
+		read = nil
+		resp, err := cache.Read(uatype.ReadRequest{
+			NoOfNodesToRead: 3,
+			NodesToRead: []uatype.ReadValueId{
+				{NodeId: speed, AttributeId: uint32(uatype.AttrTypeValue)},
+				{NodeId: level, AttributeId: uint32(uatype.AttrTypeValue)},
+				{NodeId: speed, AttributeId: uint32(uatype.AttrTypeValue)},
+			},
+		}, time.Time{})
+		require.NoError(t, err, "Read")
+		require.Len(t, resp.Results, 3, "results")
+	}
+
+	readNodes()
+	assert.Equal(t, []uatype.NodeId{speed, level}, registered, "registered nodes")
+	assert.Equal(t, []uatype.NodeId{
+		uatype.NewNumericNodeID(aliasNamespace, 1),
+		uatype.NewNumericNodeID(aliasNamespace, 2),
+		uatype.NewNumericNodeID(aliasNamespace, 1),
+	}, read, "read aliases")
+
+	// Nodes are only registered once per session.
+	readNodes()
+	assert.Len(t, registered, 2, "registered nodes")
+
+	// A new session registers the nodes again.
+	c.SetAuthenticationToken(uatype.NewNumericNodeID(0, 1))
+	readNodes()
+	assert.Equal(t, []uatype.NodeId{speed, level, speed, level}, registered, "registered nodes")
+	assert.Equal(t, uatype.NewNumericNodeID(aliasNamespace, 3), read[0], "read alias")
+
+	require.NoError(t, cache.Close(time.Time{}), "Close")
+	assert.Equal(t, []uatype.NodeId{
+		uatype.NewNumericNodeID(aliasNamespace, 3),
+		uatype.NewNumericNodeID(aliasNamespace, 4),
+	}, unregistered, "unregistered aliases")
+}
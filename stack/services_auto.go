@@ -4,10 +4,10 @@ package stack
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"time"
 
-	"github.com/searis/guma/stack/encoding/binary"
 	"github.com/searis/guma/stack/transport"
 	"github.com/searis/guma/stack/uatype"
 )
@@ -18,7 +18,7 @@ import (
 func (c *Client) CreateSession(req uatype.CreateSessionRequest, deadline time.Time) (*uatype.CreateSessionResponse, error) {
 	var buf bytes.Buffer
 
-	if err := binary.NewEncoder(&buf).Encode(req); err != nil {
+	if err := c.newEncoder(&buf).Encode(req); err != nil {
 		return nil, err
 	}
 
@@ -29,16 +29,17 @@ func (c *Client) CreateSession(req uatype.CreateSessionRequest, deadline time.Ti
 	if err != nil {
 		return nil, err
 	}
+	defer resp.Close()
 	switch resp.NodeID.Uint() {
 	case uatype.NodeIdCreateSessionResponse_Encoding_DefaultBinary:
 		res := &uatype.CreateSessionResponse{}
-		if err := binary.NewDecoder(resp.Body).Decode(res); err != nil {
+		if err := c.newDecoder(resp).Decode(res); err != nil {
 			return res, err
 		}
 		return res, nil
 	case uatype.NodeIdServiceFault_Encoding_DefaultBinary:
 		fault := uatype.ServiceFault{}
-		if err := binary.NewDecoder(resp.Body).Decode(&fault); err != nil {
+		if err := c.newDecoder(resp).Decode(&fault); err != nil {
 			return nil, err
 		}
 		return nil, fault
@@ -46,13 +47,50 @@ func (c *Client) CreateSession(req uatype.CreateSessionRequest, deadline time.Ti
 	return nil, fmt.Errorf("Unexpected NodeID: %d %s", resp.NodeID.Uint(), resp.NodeID.DisplayName())
 }
 
+// CreateSessionContext sends a CreateSession request to the server, and waits for a
+// response or for ctx to be done. The deadline of ctx is sent as the timeout
+// hint of the request. If ctx is done first, a Cancel request is sent for the
+// request handle.
+func (c *Client) CreateSessionContext(ctx context.Context, req uatype.CreateSessionRequest) (*uatype.CreateSessionResponse, error) {
+	c.contextHeader(ctx, &req.RequestHeader)
+	var buf bytes.Buffer
+
+	if err := c.newEncoder(&buf).Encode(req); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.sendContext(ctx, req.RequestHeader, transport.Request{
+		NodeID: uatype.NewFourByteNodeID(0, uatype.NodeIdCreateSessionRequest_Encoding_DefaultBinary).Expanded(),
+		Body:   &buf,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Close()
+	switch resp.NodeID.Uint() {
+	case uatype.NodeIdCreateSessionResponse_Encoding_DefaultBinary:
+		res := &uatype.CreateSessionResponse{}
+		if err := c.newDecoder(resp).Decode(res); err != nil {
+			return res, c.contextError(ctx, req.RequestHeader, err)
+		}
+		return res, nil
+	case uatype.NodeIdServiceFault_Encoding_DefaultBinary:
+		fault := uatype.ServiceFault{}
+		if err := c.newDecoder(resp).Decode(&fault); err != nil {
+			return nil, c.contextError(ctx, req.RequestHeader, err)
+		}
+		return nil, fault
+	}
+	return nil, fmt.Errorf("Unexpected NodeID: %d %s", resp.NodeID.Uint(), resp.NodeID.DisplayName())
+}
+
 // ActivateSession sends a ActivateSession request to the server, and waits for a
 // response or timeout. To send and receive with no timeout use the zero time
 // as deadline.
 func (c *Client) ActivateSession(req uatype.ActivateSessionRequest, deadline time.Time) (*uatype.ActivateSessionResponse, error) {
 	var buf bytes.Buffer
 
-	if err := binary.NewEncoder(&buf).Encode(req); err != nil {
+	if err := c.newEncoder(&buf).Encode(req); err != nil {
 		return nil, err
 	}
 
@@ -63,16 +101,17 @@ func (c *Client) ActivateSession(req uatype.ActivateSessionRequest, deadline tim
 	if err != nil {
 		return nil, err
 	}
+	defer resp.Close()
 	switch resp.NodeID.Uint() {
 	case uatype.NodeIdActivateSessionResponse_Encoding_DefaultBinary:
 		res := &uatype.ActivateSessionResponse{}
-		if err := binary.NewDecoder(resp.Body).Decode(res); err != nil {
+		if err := c.newDecoder(resp).Decode(res); err != nil {
 			return res, err
 		}
 		return res, nil
 	case uatype.NodeIdServiceFault_Encoding_DefaultBinary:
 		fault := uatype.ServiceFault{}
-		if err := binary.NewDecoder(resp.Body).Decode(&fault); err != nil {
+		if err := c.newDecoder(resp).Decode(&fault); err != nil {
 			return nil, err
 		}
 		return nil, fault
@@ -80,13 +119,50 @@ func (c *Client) ActivateSession(req uatype.ActivateSessionRequest, deadline tim
 	return nil, fmt.Errorf("Unexpected NodeID: %d %s", resp.NodeID.Uint(), resp.NodeID.DisplayName())
 }
 
+// ActivateSessionContext sends a ActivateSession request to the server, and waits for a
+// response or for ctx to be done. The deadline of ctx is sent as the timeout
+// hint of the request. If ctx is done first, a Cancel request is sent for the
+// request handle.
+func (c *Client) ActivateSessionContext(ctx context.Context, req uatype.ActivateSessionRequest) (*uatype.ActivateSessionResponse, error) {
+	c.contextHeader(ctx, &req.RequestHeader)
+	var buf bytes.Buffer
+
+	if err := c.newEncoder(&buf).Encode(req); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.sendContext(ctx, req.RequestHeader, transport.Request{
+		NodeID: uatype.NewFourByteNodeID(0, uatype.NodeIdActivateSessionRequest_Encoding_DefaultBinary).Expanded(),
+		Body:   &buf,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Close()
+	switch resp.NodeID.Uint() {
+	case uatype.NodeIdActivateSessionResponse_Encoding_DefaultBinary:
+		res := &uatype.ActivateSessionResponse{}
+		if err := c.newDecoder(resp).Decode(res); err != nil {
+			return res, c.contextError(ctx, req.RequestHeader, err)
+		}
+		return res, nil
+	case uatype.NodeIdServiceFault_Encoding_DefaultBinary:
+		fault := uatype.ServiceFault{}
+		if err := c.newDecoder(resp).Decode(&fault); err != nil {
+			return nil, c.contextError(ctx, req.RequestHeader, err)
+		}
+		return nil, fault
+	}
+	return nil, fmt.Errorf("Unexpected NodeID: %d %s", resp.NodeID.Uint(), resp.NodeID.DisplayName())
+}
+
 // CloseSession sends a CloseSession request to the server, and waits for a
 // response or timeout. To send and receive with no timeout use the zero time
 // as deadline.
 func (c *Client) CloseSession(req uatype.CloseSessionRequest, deadline time.Time) (*uatype.CloseSessionResponse, error) {
 	var buf bytes.Buffer
 
-	if err := binary.NewEncoder(&buf).Encode(req); err != nil {
+	if err := c.newEncoder(&buf).Encode(req); err != nil {
 		return nil, err
 	}
 
@@ -97,16 +173,17 @@ func (c *Client) CloseSession(req uatype.CloseSessionRequest, deadline time.Time
 	if err != nil {
 		return nil, err
 	}
+	defer resp.Close()
 	switch resp.NodeID.Uint() {
 	case uatype.NodeIdCloseSessionResponse_Encoding_DefaultBinary:
 		res := &uatype.CloseSessionResponse{}
-		if err := binary.NewDecoder(resp.Body).Decode(res); err != nil {
+		if err := c.newDecoder(resp).Decode(res); err != nil {
 			return res, err
 		}
 		return res, nil
 	case uatype.NodeIdServiceFault_Encoding_DefaultBinary:
 		fault := uatype.ServiceFault{}
-		if err := binary.NewDecoder(resp.Body).Decode(&fault); err != nil {
+		if err := c.newDecoder(resp).Decode(&fault); err != nil {
 			return nil, err
 		}
 		return nil, fault
@@ -114,13 +191,50 @@ func (c *Client) CloseSession(req uatype.CloseSessionRequest, deadline time.Time
 	return nil, fmt.Errorf("Unexpected NodeID: %d %s", resp.NodeID.Uint(), resp.NodeID.DisplayName())
 }
 
+// CloseSessionContext sends a CloseSession request to the server, and waits for a
+// response or for ctx to be done. The deadline of ctx is sent as the timeout
+// hint of the request. If ctx is done first, a Cancel request is sent for the
+// request handle.
+func (c *Client) CloseSessionContext(ctx context.Context, req uatype.CloseSessionRequest) (*uatype.CloseSessionResponse, error) {
+	c.contextHeader(ctx, &req.RequestHeader)
+	var buf bytes.Buffer
+
+	if err := c.newEncoder(&buf).Encode(req); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.sendContext(ctx, req.RequestHeader, transport.Request{
+		NodeID: uatype.NewFourByteNodeID(0, uatype.NodeIdCloseSessionRequest_Encoding_DefaultBinary).Expanded(),
+		Body:   &buf,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Close()
+	switch resp.NodeID.Uint() {
+	case uatype.NodeIdCloseSessionResponse_Encoding_DefaultBinary:
+		res := &uatype.CloseSessionResponse{}
+		if err := c.newDecoder(resp).Decode(res); err != nil {
+			return res, c.contextError(ctx, req.RequestHeader, err)
+		}
+		return res, nil
+	case uatype.NodeIdServiceFault_Encoding_DefaultBinary:
+		fault := uatype.ServiceFault{}
+		if err := c.newDecoder(resp).Decode(&fault); err != nil {
+			return nil, c.contextError(ctx, req.RequestHeader, err)
+		}
+		return nil, fault
+	}
+	return nil, fmt.Errorf("Unexpected NodeID: %d %s", resp.NodeID.Uint(), resp.NodeID.DisplayName())
+}
+
 // Cancel sends a Cancel request to the server, and waits for a
 // response or timeout. To send and receive with no timeout use the zero time
 // as deadline.
 func (c *Client) Cancel(req uatype.CancelRequest, deadline time.Time) (*uatype.CancelResponse, error) {
 	var buf bytes.Buffer
 
-	if err := binary.NewEncoder(&buf).Encode(req); err != nil {
+	if err := c.newEncoder(&buf).Encode(req); err != nil {
 		return nil, err
 	}
 
@@ -131,16 +245,17 @@ func (c *Client) Cancel(req uatype.CancelRequest, deadline time.Time) (*uatype.C
 	if err != nil {
 		return nil, err
 	}
+	defer resp.Close()
 	switch resp.NodeID.Uint() {
 	case uatype.NodeIdCancelResponse_Encoding_DefaultBinary:
 		res := &uatype.CancelResponse{}
-		if err := binary.NewDecoder(resp.Body).Decode(res); err != nil {
+		if err := c.newDecoder(resp).Decode(res); err != nil {
 			return res, err
 		}
 		return res, nil
 	case uatype.NodeIdServiceFault_Encoding_DefaultBinary:
 		fault := uatype.ServiceFault{}
-		if err := binary.NewDecoder(resp.Body).Decode(&fault); err != nil {
+		if err := c.newDecoder(resp).Decode(&fault); err != nil {
 			return nil, err
 		}
 		return nil, fault
@@ -148,13 +263,50 @@ func (c *Client) Cancel(req uatype.CancelRequest, deadline time.Time) (*uatype.C
 	return nil, fmt.Errorf("Unexpected NodeID: %d %s", resp.NodeID.Uint(), resp.NodeID.DisplayName())
 }
 
+// CancelContext sends a Cancel request to the server, and waits for a
+// response or for ctx to be done. The deadline of ctx is sent as the timeout
+// hint of the request. If ctx is done first, a Cancel request is sent for the
+// request handle.
+func (c *Client) CancelContext(ctx context.Context, req uatype.CancelRequest) (*uatype.CancelResponse, error) {
+	c.contextHeader(ctx, &req.RequestHeader)
+	var buf bytes.Buffer
+
+	if err := c.newEncoder(&buf).Encode(req); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.sendContext(ctx, req.RequestHeader, transport.Request{
+		NodeID: uatype.NewFourByteNodeID(0, uatype.NodeIdCancelRequest_Encoding_DefaultBinary).Expanded(),
+		Body:   &buf,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Close()
+	switch resp.NodeID.Uint() {
+	case uatype.NodeIdCancelResponse_Encoding_DefaultBinary:
+		res := &uatype.CancelResponse{}
+		if err := c.newDecoder(resp).Decode(res); err != nil {
+			return res, c.contextError(ctx, req.RequestHeader, err)
+		}
+		return res, nil
+	case uatype.NodeIdServiceFault_Encoding_DefaultBinary:
+		fault := uatype.ServiceFault{}
+		if err := c.newDecoder(resp).Decode(&fault); err != nil {
+			return nil, c.contextError(ctx, req.RequestHeader, err)
+		}
+		return nil, fault
+	}
+	return nil, fmt.Errorf("Unexpected NodeID: %d %s", resp.NodeID.Uint(), resp.NodeID.DisplayName())
+}
+
 // AddNodes sends a AddNodes request to the server, and waits for a
 // response or timeout. To send and receive with no timeout use the zero time
 // as deadline.
 func (c *Client) AddNodes(req uatype.AddNodesRequest, deadline time.Time) (*uatype.AddNodesResponse, error) {
 	var buf bytes.Buffer
 
-	if err := binary.NewEncoder(&buf).Encode(req); err != nil {
+	if err := c.newEncoder(&buf).Encode(req); err != nil {
 		return nil, err
 	}
 
@@ -165,16 +317,17 @@ func (c *Client) AddNodes(req uatype.AddNodesRequest, deadline time.Time) (*uaty
 	if err != nil {
 		return nil, err
 	}
+	defer resp.Close()
 	switch resp.NodeID.Uint() {
 	case uatype.NodeIdAddNodesResponse_Encoding_DefaultBinary:
 		res := &uatype.AddNodesResponse{}
-		if err := binary.NewDecoder(resp.Body).Decode(res); err != nil {
+		if err := c.newDecoder(resp).Decode(res); err != nil {
 			return res, err
 		}
 		return res, nil
 	case uatype.NodeIdServiceFault_Encoding_DefaultBinary:
 		fault := uatype.ServiceFault{}
-		if err := binary.NewDecoder(resp.Body).Decode(&fault); err != nil {
+		if err := c.newDecoder(resp).Decode(&fault); err != nil {
 			return nil, err
 		}
 		return nil, fault
@@ -182,13 +335,50 @@ func (c *Client) AddNodes(req uatype.AddNodesRequest, deadline time.Time) (*uaty
 	return nil, fmt.Errorf("Unexpected NodeID: %d %s", resp.NodeID.Uint(), resp.NodeID.DisplayName())
 }
 
+// AddNodesContext sends a AddNodes request to the server, and waits for a
+// response or for ctx to be done. The deadline of ctx is sent as the timeout
+// hint of the request. If ctx is done first, a Cancel request is sent for the
+// request handle.
+func (c *Client) AddNodesContext(ctx context.Context, req uatype.AddNodesRequest) (*uatype.AddNodesResponse, error) {
+	c.contextHeader(ctx, &req.RequestHeader)
+	var buf bytes.Buffer
+
+	if err := c.newEncoder(&buf).Encode(req); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.sendContext(ctx, req.RequestHeader, transport.Request{
+		NodeID: uatype.NewFourByteNodeID(0, uatype.NodeIdAddNodesRequest_Encoding_DefaultBinary).Expanded(),
+		Body:   &buf,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Close()
+	switch resp.NodeID.Uint() {
+	case uatype.NodeIdAddNodesResponse_Encoding_DefaultBinary:
+		res := &uatype.AddNodesResponse{}
+		if err := c.newDecoder(resp).Decode(res); err != nil {
+			return res, c.contextError(ctx, req.RequestHeader, err)
+		}
+		return res, nil
+	case uatype.NodeIdServiceFault_Encoding_DefaultBinary:
+		fault := uatype.ServiceFault{}
+		if err := c.newDecoder(resp).Decode(&fault); err != nil {
+			return nil, c.contextError(ctx, req.RequestHeader, err)
+		}
+		return nil, fault
+	}
+	return nil, fmt.Errorf("Unexpected NodeID: %d %s", resp.NodeID.Uint(), resp.NodeID.DisplayName())
+}
+
 // AddReferences sends a AddReferences request to the server, and waits for a
 // response or timeout. To send and receive with no timeout use the zero time
 // as deadline.
 func (c *Client) AddReferences(req uatype.AddReferencesRequest, deadline time.Time) (*uatype.AddReferencesResponse, error) {
 	var buf bytes.Buffer
 
-	if err := binary.NewEncoder(&buf).Encode(req); err != nil {
+	if err := c.newEncoder(&buf).Encode(req); err != nil {
 		return nil, err
 	}
 
@@ -199,16 +389,17 @@ func (c *Client) AddReferences(req uatype.AddReferencesRequest, deadline time.Ti
 	if err != nil {
 		return nil, err
 	}
+	defer resp.Close()
 	switch resp.NodeID.Uint() {
 	case uatype.NodeIdAddReferencesResponse_Encoding_DefaultBinary:
 		res := &uatype.AddReferencesResponse{}
-		if err := binary.NewDecoder(resp.Body).Decode(res); err != nil {
+		if err := c.newDecoder(resp).Decode(res); err != nil {
 			return res, err
 		}
 		return res, nil
 	case uatype.NodeIdServiceFault_Encoding_DefaultBinary:
 		fault := uatype.ServiceFault{}
-		if err := binary.NewDecoder(resp.Body).Decode(&fault); err != nil {
+		if err := c.newDecoder(resp).Decode(&fault); err != nil {
 			return nil, err
 		}
 		return nil, fault
@@ -216,13 +407,50 @@ func (c *Client) AddReferences(req uatype.AddReferencesRequest, deadline time.Ti
 	return nil, fmt.Errorf("Unexpected NodeID: %d %s", resp.NodeID.Uint(), resp.NodeID.DisplayName())
 }
 
+// AddReferencesContext sends a AddReferences request to the server, and waits for a
+// response or for ctx to be done. The deadline of ctx is sent as the timeout
+// hint of the request. If ctx is done first, a Cancel request is sent for the
+// request handle.
+func (c *Client) AddReferencesContext(ctx context.Context, req uatype.AddReferencesRequest) (*uatype.AddReferencesResponse, error) {
+	c.contextHeader(ctx, &req.RequestHeader)
+	var buf bytes.Buffer
+
+	if err := c.newEncoder(&buf).Encode(req); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.sendContext(ctx, req.RequestHeader, transport.Request{
+		NodeID: uatype.NewFourByteNodeID(0, uatype.NodeIdAddReferencesRequest_Encoding_DefaultBinary).Expanded(),
+		Body:   &buf,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Close()
+	switch resp.NodeID.Uint() {
+	case uatype.NodeIdAddReferencesResponse_Encoding_DefaultBinary:
+		res := &uatype.AddReferencesResponse{}
+		if err := c.newDecoder(resp).Decode(res); err != nil {
+			return res, c.contextError(ctx, req.RequestHeader, err)
+		}
+		return res, nil
+	case uatype.NodeIdServiceFault_Encoding_DefaultBinary:
+		fault := uatype.ServiceFault{}
+		if err := c.newDecoder(resp).Decode(&fault); err != nil {
+			return nil, c.contextError(ctx, req.RequestHeader, err)
+		}
+		return nil, fault
+	}
+	return nil, fmt.Errorf("Unexpected NodeID: %d %s", resp.NodeID.Uint(), resp.NodeID.DisplayName())
+}
+
 // DeleteNodes sends a DeleteNodes request to the server, and waits for a
 // response or timeout. To send and receive with no timeout use the zero time
 // as deadline.
 func (c *Client) DeleteNodes(req uatype.DeleteNodesRequest, deadline time.Time) (*uatype.DeleteNodesResponse, error) {
 	var buf bytes.Buffer
 
-	if err := binary.NewEncoder(&buf).Encode(req); err != nil {
+	if err := c.newEncoder(&buf).Encode(req); err != nil {
 		return nil, err
 	}
 
@@ -233,16 +461,17 @@ func (c *Client) DeleteNodes(req uatype.DeleteNodesRequest, deadline time.Time)
 	if err != nil {
 		return nil, err
 	}
+	defer resp.Close()
 	switch resp.NodeID.Uint() {
 	case uatype.NodeIdDeleteNodesResponse_Encoding_DefaultBinary:
 		res := &uatype.DeleteNodesResponse{}
-		if err := binary.NewDecoder(resp.Body).Decode(res); err != nil {
+		if err := c.newDecoder(resp).Decode(res); err != nil {
 			return res, err
 		}
 		return res, nil
 	case uatype.NodeIdServiceFault_Encoding_DefaultBinary:
 		fault := uatype.ServiceFault{}
-		if err := binary.NewDecoder(resp.Body).Decode(&fault); err != nil {
+		if err := c.newDecoder(resp).Decode(&fault); err != nil {
 			return nil, err
 		}
 		return nil, fault
@@ -250,13 +479,50 @@ func (c *Client) DeleteNodes(req uatype.DeleteNodesRequest, deadline time.Time)
 	return nil, fmt.Errorf("Unexpected NodeID: %d %s", resp.NodeID.Uint(), resp.NodeID.DisplayName())
 }
 
+// DeleteNodesContext sends a DeleteNodes request to the server, and waits for a
+// response or for ctx to be done. The deadline of ctx is sent as the timeout
+// hint of the request. If ctx is done first, a Cancel request is sent for the
+// request handle.
+func (c *Client) DeleteNodesContext(ctx context.Context, req uatype.DeleteNodesRequest) (*uatype.DeleteNodesResponse, error) {
+	c.contextHeader(ctx, &req.RequestHeader)
+	var buf bytes.Buffer
+
+	if err := c.newEncoder(&buf).Encode(req); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.sendContext(ctx, req.RequestHeader, transport.Request{
+		NodeID: uatype.NewFourByteNodeID(0, uatype.NodeIdDeleteNodesRequest_Encoding_DefaultBinary).Expanded(),
+		Body:   &buf,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Close()
+	switch resp.NodeID.Uint() {
+	case uatype.NodeIdDeleteNodesResponse_Encoding_DefaultBinary:
+		res := &uatype.DeleteNodesResponse{}
+		if err := c.newDecoder(resp).Decode(res); err != nil {
+			return res, c.contextError(ctx, req.RequestHeader, err)
+		}
+		return res, nil
+	case uatype.NodeIdServiceFault_Encoding_DefaultBinary:
+		fault := uatype.ServiceFault{}
+		if err := c.newDecoder(resp).Decode(&fault); err != nil {
+			return nil, c.contextError(ctx, req.RequestHeader, err)
+		}
+		return nil, fault
+	}
+	return nil, fmt.Errorf("Unexpected NodeID: %d %s", resp.NodeID.Uint(), resp.NodeID.DisplayName())
+}
+
 // DeleteReferences sends a DeleteReferences request to the server, and waits for a
 // response or timeout. To send and receive with no timeout use the zero time
 // as deadline.
 func (c *Client) DeleteReferences(req uatype.DeleteReferencesRequest, deadline time.Time) (*uatype.DeleteReferencesResponse, error) {
 	var buf bytes.Buffer
 
-	if err := binary.NewEncoder(&buf).Encode(req); err != nil {
+	if err := c.newEncoder(&buf).Encode(req); err != nil {
 		return nil, err
 	}
 
@@ -267,16 +533,17 @@ func (c *Client) DeleteReferences(req uatype.DeleteReferencesRequest, deadline t
 	if err != nil {
 		return nil, err
 	}
+	defer resp.Close()
 	switch resp.NodeID.Uint() {
 	case uatype.NodeIdDeleteReferencesResponse_Encoding_DefaultBinary:
 		res := &uatype.DeleteReferencesResponse{}
-		if err := binary.NewDecoder(resp.Body).Decode(res); err != nil {
+		if err := c.newDecoder(resp).Decode(res); err != nil {
 			return res, err
 		}
 		return res, nil
 	case uatype.NodeIdServiceFault_Encoding_DefaultBinary:
 		fault := uatype.ServiceFault{}
-		if err := binary.NewDecoder(resp.Body).Decode(&fault); err != nil {
+		if err := c.newDecoder(resp).Decode(&fault); err != nil {
 			return nil, err
 		}
 		return nil, fault
@@ -284,13 +551,50 @@ func (c *Client) DeleteReferences(req uatype.DeleteReferencesRequest, deadline t
 	return nil, fmt.Errorf("Unexpected NodeID: %d %s", resp.NodeID.Uint(), resp.NodeID.DisplayName())
 }
 
+// DeleteReferencesContext sends a DeleteReferences request to the server, and waits for a
+// response or for ctx to be done. The deadline of ctx is sent as the timeout
+// hint of the request. If ctx is done first, a Cancel request is sent for the
+// request handle.
+func (c *Client) DeleteReferencesContext(ctx context.Context, req uatype.DeleteReferencesRequest) (*uatype.DeleteReferencesResponse, error) {
+	c.contextHeader(ctx, &req.RequestHeader)
+	var buf bytes.Buffer
+
+	if err := c.newEncoder(&buf).Encode(req); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.sendContext(ctx, req.RequestHeader, transport.Request{
+		NodeID: uatype.NewFourByteNodeID(0, uatype.NodeIdDeleteReferencesRequest_Encoding_DefaultBinary).Expanded(),
+		Body:   &buf,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Close()
+	switch resp.NodeID.Uint() {
+	case uatype.NodeIdDeleteReferencesResponse_Encoding_DefaultBinary:
+		res := &uatype.DeleteReferencesResponse{}
+		if err := c.newDecoder(resp).Decode(res); err != nil {
+			return res, c.contextError(ctx, req.RequestHeader, err)
+		}
+		return res, nil
+	case uatype.NodeIdServiceFault_Encoding_DefaultBinary:
+		fault := uatype.ServiceFault{}
+		if err := c.newDecoder(resp).Decode(&fault); err != nil {
+			return nil, c.contextError(ctx, req.RequestHeader, err)
+		}
+		return nil, fault
+	}
+	return nil, fmt.Errorf("Unexpected NodeID: %d %s", resp.NodeID.Uint(), resp.NodeID.DisplayName())
+}
+
 // Browse sends a Browse request to the server, and waits for a
 // response or timeout. To send and receive with no timeout use the zero time
 // as deadline.
 func (c *Client) Browse(req uatype.BrowseRequest, deadline time.Time) (*uatype.BrowseResponse, error) {
 	var buf bytes.Buffer
 
-	if err := binary.NewEncoder(&buf).Encode(req); err != nil {
+	if err := c.newEncoder(&buf).Encode(req); err != nil {
 		return nil, err
 	}
 
@@ -301,16 +605,17 @@ func (c *Client) Browse(req uatype.BrowseRequest, deadline time.Time) (*uatype.B
 	if err != nil {
 		return nil, err
 	}
+	defer resp.Close()
 	switch resp.NodeID.Uint() {
 	case uatype.NodeIdBrowseResponse_Encoding_DefaultBinary:
 		res := &uatype.BrowseResponse{}
-		if err := binary.NewDecoder(resp.Body).Decode(res); err != nil {
+		if err := c.newDecoder(resp).Decode(res); err != nil {
 			return res, err
 		}
 		return res, nil
 	case uatype.NodeIdServiceFault_Encoding_DefaultBinary:
 		fault := uatype.ServiceFault{}
-		if err := binary.NewDecoder(resp.Body).Decode(&fault); err != nil {
+		if err := c.newDecoder(resp).Decode(&fault); err != nil {
 			return nil, err
 		}
 		return nil, fault
@@ -318,67 +623,71 @@ func (c *Client) Browse(req uatype.BrowseRequest, deadline time.Time) (*uatype.B
 	return nil, fmt.Errorf("Unexpected NodeID: %d %s", resp.NodeID.Uint(), resp.NodeID.DisplayName())
 }
 
-// BrowseNext sends a BrowseNext request to the server, and waits for a
-// response or timeout. To send and receive with no timeout use the zero time
-// as deadline.
-func (c *Client) BrowseNext(req uatype.BrowseNextRequest, deadline time.Time) (*uatype.BrowseNextResponse, error) {
+// BrowseContext sends a Browse request to the server, and waits for a
+// response or for ctx to be done. The deadline of ctx is sent as the timeout
+// hint of the request. If ctx is done first, a Cancel request is sent for the
+// request handle.
+func (c *Client) BrowseContext(ctx context.Context, req uatype.BrowseRequest) (*uatype.BrowseResponse, error) {
+	c.contextHeader(ctx, &req.RequestHeader)
 	var buf bytes.Buffer
 
-	if err := binary.NewEncoder(&buf).Encode(req); err != nil {
+	if err := c.newEncoder(&buf).Encode(req); err != nil {
 		return nil, err
 	}
 
-	resp, err := c.Channel.Send(transport.Request{
-		NodeID: uatype.NewFourByteNodeID(0, uatype.NodeIdBrowseNextRequest_Encoding_DefaultBinary).Expanded(),
+	resp, err := c.sendContext(ctx, req.RequestHeader, transport.Request{
+		NodeID: uatype.NewFourByteNodeID(0, uatype.NodeIdBrowseRequest_Encoding_DefaultBinary).Expanded(),
 		Body:   &buf,
-	}, deadline)
+	})
 	if err != nil {
 		return nil, err
 	}
+	defer resp.Close()
 	switch resp.NodeID.Uint() {
-	case uatype.NodeIdBrowseNextResponse_Encoding_DefaultBinary:
-		res := &uatype.BrowseNextResponse{}
-		if err := binary.NewDecoder(resp.Body).Decode(res); err != nil {
-			return res, err
+	case uatype.NodeIdBrowseResponse_Encoding_DefaultBinary:
+		res := &uatype.BrowseResponse{}
+		if err := c.newDecoder(resp).Decode(res); err != nil {
+			return res, c.contextError(ctx, req.RequestHeader, err)
 		}
 		return res, nil
 	case uatype.NodeIdServiceFault_Encoding_DefaultBinary:
 		fault := uatype.ServiceFault{}
-		if err := binary.NewDecoder(resp.Body).Decode(&fault); err != nil {
-			return nil, err
+		if err := c.newDecoder(resp).Decode(&fault); err != nil {
+			return nil, c.contextError(ctx, req.RequestHeader, err)
 		}
 		return nil, fault
 	}
 	return nil, fmt.Errorf("Unexpected NodeID: %d %s", resp.NodeID.Uint(), resp.NodeID.DisplayName())
 }
 
-// TranslateBrowsePathsToNodeIds sends a TranslateBrowsePathsToNodeIds request to the server, and waits for a
+// BrowseNext sends a BrowseNext request to the server, and waits for a
 // response or timeout. To send and receive with no timeout use the zero time
 // as deadline.
-func (c *Client) TranslateBrowsePathsToNodeIds(req uatype.TranslateBrowsePathsToNodeIdsRequest, deadline time.Time) (*uatype.TranslateBrowsePathsToNodeIdsResponse, error) {
+func (c *Client) BrowseNext(req uatype.BrowseNextRequest, deadline time.Time) (*uatype.BrowseNextResponse, error) {
 	var buf bytes.Buffer
 
-	if err := binary.NewEncoder(&buf).Encode(req); err != nil {
+	if err := c.newEncoder(&buf).Encode(req); err != nil {
 		return nil, err
 	}
 
 	resp, err := c.Channel.Send(transport.Request{
-		NodeID: uatype.NewFourByteNodeID(0, uatype.NodeIdTranslateBrowsePathsToNodeIdsRequest_Encoding_DefaultBinary).Expanded(),
+		NodeID: uatype.NewFourByteNodeID(0, uatype.NodeIdBrowseNextRequest_Encoding_DefaultBinary).Expanded(),
 		Body:   &buf,
 	}, deadline)
 	if err != nil {
 		return nil, err
 	}
+	defer resp.Close()
 	switch resp.NodeID.Uint() {
-	case uatype.NodeIdTranslateBrowsePathsToNodeIdsResponse_Encoding_DefaultBinary:
-		res := &uatype.TranslateBrowsePathsToNodeIdsResponse{}
-		if err := binary.NewDecoder(resp.Body).Decode(res); err != nil {
+	case uatype.NodeIdBrowseNextResponse_Encoding_DefaultBinary:
+		res := &uatype.BrowseNextResponse{}
+		if err := c.newDecoder(resp).Decode(res); err != nil {
 			return res, err
 		}
 		return res, nil
 	case uatype.NodeIdServiceFault_Encoding_DefaultBinary:
 		fault := uatype.ServiceFault{}
-		if err := binary.NewDecoder(resp.Body).Decode(&fault); err != nil {
+		if err := c.newDecoder(resp).Decode(&fault); err != nil {
 			return nil, err
 		}
 		return nil, fault
@@ -386,67 +695,71 @@ func (c *Client) TranslateBrowsePathsToNodeIds(req uatype.TranslateBrowsePathsTo
 	return nil, fmt.Errorf("Unexpected NodeID: %d %s", resp.NodeID.Uint(), resp.NodeID.DisplayName())
 }
 
-// RegisterNodes sends a RegisterNodes request to the server, and waits for a
-// response or timeout. To send and receive with no timeout use the zero time
-// as deadline.
-func (c *Client) RegisterNodes(req uatype.RegisterNodesRequest, deadline time.Time) (*uatype.RegisterNodesResponse, error) {
+// BrowseNextContext sends a BrowseNext request to the server, and waits for a
+// response or for ctx to be done. The deadline of ctx is sent as the timeout
+// hint of the request. If ctx is done first, a Cancel request is sent for the
+// request handle.
+func (c *Client) BrowseNextContext(ctx context.Context, req uatype.BrowseNextRequest) (*uatype.BrowseNextResponse, error) {
+	c.contextHeader(ctx, &req.RequestHeader)
 	var buf bytes.Buffer
 
-	if err := binary.NewEncoder(&buf).Encode(req); err != nil {
+	if err := c.newEncoder(&buf).Encode(req); err != nil {
 		return nil, err
 	}
 
-	resp, err := c.Channel.Send(transport.Request{
-		NodeID: uatype.NewFourByteNodeID(0, uatype.NodeIdRegisterNodesRequest_Encoding_DefaultBinary).Expanded(),
+	resp, err := c.sendContext(ctx, req.RequestHeader, transport.Request{
+		NodeID: uatype.NewFourByteNodeID(0, uatype.NodeIdBrowseNextRequest_Encoding_DefaultBinary).Expanded(),
 		Body:   &buf,
-	}, deadline)
+	})
 	if err != nil {
 		return nil, err
 	}
+	defer resp.Close()
 	switch resp.NodeID.Uint() {
-	case uatype.NodeIdRegisterNodesResponse_Encoding_DefaultBinary:
-		res := &uatype.RegisterNodesResponse{}
-		if err := binary.NewDecoder(resp.Body).Decode(res); err != nil {
-			return res, err
+	case uatype.NodeIdBrowseNextResponse_Encoding_DefaultBinary:
+		res := &uatype.BrowseNextResponse{}
+		if err := c.newDecoder(resp).Decode(res); err != nil {
+			return res, c.contextError(ctx, req.RequestHeader, err)
 		}
 		return res, nil
 	case uatype.NodeIdServiceFault_Encoding_DefaultBinary:
 		fault := uatype.ServiceFault{}
-		if err := binary.NewDecoder(resp.Body).Decode(&fault); err != nil {
-			return nil, err
+		if err := c.newDecoder(resp).Decode(&fault); err != nil {
+			return nil, c.contextError(ctx, req.RequestHeader, err)
 		}
 		return nil, fault
 	}
 	return nil, fmt.Errorf("Unexpected NodeID: %d %s", resp.NodeID.Uint(), resp.NodeID.DisplayName())
 }
 
-// UnregisterNodes sends a UnregisterNodes request to the server, and waits for a
+// TranslateBrowsePathsToNodeIds sends a TranslateBrowsePathsToNodeIds request to the server, and waits for a
 // response or timeout. To send and receive with no timeout use the zero time
 // as deadline.
-func (c *Client) UnregisterNodes(req uatype.UnregisterNodesRequest, deadline time.Time) (*uatype.UnregisterNodesResponse, error) {
+func (c *Client) TranslateBrowsePathsToNodeIds(req uatype.TranslateBrowsePathsToNodeIdsRequest, deadline time.Time) (*uatype.TranslateBrowsePathsToNodeIdsResponse, error) {
 	var buf bytes.Buffer
 
-	if err := binary.NewEncoder(&buf).Encode(req); err != nil {
+	if err := c.newEncoder(&buf).Encode(req); err != nil {
 		return nil, err
 	}
 
 	resp, err := c.Channel.Send(transport.Request{
-		NodeID: uatype.NewFourByteNodeID(0, uatype.NodeIdUnregisterNodesRequest_Encoding_DefaultBinary).Expanded(),
+		NodeID: uatype.NewFourByteNodeID(0, uatype.NodeIdTranslateBrowsePathsToNodeIdsRequest_Encoding_DefaultBinary).Expanded(),
 		Body:   &buf,
 	}, deadline)
 	if err != nil {
 		return nil, err
 	}
+	defer resp.Close()
 	switch resp.NodeID.Uint() {
-	case uatype.NodeIdUnregisterNodesResponse_Encoding_DefaultBinary:
-		res := &uatype.UnregisterNodesResponse{}
-		if err := binary.NewDecoder(resp.Body).Decode(res); err != nil {
+	case uatype.NodeIdTranslateBrowsePathsToNodeIdsResponse_Encoding_DefaultBinary:
+		res := &uatype.TranslateBrowsePathsToNodeIdsResponse{}
+		if err := c.newDecoder(resp).Decode(res); err != nil {
 			return res, err
 		}
 		return res, nil
 	case uatype.NodeIdServiceFault_Encoding_DefaultBinary:
 		fault := uatype.ServiceFault{}
-		if err := binary.NewDecoder(resp.Body).Decode(&fault); err != nil {
+		if err := c.newDecoder(resp).Decode(&fault); err != nil {
 			return nil, err
 		}
 		return nil, fault
@@ -454,67 +767,71 @@ func (c *Client) UnregisterNodes(req uatype.UnregisterNodesRequest, deadline tim
 	return nil, fmt.Errorf("Unexpected NodeID: %d %s", resp.NodeID.Uint(), resp.NodeID.DisplayName())
 }
 
-// QueryFirst sends a QueryFirst request to the server, and waits for a
-// response or timeout. To send and receive with no timeout use the zero time
-// as deadline.
-func (c *Client) QueryFirst(req uatype.QueryFirstRequest, deadline time.Time) (*uatype.QueryFirstResponse, error) {
+// TranslateBrowsePathsToNodeIdsContext sends a TranslateBrowsePathsToNodeIds request to the server, and waits for a
+// response or for ctx to be done. The deadline of ctx is sent as the timeout
+// hint of the request. If ctx is done first, a Cancel request is sent for the
+// request handle.
+func (c *Client) TranslateBrowsePathsToNodeIdsContext(ctx context.Context, req uatype.TranslateBrowsePathsToNodeIdsRequest) (*uatype.TranslateBrowsePathsToNodeIdsResponse, error) {
+	c.contextHeader(ctx, &req.RequestHeader)
 	var buf bytes.Buffer
 
-	if err := binary.NewEncoder(&buf).Encode(req); err != nil {
+	if err := c.newEncoder(&buf).Encode(req); err != nil {
 		return nil, err
 	}
 
-	resp, err := c.Channel.Send(transport.Request{
-		NodeID: uatype.NewFourByteNodeID(0, uatype.NodeIdQueryFirstRequest_Encoding_DefaultBinary).Expanded(),
+	resp, err := c.sendContext(ctx, req.RequestHeader, transport.Request{
+		NodeID: uatype.NewFourByteNodeID(0, uatype.NodeIdTranslateBrowsePathsToNodeIdsRequest_Encoding_DefaultBinary).Expanded(),
 		Body:   &buf,
-	}, deadline)
+	})
 	if err != nil {
 		return nil, err
 	}
+	defer resp.Close()
 	switch resp.NodeID.Uint() {
-	case uatype.NodeIdQueryFirstResponse_Encoding_DefaultBinary:
-		res := &uatype.QueryFirstResponse{}
-		if err := binary.NewDecoder(resp.Body).Decode(res); err != nil {
-			return res, err
+	case uatype.NodeIdTranslateBrowsePathsToNodeIdsResponse_Encoding_DefaultBinary:
+		res := &uatype.TranslateBrowsePathsToNodeIdsResponse{}
+		if err := c.newDecoder(resp).Decode(res); err != nil {
+			return res, c.contextError(ctx, req.RequestHeader, err)
 		}
 		return res, nil
 	case uatype.NodeIdServiceFault_Encoding_DefaultBinary:
 		fault := uatype.ServiceFault{}
-		if err := binary.NewDecoder(resp.Body).Decode(&fault); err != nil {
-			return nil, err
+		if err := c.newDecoder(resp).Decode(&fault); err != nil {
+			return nil, c.contextError(ctx, req.RequestHeader, err)
 		}
 		return nil, fault
 	}
 	return nil, fmt.Errorf("Unexpected NodeID: %d %s", resp.NodeID.Uint(), resp.NodeID.DisplayName())
 }
 
-// QueryNext sends a QueryNext request to the server, and waits for a
+// RegisterNodes sends a RegisterNodes request to the server, and waits for a
 // response or timeout. To send and receive with no timeout use the zero time
 // as deadline.
-func (c *Client) QueryNext(req uatype.QueryNextRequest, deadline time.Time) (*uatype.QueryNextResponse, error) {
+func (c *Client) RegisterNodes(req uatype.RegisterNodesRequest, deadline time.Time) (*uatype.RegisterNodesResponse, error) {
 	var buf bytes.Buffer
 
-	if err := binary.NewEncoder(&buf).Encode(req); err != nil {
+	if err := c.newEncoder(&buf).Encode(req); err != nil {
 		return nil, err
 	}
 
 	resp, err := c.Channel.Send(transport.Request{
-		NodeID: uatype.NewFourByteNodeID(0, uatype.NodeIdQueryNextRequest_Encoding_DefaultBinary).Expanded(),
+		NodeID: uatype.NewFourByteNodeID(0, uatype.NodeIdRegisterNodesRequest_Encoding_DefaultBinary).Expanded(),
 		Body:   &buf,
 	}, deadline)
 	if err != nil {
 		return nil, err
 	}
+	defer resp.Close()
 	switch resp.NodeID.Uint() {
-	case uatype.NodeIdQueryNextResponse_Encoding_DefaultBinary:
-		res := &uatype.QueryNextResponse{}
-		if err := binary.NewDecoder(resp.Body).Decode(res); err != nil {
+	case uatype.NodeIdRegisterNodesResponse_Encoding_DefaultBinary:
+		res := &uatype.RegisterNodesResponse{}
+		if err := c.newDecoder(resp).Decode(res); err != nil {
 			return res, err
 		}
 		return res, nil
 	case uatype.NodeIdServiceFault_Encoding_DefaultBinary:
 		fault := uatype.ServiceFault{}
-		if err := binary.NewDecoder(resp.Body).Decode(&fault); err != nil {
+		if err := c.newDecoder(resp).Decode(&fault); err != nil {
 			return nil, err
 		}
 		return nil, fault
@@ -522,33 +839,1079 @@ func (c *Client) QueryNext(req uatype.QueryNextRequest, deadline time.Time) (*ua
 	return nil, fmt.Errorf("Unexpected NodeID: %d %s", resp.NodeID.Uint(), resp.NodeID.DisplayName())
 }
 
+// RegisterNodesContext sends a RegisterNodes request to the server, and waits for a
+// response or for ctx to be done. The deadline of ctx is sent as the timeout
+// hint of the request. If ctx is done first, a Cancel request is sent for the
+// request handle.
+func (c *Client) RegisterNodesContext(ctx context.Context, req uatype.RegisterNodesRequest) (*uatype.RegisterNodesResponse, error) {
+	c.contextHeader(ctx, &req.RequestHeader)
+	var buf bytes.Buffer
+
+	if err := c.newEncoder(&buf).Encode(req); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.sendContext(ctx, req.RequestHeader, transport.Request{
+		NodeID: uatype.NewFourByteNodeID(0, uatype.NodeIdRegisterNodesRequest_Encoding_DefaultBinary).Expanded(),
+		Body:   &buf,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Close()
+	switch resp.NodeID.Uint() {
+	case uatype.NodeIdRegisterNodesResponse_Encoding_DefaultBinary:
+		res := &uatype.RegisterNodesResponse{}
+		if err := c.newDecoder(resp).Decode(res); err != nil {
+			return res, c.contextError(ctx, req.RequestHeader, err)
+		}
+		return res, nil
+	case uatype.NodeIdServiceFault_Encoding_DefaultBinary:
+		fault := uatype.ServiceFault{}
+		if err := c.newDecoder(resp).Decode(&fault); err != nil {
+			return nil, c.contextError(ctx, req.RequestHeader, err)
+		}
+		return nil, fault
+	}
+	return nil, fmt.Errorf("Unexpected NodeID: %d %s", resp.NodeID.Uint(), resp.NodeID.DisplayName())
+}
+
+// UnregisterNodes sends a UnregisterNodes request to the server, and waits for a
+// response or timeout. To send and receive with no timeout use the zero time
+// as deadline.
+func (c *Client) UnregisterNodes(req uatype.UnregisterNodesRequest, deadline time.Time) (*uatype.UnregisterNodesResponse, error) {
+	var buf bytes.Buffer
+
+	if err := c.newEncoder(&buf).Encode(req); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Channel.Send(transport.Request{
+		NodeID: uatype.NewFourByteNodeID(0, uatype.NodeIdUnregisterNodesRequest_Encoding_DefaultBinary).Expanded(),
+		Body:   &buf,
+	}, deadline)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Close()
+	switch resp.NodeID.Uint() {
+	case uatype.NodeIdUnregisterNodesResponse_Encoding_DefaultBinary:
+		res := &uatype.UnregisterNodesResponse{}
+		if err := c.newDecoder(resp).Decode(res); err != nil {
+			return res, err
+		}
+		return res, nil
+	case uatype.NodeIdServiceFault_Encoding_DefaultBinary:
+		fault := uatype.ServiceFault{}
+		if err := c.newDecoder(resp).Decode(&fault); err != nil {
+			return nil, err
+		}
+		return nil, fault
+	}
+	return nil, fmt.Errorf("Unexpected NodeID: %d %s", resp.NodeID.Uint(), resp.NodeID.DisplayName())
+}
+
+// UnregisterNodesContext sends a UnregisterNodes request to the server, and waits for a
+// response or for ctx to be done. The deadline of ctx is sent as the timeout
+// hint of the request. If ctx is done first, a Cancel request is sent for the
+// request handle.
+func (c *Client) UnregisterNodesContext(ctx context.Context, req uatype.UnregisterNodesRequest) (*uatype.UnregisterNodesResponse, error) {
+	c.contextHeader(ctx, &req.RequestHeader)
+	var buf bytes.Buffer
+
+	if err := c.newEncoder(&buf).Encode(req); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.sendContext(ctx, req.RequestHeader, transport.Request{
+		NodeID: uatype.NewFourByteNodeID(0, uatype.NodeIdUnregisterNodesRequest_Encoding_DefaultBinary).Expanded(),
+		Body:   &buf,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Close()
+	switch resp.NodeID.Uint() {
+	case uatype.NodeIdUnregisterNodesResponse_Encoding_DefaultBinary:
+		res := &uatype.UnregisterNodesResponse{}
+		if err := c.newDecoder(resp).Decode(res); err != nil {
+			return res, c.contextError(ctx, req.RequestHeader, err)
+		}
+		return res, nil
+	case uatype.NodeIdServiceFault_Encoding_DefaultBinary:
+		fault := uatype.ServiceFault{}
+		if err := c.newDecoder(resp).Decode(&fault); err != nil {
+			return nil, c.contextError(ctx, req.RequestHeader, err)
+		}
+		return nil, fault
+	}
+	return nil, fmt.Errorf("Unexpected NodeID: %d %s", resp.NodeID.Uint(), resp.NodeID.DisplayName())
+}
+
+// QueryFirst sends a QueryFirst request to the server, and waits for a
+// response or timeout. To send and receive with no timeout use the zero time
+// as deadline.
+func (c *Client) QueryFirst(req uatype.QueryFirstRequest, deadline time.Time) (*uatype.QueryFirstResponse, error) {
+	var buf bytes.Buffer
+
+	if err := c.newEncoder(&buf).Encode(req); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Channel.Send(transport.Request{
+		NodeID: uatype.NewFourByteNodeID(0, uatype.NodeIdQueryFirstRequest_Encoding_DefaultBinary).Expanded(),
+		Body:   &buf,
+	}, deadline)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Close()
+	switch resp.NodeID.Uint() {
+	case uatype.NodeIdQueryFirstResponse_Encoding_DefaultBinary:
+		res := &uatype.QueryFirstResponse{}
+		if err := c.newDecoder(resp).Decode(res); err != nil {
+			return res, err
+		}
+		return res, nil
+	case uatype.NodeIdServiceFault_Encoding_DefaultBinary:
+		fault := uatype.ServiceFault{}
+		if err := c.newDecoder(resp).Decode(&fault); err != nil {
+			return nil, err
+		}
+		return nil, fault
+	}
+	return nil, fmt.Errorf("Unexpected NodeID: %d %s", resp.NodeID.Uint(), resp.NodeID.DisplayName())
+}
+
+// QueryFirstContext sends a QueryFirst request to the server, and waits for a
+// response or for ctx to be done. The deadline of ctx is sent as the timeout
+// hint of the request. If ctx is done first, a Cancel request is sent for the
+// request handle.
+func (c *Client) QueryFirstContext(ctx context.Context, req uatype.QueryFirstRequest) (*uatype.QueryFirstResponse, error) {
+	c.contextHeader(ctx, &req.RequestHeader)
+	var buf bytes.Buffer
+
+	if err := c.newEncoder(&buf).Encode(req); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.sendContext(ctx, req.RequestHeader, transport.Request{
+		NodeID: uatype.NewFourByteNodeID(0, uatype.NodeIdQueryFirstRequest_Encoding_DefaultBinary).Expanded(),
+		Body:   &buf,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Close()
+	switch resp.NodeID.Uint() {
+	case uatype.NodeIdQueryFirstResponse_Encoding_DefaultBinary:
+		res := &uatype.QueryFirstResponse{}
+		if err := c.newDecoder(resp).Decode(res); err != nil {
+			return res, c.contextError(ctx, req.RequestHeader, err)
+		}
+		return res, nil
+	case uatype.NodeIdServiceFault_Encoding_DefaultBinary:
+		fault := uatype.ServiceFault{}
+		if err := c.newDecoder(resp).Decode(&fault); err != nil {
+			return nil, c.contextError(ctx, req.RequestHeader, err)
+		}
+		return nil, fault
+	}
+	return nil, fmt.Errorf("Unexpected NodeID: %d %s", resp.NodeID.Uint(), resp.NodeID.DisplayName())
+}
+
+// QueryNext sends a QueryNext request to the server, and waits for a
+// response or timeout. To send and receive with no timeout use the zero time
+// as deadline.
+func (c *Client) QueryNext(req uatype.QueryNextRequest, deadline time.Time) (*uatype.QueryNextResponse, error) {
+	var buf bytes.Buffer
+
+	if err := c.newEncoder(&buf).Encode(req); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Channel.Send(transport.Request{
+		NodeID: uatype.NewFourByteNodeID(0, uatype.NodeIdQueryNextRequest_Encoding_DefaultBinary).Expanded(),
+		Body:   &buf,
+	}, deadline)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Close()
+	switch resp.NodeID.Uint() {
+	case uatype.NodeIdQueryNextResponse_Encoding_DefaultBinary:
+		res := &uatype.QueryNextResponse{}
+		if err := c.newDecoder(resp).Decode(res); err != nil {
+			return res, err
+		}
+		return res, nil
+	case uatype.NodeIdServiceFault_Encoding_DefaultBinary:
+		fault := uatype.ServiceFault{}
+		if err := c.newDecoder(resp).Decode(&fault); err != nil {
+			return nil, err
+		}
+		return nil, fault
+	}
+	return nil, fmt.Errorf("Unexpected NodeID: %d %s", resp.NodeID.Uint(), resp.NodeID.DisplayName())
+}
+
+// QueryNextContext sends a QueryNext request to the server, and waits for a
+// response or for ctx to be done. The deadline of ctx is sent as the timeout
+// hint of the request. If ctx is done first, a Cancel request is sent for the
+// request handle.
+func (c *Client) QueryNextContext(ctx context.Context, req uatype.QueryNextRequest) (*uatype.QueryNextResponse, error) {
+	c.contextHeader(ctx, &req.RequestHeader)
+	var buf bytes.Buffer
+
+	if err := c.newEncoder(&buf).Encode(req); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.sendContext(ctx, req.RequestHeader, transport.Request{
+		NodeID: uatype.NewFourByteNodeID(0, uatype.NodeIdQueryNextRequest_Encoding_DefaultBinary).Expanded(),
+		Body:   &buf,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Close()
+	switch resp.NodeID.Uint() {
+	case uatype.NodeIdQueryNextResponse_Encoding_DefaultBinary:
+		res := &uatype.QueryNextResponse{}
+		if err := c.newDecoder(resp).Decode(res); err != nil {
+			return res, c.contextError(ctx, req.RequestHeader, err)
+		}
+		return res, nil
+	case uatype.NodeIdServiceFault_Encoding_DefaultBinary:
+		fault := uatype.ServiceFault{}
+		if err := c.newDecoder(resp).Decode(&fault); err != nil {
+			return nil, c.contextError(ctx, req.RequestHeader, err)
+		}
+		return nil, fault
+	}
+	return nil, fmt.Errorf("Unexpected NodeID: %d %s", resp.NodeID.Uint(), resp.NodeID.DisplayName())
+}
+
 // Read sends a Read request to the server, and waits for a
 // response or timeout. To send and receive with no timeout use the zero time
 // as deadline.
-func (c *Client) Read(req uatype.ReadRequest, deadline time.Time) (*uatype.ReadResponse, error) {
+func (c *Client) Read(req uatype.ReadRequest, deadline time.Time) (*uatype.ReadResponse, error) {
+	var buf bytes.Buffer
+
+	if err := c.newEncoder(&buf).Encode(req); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Channel.Send(transport.Request{
+		NodeID: uatype.NewFourByteNodeID(0, uatype.NodeIdReadRequest_Encoding_DefaultBinary).Expanded(),
+		Body:   &buf,
+	}, deadline)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Close()
+	switch resp.NodeID.Uint() {
+	case uatype.NodeIdReadResponse_Encoding_DefaultBinary:
+		res := &uatype.ReadResponse{}
+		if err := c.newDecoder(resp).Decode(res); err != nil {
+			return res, err
+		}
+		return res, nil
+	case uatype.NodeIdServiceFault_Encoding_DefaultBinary:
+		fault := uatype.ServiceFault{}
+		if err := c.newDecoder(resp).Decode(&fault); err != nil {
+			return nil, err
+		}
+		return nil, fault
+	}
+	return nil, fmt.Errorf("Unexpected NodeID: %d %s", resp.NodeID.Uint(), resp.NodeID.DisplayName())
+}
+
+// ReadContext sends a Read request to the server, and waits for a
+// response or for ctx to be done. The deadline of ctx is sent as the timeout
+// hint of the request. If ctx is done first, a Cancel request is sent for the
+// request handle.
+func (c *Client) ReadContext(ctx context.Context, req uatype.ReadRequest) (*uatype.ReadResponse, error) {
+	c.contextHeader(ctx, &req.RequestHeader)
+	var buf bytes.Buffer
+
+	if err := c.newEncoder(&buf).Encode(req); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.sendContext(ctx, req.RequestHeader, transport.Request{
+		NodeID: uatype.NewFourByteNodeID(0, uatype.NodeIdReadRequest_Encoding_DefaultBinary).Expanded(),
+		Body:   &buf,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Close()
+	switch resp.NodeID.Uint() {
+	case uatype.NodeIdReadResponse_Encoding_DefaultBinary:
+		res := &uatype.ReadResponse{}
+		if err := c.newDecoder(resp).Decode(res); err != nil {
+			return res, c.contextError(ctx, req.RequestHeader, err)
+		}
+		return res, nil
+	case uatype.NodeIdServiceFault_Encoding_DefaultBinary:
+		fault := uatype.ServiceFault{}
+		if err := c.newDecoder(resp).Decode(&fault); err != nil {
+			return nil, c.contextError(ctx, req.RequestHeader, err)
+		}
+		return nil, fault
+	}
+	return nil, fmt.Errorf("Unexpected NodeID: %d %s", resp.NodeID.Uint(), resp.NodeID.DisplayName())
+}
+
+// HistoryRead sends a HistoryRead request to the server, and waits for a
+// response or timeout. To send and receive with no timeout use the zero time
+// as deadline.
+func (c *Client) HistoryRead(req uatype.HistoryReadRequest, deadline time.Time) (*uatype.HistoryReadResponse, error) {
+	var buf bytes.Buffer
+
+	if err := c.newEncoder(&buf).Encode(req); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Channel.Send(transport.Request{
+		NodeID: uatype.NewFourByteNodeID(0, uatype.NodeIdHistoryReadRequest_Encoding_DefaultBinary).Expanded(),
+		Body:   &buf,
+	}, deadline)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Close()
+	switch resp.NodeID.Uint() {
+	case uatype.NodeIdHistoryReadResponse_Encoding_DefaultBinary:
+		res := &uatype.HistoryReadResponse{}
+		if err := c.newDecoder(resp).Decode(res); err != nil {
+			return res, err
+		}
+		return res, nil
+	case uatype.NodeIdServiceFault_Encoding_DefaultBinary:
+		fault := uatype.ServiceFault{}
+		if err := c.newDecoder(resp).Decode(&fault); err != nil {
+			return nil, err
+		}
+		return nil, fault
+	}
+	return nil, fmt.Errorf("Unexpected NodeID: %d %s", resp.NodeID.Uint(), resp.NodeID.DisplayName())
+}
+
+// HistoryReadContext sends a HistoryRead request to the server, and waits for a
+// response or for ctx to be done. The deadline of ctx is sent as the timeout
+// hint of the request. If ctx is done first, a Cancel request is sent for the
+// request handle.
+func (c *Client) HistoryReadContext(ctx context.Context, req uatype.HistoryReadRequest) (*uatype.HistoryReadResponse, error) {
+	c.contextHeader(ctx, &req.RequestHeader)
+	var buf bytes.Buffer
+
+	if err := c.newEncoder(&buf).Encode(req); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.sendContext(ctx, req.RequestHeader, transport.Request{
+		NodeID: uatype.NewFourByteNodeID(0, uatype.NodeIdHistoryReadRequest_Encoding_DefaultBinary).Expanded(),
+		Body:   &buf,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Close()
+	switch resp.NodeID.Uint() {
+	case uatype.NodeIdHistoryReadResponse_Encoding_DefaultBinary:
+		res := &uatype.HistoryReadResponse{}
+		if err := c.newDecoder(resp).Decode(res); err != nil {
+			return res, c.contextError(ctx, req.RequestHeader, err)
+		}
+		return res, nil
+	case uatype.NodeIdServiceFault_Encoding_DefaultBinary:
+		fault := uatype.ServiceFault{}
+		if err := c.newDecoder(resp).Decode(&fault); err != nil {
+			return nil, c.contextError(ctx, req.RequestHeader, err)
+		}
+		return nil, fault
+	}
+	return nil, fmt.Errorf("Unexpected NodeID: %d %s", resp.NodeID.Uint(), resp.NodeID.DisplayName())
+}
+
+// Write sends a Write request to the server, and waits for a
+// response or timeout. To send and receive with no timeout use the zero time
+// as deadline.
+func (c *Client) Write(req uatype.WriteRequest, deadline time.Time) (*uatype.WriteResponse, error) {
+	var buf bytes.Buffer
+
+	if err := c.newEncoder(&buf).Encode(req); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Channel.Send(transport.Request{
+		NodeID: uatype.NewFourByteNodeID(0, uatype.NodeIdWriteRequest_Encoding_DefaultBinary).Expanded(),
+		Body:   &buf,
+	}, deadline)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Close()
+	switch resp.NodeID.Uint() {
+	case uatype.NodeIdWriteResponse_Encoding_DefaultBinary:
+		res := &uatype.WriteResponse{}
+		if err := c.newDecoder(resp).Decode(res); err != nil {
+			return res, err
+		}
+		return res, nil
+	case uatype.NodeIdServiceFault_Encoding_DefaultBinary:
+		fault := uatype.ServiceFault{}
+		if err := c.newDecoder(resp).Decode(&fault); err != nil {
+			return nil, err
+		}
+		return nil, fault
+	}
+	return nil, fmt.Errorf("Unexpected NodeID: %d %s", resp.NodeID.Uint(), resp.NodeID.DisplayName())
+}
+
+// WriteContext sends a Write request to the server, and waits for a
+// response or for ctx to be done. The deadline of ctx is sent as the timeout
+// hint of the request. If ctx is done first, a Cancel request is sent for the
+// request handle.
+func (c *Client) WriteContext(ctx context.Context, req uatype.WriteRequest) (*uatype.WriteResponse, error) {
+	c.contextHeader(ctx, &req.RequestHeader)
+	var buf bytes.Buffer
+
+	if err := c.newEncoder(&buf).Encode(req); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.sendContext(ctx, req.RequestHeader, transport.Request{
+		NodeID: uatype.NewFourByteNodeID(0, uatype.NodeIdWriteRequest_Encoding_DefaultBinary).Expanded(),
+		Body:   &buf,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Close()
+	switch resp.NodeID.Uint() {
+	case uatype.NodeIdWriteResponse_Encoding_DefaultBinary:
+		res := &uatype.WriteResponse{}
+		if err := c.newDecoder(resp).Decode(res); err != nil {
+			return res, c.contextError(ctx, req.RequestHeader, err)
+		}
+		return res, nil
+	case uatype.NodeIdServiceFault_Encoding_DefaultBinary:
+		fault := uatype.ServiceFault{}
+		if err := c.newDecoder(resp).Decode(&fault); err != nil {
+			return nil, c.contextError(ctx, req.RequestHeader, err)
+		}
+		return nil, fault
+	}
+	return nil, fmt.Errorf("Unexpected NodeID: %d %s", resp.NodeID.Uint(), resp.NodeID.DisplayName())
+}
+
+// HistoryUpdate sends a HistoryUpdate request to the server, and waits for a
+// response or timeout. To send and receive with no timeout use the zero time
+// as deadline.
+func (c *Client) HistoryUpdate(req uatype.HistoryUpdateRequest, deadline time.Time) (*uatype.HistoryUpdateResponse, error) {
+	var buf bytes.Buffer
+
+	if err := c.newEncoder(&buf).Encode(req); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Channel.Send(transport.Request{
+		NodeID: uatype.NewFourByteNodeID(0, uatype.NodeIdHistoryUpdateRequest_Encoding_DefaultBinary).Expanded(),
+		Body:   &buf,
+	}, deadline)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Close()
+	switch resp.NodeID.Uint() {
+	case uatype.NodeIdHistoryUpdateResponse_Encoding_DefaultBinary:
+		res := &uatype.HistoryUpdateResponse{}
+		if err := c.newDecoder(resp).Decode(res); err != nil {
+			return res, err
+		}
+		return res, nil
+	case uatype.NodeIdServiceFault_Encoding_DefaultBinary:
+		fault := uatype.ServiceFault{}
+		if err := c.newDecoder(resp).Decode(&fault); err != nil {
+			return nil, err
+		}
+		return nil, fault
+	}
+	return nil, fmt.Errorf("Unexpected NodeID: %d %s", resp.NodeID.Uint(), resp.NodeID.DisplayName())
+}
+
+// HistoryUpdateContext sends a HistoryUpdate request to the server, and waits for a
+// response or for ctx to be done. The deadline of ctx is sent as the timeout
+// hint of the request. If ctx is done first, a Cancel request is sent for the
+// request handle.
+func (c *Client) HistoryUpdateContext(ctx context.Context, req uatype.HistoryUpdateRequest) (*uatype.HistoryUpdateResponse, error) {
+	c.contextHeader(ctx, &req.RequestHeader)
+	var buf bytes.Buffer
+
+	if err := c.newEncoder(&buf).Encode(req); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.sendContext(ctx, req.RequestHeader, transport.Request{
+		NodeID: uatype.NewFourByteNodeID(0, uatype.NodeIdHistoryUpdateRequest_Encoding_DefaultBinary).Expanded(),
+		Body:   &buf,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Close()
+	switch resp.NodeID.Uint() {
+	case uatype.NodeIdHistoryUpdateResponse_Encoding_DefaultBinary:
+		res := &uatype.HistoryUpdateResponse{}
+		if err := c.newDecoder(resp).Decode(res); err != nil {
+			return res, c.contextError(ctx, req.RequestHeader, err)
+		}
+		return res, nil
+	case uatype.NodeIdServiceFault_Encoding_DefaultBinary:
+		fault := uatype.ServiceFault{}
+		if err := c.newDecoder(resp).Decode(&fault); err != nil {
+			return nil, c.contextError(ctx, req.RequestHeader, err)
+		}
+		return nil, fault
+	}
+	return nil, fmt.Errorf("Unexpected NodeID: %d %s", resp.NodeID.Uint(), resp.NodeID.DisplayName())
+}
+
+// Call sends a Call request to the server, and waits for a
+// response or timeout. To send and receive with no timeout use the zero time
+// as deadline.
+func (c *Client) Call(req uatype.CallRequest, deadline time.Time) (*uatype.CallResponse, error) {
+	var buf bytes.Buffer
+
+	if err := c.newEncoder(&buf).Encode(req); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Channel.Send(transport.Request{
+		NodeID: uatype.NewFourByteNodeID(0, uatype.NodeIdCallRequest_Encoding_DefaultBinary).Expanded(),
+		Body:   &buf,
+	}, deadline)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Close()
+	switch resp.NodeID.Uint() {
+	case uatype.NodeIdCallResponse_Encoding_DefaultBinary:
+		res := &uatype.CallResponse{}
+		if err := c.newDecoder(resp).Decode(res); err != nil {
+			return res, err
+		}
+		return res, nil
+	case uatype.NodeIdServiceFault_Encoding_DefaultBinary:
+		fault := uatype.ServiceFault{}
+		if err := c.newDecoder(resp).Decode(&fault); err != nil {
+			return nil, err
+		}
+		return nil, fault
+	}
+	return nil, fmt.Errorf("Unexpected NodeID: %d %s", resp.NodeID.Uint(), resp.NodeID.DisplayName())
+}
+
+// CallContext sends a Call request to the server, and waits for a
+// response or for ctx to be done. The deadline of ctx is sent as the timeout
+// hint of the request. If ctx is done first, a Cancel request is sent for the
+// request handle.
+func (c *Client) CallContext(ctx context.Context, req uatype.CallRequest) (*uatype.CallResponse, error) {
+	c.contextHeader(ctx, &req.RequestHeader)
+	var buf bytes.Buffer
+
+	if err := c.newEncoder(&buf).Encode(req); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.sendContext(ctx, req.RequestHeader, transport.Request{
+		NodeID: uatype.NewFourByteNodeID(0, uatype.NodeIdCallRequest_Encoding_DefaultBinary).Expanded(),
+		Body:   &buf,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Close()
+	switch resp.NodeID.Uint() {
+	case uatype.NodeIdCallResponse_Encoding_DefaultBinary:
+		res := &uatype.CallResponse{}
+		if err := c.newDecoder(resp).Decode(res); err != nil {
+			return res, c.contextError(ctx, req.RequestHeader, err)
+		}
+		return res, nil
+	case uatype.NodeIdServiceFault_Encoding_DefaultBinary:
+		fault := uatype.ServiceFault{}
+		if err := c.newDecoder(resp).Decode(&fault); err != nil {
+			return nil, c.contextError(ctx, req.RequestHeader, err)
+		}
+		return nil, fault
+	}
+	return nil, fmt.Errorf("Unexpected NodeID: %d %s", resp.NodeID.Uint(), resp.NodeID.DisplayName())
+}
+
+// CreateMonitoredItems sends a CreateMonitoredItems request to the server, and waits for a
+// response or timeout. To send and receive with no timeout use the zero time
+// as deadline.
+func (c *Client) CreateMonitoredItems(req uatype.CreateMonitoredItemsRequest, deadline time.Time) (*uatype.CreateMonitoredItemsResponse, error) {
+	var buf bytes.Buffer
+
+	if err := c.newEncoder(&buf).Encode(req); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Channel.Send(transport.Request{
+		NodeID: uatype.NewFourByteNodeID(0, uatype.NodeIdCreateMonitoredItemsRequest_Encoding_DefaultBinary).Expanded(),
+		Body:   &buf,
+	}, deadline)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Close()
+	switch resp.NodeID.Uint() {
+	case uatype.NodeIdCreateMonitoredItemsResponse_Encoding_DefaultBinary:
+		res := &uatype.CreateMonitoredItemsResponse{}
+		if err := c.newDecoder(resp).Decode(res); err != nil {
+			return res, err
+		}
+		return res, nil
+	case uatype.NodeIdServiceFault_Encoding_DefaultBinary:
+		fault := uatype.ServiceFault{}
+		if err := c.newDecoder(resp).Decode(&fault); err != nil {
+			return nil, err
+		}
+		return nil, fault
+	}
+	return nil, fmt.Errorf("Unexpected NodeID: %d %s", resp.NodeID.Uint(), resp.NodeID.DisplayName())
+}
+
+// CreateMonitoredItemsContext sends a CreateMonitoredItems request to the server, and waits for a
+// response or for ctx to be done. The deadline of ctx is sent as the timeout
+// hint of the request. If ctx is done first, a Cancel request is sent for the
+// request handle.
+func (c *Client) CreateMonitoredItemsContext(ctx context.Context, req uatype.CreateMonitoredItemsRequest) (*uatype.CreateMonitoredItemsResponse, error) {
+	c.contextHeader(ctx, &req.RequestHeader)
+	var buf bytes.Buffer
+
+	if err := c.newEncoder(&buf).Encode(req); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.sendContext(ctx, req.RequestHeader, transport.Request{
+		NodeID: uatype.NewFourByteNodeID(0, uatype.NodeIdCreateMonitoredItemsRequest_Encoding_DefaultBinary).Expanded(),
+		Body:   &buf,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Close()
+	switch resp.NodeID.Uint() {
+	case uatype.NodeIdCreateMonitoredItemsResponse_Encoding_DefaultBinary:
+		res := &uatype.CreateMonitoredItemsResponse{}
+		if err := c.newDecoder(resp).Decode(res); err != nil {
+			return res, c.contextError(ctx, req.RequestHeader, err)
+		}
+		return res, nil
+	case uatype.NodeIdServiceFault_Encoding_DefaultBinary:
+		fault := uatype.ServiceFault{}
+		if err := c.newDecoder(resp).Decode(&fault); err != nil {
+			return nil, c.contextError(ctx, req.RequestHeader, err)
+		}
+		return nil, fault
+	}
+	return nil, fmt.Errorf("Unexpected NodeID: %d %s", resp.NodeID.Uint(), resp.NodeID.DisplayName())
+}
+
+// ModifyMonitoredItems sends a ModifyMonitoredItems request to the server, and waits for a
+// response or timeout. To send and receive with no timeout use the zero time
+// as deadline.
+func (c *Client) ModifyMonitoredItems(req uatype.ModifyMonitoredItemsRequest, deadline time.Time) (*uatype.ModifyMonitoredItemsResponse, error) {
+	var buf bytes.Buffer
+
+	if err := c.newEncoder(&buf).Encode(req); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Channel.Send(transport.Request{
+		NodeID: uatype.NewFourByteNodeID(0, uatype.NodeIdModifyMonitoredItemsRequest_Encoding_DefaultBinary).Expanded(),
+		Body:   &buf,
+	}, deadline)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Close()
+	switch resp.NodeID.Uint() {
+	case uatype.NodeIdModifyMonitoredItemsResponse_Encoding_DefaultBinary:
+		res := &uatype.ModifyMonitoredItemsResponse{}
+		if err := c.newDecoder(resp).Decode(res); err != nil {
+			return res, err
+		}
+		return res, nil
+	case uatype.NodeIdServiceFault_Encoding_DefaultBinary:
+		fault := uatype.ServiceFault{}
+		if err := c.newDecoder(resp).Decode(&fault); err != nil {
+			return nil, err
+		}
+		return nil, fault
+	}
+	return nil, fmt.Errorf("Unexpected NodeID: %d %s", resp.NodeID.Uint(), resp.NodeID.DisplayName())
+}
+
+// ModifyMonitoredItemsContext sends a ModifyMonitoredItems request to the server, and waits for a
+// response or for ctx to be done. The deadline of ctx is sent as the timeout
+// hint of the request. If ctx is done first, a Cancel request is sent for the
+// request handle.
+func (c *Client) ModifyMonitoredItemsContext(ctx context.Context, req uatype.ModifyMonitoredItemsRequest) (*uatype.ModifyMonitoredItemsResponse, error) {
+	c.contextHeader(ctx, &req.RequestHeader)
+	var buf bytes.Buffer
+
+	if err := c.newEncoder(&buf).Encode(req); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.sendContext(ctx, req.RequestHeader, transport.Request{
+		NodeID: uatype.NewFourByteNodeID(0, uatype.NodeIdModifyMonitoredItemsRequest_Encoding_DefaultBinary).Expanded(),
+		Body:   &buf,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Close()
+	switch resp.NodeID.Uint() {
+	case uatype.NodeIdModifyMonitoredItemsResponse_Encoding_DefaultBinary:
+		res := &uatype.ModifyMonitoredItemsResponse{}
+		if err := c.newDecoder(resp).Decode(res); err != nil {
+			return res, c.contextError(ctx, req.RequestHeader, err)
+		}
+		return res, nil
+	case uatype.NodeIdServiceFault_Encoding_DefaultBinary:
+		fault := uatype.ServiceFault{}
+		if err := c.newDecoder(resp).Decode(&fault); err != nil {
+			return nil, c.contextError(ctx, req.RequestHeader, err)
+		}
+		return nil, fault
+	}
+	return nil, fmt.Errorf("Unexpected NodeID: %d %s", resp.NodeID.Uint(), resp.NodeID.DisplayName())
+}
+
+// SetMonitoringMode sends a SetMonitoringMode request to the server, and waits for a
+// response or timeout. To send and receive with no timeout use the zero time
+// as deadline.
+func (c *Client) SetMonitoringMode(req uatype.SetMonitoringModeRequest, deadline time.Time) (*uatype.SetMonitoringModeResponse, error) {
+	var buf bytes.Buffer
+
+	if err := c.newEncoder(&buf).Encode(req); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Channel.Send(transport.Request{
+		NodeID: uatype.NewFourByteNodeID(0, uatype.NodeIdSetMonitoringModeRequest_Encoding_DefaultBinary).Expanded(),
+		Body:   &buf,
+	}, deadline)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Close()
+	switch resp.NodeID.Uint() {
+	case uatype.NodeIdSetMonitoringModeResponse_Encoding_DefaultBinary:
+		res := &uatype.SetMonitoringModeResponse{}
+		if err := c.newDecoder(resp).Decode(res); err != nil {
+			return res, err
+		}
+		return res, nil
+	case uatype.NodeIdServiceFault_Encoding_DefaultBinary:
+		fault := uatype.ServiceFault{}
+		if err := c.newDecoder(resp).Decode(&fault); err != nil {
+			return nil, err
+		}
+		return nil, fault
+	}
+	return nil, fmt.Errorf("Unexpected NodeID: %d %s", resp.NodeID.Uint(), resp.NodeID.DisplayName())
+}
+
+// SetMonitoringModeContext sends a SetMonitoringMode request to the server, and waits for a
+// response or for ctx to be done. The deadline of ctx is sent as the timeout
+// hint of the request. If ctx is done first, a Cancel request is sent for the
+// request handle.
+func (c *Client) SetMonitoringModeContext(ctx context.Context, req uatype.SetMonitoringModeRequest) (*uatype.SetMonitoringModeResponse, error) {
+	c.contextHeader(ctx, &req.RequestHeader)
+	var buf bytes.Buffer
+
+	if err := c.newEncoder(&buf).Encode(req); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.sendContext(ctx, req.RequestHeader, transport.Request{
+		NodeID: uatype.NewFourByteNodeID(0, uatype.NodeIdSetMonitoringModeRequest_Encoding_DefaultBinary).Expanded(),
+		Body:   &buf,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Close()
+	switch resp.NodeID.Uint() {
+	case uatype.NodeIdSetMonitoringModeResponse_Encoding_DefaultBinary:
+		res := &uatype.SetMonitoringModeResponse{}
+		if err := c.newDecoder(resp).Decode(res); err != nil {
+			return res, c.contextError(ctx, req.RequestHeader, err)
+		}
+		return res, nil
+	case uatype.NodeIdServiceFault_Encoding_DefaultBinary:
+		fault := uatype.ServiceFault{}
+		if err := c.newDecoder(resp).Decode(&fault); err != nil {
+			return nil, c.contextError(ctx, req.RequestHeader, err)
+		}
+		return nil, fault
+	}
+	return nil, fmt.Errorf("Unexpected NodeID: %d %s", resp.NodeID.Uint(), resp.NodeID.DisplayName())
+}
+
+// SetTriggering sends a SetTriggering request to the server, and waits for a
+// response or timeout. To send and receive with no timeout use the zero time
+// as deadline.
+func (c *Client) SetTriggering(req uatype.SetTriggeringRequest, deadline time.Time) (*uatype.SetTriggeringResponse, error) {
+	var buf bytes.Buffer
+
+	if err := c.newEncoder(&buf).Encode(req); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Channel.Send(transport.Request{
+		NodeID: uatype.NewFourByteNodeID(0, uatype.NodeIdSetTriggeringRequest_Encoding_DefaultBinary).Expanded(),
+		Body:   &buf,
+	}, deadline)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Close()
+	switch resp.NodeID.Uint() {
+	case uatype.NodeIdSetTriggeringResponse_Encoding_DefaultBinary:
+		res := &uatype.SetTriggeringResponse{}
+		if err := c.newDecoder(resp).Decode(res); err != nil {
+			return res, err
+		}
+		return res, nil
+	case uatype.NodeIdServiceFault_Encoding_DefaultBinary:
+		fault := uatype.ServiceFault{}
+		if err := c.newDecoder(resp).Decode(&fault); err != nil {
+			return nil, err
+		}
+		return nil, fault
+	}
+	return nil, fmt.Errorf("Unexpected NodeID: %d %s", resp.NodeID.Uint(), resp.NodeID.DisplayName())
+}
+
+// SetTriggeringContext sends a SetTriggering request to the server, and waits for a
+// response or for ctx to be done. The deadline of ctx is sent as the timeout
+// hint of the request. If ctx is done first, a Cancel request is sent for the
+// request handle.
+func (c *Client) SetTriggeringContext(ctx context.Context, req uatype.SetTriggeringRequest) (*uatype.SetTriggeringResponse, error) {
+	c.contextHeader(ctx, &req.RequestHeader)
+	var buf bytes.Buffer
+
+	if err := c.newEncoder(&buf).Encode(req); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.sendContext(ctx, req.RequestHeader, transport.Request{
+		NodeID: uatype.NewFourByteNodeID(0, uatype.NodeIdSetTriggeringRequest_Encoding_DefaultBinary).Expanded(),
+		Body:   &buf,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Close()
+	switch resp.NodeID.Uint() {
+	case uatype.NodeIdSetTriggeringResponse_Encoding_DefaultBinary:
+		res := &uatype.SetTriggeringResponse{}
+		if err := c.newDecoder(resp).Decode(res); err != nil {
+			return res, c.contextError(ctx, req.RequestHeader, err)
+		}
+		return res, nil
+	case uatype.NodeIdServiceFault_Encoding_DefaultBinary:
+		fault := uatype.ServiceFault{}
+		if err := c.newDecoder(resp).Decode(&fault); err != nil {
+			return nil, c.contextError(ctx, req.RequestHeader, err)
+		}
+		return nil, fault
+	}
+	return nil, fmt.Errorf("Unexpected NodeID: %d %s", resp.NodeID.Uint(), resp.NodeID.DisplayName())
+}
+
+// DeleteMonitoredItems sends a DeleteMonitoredItems request to the server, and waits for a
+// response or timeout. To send and receive with no timeout use the zero time
+// as deadline.
+func (c *Client) DeleteMonitoredItems(req uatype.DeleteMonitoredItemsRequest, deadline time.Time) (*uatype.DeleteMonitoredItemsResponse, error) {
+	var buf bytes.Buffer
+
+	if err := c.newEncoder(&buf).Encode(req); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Channel.Send(transport.Request{
+		NodeID: uatype.NewFourByteNodeID(0, uatype.NodeIdDeleteMonitoredItemsRequest_Encoding_DefaultBinary).Expanded(),
+		Body:   &buf,
+	}, deadline)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Close()
+	switch resp.NodeID.Uint() {
+	case uatype.NodeIdDeleteMonitoredItemsResponse_Encoding_DefaultBinary:
+		res := &uatype.DeleteMonitoredItemsResponse{}
+		if err := c.newDecoder(resp).Decode(res); err != nil {
+			return res, err
+		}
+		return res, nil
+	case uatype.NodeIdServiceFault_Encoding_DefaultBinary:
+		fault := uatype.ServiceFault{}
+		if err := c.newDecoder(resp).Decode(&fault); err != nil {
+			return nil, err
+		}
+		return nil, fault
+	}
+	return nil, fmt.Errorf("Unexpected NodeID: %d %s", resp.NodeID.Uint(), resp.NodeID.DisplayName())
+}
+
+// DeleteMonitoredItemsContext sends a DeleteMonitoredItems request to the server, and waits for a
+// response or for ctx to be done. The deadline of ctx is sent as the timeout
+// hint of the request. If ctx is done first, a Cancel request is sent for the
+// request handle.
+func (c *Client) DeleteMonitoredItemsContext(ctx context.Context, req uatype.DeleteMonitoredItemsRequest) (*uatype.DeleteMonitoredItemsResponse, error) {
+	c.contextHeader(ctx, &req.RequestHeader)
+	var buf bytes.Buffer
+
+	if err := c.newEncoder(&buf).Encode(req); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.sendContext(ctx, req.RequestHeader, transport.Request{
+		NodeID: uatype.NewFourByteNodeID(0, uatype.NodeIdDeleteMonitoredItemsRequest_Encoding_DefaultBinary).Expanded(),
+		Body:   &buf,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Close()
+	switch resp.NodeID.Uint() {
+	case uatype.NodeIdDeleteMonitoredItemsResponse_Encoding_DefaultBinary:
+		res := &uatype.DeleteMonitoredItemsResponse{}
+		if err := c.newDecoder(resp).Decode(res); err != nil {
+			return res, c.contextError(ctx, req.RequestHeader, err)
+		}
+		return res, nil
+	case uatype.NodeIdServiceFault_Encoding_DefaultBinary:
+		fault := uatype.ServiceFault{}
+		if err := c.newDecoder(resp).Decode(&fault); err != nil {
+			return nil, c.contextError(ctx, req.RequestHeader, err)
+		}
+		return nil, fault
+	}
+	return nil, fmt.Errorf("Unexpected NodeID: %d %s", resp.NodeID.Uint(), resp.NodeID.DisplayName())
+}
+
+// CreateSubscription sends a CreateSubscription request to the server, and waits for a
+// response or timeout. To send and receive with no timeout use the zero time
+// as deadline.
+func (c *Client) CreateSubscription(req uatype.CreateSubscriptionRequest, deadline time.Time) (*uatype.CreateSubscriptionResponse, error) {
+	var buf bytes.Buffer
+
+	if err := c.newEncoder(&buf).Encode(req); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Channel.Send(transport.Request{
+		NodeID: uatype.NewFourByteNodeID(0, uatype.NodeIdCreateSubscriptionRequest_Encoding_DefaultBinary).Expanded(),
+		Body:   &buf,
+	}, deadline)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Close()
+	switch resp.NodeID.Uint() {
+	case uatype.NodeIdCreateSubscriptionResponse_Encoding_DefaultBinary:
+		res := &uatype.CreateSubscriptionResponse{}
+		if err := c.newDecoder(resp).Decode(res); err != nil {
+			return res, err
+		}
+		return res, nil
+	case uatype.NodeIdServiceFault_Encoding_DefaultBinary:
+		fault := uatype.ServiceFault{}
+		if err := c.newDecoder(resp).Decode(&fault); err != nil {
+			return nil, err
+		}
+		return nil, fault
+	}
+	return nil, fmt.Errorf("Unexpected NodeID: %d %s", resp.NodeID.Uint(), resp.NodeID.DisplayName())
+}
+
+// CreateSubscriptionContext sends a CreateSubscription request to the server, and waits for a
+// response or for ctx to be done. The deadline of ctx is sent as the timeout
+// hint of the request. If ctx is done first, a Cancel request is sent for the
+// request handle.
+func (c *Client) CreateSubscriptionContext(ctx context.Context, req uatype.CreateSubscriptionRequest) (*uatype.CreateSubscriptionResponse, error) {
+	c.contextHeader(ctx, &req.RequestHeader)
+	var buf bytes.Buffer
+
+	if err := c.newEncoder(&buf).Encode(req); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.sendContext(ctx, req.RequestHeader, transport.Request{
+		NodeID: uatype.NewFourByteNodeID(0, uatype.NodeIdCreateSubscriptionRequest_Encoding_DefaultBinary).Expanded(),
+		Body:   &buf,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Close()
+	switch resp.NodeID.Uint() {
+	case uatype.NodeIdCreateSubscriptionResponse_Encoding_DefaultBinary:
+		res := &uatype.CreateSubscriptionResponse{}
+		if err := c.newDecoder(resp).Decode(res); err != nil {
+			return res, c.contextError(ctx, req.RequestHeader, err)
+		}
+		return res, nil
+	case uatype.NodeIdServiceFault_Encoding_DefaultBinary:
+		fault := uatype.ServiceFault{}
+		if err := c.newDecoder(resp).Decode(&fault); err != nil {
+			return nil, c.contextError(ctx, req.RequestHeader, err)
+		}
+		return nil, fault
+	}
+	return nil, fmt.Errorf("Unexpected NodeID: %d %s", resp.NodeID.Uint(), resp.NodeID.DisplayName())
+}
+
+// ModifySubscription sends a ModifySubscription request to the server, and waits for a
+// response or timeout. To send and receive with no timeout use the zero time
+// as deadline.
+func (c *Client) ModifySubscription(req uatype.ModifySubscriptionRequest, deadline time.Time) (*uatype.ModifySubscriptionResponse, error) {
 	var buf bytes.Buffer
 
-	if err := binary.NewEncoder(&buf).Encode(req); err != nil {
+	if err := c.newEncoder(&buf).Encode(req); err != nil {
 		return nil, err
 	}
 
 	resp, err := c.Channel.Send(transport.Request{
-		NodeID: uatype.NewFourByteNodeID(0, uatype.NodeIdReadRequest_Encoding_DefaultBinary).Expanded(),
+		NodeID: uatype.NewFourByteNodeID(0, uatype.NodeIdModifySubscriptionRequest_Encoding_DefaultBinary).Expanded(),
 		Body:   &buf,
 	}, deadline)
 	if err != nil {
 		return nil, err
 	}
+	defer resp.Close()
 	switch resp.NodeID.Uint() {
-	case uatype.NodeIdReadResponse_Encoding_DefaultBinary:
-		res := &uatype.ReadResponse{}
-		if err := binary.NewDecoder(resp.Body).Decode(res); err != nil {
+	case uatype.NodeIdModifySubscriptionResponse_Encoding_DefaultBinary:
+		res := &uatype.ModifySubscriptionResponse{}
+		if err := c.newDecoder(resp).Decode(res); err != nil {
 			return res, err
 		}
 		return res, nil
 	case uatype.NodeIdServiceFault_Encoding_DefaultBinary:
 		fault := uatype.ServiceFault{}
-		if err := binary.NewDecoder(resp.Body).Decode(&fault); err != nil {
+		if err := c.newDecoder(resp).Decode(&fault); err != nil {
 			return nil, err
 		}
 		return nil, fault
@@ -556,67 +1919,71 @@ func (c *Client) Read(req uatype.ReadRequest, deadline time.Time) (*uatype.ReadR
 	return nil, fmt.Errorf("Unexpected NodeID: %d %s", resp.NodeID.Uint(), resp.NodeID.DisplayName())
 }
 
-// HistoryRead sends a HistoryRead request to the server, and waits for a
-// response or timeout. To send and receive with no timeout use the zero time
-// as deadline.
-func (c *Client) HistoryRead(req uatype.HistoryReadRequest, deadline time.Time) (*uatype.HistoryReadResponse, error) {
+// ModifySubscriptionContext sends a ModifySubscription request to the server, and waits for a
+// response or for ctx to be done. The deadline of ctx is sent as the timeout
+// hint of the request. If ctx is done first, a Cancel request is sent for the
+// request handle.
+func (c *Client) ModifySubscriptionContext(ctx context.Context, req uatype.ModifySubscriptionRequest) (*uatype.ModifySubscriptionResponse, error) {
+	c.contextHeader(ctx, &req.RequestHeader)
 	var buf bytes.Buffer
 
-	if err := binary.NewEncoder(&buf).Encode(req); err != nil {
+	if err := c.newEncoder(&buf).Encode(req); err != nil {
 		return nil, err
 	}
 
-	resp, err := c.Channel.Send(transport.Request{
-		NodeID: uatype.NewFourByteNodeID(0, uatype.NodeIdHistoryReadRequest_Encoding_DefaultBinary).Expanded(),
+	resp, err := c.sendContext(ctx, req.RequestHeader, transport.Request{
+		NodeID: uatype.NewFourByteNodeID(0, uatype.NodeIdModifySubscriptionRequest_Encoding_DefaultBinary).Expanded(),
 		Body:   &buf,
-	}, deadline)
+	})
 	if err != nil {
 		return nil, err
 	}
+	defer resp.Close()
 	switch resp.NodeID.Uint() {
-	case uatype.NodeIdHistoryReadResponse_Encoding_DefaultBinary:
-		res := &uatype.HistoryReadResponse{}
-		if err := binary.NewDecoder(resp.Body).Decode(res); err != nil {
-			return res, err
+	case uatype.NodeIdModifySubscriptionResponse_Encoding_DefaultBinary:
+		res := &uatype.ModifySubscriptionResponse{}
+		if err := c.newDecoder(resp).Decode(res); err != nil {
+			return res, c.contextError(ctx, req.RequestHeader, err)
 		}
 		return res, nil
 	case uatype.NodeIdServiceFault_Encoding_DefaultBinary:
 		fault := uatype.ServiceFault{}
-		if err := binary.NewDecoder(resp.Body).Decode(&fault); err != nil {
-			return nil, err
+		if err := c.newDecoder(resp).Decode(&fault); err != nil {
+			return nil, c.contextError(ctx, req.RequestHeader, err)
 		}
 		return nil, fault
 	}
 	return nil, fmt.Errorf("Unexpected NodeID: %d %s", resp.NodeID.Uint(), resp.NodeID.DisplayName())
 }
 
-// Write sends a Write request to the server, and waits for a
+// SetPublishingMode sends a SetPublishingMode request to the server, and waits for a
 // response or timeout. To send and receive with no timeout use the zero time
 // as deadline.
-func (c *Client) Write(req uatype.WriteRequest, deadline time.Time) (*uatype.WriteResponse, error) {
+func (c *Client) SetPublishingMode(req uatype.SetPublishingModeRequest, deadline time.Time) (*uatype.SetPublishingModeResponse, error) {
 	var buf bytes.Buffer
 
-	if err := binary.NewEncoder(&buf).Encode(req); err != nil {
+	if err := c.newEncoder(&buf).Encode(req); err != nil {
 		return nil, err
 	}
 
 	resp, err := c.Channel.Send(transport.Request{
-		NodeID: uatype.NewFourByteNodeID(0, uatype.NodeIdWriteRequest_Encoding_DefaultBinary).Expanded(),
+		NodeID: uatype.NewFourByteNodeID(0, uatype.NodeIdSetPublishingModeRequest_Encoding_DefaultBinary).Expanded(),
 		Body:   &buf,
 	}, deadline)
 	if err != nil {
 		return nil, err
 	}
+	defer resp.Close()
 	switch resp.NodeID.Uint() {
-	case uatype.NodeIdWriteResponse_Encoding_DefaultBinary:
-		res := &uatype.WriteResponse{}
-		if err := binary.NewDecoder(resp.Body).Decode(res); err != nil {
+	case uatype.NodeIdSetPublishingModeResponse_Encoding_DefaultBinary:
+		res := &uatype.SetPublishingModeResponse{}
+		if err := c.newDecoder(resp).Decode(res); err != nil {
 			return res, err
 		}
 		return res, nil
 	case uatype.NodeIdServiceFault_Encoding_DefaultBinary:
 		fault := uatype.ServiceFault{}
-		if err := binary.NewDecoder(resp.Body).Decode(&fault); err != nil {
+		if err := c.newDecoder(resp).Decode(&fault); err != nil {
 			return nil, err
 		}
 		return nil, fault
@@ -624,67 +1991,71 @@ func (c *Client) Write(req uatype.WriteRequest, deadline time.Time) (*uatype.Wri
 	return nil, fmt.Errorf("Unexpected NodeID: %d %s", resp.NodeID.Uint(), resp.NodeID.DisplayName())
 }
 
-// HistoryUpdate sends a HistoryUpdate request to the server, and waits for a
-// response or timeout. To send and receive with no timeout use the zero time
-// as deadline.
-func (c *Client) HistoryUpdate(req uatype.HistoryUpdateRequest, deadline time.Time) (*uatype.HistoryUpdateResponse, error) {
+// SetPublishingModeContext sends a SetPublishingMode request to the server, and waits for a
+// response or for ctx to be done. The deadline of ctx is sent as the timeout
+// hint of the request. If ctx is done first, a Cancel request is sent for the
+// request handle.
+func (c *Client) SetPublishingModeContext(ctx context.Context, req uatype.SetPublishingModeRequest) (*uatype.SetPublishingModeResponse, error) {
+	c.contextHeader(ctx, &req.RequestHeader)
 	var buf bytes.Buffer
 
-	if err := binary.NewEncoder(&buf).Encode(req); err != nil {
+	if err := c.newEncoder(&buf).Encode(req); err != nil {
 		return nil, err
 	}
 
-	resp, err := c.Channel.Send(transport.Request{
-		NodeID: uatype.NewFourByteNodeID(0, uatype.NodeIdHistoryUpdateRequest_Encoding_DefaultBinary).Expanded(),
+	resp, err := c.sendContext(ctx, req.RequestHeader, transport.Request{
+		NodeID: uatype.NewFourByteNodeID(0, uatype.NodeIdSetPublishingModeRequest_Encoding_DefaultBinary).Expanded(),
 		Body:   &buf,
-	}, deadline)
+	})
 	if err != nil {
 		return nil, err
 	}
+	defer resp.Close()
 	switch resp.NodeID.Uint() {
-	case uatype.NodeIdHistoryUpdateResponse_Encoding_DefaultBinary:
-		res := &uatype.HistoryUpdateResponse{}
-		if err := binary.NewDecoder(resp.Body).Decode(res); err != nil {
-			return res, err
+	case uatype.NodeIdSetPublishingModeResponse_Encoding_DefaultBinary:
+		res := &uatype.SetPublishingModeResponse{}
+		if err := c.newDecoder(resp).Decode(res); err != nil {
+			return res, c.contextError(ctx, req.RequestHeader, err)
 		}
 		return res, nil
 	case uatype.NodeIdServiceFault_Encoding_DefaultBinary:
 		fault := uatype.ServiceFault{}
-		if err := binary.NewDecoder(resp.Body).Decode(&fault); err != nil {
-			return nil, err
+		if err := c.newDecoder(resp).Decode(&fault); err != nil {
+			return nil, c.contextError(ctx, req.RequestHeader, err)
 		}
 		return nil, fault
 	}
 	return nil, fmt.Errorf("Unexpected NodeID: %d %s", resp.NodeID.Uint(), resp.NodeID.DisplayName())
 }
 
-// Call sends a Call request to the server, and waits for a
+// Publish sends a Publish request to the server, and waits for a
 // response or timeout. To send and receive with no timeout use the zero time
 // as deadline.
-func (c *Client) Call(req uatype.CallRequest, deadline time.Time) (*uatype.CallResponse, error) {
+func (c *Client) Publish(req uatype.PublishRequest, deadline time.Time) (*uatype.PublishResponse, error) {
 	var buf bytes.Buffer
 
-	if err := binary.NewEncoder(&buf).Encode(req); err != nil {
+	if err := c.newEncoder(&buf).Encode(req); err != nil {
 		return nil, err
 	}
 
 	resp, err := c.Channel.Send(transport.Request{
-		NodeID: uatype.NewFourByteNodeID(0, uatype.NodeIdCallRequest_Encoding_DefaultBinary).Expanded(),
+		NodeID: uatype.NewFourByteNodeID(0, uatype.NodeIdPublishRequest_Encoding_DefaultBinary).Expanded(),
 		Body:   &buf,
 	}, deadline)
 	if err != nil {
 		return nil, err
 	}
+	defer resp.Close()
 	switch resp.NodeID.Uint() {
-	case uatype.NodeIdCallResponse_Encoding_DefaultBinary:
-		res := &uatype.CallResponse{}
-		if err := binary.NewDecoder(resp.Body).Decode(res); err != nil {
+	case uatype.NodeIdPublishResponse_Encoding_DefaultBinary:
+		res := &uatype.PublishResponse{}
+		if err := c.newDecoder(resp).Decode(res); err != nil {
 			return res, err
 		}
 		return res, nil
 	case uatype.NodeIdServiceFault_Encoding_DefaultBinary:
 		fault := uatype.ServiceFault{}
-		if err := binary.NewDecoder(resp.Body).Decode(&fault); err != nil {
+		if err := c.newDecoder(resp).Decode(&fault); err != nil {
 			return nil, err
 		}
 		return nil, fault
@@ -692,67 +2063,71 @@ func (c *Client) Call(req uatype.CallRequest, deadline time.Time) (*uatype.CallR
 	return nil, fmt.Errorf("Unexpected NodeID: %d %s", resp.NodeID.Uint(), resp.NodeID.DisplayName())
 }
 
-// CreateMonitoredItems sends a CreateMonitoredItems request to the server, and waits for a
-// response or timeout. To send and receive with no timeout use the zero time
-// as deadline.
-func (c *Client) CreateMonitoredItems(req uatype.CreateMonitoredItemsRequest, deadline time.Time) (*uatype.CreateMonitoredItemsResponse, error) {
+// PublishContext sends a Publish request to the server, and waits for a
+// response or for ctx to be done. The deadline of ctx is sent as the timeout
+// hint of the request. If ctx is done first, a Cancel request is sent for the
+// request handle.
+func (c *Client) PublishContext(ctx context.Context, req uatype.PublishRequest) (*uatype.PublishResponse, error) {
+	c.contextHeader(ctx, &req.RequestHeader)
 	var buf bytes.Buffer
 
-	if err := binary.NewEncoder(&buf).Encode(req); err != nil {
+	if err := c.newEncoder(&buf).Encode(req); err != nil {
 		return nil, err
 	}
 
-	resp, err := c.Channel.Send(transport.Request{
-		NodeID: uatype.NewFourByteNodeID(0, uatype.NodeIdCreateMonitoredItemsRequest_Encoding_DefaultBinary).Expanded(),
+	resp, err := c.sendContext(ctx, req.RequestHeader, transport.Request{
+		NodeID: uatype.NewFourByteNodeID(0, uatype.NodeIdPublishRequest_Encoding_DefaultBinary).Expanded(),
 		Body:   &buf,
-	}, deadline)
+	})
 	if err != nil {
 		return nil, err
 	}
+	defer resp.Close()
 	switch resp.NodeID.Uint() {
-	case uatype.NodeIdCreateMonitoredItemsResponse_Encoding_DefaultBinary:
-		res := &uatype.CreateMonitoredItemsResponse{}
-		if err := binary.NewDecoder(resp.Body).Decode(res); err != nil {
-			return res, err
+	case uatype.NodeIdPublishResponse_Encoding_DefaultBinary:
+		res := &uatype.PublishResponse{}
+		if err := c.newDecoder(resp).Decode(res); err != nil {
+			return res, c.contextError(ctx, req.RequestHeader, err)
 		}
 		return res, nil
 	case uatype.NodeIdServiceFault_Encoding_DefaultBinary:
 		fault := uatype.ServiceFault{}
-		if err := binary.NewDecoder(resp.Body).Decode(&fault); err != nil {
-			return nil, err
+		if err := c.newDecoder(resp).Decode(&fault); err != nil {
+			return nil, c.contextError(ctx, req.RequestHeader, err)
 		}
 		return nil, fault
 	}
 	return nil, fmt.Errorf("Unexpected NodeID: %d %s", resp.NodeID.Uint(), resp.NodeID.DisplayName())
 }
 
-// ModifyMonitoredItems sends a ModifyMonitoredItems request to the server, and waits for a
+// Republish sends a Republish request to the server, and waits for a
 // response or timeout. To send and receive with no timeout use the zero time
 // as deadline.
-func (c *Client) ModifyMonitoredItems(req uatype.ModifyMonitoredItemsRequest, deadline time.Time) (*uatype.ModifyMonitoredItemsResponse, error) {
+func (c *Client) Republish(req uatype.RepublishRequest, deadline time.Time) (*uatype.RepublishResponse, error) {
 	var buf bytes.Buffer
 
-	if err := binary.NewEncoder(&buf).Encode(req); err != nil {
+	if err := c.newEncoder(&buf).Encode(req); err != nil {
 		return nil, err
 	}
 
 	resp, err := c.Channel.Send(transport.Request{
-		NodeID: uatype.NewFourByteNodeID(0, uatype.NodeIdModifyMonitoredItemsRequest_Encoding_DefaultBinary).Expanded(),
+		NodeID: uatype.NewFourByteNodeID(0, uatype.NodeIdRepublishRequest_Encoding_DefaultBinary).Expanded(),
 		Body:   &buf,
 	}, deadline)
 	if err != nil {
 		return nil, err
 	}
+	defer resp.Close()
 	switch resp.NodeID.Uint() {
-	case uatype.NodeIdModifyMonitoredItemsResponse_Encoding_DefaultBinary:
-		res := &uatype.ModifyMonitoredItemsResponse{}
-		if err := binary.NewDecoder(resp.Body).Decode(res); err != nil {
+	case uatype.NodeIdRepublishResponse_Encoding_DefaultBinary:
+		res := &uatype.RepublishResponse{}
+		if err := c.newDecoder(resp).Decode(res); err != nil {
 			return res, err
 		}
 		return res, nil
 	case uatype.NodeIdServiceFault_Encoding_DefaultBinary:
 		fault := uatype.ServiceFault{}
-		if err := binary.NewDecoder(resp.Body).Decode(&fault); err != nil {
+		if err := c.newDecoder(resp).Decode(&fault); err != nil {
 			return nil, err
 		}
 		return nil, fault
@@ -760,67 +2135,71 @@ func (c *Client) ModifyMonitoredItems(req uatype.ModifyMonitoredItemsRequest, de
 	return nil, fmt.Errorf("Unexpected NodeID: %d %s", resp.NodeID.Uint(), resp.NodeID.DisplayName())
 }
 
-// SetMonitoringMode sends a SetMonitoringMode request to the server, and waits for a
-// response or timeout. To send and receive with no timeout use the zero time
-// as deadline.
-func (c *Client) SetMonitoringMode(req uatype.SetMonitoringModeRequest, deadline time.Time) (*uatype.SetMonitoringModeResponse, error) {
+// RepublishContext sends a Republish request to the server, and waits for a
+// response or for ctx to be done. The deadline of ctx is sent as the timeout
+// hint of the request. If ctx is done first, a Cancel request is sent for the
+// request handle.
+func (c *Client) RepublishContext(ctx context.Context, req uatype.RepublishRequest) (*uatype.RepublishResponse, error) {
+	c.contextHeader(ctx, &req.RequestHeader)
 	var buf bytes.Buffer
 
-	if err := binary.NewEncoder(&buf).Encode(req); err != nil {
+	if err := c.newEncoder(&buf).Encode(req); err != nil {
 		return nil, err
 	}
 
-	resp, err := c.Channel.Send(transport.Request{
-		NodeID: uatype.NewFourByteNodeID(0, uatype.NodeIdSetMonitoringModeRequest_Encoding_DefaultBinary).Expanded(),
+	resp, err := c.sendContext(ctx, req.RequestHeader, transport.Request{
+		NodeID: uatype.NewFourByteNodeID(0, uatype.NodeIdRepublishRequest_Encoding_DefaultBinary).Expanded(),
 		Body:   &buf,
-	}, deadline)
+	})
 	if err != nil {
 		return nil, err
 	}
+	defer resp.Close()
 	switch resp.NodeID.Uint() {
-	case uatype.NodeIdSetMonitoringModeResponse_Encoding_DefaultBinary:
-		res := &uatype.SetMonitoringModeResponse{}
-		if err := binary.NewDecoder(resp.Body).Decode(res); err != nil {
-			return res, err
+	case uatype.NodeIdRepublishResponse_Encoding_DefaultBinary:
+		res := &uatype.RepublishResponse{}
+		if err := c.newDecoder(resp).Decode(res); err != nil {
+			return res, c.contextError(ctx, req.RequestHeader, err)
 		}
 		return res, nil
 	case uatype.NodeIdServiceFault_Encoding_DefaultBinary:
 		fault := uatype.ServiceFault{}
-		if err := binary.NewDecoder(resp.Body).Decode(&fault); err != nil {
-			return nil, err
+		if err := c.newDecoder(resp).Decode(&fault); err != nil {
+			return nil, c.contextError(ctx, req.RequestHeader, err)
 		}
 		return nil, fault
 	}
 	return nil, fmt.Errorf("Unexpected NodeID: %d %s", resp.NodeID.Uint(), resp.NodeID.DisplayName())
 }
 
-// SetTriggering sends a SetTriggering request to the server, and waits for a
+// TransferSubscriptions sends a TransferSubscriptions request to the server, and waits for a
 // response or timeout. To send and receive with no timeout use the zero time
 // as deadline.
-func (c *Client) SetTriggering(req uatype.SetTriggeringRequest, deadline time.Time) (*uatype.SetTriggeringResponse, error) {
+func (c *Client) TransferSubscriptions(req uatype.TransferSubscriptionsRequest, deadline time.Time) (*uatype.TransferSubscriptionsResponse, error) {
 	var buf bytes.Buffer
 
-	if err := binary.NewEncoder(&buf).Encode(req); err != nil {
+	if err := c.newEncoder(&buf).Encode(req); err != nil {
 		return nil, err
 	}
 
 	resp, err := c.Channel.Send(transport.Request{
-		NodeID: uatype.NewFourByteNodeID(0, uatype.NodeIdSetTriggeringRequest_Encoding_DefaultBinary).Expanded(),
+		NodeID: uatype.NewFourByteNodeID(0, uatype.NodeIdTransferSubscriptionsRequest_Encoding_DefaultBinary).Expanded(),
 		Body:   &buf,
 	}, deadline)
 	if err != nil {
 		return nil, err
 	}
+	defer resp.Close()
 	switch resp.NodeID.Uint() {
-	case uatype.NodeIdSetTriggeringResponse_Encoding_DefaultBinary:
-		res := &uatype.SetTriggeringResponse{}
-		if err := binary.NewDecoder(resp.Body).Decode(res); err != nil {
+	case uatype.NodeIdTransferSubscriptionsResponse_Encoding_DefaultBinary:
+		res := &uatype.TransferSubscriptionsResponse{}
+		if err := c.newDecoder(resp).Decode(res); err != nil {
 			return res, err
 		}
 		return res, nil
 	case uatype.NodeIdServiceFault_Encoding_DefaultBinary:
 		fault := uatype.ServiceFault{}
-		if err := binary.NewDecoder(resp.Body).Decode(&fault); err != nil {
+		if err := c.newDecoder(resp).Decode(&fault); err != nil {
 			return nil, err
 		}
 		return nil, fault
@@ -828,67 +2207,71 @@ func (c *Client) SetTriggering(req uatype.SetTriggeringRequest, deadline time.Ti
 	return nil, fmt.Errorf("Unexpected NodeID: %d %s", resp.NodeID.Uint(), resp.NodeID.DisplayName())
 }
 
-// DeleteMonitoredItems sends a DeleteMonitoredItems request to the server, and waits for a
-// response or timeout. To send and receive with no timeout use the zero time
-// as deadline.
-func (c *Client) DeleteMonitoredItems(req uatype.DeleteMonitoredItemsRequest, deadline time.Time) (*uatype.DeleteMonitoredItemsResponse, error) {
+// TransferSubscriptionsContext sends a TransferSubscriptions request to the server, and waits for a
+// response or for ctx to be done. The deadline of ctx is sent as the timeout
+// hint of the request. If ctx is done first, a Cancel request is sent for the
+// request handle.
+func (c *Client) TransferSubscriptionsContext(ctx context.Context, req uatype.TransferSubscriptionsRequest) (*uatype.TransferSubscriptionsResponse, error) {
+	c.contextHeader(ctx, &req.RequestHeader)
 	var buf bytes.Buffer
 
-	if err := binary.NewEncoder(&buf).Encode(req); err != nil {
+	if err := c.newEncoder(&buf).Encode(req); err != nil {
 		return nil, err
 	}
 
-	resp, err := c.Channel.Send(transport.Request{
-		NodeID: uatype.NewFourByteNodeID(0, uatype.NodeIdDeleteMonitoredItemsRequest_Encoding_DefaultBinary).Expanded(),
+	resp, err := c.sendContext(ctx, req.RequestHeader, transport.Request{
+		NodeID: uatype.NewFourByteNodeID(0, uatype.NodeIdTransferSubscriptionsRequest_Encoding_DefaultBinary).Expanded(),
 		Body:   &buf,
-	}, deadline)
+	})
 	if err != nil {
 		return nil, err
 	}
+	defer resp.Close()
 	switch resp.NodeID.Uint() {
-	case uatype.NodeIdDeleteMonitoredItemsResponse_Encoding_DefaultBinary:
-		res := &uatype.DeleteMonitoredItemsResponse{}
-		if err := binary.NewDecoder(resp.Body).Decode(res); err != nil {
-			return res, err
+	case uatype.NodeIdTransferSubscriptionsResponse_Encoding_DefaultBinary:
+		res := &uatype.TransferSubscriptionsResponse{}
+		if err := c.newDecoder(resp).Decode(res); err != nil {
+			return res, c.contextError(ctx, req.RequestHeader, err)
 		}
 		return res, nil
 	case uatype.NodeIdServiceFault_Encoding_DefaultBinary:
 		fault := uatype.ServiceFault{}
-		if err := binary.NewDecoder(resp.Body).Decode(&fault); err != nil {
-			return nil, err
+		if err := c.newDecoder(resp).Decode(&fault); err != nil {
+			return nil, c.contextError(ctx, req.RequestHeader, err)
 		}
 		return nil, fault
 	}
 	return nil, fmt.Errorf("Unexpected NodeID: %d %s", resp.NodeID.Uint(), resp.NodeID.DisplayName())
 }
 
-// CreateSubscription sends a CreateSubscription request to the server, and waits for a
+// DeleteSubscriptions sends a DeleteSubscriptions request to the server, and waits for a
 // response or timeout. To send and receive with no timeout use the zero time
 // as deadline.
-func (c *Client) CreateSubscription(req uatype.CreateSubscriptionRequest, deadline time.Time) (*uatype.CreateSubscriptionResponse, error) {
+func (c *Client) DeleteSubscriptions(req uatype.DeleteSubscriptionsRequest, deadline time.Time) (*uatype.DeleteSubscriptionsResponse, error) {
 	var buf bytes.Buffer
 
-	if err := binary.NewEncoder(&buf).Encode(req); err != nil {
+	if err := c.newEncoder(&buf).Encode(req); err != nil {
 		return nil, err
 	}
 
 	resp, err := c.Channel.Send(transport.Request{
-		NodeID: uatype.NewFourByteNodeID(0, uatype.NodeIdCreateSubscriptionRequest_Encoding_DefaultBinary).Expanded(),
+		NodeID: uatype.NewFourByteNodeID(0, uatype.NodeIdDeleteSubscriptionsRequest_Encoding_DefaultBinary).Expanded(),
 		Body:   &buf,
 	}, deadline)
 	if err != nil {
 		return nil, err
 	}
+	defer resp.Close()
 	switch resp.NodeID.Uint() {
-	case uatype.NodeIdCreateSubscriptionResponse_Encoding_DefaultBinary:
-		res := &uatype.CreateSubscriptionResponse{}
-		if err := binary.NewDecoder(resp.Body).Decode(res); err != nil {
+	case uatype.NodeIdDeleteSubscriptionsResponse_Encoding_DefaultBinary:
+		res := &uatype.DeleteSubscriptionsResponse{}
+		if err := c.newDecoder(resp).Decode(res); err != nil {
 			return res, err
 		}
 		return res, nil
 	case uatype.NodeIdServiceFault_Encoding_DefaultBinary:
 		fault := uatype.ServiceFault{}
-		if err := binary.NewDecoder(resp.Body).Decode(&fault); err != nil {
+		if err := c.newDecoder(resp).Decode(&fault); err != nil {
 			return nil, err
 		}
 		return nil, fault
@@ -896,67 +2279,71 @@ func (c *Client) CreateSubscription(req uatype.CreateSubscriptionRequest, deadli
 	return nil, fmt.Errorf("Unexpected NodeID: %d %s", resp.NodeID.Uint(), resp.NodeID.DisplayName())
 }
 
-// ModifySubscription sends a ModifySubscription request to the server, and waits for a
-// response or timeout. To send and receive with no timeout use the zero time
-// as deadline.
-func (c *Client) ModifySubscription(req uatype.ModifySubscriptionRequest, deadline time.Time) (*uatype.ModifySubscriptionResponse, error) {
+// DeleteSubscriptionsContext sends a DeleteSubscriptions request to the server, and waits for a
+// response or for ctx to be done. The deadline of ctx is sent as the timeout
+// hint of the request. If ctx is done first, a Cancel request is sent for the
+// request handle.
+func (c *Client) DeleteSubscriptionsContext(ctx context.Context, req uatype.DeleteSubscriptionsRequest) (*uatype.DeleteSubscriptionsResponse, error) {
+	c.contextHeader(ctx, &req.RequestHeader)
 	var buf bytes.Buffer
 
-	if err := binary.NewEncoder(&buf).Encode(req); err != nil {
+	if err := c.newEncoder(&buf).Encode(req); err != nil {
 		return nil, err
 	}
 
-	resp, err := c.Channel.Send(transport.Request{
-		NodeID: uatype.NewFourByteNodeID(0, uatype.NodeIdModifySubscriptionRequest_Encoding_DefaultBinary).Expanded(),
+	resp, err := c.sendContext(ctx, req.RequestHeader, transport.Request{
+		NodeID: uatype.NewFourByteNodeID(0, uatype.NodeIdDeleteSubscriptionsRequest_Encoding_DefaultBinary).Expanded(),
 		Body:   &buf,
-	}, deadline)
+	})
 	if err != nil {
 		return nil, err
 	}
+	defer resp.Close()
 	switch resp.NodeID.Uint() {
-	case uatype.NodeIdModifySubscriptionResponse_Encoding_DefaultBinary:
-		res := &uatype.ModifySubscriptionResponse{}
-		if err := binary.NewDecoder(resp.Body).Decode(res); err != nil {
-			return res, err
+	case uatype.NodeIdDeleteSubscriptionsResponse_Encoding_DefaultBinary:
+		res := &uatype.DeleteSubscriptionsResponse{}
+		if err := c.newDecoder(resp).Decode(res); err != nil {
+			return res, c.contextError(ctx, req.RequestHeader, err)
 		}
 		return res, nil
 	case uatype.NodeIdServiceFault_Encoding_DefaultBinary:
 		fault := uatype.ServiceFault{}
-		if err := binary.NewDecoder(resp.Body).Decode(&fault); err != nil {
-			return nil, err
+		if err := c.newDecoder(resp).Decode(&fault); err != nil {
+			return nil, c.contextError(ctx, req.RequestHeader, err)
 		}
 		return nil, fault
 	}
 	return nil, fmt.Errorf("Unexpected NodeID: %d %s", resp.NodeID.Uint(), resp.NodeID.DisplayName())
 }
 
-// SetPublishingMode sends a SetPublishingMode request to the server, and waits for a
+// FindServers sends a FindServers request to the server, and waits for a
 // response or timeout. To send and receive with no timeout use the zero time
 // as deadline.
-func (c *Client) SetPublishingMode(req uatype.SetPublishingModeRequest, deadline time.Time) (*uatype.SetPublishingModeResponse, error) {
+func (c *Client) FindServers(req uatype.FindServersRequest, deadline time.Time) (*uatype.FindServersResponse, error) {
 	var buf bytes.Buffer
 
-	if err := binary.NewEncoder(&buf).Encode(req); err != nil {
+	if err := c.newEncoder(&buf).Encode(req); err != nil {
 		return nil, err
 	}
 
 	resp, err := c.Channel.Send(transport.Request{
-		NodeID: uatype.NewFourByteNodeID(0, uatype.NodeIdSetPublishingModeRequest_Encoding_DefaultBinary).Expanded(),
+		NodeID: uatype.NewFourByteNodeID(0, uatype.NodeIdFindServersRequest_Encoding_DefaultBinary).Expanded(),
 		Body:   &buf,
 	}, deadline)
 	if err != nil {
 		return nil, err
 	}
+	defer resp.Close()
 	switch resp.NodeID.Uint() {
-	case uatype.NodeIdSetPublishingModeResponse_Encoding_DefaultBinary:
-		res := &uatype.SetPublishingModeResponse{}
-		if err := binary.NewDecoder(resp.Body).Decode(res); err != nil {
+	case uatype.NodeIdFindServersResponse_Encoding_DefaultBinary:
+		res := &uatype.FindServersResponse{}
+		if err := c.newDecoder(resp).Decode(res); err != nil {
 			return res, err
 		}
 		return res, nil
 	case uatype.NodeIdServiceFault_Encoding_DefaultBinary:
 		fault := uatype.ServiceFault{}
-		if err := binary.NewDecoder(resp.Body).Decode(&fault); err != nil {
+		if err := c.newDecoder(resp).Decode(&fault); err != nil {
 			return nil, err
 		}
 		return nil, fault
@@ -964,67 +2351,71 @@ func (c *Client) SetPublishingMode(req uatype.SetPublishingModeRequest, deadline
 	return nil, fmt.Errorf("Unexpected NodeID: %d %s", resp.NodeID.Uint(), resp.NodeID.DisplayName())
 }
 
-// Publish sends a Publish request to the server, and waits for a
-// response or timeout. To send and receive with no timeout use the zero time
-// as deadline.
-func (c *Client) Publish(req uatype.PublishRequest, deadline time.Time) (*uatype.PublishResponse, error) {
+// FindServersContext sends a FindServers request to the server, and waits for a
+// response or for ctx to be done. The deadline of ctx is sent as the timeout
+// hint of the request. If ctx is done first, a Cancel request is sent for the
+// request handle.
+func (c *Client) FindServersContext(ctx context.Context, req uatype.FindServersRequest) (*uatype.FindServersResponse, error) {
+	c.contextHeader(ctx, &req.RequestHeader)
 	var buf bytes.Buffer
 
-	if err := binary.NewEncoder(&buf).Encode(req); err != nil {
+	if err := c.newEncoder(&buf).Encode(req); err != nil {
 		return nil, err
 	}
 
-	resp, err := c.Channel.Send(transport.Request{
-		NodeID: uatype.NewFourByteNodeID(0, uatype.NodeIdPublishRequest_Encoding_DefaultBinary).Expanded(),
+	resp, err := c.sendContext(ctx, req.RequestHeader, transport.Request{
+		NodeID: uatype.NewFourByteNodeID(0, uatype.NodeIdFindServersRequest_Encoding_DefaultBinary).Expanded(),
 		Body:   &buf,
-	}, deadline)
+	})
 	if err != nil {
 		return nil, err
 	}
+	defer resp.Close()
 	switch resp.NodeID.Uint() {
-	case uatype.NodeIdPublishResponse_Encoding_DefaultBinary:
-		res := &uatype.PublishResponse{}
-		if err := binary.NewDecoder(resp.Body).Decode(res); err != nil {
-			return res, err
+	case uatype.NodeIdFindServersResponse_Encoding_DefaultBinary:
+		res := &uatype.FindServersResponse{}
+		if err := c.newDecoder(resp).Decode(res); err != nil {
+			return res, c.contextError(ctx, req.RequestHeader, err)
 		}
 		return res, nil
 	case uatype.NodeIdServiceFault_Encoding_DefaultBinary:
 		fault := uatype.ServiceFault{}
-		if err := binary.NewDecoder(resp.Body).Decode(&fault); err != nil {
-			return nil, err
+		if err := c.newDecoder(resp).Decode(&fault); err != nil {
+			return nil, c.contextError(ctx, req.RequestHeader, err)
 		}
 		return nil, fault
 	}
 	return nil, fmt.Errorf("Unexpected NodeID: %d %s", resp.NodeID.Uint(), resp.NodeID.DisplayName())
 }
 
-// Republish sends a Republish request to the server, and waits for a
+// FindServersOnNetwork sends a FindServersOnNetwork request to the server, and waits for a
 // response or timeout. To send and receive with no timeout use the zero time
 // as deadline.
-func (c *Client) Republish(req uatype.RepublishRequest, deadline time.Time) (*uatype.RepublishResponse, error) {
+func (c *Client) FindServersOnNetwork(req uatype.FindServersOnNetworkRequest, deadline time.Time) (*uatype.FindServersOnNetworkResponse, error) {
 	var buf bytes.Buffer
 
-	if err := binary.NewEncoder(&buf).Encode(req); err != nil {
+	if err := c.newEncoder(&buf).Encode(req); err != nil {
 		return nil, err
 	}
 
 	resp, err := c.Channel.Send(transport.Request{
-		NodeID: uatype.NewFourByteNodeID(0, uatype.NodeIdRepublishRequest_Encoding_DefaultBinary).Expanded(),
+		NodeID: uatype.NewFourByteNodeID(0, uatype.NodeIdFindServersOnNetworkRequest_Encoding_DefaultBinary).Expanded(),
 		Body:   &buf,
 	}, deadline)
 	if err != nil {
 		return nil, err
 	}
+	defer resp.Close()
 	switch resp.NodeID.Uint() {
-	case uatype.NodeIdRepublishResponse_Encoding_DefaultBinary:
-		res := &uatype.RepublishResponse{}
-		if err := binary.NewDecoder(resp.Body).Decode(res); err != nil {
+	case uatype.NodeIdFindServersOnNetworkResponse_Encoding_DefaultBinary:
+		res := &uatype.FindServersOnNetworkResponse{}
+		if err := c.newDecoder(resp).Decode(res); err != nil {
 			return res, err
 		}
 		return res, nil
 	case uatype.NodeIdServiceFault_Encoding_DefaultBinary:
 		fault := uatype.ServiceFault{}
-		if err := binary.NewDecoder(resp.Body).Decode(&fault); err != nil {
+		if err := c.newDecoder(resp).Decode(&fault); err != nil {
 			return nil, err
 		}
 		return nil, fault
@@ -1032,67 +2423,71 @@ func (c *Client) Republish(req uatype.RepublishRequest, deadline time.Time) (*ua
 	return nil, fmt.Errorf("Unexpected NodeID: %d %s", resp.NodeID.Uint(), resp.NodeID.DisplayName())
 }
 
-// TransferSubscriptions sends a TransferSubscriptions request to the server, and waits for a
-// response or timeout. To send and receive with no timeout use the zero time
-// as deadline.
-func (c *Client) TransferSubscriptions(req uatype.TransferSubscriptionsRequest, deadline time.Time) (*uatype.TransferSubscriptionsResponse, error) {
+// FindServersOnNetworkContext sends a FindServersOnNetwork request to the server, and waits for a
+// response or for ctx to be done. The deadline of ctx is sent as the timeout
+// hint of the request. If ctx is done first, a Cancel request is sent for the
+// request handle.
+func (c *Client) FindServersOnNetworkContext(ctx context.Context, req uatype.FindServersOnNetworkRequest) (*uatype.FindServersOnNetworkResponse, error) {
+	c.contextHeader(ctx, &req.RequestHeader)
 	var buf bytes.Buffer
 
-	if err := binary.NewEncoder(&buf).Encode(req); err != nil {
+	if err := c.newEncoder(&buf).Encode(req); err != nil {
 		return nil, err
 	}
 
-	resp, err := c.Channel.Send(transport.Request{
-		NodeID: uatype.NewFourByteNodeID(0, uatype.NodeIdTransferSubscriptionsRequest_Encoding_DefaultBinary).Expanded(),
+	resp, err := c.sendContext(ctx, req.RequestHeader, transport.Request{
+		NodeID: uatype.NewFourByteNodeID(0, uatype.NodeIdFindServersOnNetworkRequest_Encoding_DefaultBinary).Expanded(),
 		Body:   &buf,
-	}, deadline)
+	})
 	if err != nil {
 		return nil, err
 	}
+	defer resp.Close()
 	switch resp.NodeID.Uint() {
-	case uatype.NodeIdTransferSubscriptionsResponse_Encoding_DefaultBinary:
-		res := &uatype.TransferSubscriptionsResponse{}
-		if err := binary.NewDecoder(resp.Body).Decode(res); err != nil {
-			return res, err
+	case uatype.NodeIdFindServersOnNetworkResponse_Encoding_DefaultBinary:
+		res := &uatype.FindServersOnNetworkResponse{}
+		if err := c.newDecoder(resp).Decode(res); err != nil {
+			return res, c.contextError(ctx, req.RequestHeader, err)
 		}
 		return res, nil
 	case uatype.NodeIdServiceFault_Encoding_DefaultBinary:
 		fault := uatype.ServiceFault{}
-		if err := binary.NewDecoder(resp.Body).Decode(&fault); err != nil {
-			return nil, err
+		if err := c.newDecoder(resp).Decode(&fault); err != nil {
+			return nil, c.contextError(ctx, req.RequestHeader, err)
 		}
 		return nil, fault
 	}
 	return nil, fmt.Errorf("Unexpected NodeID: %d %s", resp.NodeID.Uint(), resp.NodeID.DisplayName())
 }
 
-// DeleteSubscriptions sends a DeleteSubscriptions request to the server, and waits for a
+// GetEndpoints sends a GetEndpoints request to the server, and waits for a
 // response or timeout. To send and receive with no timeout use the zero time
 // as deadline.
-func (c *Client) DeleteSubscriptions(req uatype.DeleteSubscriptionsRequest, deadline time.Time) (*uatype.DeleteSubscriptionsResponse, error) {
+func (c *Client) GetEndpoints(req uatype.GetEndpointsRequest, deadline time.Time) (*uatype.GetEndpointsResponse, error) {
 	var buf bytes.Buffer
 
-	if err := binary.NewEncoder(&buf).Encode(req); err != nil {
+	if err := c.newEncoder(&buf).Encode(req); err != nil {
 		return nil, err
 	}
 
 	resp, err := c.Channel.Send(transport.Request{
-		NodeID: uatype.NewFourByteNodeID(0, uatype.NodeIdDeleteSubscriptionsRequest_Encoding_DefaultBinary).Expanded(),
+		NodeID: uatype.NewFourByteNodeID(0, uatype.NodeIdGetEndpointsRequest_Encoding_DefaultBinary).Expanded(),
 		Body:   &buf,
 	}, deadline)
 	if err != nil {
 		return nil, err
 	}
+	defer resp.Close()
 	switch resp.NodeID.Uint() {
-	case uatype.NodeIdDeleteSubscriptionsResponse_Encoding_DefaultBinary:
-		res := &uatype.DeleteSubscriptionsResponse{}
-		if err := binary.NewDecoder(resp.Body).Decode(res); err != nil {
+	case uatype.NodeIdGetEndpointsResponse_Encoding_DefaultBinary:
+		res := &uatype.GetEndpointsResponse{}
+		if err := c.newDecoder(resp).Decode(res); err != nil {
 			return res, err
 		}
 		return res, nil
 	case uatype.NodeIdServiceFault_Encoding_DefaultBinary:
 		fault := uatype.ServiceFault{}
-		if err := binary.NewDecoder(resp.Body).Decode(&fault); err != nil {
+		if err := c.newDecoder(resp).Decode(&fault); err != nil {
 			return nil, err
 		}
 		return nil, fault
@@ -1100,67 +2495,71 @@ func (c *Client) DeleteSubscriptions(req uatype.DeleteSubscriptionsRequest, dead
 	return nil, fmt.Errorf("Unexpected NodeID: %d %s", resp.NodeID.Uint(), resp.NodeID.DisplayName())
 }
 
-// FindServers sends a FindServers request to the server, and waits for a
-// response or timeout. To send and receive with no timeout use the zero time
-// as deadline.
-func (c *Client) FindServers(req uatype.FindServersRequest, deadline time.Time) (*uatype.FindServersResponse, error) {
+// GetEndpointsContext sends a GetEndpoints request to the server, and waits for a
+// response or for ctx to be done. The deadline of ctx is sent as the timeout
+// hint of the request. If ctx is done first, a Cancel request is sent for the
+// request handle.
+func (c *Client) GetEndpointsContext(ctx context.Context, req uatype.GetEndpointsRequest) (*uatype.GetEndpointsResponse, error) {
+	c.contextHeader(ctx, &req.RequestHeader)
 	var buf bytes.Buffer
 
-	if err := binary.NewEncoder(&buf).Encode(req); err != nil {
+	if err := c.newEncoder(&buf).Encode(req); err != nil {
 		return nil, err
 	}
 
-	resp, err := c.Channel.Send(transport.Request{
-		NodeID: uatype.NewFourByteNodeID(0, uatype.NodeIdFindServersRequest_Encoding_DefaultBinary).Expanded(),
+	resp, err := c.sendContext(ctx, req.RequestHeader, transport.Request{
+		NodeID: uatype.NewFourByteNodeID(0, uatype.NodeIdGetEndpointsRequest_Encoding_DefaultBinary).Expanded(),
 		Body:   &buf,
-	}, deadline)
+	})
 	if err != nil {
 		return nil, err
 	}
+	defer resp.Close()
 	switch resp.NodeID.Uint() {
-	case uatype.NodeIdFindServersResponse_Encoding_DefaultBinary:
-		res := &uatype.FindServersResponse{}
-		if err := binary.NewDecoder(resp.Body).Decode(res); err != nil {
-			return res, err
+	case uatype.NodeIdGetEndpointsResponse_Encoding_DefaultBinary:
+		res := &uatype.GetEndpointsResponse{}
+		if err := c.newDecoder(resp).Decode(res); err != nil {
+			return res, c.contextError(ctx, req.RequestHeader, err)
 		}
 		return res, nil
 	case uatype.NodeIdServiceFault_Encoding_DefaultBinary:
 		fault := uatype.ServiceFault{}
-		if err := binary.NewDecoder(resp.Body).Decode(&fault); err != nil {
-			return nil, err
+		if err := c.newDecoder(resp).Decode(&fault); err != nil {
+			return nil, c.contextError(ctx, req.RequestHeader, err)
 		}
 		return nil, fault
 	}
 	return nil, fmt.Errorf("Unexpected NodeID: %d %s", resp.NodeID.Uint(), resp.NodeID.DisplayName())
 }
 
-// FindServersOnNetwork sends a FindServersOnNetwork request to the server, and waits for a
+// RegisterServer sends a RegisterServer request to the server, and waits for a
 // response or timeout. To send and receive with no timeout use the zero time
 // as deadline.
-func (c *Client) FindServersOnNetwork(req uatype.FindServersOnNetworkRequest, deadline time.Time) (*uatype.FindServersOnNetworkResponse, error) {
+func (c *Client) RegisterServer(req uatype.RegisterServerRequest, deadline time.Time) (*uatype.RegisterServerResponse, error) {
 	var buf bytes.Buffer
 
-	if err := binary.NewEncoder(&buf).Encode(req); err != nil {
+	if err := c.newEncoder(&buf).Encode(req); err != nil {
 		return nil, err
 	}
 
 	resp, err := c.Channel.Send(transport.Request{
-		NodeID: uatype.NewFourByteNodeID(0, uatype.NodeIdFindServersOnNetworkRequest_Encoding_DefaultBinary).Expanded(),
+		NodeID: uatype.NewFourByteNodeID(0, uatype.NodeIdRegisterServerRequest_Encoding_DefaultBinary).Expanded(),
 		Body:   &buf,
 	}, deadline)
 	if err != nil {
 		return nil, err
 	}
+	defer resp.Close()
 	switch resp.NodeID.Uint() {
-	case uatype.NodeIdFindServersOnNetworkResponse_Encoding_DefaultBinary:
-		res := &uatype.FindServersOnNetworkResponse{}
-		if err := binary.NewDecoder(resp.Body).Decode(res); err != nil {
+	case uatype.NodeIdRegisterServerResponse_Encoding_DefaultBinary:
+		res := &uatype.RegisterServerResponse{}
+		if err := c.newDecoder(resp).Decode(res); err != nil {
 			return res, err
 		}
 		return res, nil
 	case uatype.NodeIdServiceFault_Encoding_DefaultBinary:
 		fault := uatype.ServiceFault{}
-		if err := binary.NewDecoder(resp.Body).Decode(&fault); err != nil {
+		if err := c.newDecoder(resp).Decode(&fault); err != nil {
 			return nil, err
 		}
 		return nil, fault
@@ -1168,67 +2567,71 @@ func (c *Client) FindServersOnNetwork(req uatype.FindServersOnNetworkRequest, de
 	return nil, fmt.Errorf("Unexpected NodeID: %d %s", resp.NodeID.Uint(), resp.NodeID.DisplayName())
 }
 
-// GetEndpoints sends a GetEndpoints request to the server, and waits for a
-// response or timeout. To send and receive with no timeout use the zero time
-// as deadline.
-func (c *Client) GetEndpoints(req uatype.GetEndpointsRequest, deadline time.Time) (*uatype.GetEndpointsResponse, error) {
+// RegisterServerContext sends a RegisterServer request to the server, and waits for a
+// response or for ctx to be done. The deadline of ctx is sent as the timeout
+// hint of the request. If ctx is done first, a Cancel request is sent for the
+// request handle.
+func (c *Client) RegisterServerContext(ctx context.Context, req uatype.RegisterServerRequest) (*uatype.RegisterServerResponse, error) {
+	c.contextHeader(ctx, &req.RequestHeader)
 	var buf bytes.Buffer
 
-	if err := binary.NewEncoder(&buf).Encode(req); err != nil {
+	if err := c.newEncoder(&buf).Encode(req); err != nil {
 		return nil, err
 	}
 
-	resp, err := c.Channel.Send(transport.Request{
-		NodeID: uatype.NewFourByteNodeID(0, uatype.NodeIdGetEndpointsRequest_Encoding_DefaultBinary).Expanded(),
+	resp, err := c.sendContext(ctx, req.RequestHeader, transport.Request{
+		NodeID: uatype.NewFourByteNodeID(0, uatype.NodeIdRegisterServerRequest_Encoding_DefaultBinary).Expanded(),
 		Body:   &buf,
-	}, deadline)
+	})
 	if err != nil {
 		return nil, err
 	}
+	defer resp.Close()
 	switch resp.NodeID.Uint() {
-	case uatype.NodeIdGetEndpointsResponse_Encoding_DefaultBinary:
-		res := &uatype.GetEndpointsResponse{}
-		if err := binary.NewDecoder(resp.Body).Decode(res); err != nil {
-			return res, err
+	case uatype.NodeIdRegisterServerResponse_Encoding_DefaultBinary:
+		res := &uatype.RegisterServerResponse{}
+		if err := c.newDecoder(resp).Decode(res); err != nil {
+			return res, c.contextError(ctx, req.RequestHeader, err)
 		}
 		return res, nil
 	case uatype.NodeIdServiceFault_Encoding_DefaultBinary:
 		fault := uatype.ServiceFault{}
-		if err := binary.NewDecoder(resp.Body).Decode(&fault); err != nil {
-			return nil, err
+		if err := c.newDecoder(resp).Decode(&fault); err != nil {
+			return nil, c.contextError(ctx, req.RequestHeader, err)
 		}
 		return nil, fault
 	}
 	return nil, fmt.Errorf("Unexpected NodeID: %d %s", resp.NodeID.Uint(), resp.NodeID.DisplayName())
 }
 
-// RegisterServer sends a RegisterServer request to the server, and waits for a
+// RegisterServer2 sends a RegisterServer2 request to the server, and waits for a
 // response or timeout. To send and receive with no timeout use the zero time
 // as deadline.
-func (c *Client) RegisterServer(req uatype.RegisterServerRequest, deadline time.Time) (*uatype.RegisterServerResponse, error) {
+func (c *Client) RegisterServer2(req uatype.RegisterServer2Request, deadline time.Time) (*uatype.RegisterServer2Response, error) {
 	var buf bytes.Buffer
 
-	if err := binary.NewEncoder(&buf).Encode(req); err != nil {
+	if err := c.newEncoder(&buf).Encode(req); err != nil {
 		return nil, err
 	}
 
 	resp, err := c.Channel.Send(transport.Request{
-		NodeID: uatype.NewFourByteNodeID(0, uatype.NodeIdRegisterServerRequest_Encoding_DefaultBinary).Expanded(),
+		NodeID: uatype.NewFourByteNodeID(0, uatype.NodeIdRegisterServer2Request_Encoding_DefaultBinary).Expanded(),
 		Body:   &buf,
 	}, deadline)
 	if err != nil {
 		return nil, err
 	}
+	defer resp.Close()
 	switch resp.NodeID.Uint() {
-	case uatype.NodeIdRegisterServerResponse_Encoding_DefaultBinary:
-		res := &uatype.RegisterServerResponse{}
-		if err := binary.NewDecoder(resp.Body).Decode(res); err != nil {
+	case uatype.NodeIdRegisterServer2Response_Encoding_DefaultBinary:
+		res := &uatype.RegisterServer2Response{}
+		if err := c.newDecoder(resp).Decode(res); err != nil {
 			return res, err
 		}
 		return res, nil
 	case uatype.NodeIdServiceFault_Encoding_DefaultBinary:
 		fault := uatype.ServiceFault{}
-		if err := binary.NewDecoder(resp.Body).Decode(&fault); err != nil {
+		if err := c.newDecoder(resp).Decode(&fault); err != nil {
 			return nil, err
 		}
 		return nil, fault
@@ -1236,34 +2639,37 @@ func (c *Client) RegisterServer(req uatype.RegisterServerRequest, deadline time.
 	return nil, fmt.Errorf("Unexpected NodeID: %d %s", resp.NodeID.Uint(), resp.NodeID.DisplayName())
 }
 
-// RegisterServer2 sends a RegisterServer2 request to the server, and waits for a
-// response or timeout. To send and receive with no timeout use the zero time
-// as deadline.
-func (c *Client) RegisterServer2(req uatype.RegisterServer2Request, deadline time.Time) (*uatype.RegisterServer2Response, error) {
+// RegisterServer2Context sends a RegisterServer2 request to the server, and waits for a
+// response or for ctx to be done. The deadline of ctx is sent as the timeout
+// hint of the request. If ctx is done first, a Cancel request is sent for the
+// request handle.
+func (c *Client) RegisterServer2Context(ctx context.Context, req uatype.RegisterServer2Request) (*uatype.RegisterServer2Response, error) {
+	c.contextHeader(ctx, &req.RequestHeader)
 	var buf bytes.Buffer
 
-	if err := binary.NewEncoder(&buf).Encode(req); err != nil {
+	if err := c.newEncoder(&buf).Encode(req); err != nil {
 		return nil, err
 	}
 
-	resp, err := c.Channel.Send(transport.Request{
+	resp, err := c.sendContext(ctx, req.RequestHeader, transport.Request{
 		NodeID: uatype.NewFourByteNodeID(0, uatype.NodeIdRegisterServer2Request_Encoding_DefaultBinary).Expanded(),
 		Body:   &buf,
-	}, deadline)
+	})
 	if err != nil {
 		return nil, err
 	}
+	defer resp.Close()
 	switch resp.NodeID.Uint() {
 	case uatype.NodeIdRegisterServer2Response_Encoding_DefaultBinary:
 		res := &uatype.RegisterServer2Response{}
-		if err := binary.NewDecoder(resp.Body).Decode(res); err != nil {
-			return res, err
+		if err := c.newDecoder(resp).Decode(res); err != nil {
+			return res, c.contextError(ctx, req.RequestHeader, err)
 		}
 		return res, nil
 	case uatype.NodeIdServiceFault_Encoding_DefaultBinary:
 		fault := uatype.ServiceFault{}
-		if err := binary.NewDecoder(resp.Body).Decode(&fault); err != nil {
-			return nil, err
+		if err := c.newDecoder(resp).Decode(&fault); err != nil {
+			return nil, c.contextError(ctx, req.RequestHeader, err)
 		}
 		return nil, fault
 	}
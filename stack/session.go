@@ -0,0 +1,541 @@
+package stack
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/searis/guma/stack/transport"
+	"github.com/searis/guma/stack/uatype"
+)
+
+// SessionConfig holds the requests used to create and activate a session.
+// The request headers are set by the session.
+type SessionConfig struct {
+	CreateSession   uatype.CreateSessionRequest
+	ActivateSession uatype.ActivateSessionRequest
+
+	// Republished is called with notification messages that are republished
+	// by the server after the session has been recovered. It's called by
+	// Restore after the session is unlocked, so it may use the Session. It
+	// may be nil.
+	Republished func(subscriptionID uint32, msg uatype.NotificationMessage)
+}
+
+// Session is a session created with OpenSession. It stores the definition of
+// subscriptions and monitored items created through it, so that the session
+// can be recovered after a communication fault; see Recover. A Session is
+// safe for concurrent use.
+type Session struct {
+	c   *Client
+	cfg SessionConfig
+
+	mu   sync.Mutex
+	subs []*Subscription
+}
+
+// Subscription is a subscription created through a Session. Notifications
+// reference monitored items by their client handle, which is preserved when
+// the subscription is recovered; the subscription and monitored item IDs may
+// change.
+type Subscription struct {
+	s     *Session
+	req   uatype.CreateSubscriptionRequest
+	id    uint32
+	items []monitoredItem
+
+	// created is false if the subscription failed to be created again after
+	// the session was lost.
+	created bool
+
+	// lastSeq is the sequence number of the last notification message
+	// received, or zero if none are received.
+	lastSeq uint32
+}
+
+// monitoredItem holds the definition of a monitored item. The item ID is
+// only valid if the item is created in the current subscription.
+type monitoredItem struct {
+	id                 uint32
+	created            bool
+	timestampsToReturn uatype.TimestampsToReturn
+	req                uatype.MonitoredItemCreateRequest
+}
+
+// OpenSession creates and activates a session, and sets the authentication
+// token of c to the token of the new session.
+func (c *Client) OpenSession(cfg SessionConfig, deadline time.Time) (*Session, error) {
+	s := &Session{c: c, cfg: cfg}
+	if err := s.open(deadline); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// open creates and activates a new session.
+func (s *Session) open(deadline time.Time) error {
+	req := s.cfg.CreateSession
+	req.RequestHeader = uatype.RequestHeader{Timestamp: time.Now()}
+	resp, err := s.c.CreateSession(req, deadline)
+	if err != nil {
+		return err
+	}
+	if resp.ResponseHeader.ServiceResult.IsBad() {
+		return resp.ResponseHeader.ServiceResult
+	}
+	s.c.SetAuthenticationToken(resp.AuthenticationToken)
+	return s.activate(deadline)
+}
+
+// activate activates the session on the current channel.
+func (s *Session) activate(deadline time.Time) error {
+	req := s.cfg.ActivateSession
+	req.RequestHeader = s.c.requestHeader()
+	resp, err := s.c.ActivateSession(req, deadline)
+	if err != nil {
+		return err
+	}
+	if resp.ResponseHeader.ServiceResult.IsBad() {
+		return resp.ResponseHeader.ServiceResult
+	}
+	return nil
+}
+
+// Close closes the session, and deletes its subscriptions.
+func (s *Session) Close(deadline time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	resp, err := s.c.CloseSession(uatype.CloseSessionRequest{
+		RequestHeader:       s.c.requestHeader(),
+		DeleteSubscriptions: true,
+	}, deadline)
+	if err != nil {
+		return err
+	}
+	s.subs = nil
+	if resp.ResponseHeader.ServiceResult.IsBad() {
+		return resp.ResponseHeader.ServiceResult
+	}
+	return nil
+}
+
+// CreateSubscription creates a subscription, and stores req so that the
+// subscription can be created again if it's lost.
+func (s *Session) CreateSubscription(req uatype.CreateSubscriptionRequest, deadline time.Time) (*Subscription, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sub := &Subscription{s: s, req: req}
+	if err := sub.create(deadline); err != nil {
+		return nil, err
+	}
+	s.subs = append(s.subs, sub)
+	return sub, nil
+}
+
+// DeleteSubscription deletes sub from the server and the session.
+func (s *Session) DeleteSubscription(sub *Subscription, deadline time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range s.subs {
+		if s.subs[i] == sub {
+			s.subs = append(s.subs[:i], s.subs[i+1:]...)
+			break
+		}
+	}
+	resp, err := s.c.DeleteSubscriptions(uatype.DeleteSubscriptionsRequest{
+		RequestHeader:       s.c.requestHeader(),
+		NoOfSubscriptionIds: 1,
+		SubscriptionIds:     []uint32{sub.id},
+	}, deadline)
+	if err != nil {
+		return err
+	}
+	if resp.ResponseHeader.ServiceResult.IsBad() {
+		return resp.ResponseHeader.ServiceResult
+	}
+	if len(resp.Results) != 1 {
+		return uatype.StatusBadUnexpectedError
+	}
+	if resp.Results[0].IsBad() {
+		return resp.Results[0]
+	}
+	return nil
+}
+
+// Publish sends req, and records the sequence number of the returned
+// notification message, so that missing messages can be republished after
+// the session is recovered.
+func (s *Session) Publish(req uatype.PublishRequest, deadline time.Time) (*uatype.PublishResponse, error) {
+	req.RequestHeader = s.c.requestHeader()
+	resp, err := s.c.Publish(req, deadline)
+	if err != nil {
+		return resp, err
+	}
+	// Keep-alive messages hold the next sequence number, and are not
+	// recorded.
+	if resp.NotificationMessage.NoOfNotificationData > 0 {
+		s.mu.Lock()
+		if sub := s.subscription(resp.SubscriptionId); sub != nil {
+			sub.lastSeq = resp.NotificationMessage.SequenceNumber
+		}
+		s.mu.Unlock()
+	}
+	return resp, nil
+}
+
+// subscription returns the subscription with the given ID, or nil. The
+// caller must hold s.mu.
+func (s *Session) subscription(id uint32) *Subscription {
+	for _, sub := range s.subs {
+		if sub.id == id {
+			return sub
+		}
+	}
+	return nil
+}
+
+// Recover reconnects the channel if it implements transport.Reconnector,
+// and restores the session; see Restore. Recover may be used as the Recover
+// function of a Watchdog.
+func (s *Session) Recover(deadline time.Time) error {
+	if r, ok := s.c.Channel.(transport.Reconnector); ok {
+		if err := r.Reconnect(deadline); err != nil {
+			return err
+		}
+	}
+	return s.Restore(deadline)
+}
+
+// Restore activates the session on the current channel. If the session no
+// longer exists on the server, a new session is created, and subscriptions
+// are transferred to it with initial values. Subscriptions that can't be
+// transferred are created again from their stored definitions. Notification
+// messages that were not received are republished to
+// SessionConfig.Republished.
+//
+// If the session is restored, but some subscriptions or monitored items are
+// not, Restore continues with the remaining subscriptions and returns a
+// RestoreError. What was not restored is kept, and is created again by the
+// next call to Restore.
+func (s *Session) Restore(deadline time.Time) error {
+	s.mu.Lock()
+	msgs, err := s.restore(deadline)
+	s.mu.Unlock()
+
+	if s.cfg.Republished != nil {
+		for _, m := range msgs {
+			s.cfg.Republished(m.subscriptionID, m.msg)
+		}
+	}
+	return err
+}
+
+// republishedMessage is a notification message republished by the server.
+type republishedMessage struct {
+	subscriptionID uint32
+	msg            uatype.NotificationMessage
+}
+
+// restore restores the session as described for Restore, and returns the
+// republished notification messages. The caller must hold s.mu.
+func (s *Session) restore(deadline time.Time) ([]republishedMessage, error) {
+	var rerr RestoreError
+	var msgs []republishedMessage
+	err := s.activate(deadline)
+	if err == nil {
+		for _, sub := range s.subs {
+			if !sub.created {
+				rerr.add(sub, sub.create(deadline))
+				continue
+			}
+			rerr.add(sub, sub.createItems(deadline))
+			msgs = append(msgs, s.republish(sub, nil, deadline)...)
+		}
+		return msgs, rerr.errOrNil()
+	} else if !sessionLost(err) {
+		return nil, err
+	}
+
+	if err := s.open(deadline); err != nil {
+		return nil, err
+	}
+	if len(s.subs) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]uint32, len(s.subs))
+	for i, sub := range s.subs {
+		ids[i] = sub.id
+	}
+	resp, err := s.c.TransferSubscriptions(uatype.TransferSubscriptionsRequest{
+		RequestHeader:       s.c.requestHeader(),
+		NoOfSubscriptionIds: int32(len(ids)),
+		SubscriptionIds:     ids,
+		SendInitialValues:   true,
+	}, deadline)
+	if err == nil && resp.ResponseHeader.ServiceResult.IsBad() {
+		err = resp.ResponseHeader.ServiceResult
+	} else if err == nil && len(resp.Results) != len(ids) {
+		err = uatype.StatusBadUnexpectedError
+	}
+
+	for i, sub := range s.subs {
+		if sub.created && err == nil && resp.Results[i].StatusCode.IsGood() {
+			rerr.add(sub, sub.createItems(deadline))
+			msgs = append(msgs, s.republish(sub, resp.Results[i].AvailableSequenceNumbers, deadline)...)
+			continue
+		}
+		rerr.add(sub, sub.create(deadline))
+	}
+	return msgs, rerr.errOrNil()
+}
+
+// RestoreError is returned by Session.Restore when the session is restored,
+// but some of its subscriptions are not fully restored.
+type RestoreError struct {
+	// Subscriptions holds the subscriptions that are not fully restored, and
+	// Errs the error of each of them.
+	Subscriptions []*Subscription
+	Errs          []error
+}
+
+// Error returns a human readable description of the error.
+func (err RestoreError) Error() string {
+	msgs := make([]string, len(err.Errs))
+	for i, e := range err.Errs {
+		msgs[i] = e.Error()
+	}
+	return fmt.Sprintf("%d of the subscriptions not restored: %s", len(err.Errs), strings.Join(msgs, "; "))
+}
+
+// add records that sub is not restored if err is not nil.
+func (err *RestoreError) add(sub *Subscription, e error) {
+	if e != nil {
+		err.Subscriptions = append(err.Subscriptions, sub)
+		err.Errs = append(err.Errs, e)
+	}
+}
+
+// errOrNil returns err, or nil if no errors are recorded.
+func (err RestoreError) errOrNil() error {
+	if len(err.Errs) == 0 {
+		return nil
+	}
+	return err
+}
+
+// sessionLost returns true if err means that the session no longer exists on
+// the server.
+func sessionLost(err error) bool {
+	var code uatype.StatusCode
+	switch e := err.(type) {
+	case uatype.ServiceFault:
+		code = e.ResponseHeader.ServiceResult
+	case uatype.StatusCode:
+		code = e
+	default:
+		return false
+	}
+	switch code {
+	case uatype.StatusBadSessionIdInvalid, uatype.StatusBadSessionClosed, uatype.StatusBadSessionNotActivated:
+		return true
+	}
+	return false
+}
+
+// republish requests the notification messages of sub that are newer than the
+// last received message, and returns them. If available is nil, messages are
+// requested in sequence until the server has no more. The caller must hold
+// s.mu.
+func (s *Session) republish(sub *Subscription, available []uint32, deadline time.Time) []republishedMessage {
+	if sub.lastSeq == 0 {
+		return nil
+	}
+	var msgs []republishedMessage
+	next := func(seq uint32) (uint32, bool) {
+		if available == nil {
+			return seq + 1, true
+		}
+		for _, n := range available {
+			if n > seq {
+				return n, true
+			}
+		}
+		return 0, false
+	}
+	for seq, ok := next(sub.lastSeq); ok; seq, ok = next(seq) {
+		resp, err := s.c.Republish(uatype.RepublishRequest{
+			RequestHeader:            s.c.requestHeader(),
+			SubscriptionId:           sub.id,
+			RetransmitSequenceNumber: seq,
+		}, deadline)
+		if err != nil || resp.ResponseHeader.ServiceResult.IsBad() {
+			// The message is no longer available, or was never sent.
+			if available == nil {
+				return msgs
+			}
+			continue
+		}
+		sub.lastSeq = seq
+		msgs = append(msgs, republishedMessage{subscriptionID: sub.id, msg: resp.NotificationMessage})
+	}
+	return msgs
+}
+
+// ID returns the current subscription ID of sub.
+func (sub *Subscription) ID() uint32 {
+	sub.s.mu.Lock()
+	defer sub.s.mu.Unlock()
+	return sub.id
+}
+
+// create creates sub and its monitored items on the server. The caller must
+// hold sub.s.mu.
+func (sub *Subscription) create(deadline time.Time) error {
+	c := sub.s.c
+	req := sub.req
+	req.RequestHeader = c.requestHeader()
+	sub.created = false
+	for i := range sub.items {
+		sub.items[i].created = false
+	}
+	resp, err := c.CreateSubscription(req, deadline)
+	if err != nil {
+		return err
+	}
+	if resp.ResponseHeader.ServiceResult.IsBad() {
+		return resp.ResponseHeader.ServiceResult
+	}
+	sub.id = resp.SubscriptionId
+	sub.created = true
+	sub.lastSeq = 0
+	return sub.createItems(deadline)
+}
+
+// createItems creates the monitored items of sub that are not created on the
+// server, in groups by TimestampsToReturn. Items that fail to be created keep
+// their definitions, so that they can be created later. The caller must hold
+// sub.s.mu.
+func (sub *Subscription) createItems(deadline time.Time) error {
+	var pending []int
+	for i, item := range sub.items {
+		if !item.created {
+			pending = append(pending, i)
+		}
+	}
+
+	var firstErr error
+	for len(pending) > 0 {
+		var group, rest []int
+		tsr := sub.items[pending[0]].timestampsToReturn
+		for _, i := range pending {
+			if sub.items[i].timestampsToReturn == tsr {
+				group = append(group, i)
+			} else {
+				rest = append(rest, i)
+			}
+		}
+		reqs := make([]uatype.MonitoredItemCreateRequest, len(group))
+		for j, i := range group {
+			reqs[j] = sub.items[i].req
+		}
+		resp, err := sub.s.c.CreateMonitoredItemsBatched(uatype.CreateMonitoredItemsRequest{
+			RequestHeader:      sub.s.c.requestHeader(),
+			SubscriptionId:     sub.id,
+			TimestampsToReturn: tsr,
+			NoOfItemsToCreate:  int32(len(reqs)),
+			ItemsToCreate:      reqs,
+		}, deadline)
+		if err == nil && len(resp.Results) != len(group) {
+			err = uatype.StatusBadUnexpectedError
+		}
+		if resp != nil {
+			// Batched calls may return the results of the items that were
+			// created before an error.
+			for j, r := range resp.Results {
+				if j >= len(group) {
+					break
+				}
+				if r.StatusCode.IsBad() {
+					if firstErr == nil {
+						firstErr = r.StatusCode
+					}
+					continue
+				}
+				sub.items[group[j]].id = r.MonitoredItemId
+				sub.items[group[j]].created = true
+			}
+		}
+		if err != nil {
+			return err
+		}
+		pending = rest
+	}
+	return firstErr
+}
+
+// CreateMonitoredItems creates monitored items in sub, and stores the
+// definitions of the items that are created so that they can be created
+// again if the subscription is lost. The request header and subscription ID
+// of req are set by sub. The request is batched according to the operation
+// limits of the server; see CreateMonitoredItemsBatched.
+func (sub *Subscription) CreateMonitoredItems(req uatype.CreateMonitoredItemsRequest, deadline time.Time) (*uatype.CreateMonitoredItemsResponse, error) {
+	sub.s.mu.Lock()
+	defer sub.s.mu.Unlock()
+	return sub.createMonitoredItems(req, deadline)
+}
+
+// createMonitoredItems implements CreateMonitoredItems. The caller must hold
+// sub.s.mu.
+func (sub *Subscription) createMonitoredItems(req uatype.CreateMonitoredItemsRequest, deadline time.Time) (*uatype.CreateMonitoredItemsResponse, error) {
+	req.RequestHeader = sub.s.c.requestHeader()
+	req.SubscriptionId = sub.id
+	resp, err := sub.s.c.CreateMonitoredItemsBatched(req, deadline)
+	if resp == nil {
+		return nil, err
+	}
+	// On a BatchError, the items of the failed requests have a Bad status.
+	for i, r := range resp.Results {
+		if r.StatusCode.IsBad() {
+			continue
+		}
+		sub.items = append(sub.items, monitoredItem{
+			id:                 r.MonitoredItemId,
+			created:            true,
+			timestampsToReturn: req.TimestampsToReturn,
+			req:                req.ItemsToCreate[i],
+		})
+	}
+	return resp, err
+}
+
+// DeleteMonitoredItems deletes the monitored items with the given IDs from
+// sub, and removes their stored definitions.
+func (sub *Subscription) DeleteMonitoredItems(ids []uint32, deadline time.Time) (*uatype.DeleteMonitoredItemsResponse, error) {
+	sub.s.mu.Lock()
+	defer sub.s.mu.Unlock()
+	resp, err := sub.s.c.DeleteMonitoredItemsBatched(uatype.DeleteMonitoredItemsRequest{
+		RequestHeader:        sub.s.c.requestHeader(),
+		SubscriptionId:       sub.id,
+		NoOfMonitoredItemIds: int32(len(ids)),
+		MonitoredItemIds:     ids,
+	}, deadline)
+	if resp == nil {
+		return nil, err
+	}
+	deleted := make(map[uint32]bool, len(ids))
+	for i, code := range resp.Results {
+		if code.IsGood() || code == uatype.StatusBadMonitoredItemIdInvalid {
+			deleted[ids[i]] = true
+		}
+	}
+	items := sub.items[:0]
+	for _, item := range sub.items {
+		if !item.created || !deleted[item.id] {
+			items = append(items, item)
+		}
+	}
+	sub.items = items
+	return resp, err
+}
@@ -0,0 +1,293 @@
+package stack_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/searis/guma/stack"
+	"github.com/searis/guma/stack/uatype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSessionServer implements the session and subscription services needed
+// to test session recovery. Requests may be handled concurrently.
+type fakeSessionServer struct {
+	t        *testing.T
+	mu       sync.Mutex
+	sessions uint32
+	token    uatype.NodeId // token of the live session
+	subs     uint32
+	transfer bool // whether TransferSubscriptions succeeds
+
+	// failSubs and failItems are the number of CreateSubscription and
+	// CreateMonitoredItems requests to fail.
+	failSubs  int
+	failItems int
+
+	requests []string
+	items    map[uint32][]uatype.MonitoredItemCreateRequest
+}
+
+func (srv *fakeSessionServer) handle(req interface{}) interface{} {
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	switch r := req.(type) {
+	case uatype.CreateSessionRequest:
+		srv.requests = append(srv.requests, "CreateSession")
+		srv.sessions++
+		srv.token = uatype.NewNumericNodeID(1, srv.sessions)
+		return uatype.CreateSessionResponse{
+			ResponseHeader:      responseHeader(),
+			AuthenticationToken: srv.token,
+		}
+	case uatype.ActivateSessionRequest:
+		srv.requests = append(srv.requests, "ActivateSession")
+		if !r.RequestHeader.AuthenticationToken.Equal(srv.token) {
+			return uatype.ServiceFault{ResponseHeader: uatype.ResponseHeader{
+				ServiceResult:      uatype.StatusBadSessionIdInvalid,
+				ServiceDiagnostics: &uatype.DiagnosticInfo{},
+			}}
+		}
+		return uatype.ActivateSessionResponse{ResponseHeader: responseHeader()}
+	case uatype.CreateSubscriptionRequest:
+		srv.requests = append(srv.requests, "CreateSubscription")
+		if srv.failSubs > 0 {
+			srv.failSubs--
+			header := responseHeader()
+			header.ServiceResult = uatype.StatusBadTooManySubscriptions
+			return uatype.CreateSubscriptionResponse{ResponseHeader: header}
+		}
+		srv.subs++
+		return uatype.CreateSubscriptionResponse{
+			ResponseHeader: responseHeader(),
+			SubscriptionId: srv.subs,
+		}
+	case uatype.CreateMonitoredItemsRequest:
+		srv.requests = append(srv.requests, "CreateMonitoredItems")
+		if srv.failItems > 0 {
+			srv.failItems--
+			header := responseHeader()
+			header.ServiceResult = uatype.StatusBadTooManyOperations
+			return uatype.CreateMonitoredItemsResponse{ResponseHeader: header}
+		}
+		srv.items[r.SubscriptionId] = append(srv.items[r.SubscriptionId], r.ItemsToCreate...)
+		results := make([]uatype.MonitoredItemCreateResult, len(r.ItemsToCreate))
+		for i := range results {
+			results[i].MonitoredItemId = uint32(len(srv.items[r.SubscriptionId]) - len(results) + i + 1)
+		}
+		return uatype.CreateMonitoredItemsResponse{
+			ResponseHeader: responseHeader(),
+			NoOfResults:    int32(len(results)),
+			Results:        results,
+		}
+	case uatype.TransferSubscriptionsRequest:
+		srv.requests = append(srv.requests, "TransferSubscriptions")
+		require.True(srv.t, r.SendInitialValues, "SendInitialValues")
+		results := make([]uatype.TransferResult, len(r.SubscriptionIds))
+		for i := range results {
+			if !srv.transfer {
+				results[i].StatusCode = uatype.StatusBadSubscriptionIdInvalid
+				continue
+			}
+			results[i].NoOfAvailableSequenceNumbers = 3
+			results[i].AvailableSequenceNumbers = []uint32{1, 2, 3}
+		}
+		return uatype.TransferSubscriptionsResponse{
+			ResponseHeader: responseHeader(),
+			NoOfResults:    int32(len(results)),
+			Results:        results,
+		}
+	case uatype.PublishRequest:
+		srv.requests = append(srv.requests, "Publish")
+		return uatype.PublishResponse{
+			ResponseHeader: responseHeader(),
+			SubscriptionId: srv.subs,
+			NotificationMessage: uatype.NotificationMessage{
+				SequenceNumber:       1,
+				NoOfNotificationData: 1,
+				NotificationData:     []uatype.ExtensionObject{{Value: uatype.DataChangeNotification{}}},
+			},
+		}
+	case uatype.RepublishRequest:
+		srv.requests = append(srv.requests, "Republish")
+		if r.RetransmitSequenceNumber > 3 {
+			header := responseHeader()
+			header.ServiceResult = uatype.StatusBadMessageNotAvailable
+			return uatype.RepublishResponse{ResponseHeader: header}
+		}
+		return uatype.RepublishResponse{
+			ResponseHeader:      responseHeader(),
+			NotificationMessage: uatype.NotificationMessage{SequenceNumber: r.RetransmitSequenceNumber},
+		}
+	case uatype.ReadRequest:
+		// Operation limits are not published.
+		results := make([]uatype.DataValue, len(r.NodesToRead))
+		for i := range results {
+			results[i].StatusCodeSpecified = true
+			results[i].StatusCode = uatype.StatusBadNodeIdUnknown
+		}
+		return uatype.ReadResponse{
+			ResponseHeader: responseHeader(),
+			NoOfResults:    int32(len(results)),
+			Results:        results,
+		}
+	}
+	srv.t.Fatalf("unexpected request %T", req)
+	return nil
+}
+
+// openTestSession opens a session with one subscription with two monitored
+// items, and publishes one notification message.
+func openTestSession(t *testing.T, srv *fakeSessionServer, republished *[]uint32) (*stack.Client, *stack.Session, *stack.Subscription) {
+	c := &stack.Client{Channel: fakeChannel{t: t, handle: srv.handle}}
+	var sub *stack.Subscription
+	s, err := c.OpenSession(stack.SessionConfig{
+		Republished: func(id uint32, msg uatype.NotificationMessage) {
+			// The Session is unlocked, so it may be used by the callback.
+			assert.Equal(t, sub.ID(), id, "republished subscription ID")
+			*republished = append(*republished, msg.SequenceNumber)
+		},
+	}, time.Time{})
+	require.NoError(t, err, "OpenSession")
+	assert.Equal(t, srv.token, c.AuthenticationToken(), "AuthenticationToken")
+
+	sub, err = s.CreateSubscription(uatype.CreateSubscriptionRequest{PublishingEnabled: true}, time.Time{})
+	require.NoError(t, err, "CreateSubscription")
+	items := []uatype.MonitoredItemCreateRequest{
+		{RequestedParameters: uatype.MonitoringParameters{ClientHandle: 10}},
+		{RequestedParameters: uatype.MonitoringParameters{ClientHandle: 11}},
+	}
+	_, err = sub.CreateMonitoredItems(uatype.CreateMonitoredItemsRequest{
+		TimestampsToReturn: uatype.TimestampsToReturnSource,
+		NoOfItemsToCreate:  int32(len(items)),
+		ItemsToCreate:      items,
+	}, time.Time{})
+	require.NoError(t, err, "CreateMonitoredItems")
+	_, err = s.Publish(uatype.PublishRequest{}, time.Time{})
+	require.NoError(t, err, "Publish")
+	return c, s, sub
+}
+
+func TestSessionRestoreTransfer(t *testing.T) {
+	srv := &fakeSessionServer{t: t, transfer: true, items: make(map[uint32][]uatype.MonitoredItemCreateRequest)}
+	var republished []uint32
+	c, s, sub := openTestSession(t, srv, &republished)
+
+	// The server has lost the session.
+	srv.token = uatype.NodeId{}
+	srv.requests = nil
+	require.NoError(t, s.Restore(time.Time{}), "Restore")
+	assert.Equal(t, []string{
+		"ActivateSession", "CreateSession", "ActivateSession",
+		"TransferSubscriptions", "Republish", "Republish",
+	}, srv.requests, "requests")
+	assert.Equal(t, srv.token, c.AuthenticationToken(), "AuthenticationToken")
+	assert.Equal(t, []uint32{2, 3}, republished, "republished sequence numbers")
+	assert.Equal(t, uint32(1), sub.ID(), "subscription ID")
+}
+
+func TestSessionRestoreRecreate(t *testing.T) {
+	srv := &fakeSessionServer{t: t, items: make(map[uint32][]uatype.MonitoredItemCreateRequest)}
+	var republished []uint32
+	_, s, sub := openTestSession(t, srv, &republished)
+
+	srv.token = uatype.NodeId{}
+	srv.requests = nil
+	require.NoError(t, s.Restore(time.Time{}), "Restore")
+	assert.Equal(t, []string{
+		"ActivateSession", "CreateSession", "ActivateSession",
+		"TransferSubscriptions", "CreateSubscription", "CreateMonitoredItems",
+	}, srv.requests, "requests")
+	assert.Empty(t, republished, "republished sequence numbers")
+	assert.Equal(t, uint32(2), sub.ID(), "subscription ID")
+	assert.Equal(t, srv.items[1], srv.items[2], "recreated monitored items")
+}
+
+func TestSessionRestoreRecreateFailure(t *testing.T) {
+	srv := &fakeSessionServer{t: t, items: make(map[uint32][]uatype.MonitoredItemCreateRequest)}
+	var republished []uint32
+	_, s, sub := openTestSession(t, srv, &republished)
+
+	// Monitored items that fail to be created are kept, and created when
+	// the session is restored again.
+	srv.token = uatype.NodeId{}
+	srv.failItems = 1
+	require.Error(t, s.Restore(time.Time{}), "Restore")
+	assert.Empty(t, srv.items[2], "monitored items after failure")
+
+	srv.token = uatype.NodeId{}
+	require.NoError(t, s.Restore(time.Time{}), "Restore again")
+	assert.Equal(t, uint32(3), sub.ID(), "subscription ID")
+	assert.Equal(t, srv.items[1], srv.items[3], "recreated monitored items")
+}
+
+func TestSessionRestorePartial(t *testing.T) {
+	srv := &fakeSessionServer{t: t, items: make(map[uint32][]uatype.MonitoredItemCreateRequest)}
+	var republished []uint32
+	_, s, sub1 := openTestSession(t, srv, &republished)
+	sub2, err := s.CreateSubscription(uatype.CreateSubscriptionRequest{}, time.Time{})
+	require.NoError(t, err, "CreateSubscription")
+
+	// The first subscription fails to be created again, but the second is
+	// still restored.
+	srv.token = uatype.NodeId{}
+	srv.failSubs = 1
+	err = s.Restore(time.Time{})
+	require.IsType(t, stack.RestoreError{}, err, "Restore")
+	assert.Equal(t, []*stack.Subscription{sub1}, err.(stack.RestoreError).Subscriptions, "subscriptions not restored")
+	assert.Equal(t, []error{uatype.StatusBadTooManySubscriptions}, err.(stack.RestoreError).Errs, "errors")
+	assert.Equal(t, uint32(3), sub2.ID(), "second subscription ID")
+
+	// The session is still alive, and the first subscription is created.
+	srv.requests = nil
+	require.NoError(t, s.Restore(time.Time{}), "Restore again")
+	assert.Equal(t, []string{
+		"ActivateSession", "CreateSubscription", "CreateMonitoredItems",
+	}, srv.requests, "requests")
+	assert.Equal(t, uint32(4), sub1.ID(), "first subscription ID")
+	assert.Equal(t, srv.items[1], srv.items[4], "recreated monitored items")
+}
+
+func TestSessionRestoreActive(t *testing.T) {
+	srv := &fakeSessionServer{t: t, items: make(map[uint32][]uatype.MonitoredItemCreateRequest)}
+	var republished []uint32
+	_, s, _ := openTestSession(t, srv, &republished)
+
+	// The session is still alive; only missing messages are republished.
+	srv.requests = nil
+	require.NoError(t, s.Restore(time.Time{}), "Restore")
+	assert.Equal(t, []string{
+		"ActivateSession", "Republish", "Republish", "Republish",
+	}, srv.requests, "requests")
+	assert.Equal(t, []uint32{2, 3}, republished, "republished sequence numbers")
+}
+
+func TestSessionRestoreConcurrentPublish(t *testing.T) {
+	srv := &fakeSessionServer{t: t, transfer: true, items: make(map[uint32][]uatype.MonitoredItemCreateRequest)}
+	var republished []uint32
+	c, s, _ := openTestSession(t, srv, &republished)
+
+	// Publish while the session is restored, which replaces the
+	// authentication token of c. Run with -race to detect unsynchronized
+	// access.
+	srv.mu.Lock()
+	srv.token = uatype.NodeId{}
+	srv.mu.Unlock()
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 20; i++ {
+			_, err := s.Publish(uatype.PublishRequest{}, time.Time{})
+			assert.NoError(t, err, "Publish")
+		}
+	}()
+	require.NoError(t, s.Restore(time.Time{}), "Restore")
+	wg.Wait()
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	assert.Equal(t, srv.token, c.AuthenticationToken(), "AuthenticationToken")
+}
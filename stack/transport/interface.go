@@ -1,6 +1,7 @@
 package transport
 
 import (
+	"context"
 	"io"
 	"time"
 
@@ -15,10 +16,26 @@ type Request struct {
 }
 
 // Response should contain a matching NodeID and encoded Body, and is returned
-// from a server to a client.
+// from a server to a client. The Body may be read while the rest of the
+// response is still being received; Close should be called when the caller is
+// done reading it.
 type Response struct {
 	NodeID uatype.ExpandedNodeId
 	Body   io.Reader
+
+	// MaxMessageSize is the max message size negotiated for the channel the
+	// response was received on, or zero if there is no limit. It is used to
+	// derive limits for decoding the body.
+	MaxMessageSize uint32
+}
+
+// Close releases any resources held by the body of r, and discards unread
+// data. If Body does not implement io.Closer, Close is a no-op.
+func (r *Response) Close() error {
+	if c, ok := r.Body.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
 }
 
 // SecureChannel is an abstract interface for the (client-side) OPC UA Secure
@@ -33,3 +50,21 @@ type SecureChannel interface {
 	// Close closes the channel after performing necessary clean-up.
 	Close() error
 }
+
+// Reconnector is implemented by secure channels that can re-establish the
+// underlying connection and open a new channel after a communication fault.
+type Reconnector interface {
+	// Reconnect closes the underlying connection, connects it again and opens
+	// a new channel, or returns an error when deadline is reached. To
+	// reconnect without a deadline, use the zero time.
+	Reconnect(deadline time.Time) error
+}
+
+// ContextSender is implemented by secure channels that can abort waiting for
+// a response when a context is done.
+type ContextSender interface {
+	// SendContext transmits a request via the channel, using the deadline of
+	// ctx if any. If ctx is done before a response is received, any resources
+	// held for the request are released, and ctx.Err() is returned.
+	SendContext(ctx context.Context, req Request) (*Response, error)
+}
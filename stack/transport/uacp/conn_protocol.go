@@ -282,7 +282,15 @@ func (cm *connMgr) RecvChunk(recvBuffer []byte, deadline time.Time) (int, error)
 	h := msgHeader(recvBuffer)
 	size := h.Size()
 	if size > uint32(len(recvBuffer)) {
-		return n, transport.LocalError(uatype.StatusBadTcpMessageTooLarge, err)
+		return n, transport.LocalError(
+			uatype.StatusBadTcpMessageTooLarge,
+			fmt.Errorf("chunk size %d exceeds receive buffer size %d", size, len(recvBuffer)),
+		)
+	} else if size < msgHeaderSize {
+		return n, transport.LocalError(
+			uatype.StatusBadTcpMessageTypeInvalid,
+			fmt.Errorf("chunk size %d is smaller than the message header", size),
+		)
 	}
 
 	nn, err = io.ReadAtLeast(cm.conn, recvBuffer[n:size], int(size)-n)
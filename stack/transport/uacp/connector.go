@@ -45,6 +45,13 @@ var errDeadlineReached = transport.LocalError(
 	errors.New("local deadline reached"),
 )
 
+// errCanceled is returned when a request is canceled while waiting for a
+// receive queue or a response.
+var errCanceled = transport.LocalError(
+	uatype.StatusBadRequestCancelledByClient,
+	errors.New("request canceled"),
+)
+
 // ConnectSecureTCPChannel returns a new SecureChannel over TCP using the
 // connection settings defined in opening.
 func ConnectSecureTCPChannel(address, endpointURL string, security ChSecurity) (*SecureChannel, error) {
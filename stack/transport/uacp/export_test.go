@@ -0,0 +1,58 @@
+package uacp
+
+import (
+	"bytes"
+	"net"
+	"time"
+)
+
+// fakeConn is a net.Conn that reads from a fixed byte stream, and discards
+// writes.
+type fakeConn struct {
+	*bytes.Reader
+}
+
+func (fakeConn) Write(b []byte) (int, error)        { return len(b), nil }
+func (fakeConn) Close() error                       { return nil }
+func (fakeConn) LocalAddr() net.Addr                { return nil }
+func (fakeConn) RemoteAddr() net.Addr               { return nil }
+func (fakeConn) SetDeadline(t time.Time) error      { return nil }
+func (fakeConn) SetReadDeadline(t time.Time) error  { return nil }
+func (fakeConn) SetWriteDeadline(t time.Time) error { return nil }
+
+// RecvChunks feeds data to a receiver through a connected connMgr, as if it
+// was received from a server, and returns the request ID and body of each
+// message chunk until the first error.
+func RecvChunks(data []byte, chunking MsgChunking) (requestIDs []uint32, bodies [][]byte, err error) {
+	cm := newConnMgr(nil, "", chunking)
+	cm.conn = fakeConn{bytes.NewReader(data)}
+	cm.connState = connStateConnected
+	rcv := newRecvState(cm, chunking, MsgBuffering{RecvBufferCount: 1, RecvQueueCount: 1})
+
+	for {
+		e, requestID := rcv.waitForEvent()
+		body := append([]byte(nil), e.body...)
+		e.freeBuffer()
+		if requestID&requestIDInvalidMask != 0 {
+			return requestIDs, bodies, e.err
+		}
+		requestIDs = append(requestIDs, requestID)
+		bodies = append(bodies, body)
+	}
+}
+
+// NewSilentSecureChannel returns a SecureChannel on a connection that discards
+// writes and never responds. The channel reconnects using dial.
+func NewSilentSecureChannel(dial DialFunc, chunking MsgChunking) *SecureChannel {
+	cm := newConnMgr(dial, "", chunking)
+	cm.conn = fakeConn{bytes.NewReader(nil)}
+	cm.connState = connStateConnected
+	return &SecureChannel{
+		cur: &channelState{
+			connMgr:   cm,
+			recvWait:  make(chan error, 1),
+			recvState: newRecvState(cm, chunking, MsgBuffering{RecvBufferCount: 1, RecvQueueCount: 1}),
+			sendState: newSendState(cm, chunking),
+		},
+	}
+}
@@ -1,6 +1,7 @@
 package uacp
 
 import (
+	"sync"
 	"time"
 
 	"github.com/searis/guma/stack/uatype"
@@ -8,6 +9,7 @@ import (
 
 // Reconnection constants according to OPC UA 1.03 Part 6 section 7.1.6.
 const (
+	reconnectMinDelay      = 100 * time.Millisecond
 	reconnectDelayMultiply = 2
 	reconnectMaxDelay      = 2 * time.Minute
 )
@@ -20,9 +22,21 @@ const (
 // SecureChannel implements the OPC Secure Channel over a raw UACP compatible
 // connection.
 type SecureChannel struct {
-	security ChSecurity
-	timeouts Timeouts
+	security  ChSecurity
+	timeouts  Timeouts
+	buffering MsgBuffering
 
+	// reconnectM serializes calls to Reconnect, while stateM guards cur, which
+	// is replaced on reconnect.
+	reconnectM sync.Mutex
+	stateM     sync.RWMutex
+	cur        *channelState
+}
+
+// channelState holds the UACP connection and secure channel state of one
+// connection.
+type channelState struct {
+	connMgr   *connMgr
 	recvWait  chan error
 	recvState *recvState
 	sendState *sendState
@@ -32,8 +46,9 @@ type SecureChannel struct {
 
 func newSecureChannel(connMgr *connMgr, security ChSecurity, buffering MsgBuffering, timeouts Timeouts) (*SecureChannel, error) {
 	sc := &SecureChannel{
-		security: security,
-		timeouts: timeouts,
+		security:  security,
+		timeouts:  timeouts,
+		buffering: buffering,
 	}
 
 	// Define a monotonic deadline for the secure channel connect operation.
@@ -43,33 +58,91 @@ func newSecureChannel(connMgr *connMgr, security ChSecurity, buffering MsgBuffer
 	}
 
 	// First connect.
+	st, err := sc.connect(connMgr, deadline)
+	if err != nil {
+		return nil, err
+	}
+	sc.cur = st
+	// TODO: Re-open on 75% of revised lifetime (after open).
+	return sc, nil
+}
+
+// connect connects connMgr, starts a new receive thread and opens a new
+// secure channel. The returned state is not used by sc until the caller
+// stores it.
+func (sc *SecureChannel) connect(connMgr *connMgr, deadline time.Time) (*channelState, error) {
 	if err := connMgr.Connect(deadline); err != nil {
 		return nil, err
 	}
 
-	// On first connect, read chunk settings.
+	// On connect, read chunk settings.
 	chunking, _ := connMgr.Chunking()
-	sc.sendState = newSendState(connMgr, chunking)
-	sc.recvState = newRecvState(connMgr, chunking, buffering)
-	sc.recvWait = make(chan error)
-	go func() {
-		sc.recvWait <- sc.recvState.Run()
-	}()
-
-	if err := sc.open(deadline); err != nil {
-		logger.LogIfError("newSecureChannel", connMgr.Close())
-		return nil, err
+	st := &channelState{
+		connMgr:   connMgr,
+		recvWait:  make(chan error, 1),
+		sendState: newSendState(connMgr, chunking),
+		recvState: newRecvState(connMgr, chunking, sc.buffering),
 	}
 
+	recvState, recvWait := st.recvState, st.recvWait
 	go func() {
-		// FIXME: Stop-gap logging only, should handle reconnect.
-		if err := <-sc.recvWait; err != nil {
+		err := recvState.Run()
+		if err != nil {
 			debugLogger.Println("SecureChannel: recvState.Run closed with error: ", err)
 		} else {
-			debugLogger.Println("SecureChanne: recvState.Run closed with no error")
+			debugLogger.Println("SecureChannel: recvState.Run closed with no error")
 		}
+		recvWait <- err
 	}()
-	// TODO: Reconnect UACP and re-open on connection failure.
-	// TODO: Re-open on 75% of revised lifetime (after open).
-	return sc, nil
+
+	if err := sc.open(st, deadline); err != nil {
+		logger.LogIfError("SecureChannel.connect", connMgr.Close())
+		return nil, err
+	}
+	return st, nil
+}
+
+// Reconnect closes the underlying UACP connection, connects it again and
+// opens a new secure channel, retrying with an increasing delay until it
+// succeeds or deadline is reached. Requests waiting for a response on the old
+// connection fail or time out, and requests sent while reconnecting fail on
+// the old connection. To retry with no deadline, let deadline be the zero
+// time.
+func (sc *SecureChannel) Reconnect(deadline time.Time) error {
+	sc.reconnectM.Lock()
+	defer sc.reconnectM.Unlock()
+
+	// Close the old connection so that ongoing requests fail fast. The state
+	// lock is only held to swap in the new state, so that requests never wait
+	// for the reconnect.
+	old := sc.state().connMgr
+	logger.LogIfError("SecureChannel.Reconnect: connMgr.Close", old.Close())
+
+	delay := reconnectMinDelay
+	for {
+		// The old receive thread may still be reading from the old
+		// connection, so each attempt use a new connMgr.
+		st, err := sc.connect(newConnMgr(old.dial, old.endpointURL, old.chunking), deadline)
+		if err == nil {
+			sc.stateM.Lock()
+			sc.cur = st
+			sc.stateM.Unlock()
+			return nil
+		}
+		debugLogger.Println("SecureChannel: reconnect failed: ", err)
+		if !deadline.IsZero() && time.Now().Add(delay).After(deadline) {
+			return err
+		}
+		time.Sleep(delay)
+		if delay *= reconnectDelayMultiply; delay > reconnectMaxDelay {
+			delay = reconnectMaxDelay
+		}
+	}
+}
+
+// state returns the current connection and secure channel state.
+func (sc *SecureChannel) state() *channelState {
+	sc.stateM.RLock()
+	defer sc.stateM.RUnlock()
+	return sc.cur
 }
@@ -11,22 +11,20 @@ import (
 	"github.com/searis/guma/stack/uatype"
 )
 
-func (sc *SecureChannel) open(deadline time.Time) error {
+// open opens a new secure channel on st, and stores the security token in st.
+func (sc *SecureChannel) open(st *channelState, deadline time.Time) error {
 	// Wait for receiveQueue spot or deadline.
-	requestID, err := sc.recvState.WaitForRequestID(deadline)
+	requestID, err := st.recvState.WaitForRequestID(deadline, nil)
 	if err != nil {
 		return err
 	}
-	// The receivequeue must always be freed, and it is always safe to cancel,
-	// even on success.
-	defer sc.recvState.CancelRequestID(requestID)
 
 	// Prepare and encode request.
 	var msgBuff bytes.Buffer
 	enc := binary.NewEncoder(&msgBuff)
 
 	requestType := uatype.SecurityTokenRequestTypeIssue
-	if sc.securityToken.ChannelId != 0 {
+	if st.securityToken.ChannelId != 0 {
 		requestType = uatype.SecurityTokenRequestTypeRenew
 	}
 	var timeoutHint uint32
@@ -46,9 +44,9 @@ func (sc *SecureChannel) open(deadline time.Time) error {
 	}
 
 	// Send request.
-	if err := sc.sendState.SendMsg(secureMsg{
+	if err := st.sendState.SendMsg(secureMsg{
 		Type:           secureMsgTypeOpn,
-		ChannelID:      sc.securityToken.ChannelId,
+		ChannelID:      st.securityToken.ChannelId,
 		RequestID:      requestID,
 		SecurityHeader: sc.security.SecurityHeader,
 		Request: transport.Request{
@@ -56,14 +54,19 @@ func (sc *SecureChannel) open(deadline time.Time) error {
 			Body:   &msgBuff,
 		},
 	}, deadline); err != nil {
+		st.recvState.CancelRequestID(requestID)
 		return err
 	}
 
-	resp, err := sc.recvState.WaitForResponse(requestID, msgTypeOpn, deadline)
+	resp, err := st.recvState.WaitForResponse(requestID, msgTypeOpn, deadline, nil)
 	if err != nil {
 		return err
 	}
+	defer resp.Close()
 	p, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
 	dec := binary.NewDecoder(bytes.NewBuffer(p))
 	switch resp.NodeID.Uint() {
 	case uatype.NodeIdOpenSecureChannelResponse_Encoding_DefaultBinary:
@@ -71,8 +74,8 @@ func (sc *SecureChannel) open(deadline time.Time) error {
 		if err := dec.Decode(&target); err != nil {
 			return transport.LocalError(uatype.StatusBadInternalError, err)
 		}
-		if sc.securityToken.ChannelId == 0 {
-			sc.securityToken = target.SecurityToken
+		if st.securityToken.ChannelId == 0 {
+			st.securityToken = target.SecurityToken
 		}
 
 		// TODO handle more security stuff.
@@ -1,9 +1,9 @@
 package uacp
 
 import (
-	"bytes"
 	"errors"
 	"fmt"
+	"io"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -131,16 +131,25 @@ func newRecvState(connMgr *connMgr, chunking MsgChunking, buffering MsgBuffering
 	return &rcv
 }
 
-// WaitForRequestID will wait for a receive queue to be free or the deadline to
-// be reached. On success, it assigns the queue and returns a Request ID that
+// deadlineTimer returns a channel that receives when deadline is reached, or
+// nil for the zero deadline. The returned stop function must be called to
+// release the timer.
+func deadlineTimer(deadline time.Time) (<-chan time.Time, func()) {
+	if deadline.IsZero() {
+		return nil, func() {}
+	}
+	t := time.NewTimer(time.Until(deadline))
+	return t.C, func() { t.Stop() }
+}
+
+// WaitForRequestID will wait for a receive queue to be free, the deadline to
+// be reached or cancel to be closed. On success, it assigns the queue and returns a Request ID that
 // should be used when sending request messages, and for waiting for a response.
 // either WaitForResponse ro CancelRequestID must be called to free the
 // associated receive queue. Failing to do so may result in leaks!
-func (rcv *recvState) WaitForRequestID(deadline time.Time) (uint32, error) {
-	var timeout <-chan time.Time
-	if deadline.IsZero() {
-		timeout = time.After(time.Until(deadline))
-	}
+func (rcv *recvState) WaitForRequestID(deadline time.Time, cancel <-chan struct{}) (uint32, error) {
+	timeout, stop := deadlineTimer(deadline)
+	defer stop()
 	select {
 	case qi := <-rcv.queueSpots:
 		requestID := uint32(qi) | atomic.AddUint32(&rcv.monotonicRequestID, requestIDMonotonicIncr)
@@ -155,17 +164,20 @@ func (rcv *recvState) WaitForRequestID(deadline time.Time) (uint32, error) {
 		return requestID, nil
 	case <-timeout:
 		return 0, errDeadlineReached
+	case <-cancel:
+		return 0, errCanceled
 	}
 
 }
 
-// Wait for response will wait for all message chunks to be received, or the
-// deadline to be reached. Incoming chunks are validated against t as well as
-// internal rcv values. When all chunks have been received, or if the deadline
-// is reached, CancelRequestID is called to free the receive queue.
-func (rcv *recvState) WaitForResponse(requestID uint32, t msgType, deadline time.Time) (*transport.Response, error) {
-	defer rcv.CancelRequestID(requestID)
-
+// WaitForResponse will wait for the first message chunk to be received, the
+// deadline to be reached or cancel to be closed. The returned response body
+// is fed with the remaining chunks as they are received, until the deadline
+// is reached or cancel is closed. Incoming chunks are validated against t as
+// well as internal rcv values. When all chunks have been read, or on errors,
+// CancelRequestID is called to free the receive queue; the response body
+// must be closed to free it if it is not read to the end.
+func (rcv *recvState) WaitForResponse(requestID uint32, t msgType, deadline time.Time, cancel <-chan struct{}) (*transport.Response, error) {
 	debugLogger.Printf(
 		"recvState.WaitForResponse: waiting for request ID %d\n",
 		requestID,
@@ -173,79 +185,127 @@ func (rcv *recvState) WaitForResponse(requestID uint32, t msgType, deadline time
 
 	queue, err := rcv.eventQueue(requestID)
 	if err != nil {
+		rcv.CancelRequestID(requestID)
 		return nil, transport.LocalError(uatype.StatusBadInternalError, err)
 	}
-	timeout := time.After(time.Until(deadline))
-
-	var chunkCnt uint32
-	var nodeID uatype.ExpandedNodeId
-	var buff *bytes.Buffer
+	timeout, stop := deadlineTimer(deadline)
+	body := &chunkReader{
+		rcv:       rcv,
+		requestID: requestID,
+		t:         t,
+		queue:     queue,
+		timeout:   timeout,
+		stop:      stop,
+		cancel:    cancel,
+	}
 
 	// Read first chunk, and get NodeID
-	select {
-	case e, ok := <-queue:
-		if !ok {
-			return &transport.Response{NodeID: nodeID, Body: buff}, nil
-		}
-		chunkCnt++
-
-		if err := rcv.validateChunkMsgHeaders(e.msgHeader, t); err != nil {
-			logger.LogIfError("connMgr.Close during recvState.WaitForResponse", rcv.connMgr.Close())
-			return nil, err
-		}
-		if e.err != nil {
-			e.freeBuffer()
-			return nil, e.err
-		}
-
+	var nodeID uatype.ExpandedNodeId
+	if body.next(); body.err != nil && body.err != io.EOF {
+		return nil, body.err
+	}
+	if len(body.buf) > 0 {
 		// Hack to decode first chunk with Node ID
-
-		err := binary.Unmarshal(e.body, &nodeID)
-		e.freeBuffer()
-		if err != nil {
+		if err := binary.Unmarshal(body.buf, &nodeID); err != nil {
+			body.Close()
 			return nil, transport.LocalError(
 				uatype.StatusBadUnknownResponse,
 				errors.New("could not decode NodeID: "+err.Error()),
 			)
 		}
-		buff = bytes.NewBuffer(e.body[nodeID.Size():])
-	case <-timeout:
-		return nil, errDeadlineReached
+		body.buf = body.buf[nodeID.Size():]
 	}
+	return &transport.Response{NodeID: nodeID, Body: body, MaxMessageSize: rcv.maxMessageSize}, nil
+}
 
-	for {
-		select {
-		case e, ok := <-queue:
-			if !ok {
-				return &transport.Response{NodeID: nodeID, Body: buff}, nil
-			}
-			chunkCnt++
+// chunkReader reads a response body from the message chunks routed to a
+// receive queue. Each chunk is copied before its receive buffer is freed, so
+// that a slow reader never holds on to receive buffers.
+type chunkReader struct {
+	rcv       *recvState
+	requestID uint32
+	t         msgType
+	queue     <-chan recvEvent
+	timeout   <-chan time.Time
+	stop      func()
+	cancel    <-chan struct{}
+
+	chunk    []byte // copy of the current chunk body
+	buf      []byte // unread part of chunk
+	chunkCnt uint32
+	err      error // set when no more chunks will be read
+}
 
-			if err := rcv.validateChunkMsgHeaders(e.msgHeader, t); err != nil {
-				logger.LogIfError("connMgr.Close during recvState.WaitForResponse", rcv.connMgr.Close())
-				return nil, err
-			}
-			if e.err != nil {
-				e.freeBuffer()
-				return nil, e.err
-			}
-			if _, err := buff.Write(e.body); err != nil {
-				return nil, transport.LocalError(uatype.StatusBadInternalError, err)
-			}
+// Read reads from the current chunk, waiting for the next chunk if needed.
+func (r *chunkReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		if r.err != nil {
+			return 0, r.err
+		}
+		r.next()
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
 
-			// Are we done yet?
+// Close stops reading chunks, and frees the receive queue.
+func (r *chunkReader) Close() error {
+	r.buf = nil
+	r.finish(io.EOF)
+	return nil
+}
+
+// next waits for the next chunk, and sets r.buf to its body, or sets r.err.
+func (r *chunkReader) next() {
+	select {
+	case e, ok := <-r.queue:
+		if !ok {
+			r.finish(io.EOF)
+			return
+		}
+		r.chunkCnt++
+
+		if err := r.rcv.validateChunkMsgHeaders(e.msgHeader, r.t); err != nil {
 			e.freeBuffer()
-		case <-timeout:
-			return nil, errDeadlineReached
+			logger.LogIfError("connMgr.Close during chunkReader.next", r.rcv.connMgr.Close())
+			r.finish(err)
+			return
 		}
-		if chunkCnt >= rcv.maxChunkCount {
-			err := fmt.Errorf("counter more than %d chunks", rcv.maxChunkCount)
-			return nil, transport.LocalError(uatype.StatusBadResponseTooLarge, err)
+		if e.err != nil {
+			e.freeBuffer()
+			r.finish(e.err)
+			return
+		}
+		r.chunk = append(r.chunk[:0], e.body...)
+		r.buf = r.chunk
+		e.freeBuffer()
 
+		switch {
+		case e.msgHeader.ChunkType == chunkTypeFinal:
+			// Release the queue without waiting for the body to be read.
+			r.finish(io.EOF)
+		case r.chunkCnt >= r.rcv.maxChunkCount:
+			err := fmt.Errorf("counter more than %d chunks", r.rcv.maxChunkCount)
+			r.finish(transport.LocalError(uatype.StatusBadResponseTooLarge, err))
 		}
+	case <-r.timeout:
+		r.finish(errDeadlineReached)
+	case <-r.cancel:
+		r.finish(errCanceled)
 	}
 }
 
+// finish sets r.err if it's not already set, and frees the receive queue.
+func (r *chunkReader) finish(err error) {
+	if r.err != nil {
+		return
+	}
+	r.err = err
+	r.stop()
+	r.rcv.CancelRequestID(r.requestID)
+}
+
 func (rcv *recvState) SetSecurityToken(st uatype.ChannelSecurityToken) {
 	rcv.securityTokenM.Lock()
 	rcv.securityToken = st
@@ -351,8 +411,12 @@ func (rcv *recvState) waitForEvent() (recvEvent, uint32) {
 		return e, requestIDInvalidMask
 	}
 
+	// Only decode the received part of the buffer, so that chunks that are
+	// too short for their headers fail to decode.
+	chunk := buff[:msgSize]
+
 	// Decode msg header.
-	if err := binary.Unmarshal(buff[0:secureMsgHeaderSize], &e.msgHeader); err != nil {
+	if err := binary.Unmarshal(chunk, &e.msgHeader); err != nil {
 		e.err = err
 		return e, requestIDInvalidMask
 	}
@@ -362,14 +426,19 @@ func (rcv *recvState) waitForEvent() (recvEvent, uint32) {
 	switch e.msgHeader.msgType() {
 	case msgTypeOpn, msgTypeClo:
 		ah := AsymmetricAlgorithmSecurityHeader{}
-		if err := binary.Unmarshal(buff[i:], &ah); err != nil {
+		if err := binary.Unmarshal(chunk[i:], &ah); err != nil {
 			e.err = transport.LocalError(uatype.StatusBadInternalError, err)
 			return e, requestIDInvalidMask
 		}
 		i += ah.size()
+		if i > len(chunk) {
+			err := fmt.Errorf("security header of %d bytes exceeds chunk size %d", i, len(chunk))
+			e.err = transport.LocalError(uatype.StatusBadDecodingError, err)
+			return e, requestIDInvalidMask
+		}
 	case msgTypeMsg:
 		sh := symmetricAlgorithmSecurityHeader{}
-		if err := binary.Unmarshal(buff[i:], &sh); err != nil {
+		if err := binary.Unmarshal(chunk[i:], &sh); err != nil {
 			e.err = err
 			return e, requestIDInvalidMask
 		}
@@ -382,7 +451,7 @@ func (rcv *recvState) waitForEvent() (recvEvent, uint32) {
 	}
 
 	// Decode and validate sequence headers.
-	if err := binary.Unmarshal(buff[i:], &seqh); err != nil {
+	if err := binary.Unmarshal(chunk[i:], &seqh); err != nil {
 		e.err = err
 		return e, requestIDInvalidMask
 	}
@@ -413,13 +482,13 @@ func (rcv *recvState) waitForEvent() (recvEvent, uint32) {
 	switch e.msgHeader.ChunkType {
 	case chunkTypeFinalAborted:
 		var abort secureAbortBody
-		if err := binary.Unmarshal(buff[i:msgSize], &abort); err != nil {
+		if err := binary.Unmarshal(chunk[i:], &abort); err != nil {
 			e.err = transport.LocalError(uatype.StatusBadInternalError, err)
 		} else {
 			e.err = transport.RemoteError(abort.Status, abort.Reason)
 		}
 	case chunkTypeIntermediate, chunkTypeFinal:
-		e.body = buff[i:msgSize]
+		e.body = chunk[i:]
 	}
 	return e, seqh.RequestID
 
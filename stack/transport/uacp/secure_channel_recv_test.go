@@ -0,0 +1,112 @@
+package uacp_test
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/searis/guma/stack/transport/uacp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testChunking = uacp.MsgChunking{
+	ReceiveBufferSize: 256,
+	SendBufferSize:    256,
+	MaxMessageSize:    1024,
+	MaxChunkCount:     4,
+}
+
+// msgChunk returns a symmetric MSG chunk with the given chunk type, sequence
+// number, request ID and body.
+func msgChunk(chunkType byte, seq, requestID uint32, body []byte) []byte {
+	b := make([]byte, 24, 24+len(body))
+	copy(b, "MSG")
+	b[3] = chunkType
+	binary.LittleEndian.PutUint32(b[4:], uint32(24+len(body)))
+	binary.LittleEndian.PutUint32(b[8:], 1)  // SecureChannelID
+	binary.LittleEndian.PutUint32(b[12:], 1) // TokenID
+	binary.LittleEndian.PutUint32(b[16:], seq)
+	binary.LittleEndian.PutUint32(b[20:], requestID)
+	return append(b, body...)
+}
+
+func concat(chunks ...[]byte) []byte {
+	var b []byte
+	for _, c := range chunks {
+		b = append(b, c...)
+	}
+	return b
+}
+
+func TestRecvChunks(t *testing.T) {
+	data := concat(
+		msgChunk('C', 1, 7, []byte("hello, ")),
+		msgChunk('F', 2, 7, []byte("world")),
+		msgChunk('F', 3, 8, nil),
+	)
+	ids, bodies, err := uacp.RecvChunks(data, testChunking)
+	require.Error(t, err, "end of stream")
+	assert.Equal(t, []uint32{7, 7, 8}, ids, "request IDs")
+	assert.Equal(t, [][]byte{[]byte("hello, "), []byte("world"), nil}, bodies, "bodies")
+}
+
+func TestRecvChunksInvalid(t *testing.T) {
+	short := msgChunk('F', 1, 7, nil)[:20]
+	binary.LittleEndian.PutUint32(short[4:], 20)
+
+	cases := []struct {
+		Name       string
+		Data       []byte
+		RequestIDs []uint32
+		Error      string
+	}{
+		{
+			Name:  "SequenceNumber",
+			Data:  msgChunk('F', 2, 7, nil),
+			Error: "got sequence number 2, expected 1",
+		},
+		{
+			Name:       "RequestID",
+			Data:       concat(msgChunk('C', 1, 7, nil), msgChunk('F', 2, 8, nil)),
+			RequestIDs: []uint32{7},
+			Error:      "got new request ID after an intermediate chunk",
+		},
+		{
+			Name:  "SizeBelowHeader",
+			Data:  []byte("MSGF\x03\x00\x00\x00"),
+			Error: "chunk size 3 is smaller than the message header",
+		},
+		{
+			Name:  "SizeBelowSequenceHeader",
+			Data:  short,
+			Error: "DecoderError",
+		},
+		{
+			Name:  "TooLarge",
+			Data:  msgChunk('F', 1, 7, make([]byte, 256)),
+			Error: "chunk size 280 exceeds receive buffer size 256",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			ids, _, err := uacp.RecvChunks(tc.Data, testChunking)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.Error)
+			assert.Equal(t, tc.RequestIDs, ids, "request IDs")
+		})
+	}
+}
+
+func FuzzRecvChunks(f *testing.F) {
+	f.Add(msgChunk('F', 1, 7, []byte("body")))
+	f.Add(concat(msgChunk('C', 1, 7, []byte("a")), msgChunk('F', 2, 7, []byte("b"))))
+	f.Add(msgChunk('A', 1, 7, []byte{0x00, 0x00, 0x01, 0x80, 0x02, 0x00, 0x00, 0x00, 'n', 'o'}))
+	f.Fuzz(func(t *testing.T, data []byte) {
+		_, bodies, _ := uacp.RecvChunks(data, testChunking)
+		for _, b := range bodies {
+			if len(b) > int(testChunking.ReceiveBufferSize) {
+				t.Fatalf("body of %d bytes exceeds the receive buffer", len(b))
+			}
+		}
+	})
+}
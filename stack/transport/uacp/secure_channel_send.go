@@ -2,6 +2,7 @@ package uacp
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"io"
@@ -17,30 +18,50 @@ import (
 // Send sends a request through an open channel or times out. To run with no
 // timeout, let deadaline be the zero time.
 func (sc *SecureChannel) Send(r transport.Request, deadline time.Time) (*transport.Response, error) {
-	// Wait for receiveQueue spot or deadline.
-	requestID, err := sc.recvState.WaitForRequestID(deadline)
-	if err != nil {
-		return nil, err
+	return sc.send(r, deadline, nil)
+}
+
+// SendContext sends a request through an open channel, and returns ctx.Err()
+// if ctx is done before a response is received. The receive queue is freed on
+// cancellation. If ctx is done while the body of the response is read, reads
+// fail with a local transport error with status BadRequestCancelledByClient.
+// SendContext implements transport.ContextSender.
+func (sc *SecureChannel) SendContext(ctx context.Context, r transport.Request) (*transport.Response, error) {
+	deadline, _ := ctx.Deadline()
+	resp, err := sc.send(r, deadline, ctx.Done())
+	if err == errCanceled {
+		return nil, ctx.Err()
 	}
-	// The receivequeue must always be freed, and it is always safe to cancel,
-	// even on success.
-	defer sc.recvState.CancelRequestID(requestID)
+	return resp, err
+}
+
+// send sends a request, and waits for a response until deadline is reached or
+// cancel is closed.
+func (sc *SecureChannel) send(r transport.Request, deadline time.Time, cancel <-chan struct{}) (*transport.Response, error) {
+	// Use the same state for the whole request, even if the channel is
+	// reconnected meanwhile.
+	st := sc.state()
+	recvState, sendState, securityToken := st.recvState, st.sendState, st.securityToken
 
+	// Wait for receiveQueue spot or deadline.
+	requestID, err := recvState.WaitForRequestID(deadline, cancel)
 	if err != nil {
-		return nil, transport.LocalError(uatype.StatusBadInternalError, err)
+		return nil, err
 	}
-
-	if err := sc.sendState.SendMsg(secureMsg{
+	if err := sendState.SendMsg(secureMsg{
 		Type:           secureMsgTypeMsg,
-		ChannelID:      sc.securityToken.ChannelId,
+		ChannelID:      securityToken.ChannelId,
 		RequestID:      requestID,
-		SecurityHeader: symmetricAlgorithmSecurityHeader{TokenID: sc.securityToken.TokenId},
+		SecurityHeader: symmetricAlgorithmSecurityHeader{TokenID: securityToken.TokenId},
 		Request:        r,
 	}, deadline); err != nil {
+		recvState.CancelRequestID(requestID)
 		return nil, err
 	}
 
-	return sc.recvState.WaitForResponse(requestID, msgTypeMsg, deadline)
+	// The receive queue is freed by WaitForResponse on errors, and by the
+	// response body otherwise.
+	return recvState.WaitForResponse(requestID, msgTypeMsg, deadline, cancel)
 }
 
 // sendState manages chunking and sending of messages. In the future it will
@@ -0,0 +1,39 @@
+package uacp_test
+
+import (
+	"bytes"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/searis/guma/stack/transport"
+	"github.com/searis/guma/stack/transport/uacp"
+	"github.com/searis/guma/stack/uatype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSendDuringReconnect(t *testing.T) {
+	dial := func(deadline time.Time) (net.Conn, error) {
+		return nil, errors.New("connection refused")
+	}
+	sc := uacp.NewSilentSecureChannel(dial, testChunking)
+
+	reconnected := make(chan error, 1)
+	go func() {
+		reconnected <- sc.Reconnect(time.Now().Add(time.Second))
+	}()
+	time.Sleep(10 * time.Millisecond)
+
+	start := time.Now()
+	_, err := sc.Send(transport.Request{
+		NodeID: uatype.NewFourByteNodeID(0, uatype.NodeIdReadRequest_Encoding_DefaultBinary).Expanded(),
+		Body:   bytes.NewReader(nil),
+	}, start.Add(50*time.Millisecond))
+	elapsed := time.Since(start)
+
+	require.Error(t, err, "Send")
+	assert.True(t, elapsed < 300*time.Millisecond, "Send returned after %s, expected it to respect its deadline", elapsed)
+	assert.Error(t, <-reconnected, "Reconnect")
+}
@@ -0,0 +1,139 @@
+package stack
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/searis/guma/stack/uatype"
+	"github.com/searis/guma/stack/uatype/typedict"
+)
+
+// LoadTypeDictionaries reads the OPC Binary type dictionaries of the server
+// into set, and registers each structured type that has a binary encoding
+// node for automatic encoding and decoding of ExtensionObjects in requests
+// and responses of c. Dictionaries in the OPC UA namespace are skipped, as
+// those types are already defined by the uatype package.
+//
+// As encoding node IDs depend on the server's namespace table, set must only
+// be used for this server; c uses the types of the set it last loaded. If
+// some types can not be built, the remaining types are still registered, and
+// an error describing the first failure is returned.
+func (c *Client) LoadTypeDictionaries(set *typedict.Set, deadline time.Time) error {
+	c.setExtensionObjectTypes(set.ExtensionObjectTypes())
+
+	// Find the dictionary nodes.
+	refs, err := c.browseAll([]uatype.BrowseDescription{{
+		NodeId:          uatype.NewFourByteNodeID(0, uatype.NodeIdOPCBinarySchema_TypeSystem),
+		BrowseDirection: uatype.BrowseDirectionForward,
+		ReferenceTypeId: uatype.NewFourByteNodeID(0, uatype.NodeIdHasComponent),
+		IncludeSubtypes: true,
+		NodeClassMask:   uint32(uatype.NodeClassVariable),
+		ResultMask:      uint32(uatype.BrowseResultMaskAll),
+	}}, deadline)
+	if err != nil {
+		return err
+	}
+	var dictNodes []uatype.NodeId
+	for _, ref := range refs[0] {
+		if nid, ok := ref.NodeId.NodeID(); ok && nid.NamespaceIndex() != 0 {
+			dictNodes = append(dictNodes, nid)
+		}
+	}
+	if len(dictNodes) == 0 {
+		return nil
+	}
+
+	// Parse all dictionaries before building any types, as types may refer
+	// to other dictionaries.
+	values, err := c.readValues(dictNodes, deadline)
+	if err != nil {
+		return err
+	}
+	dicts := make([]*typedict.Dictionary, len(dictNodes))
+	for i, dv := range values {
+		if dv.StatusCode.IsBad() {
+			return fmt.Errorf("dictionary %s: %s", dictNodes[i].Expanded().DisplayName(), dv.StatusCode)
+		}
+		v, err := dv.Value.Value()
+		if err != nil {
+			return err
+		}
+		b, ok := v.(uatype.ByteString)
+		if !ok {
+			return fmt.Errorf("dictionary %s: unexpected value type %T", dictNodes[i].Expanded().DisplayName(), v)
+		}
+		if dicts[i], err = set.Parse(bytes.NewReader(b)); err != nil {
+			return fmt.Errorf("dictionary %s: %s", dictNodes[i].Expanded().DisplayName(), err)
+		}
+	}
+
+	// Find the type description variables of each dictionary, and the
+	// encoding node that refers to each of them.
+	browse := make([]uatype.BrowseDescription, len(dictNodes))
+	for i, nid := range dictNodes {
+		browse[i] = uatype.BrowseDescription{
+			NodeId:          nid,
+			BrowseDirection: uatype.BrowseDirectionForward,
+			ReferenceTypeId: uatype.NewFourByteNodeID(0, uatype.NodeIdHasComponent),
+			IncludeSubtypes: true,
+			NodeClassMask:   uint32(uatype.NodeClassVariable),
+			ResultMask:      uint32(uatype.BrowseResultMaskAll),
+		}
+	}
+	if refs, err = c.browseAll(browse, deadline); err != nil {
+		return err
+	}
+	var descNodes []uatype.NodeId
+	var descDicts []*typedict.Dictionary
+	for i := range refs {
+		for _, ref := range refs[i] {
+			if nid, ok := ref.NodeId.NodeID(); ok {
+				descNodes = append(descNodes, nid)
+				descDicts = append(descDicts, dicts[i])
+			}
+		}
+	}
+	if len(descNodes) == 0 {
+		return nil
+	}
+	if values, err = c.readValues(descNodes, deadline); err != nil {
+		return err
+	}
+	browse = make([]uatype.BrowseDescription, len(descNodes))
+	for i, nid := range descNodes {
+		browse[i] = uatype.BrowseDescription{
+			NodeId:          nid,
+			BrowseDirection: uatype.BrowseDirectionInverse,
+			ReferenceTypeId: uatype.NewFourByteNodeID(0, uatype.NodeIdHasDescription),
+			ResultMask:      uint32(uatype.BrowseResultMaskAll),
+		}
+	}
+	if refs, err = c.browseAll(browse, deadline); err != nil {
+		return err
+	}
+
+	// Build and register the types.
+	var firstErr error
+	for i, dv := range values {
+		name, _ := dv.Value.Value()
+		typeName, ok := name.(string)
+		if !ok || len(refs[i]) == 0 {
+			// Not a structured type description, or no binary encoding.
+			continue
+		}
+		encodingID, ok := refs[i][0].NodeId.NodeID()
+		if !ok {
+			continue
+		}
+		t, err := descDicts[i].Type(typeName)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		t.Register(encodingID)
+	}
+	return firstErr
+}
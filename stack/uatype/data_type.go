@@ -0,0 +1,49 @@
+package uatype
+
+// dataTypeBuiltins maps DataTypes in the OPC UA namespace that are simple
+// subtypes of a built-in type to the built-in type ID.
+var dataTypeBuiltins = map[uint16]byte{
+	NodeIdEnumeration:  6,  // Int32
+	NodeIdImage:        15, // ByteString
+	NodeIdImageBMP:     15,
+	NodeIdImageGIF:     15,
+	NodeIdImageJPG:     15,
+	NodeIdImagePNG:     15,
+	NodeIdIntegerId:    7,  // UInt32
+	NodeIdCounter:      7,  // UInt32
+	NodeIdDuration:     11, // Double
+	NodeIdNumericRange: 12, // String
+	NodeIdTime:         12, // String
+	NodeIdLocaleId:     12, // String
+	NodeIdDate:         13, // DateTime
+	NodeIdUtcTime:      13, // DateTime
+}
+
+// BuiltinType returns the ID of the built-in type used to encode values of the
+// DataType with node ID dataType, and true. The built-in type ID is also the
+// VariantType of Variants holding such values. Only DataTypes in the OPC UA
+// namespace are known; structured DataTypes with a Go type in this package are
+// encoded as ExtensionObject. For abstract DataTypes such as BaseDataType and
+// Number, or unknown DataTypes, 0 and false is returned.
+func BuiltinType(dataType NodeId) (byte, bool) {
+	if dataType.NamespaceIndex() != 0 || dataType.NodeIdType == NodeIdTypeNumeric && dataType.Numeric.Identifier > 0xffff {
+		return 0, false
+	}
+	id := dataType.Uint()
+	switch {
+	case id == NodeIdBaseDataType:
+		return 0, false
+	case id >= 1 && id <= 25:
+		return byte(id), true
+	}
+	if t, ok := dataTypeBuiltins[id]; ok {
+		return t, true
+	}
+	if enumDataTypes[id] {
+		return 6, true // Int32
+	}
+	if _, ok := TypeByName(dataType.Expanded().DisplayName()); ok {
+		return 22, true
+	}
+	return 0, false
+}
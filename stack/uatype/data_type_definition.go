@@ -0,0 +1,90 @@
+package uatype
+
+// Types and node IDs introduced in OPC UA 1.04 for the DataTypeDefinition
+// attribute. They are declared by hand, as the generated code is based on the
+// 1.03 schemas.
+
+import "strconv"
+
+// Node IDs introduced in OPC UA 1.04.
+var (
+	NodeIdDataTypeDefinition                         uint16 = 0x0061
+	NodeIdStructureType                              uint16 = 0x0062
+	NodeIdStructureDefinition                        uint16 = 0x0063
+	NodeIdEnumDefinition                             uint16 = 0x0064
+	NodeIdStructureField                             uint16 = 0x0065
+	NodeIdEnumField                                  uint16 = 0x0066
+	NodeIdStructureDefinition_Encoding_DefaultBinary uint16 = 0x007a
+	NodeIdEnumDefinition_Encoding_DefaultBinary      uint16 = 0x007b
+	NodeIdStructureDefinition_Encoding_DefaultXml    uint16 = 0x39ce
+	NodeIdEnumDefinition_Encoding_DefaultXml         uint16 = 0x39cf
+)
+
+// AttrTypeDataTypeDefinition is the attribute ID of the DataTypeDefinition
+// attribute of DataType nodes, introduced in OPC UA 1.04.
+const AttrTypeDataTypeDefinition attrType = 23
+
+type StructureType uint32
+
+// The kind of structure described by a StructureDefinition.
+const (
+	StructureTypeStructure                   StructureType = 0
+	StructureTypeStructureWithOptionalFields StructureType = 1
+	StructureTypeUnion                       StructureType = 2
+)
+
+// String returns the name of t, or its value if t is unknown.
+func (t StructureType) String() string {
+	switch t {
+	case StructureTypeStructure:
+		return "Structure"
+	case StructureTypeStructureWithOptionalFields:
+		return "StructureWithOptionalFields"
+	case StructureTypeUnion:
+		return "Union"
+	}
+	return strconv.FormatUint(uint64(t), 10)
+}
+
+// StructureField describes a field of a structured DataType.
+type StructureField struct {
+	Name                string
+	Description         LocalizedText
+	DataType            NodeId
+	ValueRank           int32
+	NoOfArrayDimensions int32
+	ArrayDimensions     []uint32 `opcua:"lengthField=NoOfArrayDimensions"`
+	MaxStringLength     uint32
+	IsOptional          bool
+}
+
+// StructureDefinition describes the fields of a structured DataType,
+// including all inherited fields.
+type StructureDefinition struct {
+	DefaultEncodingId NodeId
+	BaseDataType      NodeId
+	StructureType     StructureType
+	NoOfFields        int32
+	Fields            []StructureField `opcua:"lengthField=NoOfFields"`
+}
+
+// EnumField describes a value of an enumerated DataType.
+type EnumField struct {
+	Value       int64
+	DisplayName LocalizedText
+	Description LocalizedText
+	Name        string
+}
+
+// EnumDefinition describes the values of an enumerated DataType.
+type EnumDefinition struct {
+	NoOfFields int32
+	Fields     []EnumField `opcua:"lengthField=NoOfFields"`
+}
+
+func init() {
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdStructureDefinition_Encoding_DefaultBinary), StructureDefinition{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdEnumDefinition_Encoding_DefaultBinary), EnumDefinition{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdStructureDefinition_Encoding_DefaultXml), StructureDefinition{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdEnumDefinition_Encoding_DefaultXml), EnumDefinition{})
+}
@@ -0,0 +1,93 @@
+package uatype
+
+import "time"
+
+// MinDateTime and MaxDateTime are the bounds of the DateTime range that OPC UA
+// encodings are able to represent. Times at or before MinDateTime are encoded
+// as 0, which is decoded as the zero time.Time. Times at or after MaxDateTime
+// are encoded as the maximum Int64 value, which is decoded as MaxDateTime.
+var (
+	MinDateTime = time.Date(1601, 1, 1, 0, 0, 0, 0, time.UTC)
+	MaxDateTime = time.Date(9999, 12, 31, 23, 59, 59, 0, time.UTC)
+)
+
+// MaxPicoseconds is the highest valid number of 10 picosecond intervals of a
+// Timestamp.
+const MaxPicoseconds = 9999
+
+// Timestamp is a DateTime with the additional precision given by the
+// picoseconds of a DataValue. DateTime values have a resolution of 100
+// nanoseconds, which Picoseconds extends with the number of 10 picosecond
+// intervals to add, from 0 to MaxPicoseconds.
+type Timestamp struct {
+	DateTime    time.Time
+	Picoseconds uint16
+}
+
+// NewTimestamp splits t into a DateTime of 100 nanosecond resolution and the
+// picoseconds of the remaining nanoseconds.
+func NewTimestamp(t time.Time) Timestamp {
+	rest := t.Nanosecond() % 100
+	return Timestamp{
+		DateTime:    t.Add(-time.Duration(rest)),
+		Picoseconds: uint16(rest * 100),
+	}
+}
+
+// Time returns ts as a time.Time, with the picoseconds merged into the
+// nanoseconds. As time.Time has nanosecond resolution, picoseconds below one
+// nanosecond are truncated.
+func (ts Timestamp) Time() time.Time {
+	if ts.DateTime.IsZero() {
+		return ts.DateTime
+	}
+	return ts.DateTime.Add(time.Duration(ts.Picoseconds/100) * time.Nanosecond)
+}
+
+// SourceTime returns the SourceTimestamp and SourcePicoseconds of dv as a
+// Timestamp. Fields that are not specified are zero.
+func (dv DataValue) SourceTime() Timestamp {
+	var ts Timestamp
+	if dv.SourceTimestampSpecified {
+		ts.DateTime = dv.SourceTimestamp
+	}
+	if dv.SourcePicosecondsSpecified {
+		ts.Picoseconds = dv.SourcePicoseconds
+	}
+	return ts
+}
+
+// SetSourceTime sets the SourceTimestamp and SourcePicoseconds of dv from t,
+// such that dv.SourceTime().Time() equals t. A zero t unsets both, and
+// picoseconds are only specified when t has nanoseconds below 100.
+func (dv *DataValue) SetSourceTime(t time.Time) {
+	ts := NewTimestamp(t)
+	dv.SourceTimestampSpecified = Bit(!t.IsZero())
+	dv.SourceTimestamp = ts.DateTime
+	dv.SourcePicosecondsSpecified = Bit(ts.Picoseconds != 0)
+	dv.SourcePicoseconds = ts.Picoseconds
+}
+
+// ServerTime returns the ServerTimestamp and ServerPicoseconds of dv as a
+// Timestamp. Fields that are not specified are zero.
+func (dv DataValue) ServerTime() Timestamp {
+	var ts Timestamp
+	if dv.ServerTimestampSpecified {
+		ts.DateTime = dv.ServerTimestamp
+	}
+	if dv.ServerPicosecondsSpecified {
+		ts.Picoseconds = dv.ServerPicoseconds
+	}
+	return ts
+}
+
+// SetServerTime sets the ServerTimestamp and ServerPicoseconds of dv from t,
+// such that dv.ServerTime().Time() equals t. A zero t unsets both, and
+// picoseconds are only specified when t has nanoseconds below 100.
+func (dv *DataValue) SetServerTime(t time.Time) {
+	ts := NewTimestamp(t)
+	dv.ServerTimestampSpecified = Bit(!t.IsZero())
+	dv.ServerTimestamp = ts.DateTime
+	dv.ServerPicosecondsSpecified = Bit(ts.Picoseconds != 0)
+	dv.ServerPicoseconds = ts.Picoseconds
+}
@@ -3,6 +3,8 @@
 
 package uatype
 
+import "fmt"
+
 // StatusCode is expected to hold any value defined in the StatusCode<...> const
 // block.
 type StatusCode uint32
@@ -12,3 +14,38 @@ type StatusCode uint32
 func StatusText(code StatusCode) string {
 	return statusText[code]
 }
+
+// StatusSymbol returns the symbolic name of the OPC UA status code, e.g.
+// "BadTimeout". It returns "Good" for 0, and the empty string if the code is
+// unknown.
+func StatusSymbol(code StatusCode) string {
+	if code == 0 {
+		return "Good"
+	}
+	return statusSymbol[code]
+}
+
+// IsGood returns true if the severity of code is Good.
+func (code StatusCode) IsGood() bool {
+	return code&0xC0000000 == 0
+}
+
+// IsUncertain returns true if the severity of code is Uncertain.
+func (code StatusCode) IsUncertain() bool {
+	return code&0xC0000000 == 0x40000000
+}
+
+// IsBad returns true if the severity of code is Bad.
+func (code StatusCode) IsBad() bool {
+	return code&0x80000000 != 0
+}
+
+// Error implements the built-in error interface, so that bad status codes of
+// individual results can be returned as errors.
+func (code StatusCode) Error() string {
+	s := statusText[code]
+	if s == "" {
+		return fmt.Sprintf("unknown status code 0x%.8X", uint32(code))
+	}
+	return fmt.Sprintf("status code 0x%.8X: %s", uint32(code), s)
+}
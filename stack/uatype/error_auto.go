@@ -461,3 +461,233 @@ var statusText = map[StatusCode]string{
 	StatusBadSyntaxError:                          "a value had an invalid syntax",
 	StatusBadMaxConnectionsReached:                "the operation could not be finished because all available connections are in use",
 }
+
+var statusSymbol = map[StatusCode]string{
+	StatusBadUnexpectedError:                      "BadUnexpectedError",
+	StatusBadInternalError:                        "BadInternalError",
+	StatusBadOutOfMemory:                          "BadOutOfMemory",
+	StatusBadResourceUnavailable:                  "BadResourceUnavailable",
+	StatusBadCommunicationError:                   "BadCommunicationError",
+	StatusBadEncodingError:                        "BadEncodingError",
+	StatusBadDecodingError:                        "BadDecodingError",
+	StatusBadEncodingLimitsExceeded:               "BadEncodingLimitsExceeded",
+	StatusBadRequestTooLarge:                      "BadRequestTooLarge",
+	StatusBadResponseTooLarge:                     "BadResponseTooLarge",
+	StatusBadUnknownResponse:                      "BadUnknownResponse",
+	StatusBadTimeout:                              "BadTimeout",
+	StatusBadServiceUnsupported:                   "BadServiceUnsupported",
+	StatusBadShutdown:                             "BadShutdown",
+	StatusBadServerNotConnected:                   "BadServerNotConnected",
+	StatusBadServerHalted:                         "BadServerHalted",
+	StatusBadNothingToDo:                          "BadNothingToDo",
+	StatusBadTooManyOperations:                    "BadTooManyOperations",
+	StatusBadTooManyMonitoredItems:                "BadTooManyMonitoredItems",
+	StatusBadDataTypeIdUnknown:                    "BadDataTypeIdUnknown",
+	StatusBadCertificateInvalid:                   "BadCertificateInvalid",
+	StatusBadSecurityChecksFailed:                 "BadSecurityChecksFailed",
+	StatusBadCertificateTimeInvalid:               "BadCertificateTimeInvalid",
+	StatusBadCertificateIssuerTimeInvalid:         "BadCertificateIssuerTimeInvalid",
+	StatusBadCertificateHostNameInvalid:           "BadCertificateHostNameInvalid",
+	StatusBadCertificateUriInvalid:                "BadCertificateUriInvalid",
+	StatusBadCertificateUseNotAllowed:             "BadCertificateUseNotAllowed",
+	StatusBadCertificateIssuerUseNotAllowed:       "BadCertificateIssuerUseNotAllowed",
+	StatusBadCertificateUntrusted:                 "BadCertificateUntrusted",
+	StatusBadCertificateRevocationUnknown:         "BadCertificateRevocationUnknown",
+	StatusBadCertificateIssuerRevocationUnknown:   "BadCertificateIssuerRevocationUnknown",
+	StatusBadCertificateRevoked:                   "BadCertificateRevoked",
+	StatusBadCertificateIssuerRevoked:             "BadCertificateIssuerRevoked",
+	StatusBadCertificateChainIncomplete:           "BadCertificateChainIncomplete",
+	StatusBadUserAccessDenied:                     "BadUserAccessDenied",
+	StatusBadIdentityTokenInvalid:                 "BadIdentityTokenInvalid",
+	StatusBadIdentityTokenRejected:                "BadIdentityTokenRejected",
+	StatusBadSecureChannelIdInvalid:               "BadSecureChannelIdInvalid",
+	StatusBadInvalidTimestamp:                     "BadInvalidTimestamp",
+	StatusBadNonceInvalid:                         "BadNonceInvalid",
+	StatusBadSessionIdInvalid:                     "BadSessionIdInvalid",
+	StatusBadSessionClosed:                        "BadSessionClosed",
+	StatusBadSessionNotActivated:                  "BadSessionNotActivated",
+	StatusBadSubscriptionIdInvalid:                "BadSubscriptionIdInvalid",
+	StatusBadRequestHeaderInvalid:                 "BadRequestHeaderInvalid",
+	StatusBadTimestampsToReturnInvalid:            "BadTimestampsToReturnInvalid",
+	StatusBadRequestCancelledByClient:             "BadRequestCancelledByClient",
+	StatusBadTooManyArguments:                     "BadTooManyArguments",
+	StatusGoodSubscriptionTransferred:             "GoodSubscriptionTransferred",
+	StatusGoodCompletesAsynchronously:             "GoodCompletesAsynchronously",
+	StatusGoodOverload:                            "GoodOverload",
+	StatusGoodClamped:                             "GoodClamped",
+	StatusBadNoCommunication:                      "BadNoCommunication",
+	StatusBadWaitingForInitialData:                "BadWaitingForInitialData",
+	StatusBadNodeIdInvalid:                        "BadNodeIdInvalid",
+	StatusBadNodeIdUnknown:                        "BadNodeIdUnknown",
+	StatusBadAttributeIdInvalid:                   "BadAttributeIdInvalid",
+	StatusBadIndexRangeInvalid:                    "BadIndexRangeInvalid",
+	StatusBadIndexRangeNoData:                     "BadIndexRangeNoData",
+	StatusBadDataEncodingInvalid:                  "BadDataEncodingInvalid",
+	StatusBadDataEncodingUnsupported:              "BadDataEncodingUnsupported",
+	StatusBadNotReadable:                          "BadNotReadable",
+	StatusBadNotWritable:                          "BadNotWritable",
+	StatusBadOutOfRange:                           "BadOutOfRange",
+	StatusBadNotSupported:                         "BadNotSupported",
+	StatusBadNotFound:                             "BadNotFound",
+	StatusBadObjectDeleted:                        "BadObjectDeleted",
+	StatusBadNotImplemented:                       "BadNotImplemented",
+	StatusBadMonitoringModeInvalid:                "BadMonitoringModeInvalid",
+	StatusBadMonitoredItemIdInvalid:               "BadMonitoredItemIdInvalid",
+	StatusBadMonitoredItemFilterInvalid:           "BadMonitoredItemFilterInvalid",
+	StatusBadMonitoredItemFilterUnsupported:       "BadMonitoredItemFilterUnsupported",
+	StatusBadFilterNotAllowed:                     "BadFilterNotAllowed",
+	StatusBadStructureMissing:                     "BadStructureMissing",
+	StatusBadEventFilterInvalid:                   "BadEventFilterInvalid",
+	StatusBadContentFilterInvalid:                 "BadContentFilterInvalid",
+	StatusBadFilterOperatorInvalid:                "BadFilterOperatorInvalid",
+	StatusBadFilterOperatorUnsupported:            "BadFilterOperatorUnsupported",
+	StatusBadFilterOperandCountMismatch:           "BadFilterOperandCountMismatch",
+	StatusBadFilterOperandInvalid:                 "BadFilterOperandInvalid",
+	StatusBadFilterElementInvalid:                 "BadFilterElementInvalid",
+	StatusBadFilterLiteralInvalid:                 "BadFilterLiteralInvalid",
+	StatusBadContinuationPointInvalid:             "BadContinuationPointInvalid",
+	StatusBadNoContinuationPoints:                 "BadNoContinuationPoints",
+	StatusBadReferenceTypeIdInvalid:               "BadReferenceTypeIdInvalid",
+	StatusBadBrowseDirectionInvalid:               "BadBrowseDirectionInvalid",
+	StatusBadNodeNotInView:                        "BadNodeNotInView",
+	StatusBadServerUriInvalid:                     "BadServerUriInvalid",
+	StatusBadServerNameMissing:                    "BadServerNameMissing",
+	StatusBadDiscoveryUrlMissing:                  "BadDiscoveryUrlMissing",
+	StatusBadSempahoreFileMissing:                 "BadSempahoreFileMissing",
+	StatusBadRequestTypeInvalid:                   "BadRequestTypeInvalid",
+	StatusBadSecurityModeRejected:                 "BadSecurityModeRejected",
+	StatusBadSecurityPolicyRejected:               "BadSecurityPolicyRejected",
+	StatusBadTooManySessions:                      "BadTooManySessions",
+	StatusBadUserSignatureInvalid:                 "BadUserSignatureInvalid",
+	StatusBadApplicationSignatureInvalid:          "BadApplicationSignatureInvalid",
+	StatusBadNoValidCertificates:                  "BadNoValidCertificates",
+	StatusBadIdentityChangeNotSupported:           "BadIdentityChangeNotSupported",
+	StatusBadRequestCancelledByRequest:            "BadRequestCancelledByRequest",
+	StatusBadParentNodeIdInvalid:                  "BadParentNodeIdInvalid",
+	StatusBadReferenceNotAllowed:                  "BadReferenceNotAllowed",
+	StatusBadNodeIdRejected:                       "BadNodeIdRejected",
+	StatusBadNodeIdExists:                         "BadNodeIdExists",
+	StatusBadNodeClassInvalid:                     "BadNodeClassInvalid",
+	StatusBadBrowseNameInvalid:                    "BadBrowseNameInvalid",
+	StatusBadBrowseNameDuplicated:                 "BadBrowseNameDuplicated",
+	StatusBadNodeAttributesInvalid:                "BadNodeAttributesInvalid",
+	StatusBadTypeDefinitionInvalid:                "BadTypeDefinitionInvalid",
+	StatusBadSourceNodeIdInvalid:                  "BadSourceNodeIdInvalid",
+	StatusBadTargetNodeIdInvalid:                  "BadTargetNodeIdInvalid",
+	StatusBadDuplicateReferenceNotAllowed:         "BadDuplicateReferenceNotAllowed",
+	StatusBadInvalidSelfReference:                 "BadInvalidSelfReference",
+	StatusBadReferenceLocalOnly:                   "BadReferenceLocalOnly",
+	StatusBadNoDeleteRights:                       "BadNoDeleteRights",
+	StatusUncertainReferenceNotDeleted:            "UncertainReferenceNotDeleted",
+	StatusBadServerIndexInvalid:                   "BadServerIndexInvalid",
+	StatusBadViewIdUnknown:                        "BadViewIdUnknown",
+	StatusBadViewTimestampInvalid:                 "BadViewTimestampInvalid",
+	StatusBadViewParameterMismatch:                "BadViewParameterMismatch",
+	StatusBadViewVersionInvalid:                   "BadViewVersionInvalid",
+	StatusUncertainNotAllNodesAvailable:           "UncertainNotAllNodesAvailable",
+	StatusGoodResultsMayBeIncomplete:              "GoodResultsMayBeIncomplete",
+	StatusBadNotTypeDefinition:                    "BadNotTypeDefinition",
+	StatusUncertainReferenceOutOfServer:           "UncertainReferenceOutOfServer",
+	StatusBadTooManyMatches:                       "BadTooManyMatches",
+	StatusBadQueryTooComplex:                      "BadQueryTooComplex",
+	StatusBadNoMatch:                              "BadNoMatch",
+	StatusBadMaxAgeInvalid:                        "BadMaxAgeInvalid",
+	StatusBadSecurityModeInsufficient:             "BadSecurityModeInsufficient",
+	StatusBadHistoryOperationInvalid:              "BadHistoryOperationInvalid",
+	StatusBadHistoryOperationUnsupported:          "BadHistoryOperationUnsupported",
+	StatusBadInvalidTimestampArgument:             "BadInvalidTimestampArgument",
+	StatusBadWriteNotSupported:                    "BadWriteNotSupported",
+	StatusBadTypeMismatch:                         "BadTypeMismatch",
+	StatusBadMethodInvalid:                        "BadMethodInvalid",
+	StatusBadArgumentsMissing:                     "BadArgumentsMissing",
+	StatusBadTooManySubscriptions:                 "BadTooManySubscriptions",
+	StatusBadTooManyPublishRequests:               "BadTooManyPublishRequests",
+	StatusBadNoSubscription:                       "BadNoSubscription",
+	StatusBadSequenceNumberUnknown:                "BadSequenceNumberUnknown",
+	StatusBadMessageNotAvailable:                  "BadMessageNotAvailable",
+	StatusBadInsufficientClientProfile:            "BadInsufficientClientProfile",
+	StatusBadStateNotActive:                       "BadStateNotActive",
+	StatusBadTcpServerTooBusy:                     "BadTcpServerTooBusy",
+	StatusBadTcpMessageTypeInvalid:                "BadTcpMessageTypeInvalid",
+	StatusBadTcpSecureChannelUnknown:              "BadTcpSecureChannelUnknown",
+	StatusBadTcpMessageTooLarge:                   "BadTcpMessageTooLarge",
+	StatusBadTcpNotEnoughResources:                "BadTcpNotEnoughResources",
+	StatusBadTcpInternalError:                     "BadTcpInternalError",
+	StatusBadTcpEndpointUrlInvalid:                "BadTcpEndpointUrlInvalid",
+	StatusBadRequestInterrupted:                   "BadRequestInterrupted",
+	StatusBadRequestTimeout:                       "BadRequestTimeout",
+	StatusBadSecureChannelClosed:                  "BadSecureChannelClosed",
+	StatusBadSecureChannelTokenUnknown:            "BadSecureChannelTokenUnknown",
+	StatusBadSequenceNumberInvalid:                "BadSequenceNumberInvalid",
+	StatusBadProtocolVersionUnsupported:           "BadProtocolVersionUnsupported",
+	StatusBadConfigurationError:                   "BadConfigurationError",
+	StatusBadNotConnected:                         "BadNotConnected",
+	StatusBadDeviceFailure:                        "BadDeviceFailure",
+	StatusBadSensorFailure:                        "BadSensorFailure",
+	StatusBadOutOfService:                         "BadOutOfService",
+	StatusBadDeadbandFilterInvalid:                "BadDeadbandFilterInvalid",
+	StatusUncertainNoCommunicationLastUsableValue: "UncertainNoCommunicationLastUsableValue",
+	StatusUncertainLastUsableValue:                "UncertainLastUsableValue",
+	StatusUncertainSubstituteValue:                "UncertainSubstituteValue",
+	StatusUncertainInitialValue:                   "UncertainInitialValue",
+	StatusUncertainSensorNotAccurate:              "UncertainSensorNotAccurate",
+	StatusUncertainEngineeringUnitsExceeded:       "UncertainEngineeringUnitsExceeded",
+	StatusUncertainSubNormal:                      "UncertainSubNormal",
+	StatusGoodLocalOverride:                       "GoodLocalOverride",
+	StatusBadRefreshInProgress:                    "BadRefreshInProgress",
+	StatusBadConditionAlreadyDisabled:             "BadConditionAlreadyDisabled",
+	StatusBadConditionAlreadyEnabled:              "BadConditionAlreadyEnabled",
+	StatusBadConditionDisabled:                    "BadConditionDisabled",
+	StatusBadEventIdUnknown:                       "BadEventIdUnknown",
+	StatusBadEventNotAcknowledgeable:              "BadEventNotAcknowledgeable",
+	StatusBadDialogNotActive:                      "BadDialogNotActive",
+	StatusBadDialogResponseInvalid:                "BadDialogResponseInvalid",
+	StatusBadConditionBranchAlreadyAcked:          "BadConditionBranchAlreadyAcked",
+	StatusBadConditionBranchAlreadyConfirmed:      "BadConditionBranchAlreadyConfirmed",
+	StatusBadConditionAlreadyShelved:              "BadConditionAlreadyShelved",
+	StatusBadConditionNotShelved:                  "BadConditionNotShelved",
+	StatusBadShelvingTimeOutOfRange:               "BadShelvingTimeOutOfRange",
+	StatusBadNoData:                               "BadNoData",
+	StatusBadBoundNotFound:                        "BadBoundNotFound",
+	StatusBadBoundNotSupported:                    "BadBoundNotSupported",
+	StatusBadDataLost:                             "BadDataLost",
+	StatusBadDataUnavailable:                      "BadDataUnavailable",
+	StatusBadEntryExists:                          "BadEntryExists",
+	StatusBadNoEntryExists:                        "BadNoEntryExists",
+	StatusBadTimestampNotSupported:                "BadTimestampNotSupported",
+	StatusGoodEntryInserted:                       "GoodEntryInserted",
+	StatusGoodEntryReplaced:                       "GoodEntryReplaced",
+	StatusUncertainDataSubNormal:                  "UncertainDataSubNormal",
+	StatusGoodNoData:                              "GoodNoData",
+	StatusGoodMoreData:                            "GoodMoreData",
+	StatusBadAggregateListMismatch:                "BadAggregateListMismatch",
+	StatusBadAggregateNotSupported:                "BadAggregateNotSupported",
+	StatusBadAggregateInvalidInputs:               "BadAggregateInvalidInputs",
+	StatusBadAggregateConfigurationRejected:       "BadAggregateConfigurationRejected",
+	StatusGoodDataIgnored:                         "GoodDataIgnored",
+	StatusBadRequestNotAllowed:                    "BadRequestNotAllowed",
+	StatusGoodEdited:                              "GoodEdited",
+	StatusGoodPostActionFailed:                    "GoodPostActionFailed",
+	StatusUncertainDominantValueChanged:           "UncertainDominantValueChanged",
+	StatusGoodDependentValueChanged:               "GoodDependentValueChanged",
+	StatusBadDominantValueChanged:                 "BadDominantValueChanged",
+	StatusUncertainDependentValueChanged:          "UncertainDependentValueChanged",
+	StatusBadDependentValueChanged:                "BadDependentValueChanged",
+	StatusGoodCommunicationEvent:                  "GoodCommunicationEvent",
+	StatusGoodShutdownEvent:                       "GoodShutdownEvent",
+	StatusGoodCallAgain:                           "GoodCallAgain",
+	StatusGoodNonCriticalTimeout:                  "GoodNonCriticalTimeout",
+	StatusBadInvalidArgument:                      "BadInvalidArgument",
+	StatusBadConnectionRejected:                   "BadConnectionRejected",
+	StatusBadDisconnect:                           "BadDisconnect",
+	StatusBadConnectionClosed:                     "BadConnectionClosed",
+	StatusBadInvalidState:                         "BadInvalidState",
+	StatusBadEndOfStream:                          "BadEndOfStream",
+	StatusBadNoDataAvailable:                      "BadNoDataAvailable",
+	StatusBadWaitingForResponse:                   "BadWaitingForResponse",
+	StatusBadOperationAbandoned:                   "BadOperationAbandoned",
+	StatusBadExpectedStreamToBlock:                "BadExpectedStreamToBlock",
+	StatusBadWouldBlock:                           "BadWouldBlock",
+	StatusBadSyntaxError:                          "BadSyntaxError",
+	StatusBadMaxConnectionsReached:                "BadMaxConnectionsReached",
+}
@@ -0,0 +1,231 @@
+package uatype
+
+import (
+	"reflect"
+	"sync"
+)
+
+// extensionObjectRegistry maps binary and XML encoding node IDs to Go types
+// and back. It's used by encoders and decoders to automatically wrap and
+// unwrap the ExtensionObject Value field.
+var extensionObjectRegistry = struct {
+	sync.RWMutex
+	types    map[NodeKey]reflect.Type
+	ids      map[reflect.Type]NodeId
+	xmlTypes map[NodeKey]reflect.Type
+	xmlIDs   map[reflect.Type]NodeId
+	names    map[string]reflect.Type
+}{
+	types:    make(map[NodeKey]reflect.Type),
+	ids:      make(map[reflect.Type]NodeId),
+	xmlTypes: make(map[NodeKey]reflect.Type),
+	xmlIDs:   make(map[reflect.Type]NodeId),
+	names:    make(map[string]reflect.Type),
+}
+
+// builtinTypes maps the names of OPC UA built-in types that are not plain Go
+// types to their Go type.
+var builtinTypes = map[string]reflect.Type{
+	"Guid":            reflect.TypeOf(Guid{}),
+	"ByteString":      reflect.TypeOf(ByteString{}),
+	"XmlElement":      reflect.TypeOf(XmlElement{}),
+	"NodeId":          reflect.TypeOf(NodeId{}),
+	"ExpandedNodeId":  reflect.TypeOf(ExpandedNodeId{}),
+	"StatusCode":      reflect.TypeOf(StatusCode(0)),
+	"DiagnosticInfo":  reflect.TypeOf(&DiagnosticInfo{}),
+	"QualifiedName":   reflect.TypeOf(QualifiedName{}),
+	"LocalizedText":   reflect.TypeOf(LocalizedText{}),
+	"DataValue":       reflect.TypeOf(DataValue{}),
+	"ExtensionObject": reflect.TypeOf(ExtensionObject{}),
+	"Variant":         reflect.TypeOf(Variant{}),
+}
+
+// pkgPath is the import path of this package.
+var pkgPath = reflect.TypeOf(NodeId{}).PkgPath()
+
+// extensionObjectKey returns the key of nid in namespace ns.
+func extensionObjectKey(ns uint16, nid ExpandedNodeId) NodeKey {
+	key := nid.node().Key()
+	key.Namespace = ns
+	return key
+}
+
+// RegisterExtensionObject registers the type of v to be used for
+// ExtensionObjects with a TypeId equal to the binary encoding node ID
+// encodingID. Once registered, decoding an ExtensionObject with a matching
+// TypeId will set its Value field, or its ValueError field if the Body can't
+// be decoded, and encoding an ExtensionObject with Value set to the type of v
+// will fill in TypeId and Body. If v is a pointer, the type it points to is
+// registered. Registering the same node ID or type again replaces the
+// previous registration.
+//
+// All structured types in this package are registered on initialization.
+// Vendor specific types have node IDs that depend on the server, as namespace
+// indices may differ between servers; register them in an
+// ExtensionObjectTypes for that server instead.
+func RegisterExtensionObject(encodingID NodeId, v interface{}) {
+	rt := reflect.TypeOf(v)
+	for rt.Kind() == reflect.Ptr {
+		rt = rt.Elem()
+	}
+	key := encodingID.Key()
+
+	extensionObjectRegistry.Lock()
+	defer extensionObjectRegistry.Unlock()
+	extensionObjectRegistry.types[key] = rt
+	extensionObjectRegistry.ids[rt] = encodingID
+	if rt.PkgPath() == pkgPath {
+		extensionObjectRegistry.names[rt.Name()] = rt
+	}
+}
+
+// RegisterExtensionObjectXML is like RegisterExtensionObject, but registers
+// the type of v for ExtensionObjects in the XML encoding, with a TypeId equal
+// to the XML encoding node ID encodingID.
+func RegisterExtensionObjectXML(encodingID NodeId, v interface{}) {
+	rt := reflect.TypeOf(v)
+	for rt.Kind() == reflect.Ptr {
+		rt = rt.Elem()
+	}
+	key := encodingID.Key()
+
+	extensionObjectRegistry.Lock()
+	defer extensionObjectRegistry.Unlock()
+	extensionObjectRegistry.xmlTypes[key] = rt
+	extensionObjectRegistry.xmlIDs[rt] = encodingID
+}
+
+// TypeByName returns the Go type for a built-in type, e.g. "LocalizedText",
+// or a registered structured type defined in this package, e.g. "Argument",
+// and true. If no such type exists, nil and false is returned. Plain built-in
+// types such as "Int32" are not handled.
+func TypeByName(name string) (reflect.Type, bool) {
+	if rt, ok := builtinTypes[name]; ok {
+		return rt, true
+	}
+	extensionObjectRegistry.RLock()
+	defer extensionObjectRegistry.RUnlock()
+	rt, ok := extensionObjectRegistry.names[name]
+	return rt, ok
+}
+
+// ExtensionObjectType returns the Go type registered for the binary encoding
+// node ID encodingID, and true, or nil and false if no type is registered. If
+// encodingID specifies a namespace URI, only the OPC UA namespace URI is
+// understood.
+func ExtensionObjectType(encodingID ExpandedNodeId) (reflect.Type, bool) {
+	ns, ok := encodingID.NamespaceIndex()
+	if !ok && encodingID.NamespaceURI != DefaultNamespaceURI {
+		return nil, false
+	}
+	key := extensionObjectKey(ns, encodingID)
+
+	extensionObjectRegistry.RLock()
+	defer extensionObjectRegistry.RUnlock()
+	rt, ok := extensionObjectRegistry.types[key]
+	return rt, ok
+}
+
+// ExtensionObjectEncodingID returns the binary encoding node ID registered for
+// the type of v, and true, or the zero NodeId and false if the type of v is
+// not registered. If v is a pointer, the type it points to is looked up.
+func ExtensionObjectEncodingID(v interface{}) (NodeId, bool) {
+	rt := reflect.TypeOf(v)
+	for rt != nil && rt.Kind() == reflect.Ptr {
+		rt = rt.Elem()
+	}
+
+	extensionObjectRegistry.RLock()
+	defer extensionObjectRegistry.RUnlock()
+	id, ok := extensionObjectRegistry.ids[rt]
+	return id, ok
+}
+
+// ExtensionObjectXMLType is like ExtensionObjectType, but looks up the Go
+// type registered for the XML encoding node ID encodingID.
+func ExtensionObjectXMLType(encodingID ExpandedNodeId) (reflect.Type, bool) {
+	ns, ok := encodingID.NamespaceIndex()
+	if !ok && encodingID.NamespaceURI != DefaultNamespaceURI {
+		return nil, false
+	}
+	key := extensionObjectKey(ns, encodingID)
+
+	extensionObjectRegistry.RLock()
+	defer extensionObjectRegistry.RUnlock()
+	rt, ok := extensionObjectRegistry.xmlTypes[key]
+	return rt, ok
+}
+
+// ExtensionObjectXMLEncodingID is like ExtensionObjectEncodingID, but returns
+// the XML encoding node ID registered for the type of v.
+func ExtensionObjectXMLEncodingID(v interface{}) (NodeId, bool) {
+	rt := reflect.TypeOf(v)
+	for rt != nil && rt.Kind() == reflect.Ptr {
+		rt = rt.Elem()
+	}
+
+	extensionObjectRegistry.RLock()
+	defer extensionObjectRegistry.RUnlock()
+	id, ok := extensionObjectRegistry.xmlIDs[rt]
+	return id, ok
+}
+
+// ExtensionObjectTypes maps binary encoding node IDs to Go types and back,
+// like RegisterExtensionObject, but for a single server. Encoders and
+// Decoders that are given an ExtensionObjectTypes consult it before the
+// types registered through RegisterExtensionObject. The zero value is an
+// empty registry ready to use. It's safe for concurrent use.
+type ExtensionObjectTypes struct {
+	m     sync.RWMutex
+	types map[NodeKey]reflect.Type
+	ids   map[reflect.Type]NodeId
+}
+
+// Register registers the type of v for ExtensionObjects with a TypeId equal
+// to the binary encoding node ID encodingID. See RegisterExtensionObject.
+func (r *ExtensionObjectTypes) Register(encodingID NodeId, v interface{}) {
+	rt := reflect.TypeOf(v)
+	for rt.Kind() == reflect.Ptr {
+		rt = rt.Elem()
+	}
+
+	r.m.Lock()
+	defer r.m.Unlock()
+	if r.types == nil {
+		r.types = make(map[NodeKey]reflect.Type)
+		r.ids = make(map[reflect.Type]NodeId)
+	}
+	r.types[encodingID.Key()] = rt
+	r.ids[rt] = encodingID
+}
+
+// Type returns the Go type registered in r for the binary encoding node ID
+// encodingID, and true, or nil and false if no type is registered. See
+// ExtensionObjectType.
+func (r *ExtensionObjectTypes) Type(encodingID ExpandedNodeId) (reflect.Type, bool) {
+	ns, ok := encodingID.NamespaceIndex()
+	if !ok && encodingID.NamespaceURI != DefaultNamespaceURI {
+		return nil, false
+	}
+	key := extensionObjectKey(ns, encodingID)
+
+	r.m.RLock()
+	defer r.m.RUnlock()
+	rt, ok := r.types[key]
+	return rt, ok
+}
+
+// EncodingID returns the binary encoding node ID registered in r for the type
+// of v, and true, or the zero NodeId and false if the type of v is not
+// registered. See ExtensionObjectEncodingID.
+func (r *ExtensionObjectTypes) EncodingID(v interface{}) (NodeId, bool) {
+	rt := reflect.TypeOf(v)
+	for rt != nil && rt.Kind() == reflect.Ptr {
+		rt = rt.Elem()
+	}
+
+	r.m.RLock()
+	defer r.m.RUnlock()
+	id, ok := r.ids[rt]
+	return id, ok
+}
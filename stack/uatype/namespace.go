@@ -0,0 +1,134 @@
+package uatype
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrUnknownNamespace is returned when a namespace URI or index is not in a
+// NamespaceTable.
+var ErrUnknownNamespace = errors.New("unknown namespace")
+
+// NamespaceTable maps between namespace URIs and the namespace indexes used
+// by a server, as published in the Server NamespaceArray property. Namespace
+// indexes other than 0 may change between servers and server restarts, so
+// the table should be read for each session. A NamespaceTable is immutable
+// and safe for concurrent use.
+type NamespaceTable struct {
+	uris    []string
+	indexes map[string]uint16
+}
+
+// NewNamespaceTable returns a namespace table where the namespace index of
+// each URI is its index in uris. The first URI should be DefaultNamespaceURI.
+func NewNamespaceTable(uris []string) *NamespaceTable {
+	t := &NamespaceTable{
+		uris:    append([]string(nil), uris...),
+		indexes: make(map[string]uint16, len(uris)),
+	}
+	for i, uri := range uris {
+		if _, ok := t.indexes[uri]; !ok {
+			t.indexes[uri] = uint16(i)
+		}
+	}
+	return t
+}
+
+// URIs returns the namespace URIs of t, ordered by namespace index.
+func (t *NamespaceTable) URIs() []string {
+	return append([]string(nil), t.uris...)
+}
+
+// Index returns the namespace index of uri and true, or 0 and false if uri is
+// not in t.
+func (t *NamespaceTable) Index(uri string) (uint16, bool) {
+	i, ok := t.indexes[uri]
+	return i, ok
+}
+
+// URI returns the namespace URI at index and true, or the empty string and
+// false if index is not in t.
+func (t *NamespaceTable) URI(index uint16) (string, bool) {
+	if int(index) >= len(t.uris) {
+		return "", false
+	}
+	return t.uris[index], true
+}
+
+// NodeID returns nid as a NodeId with its namespace URI, if any, replaced by
+// the namespace index. An error is returned if the namespace URI is not in t,
+// or if nid refers to another server.
+func (t *NamespaceTable) NodeID(nid ExpandedNodeId) (NodeId, error) {
+	if nid.ServerIndexSpecified && nid.ServerIndex != 0 {
+		return NodeId{}, fmt.Errorf("%s: node ID refers to server %d", ErrInvalidNodeID, nid.ServerIndex)
+	}
+	if !nid.NamespaceURISpecified {
+		nid.ServerIndexSpecified = false
+		id, _ := nid.NodeID()
+		return id, nil
+	}
+	ns, ok := t.Index(nid.NamespaceURI)
+	if !ok {
+		return NodeId{}, fmt.Errorf("%s: %s", ErrUnknownNamespace, nid.NamespaceURI)
+	}
+	nid.NamespaceURISpecified = false
+	nid.NamespaceURI = ""
+	nid.ServerIndexSpecified = false
+	id, _ := nid.NodeID()
+	return id.withNamespace(ns), nil
+}
+
+// Expanded returns nid as an ExpandedNodeId with the namespace URI set in
+// place of the namespace index. Node IDs in namespace 0 are returned with the
+// index. An error is returned if the namespace index is not in t.
+func (t *NamespaceTable) Expanded(nid NodeId) (ExpandedNodeId, error) {
+	ns := nid.NamespaceIndex()
+	if ns == 0 {
+		return nid.Expanded(), nil
+	}
+	uri, ok := t.URI(ns)
+	if !ok {
+		return ExpandedNodeId{}, fmt.Errorf("%s: namespace index %d", ErrUnknownNamespace, ns)
+	}
+	expanded := nid.withNamespace(0).Expanded()
+	expanded.NamespaceURISpecified = true
+	expanded.NamespaceURI = uri
+	return expanded, nil
+}
+
+// ParseNodeID parses s as an expanded node ID, such as
+// "nsu=http://vendor/;s=Tag1", and returns it as a NodeId with the namespace
+// index used by t. See ParseExpandedNodeID.
+func (t *NamespaceTable) ParseNodeID(s string) (NodeId, error) {
+	nid, err := ParseExpandedNodeID(s)
+	if err != nil {
+		return NodeId{}, err
+	}
+	return t.NodeID(nid)
+}
+
+// withNamespace returns nid with the namespace index ns. TwoByte and FourByte
+// node IDs are converted to a larger encoding if ns does not fit.
+func (nid NodeId) withNamespace(ns uint16) NodeId {
+	switch nid.NodeIdType {
+	case NodeIdTypeTwoByte:
+		if ns == 0 {
+			return nid
+		}
+		return NewNodeID(ns, uint32(nid.TwoByte.Identifier))
+	case NodeIdTypeFourByte:
+		if ns > 0xff {
+			return NewNodeID(ns, uint32(nid.FourByte.Identifier))
+		}
+		nid.FourByte.NamespaceIndex = uint8(ns)
+	case NodeIdTypeNumeric:
+		nid.Numeric.NamespaceIndex = ns
+	case NodeIdTypeString:
+		nid.StringID.NamespaceIndex = ns
+	case NodeIdTypeGuid:
+		nid.Guid.NamespaceIndex = ns
+	case NodeIdTypeByteString:
+		nid.ByteString.NamespaceIndex = ns
+	}
+	return nid
+}
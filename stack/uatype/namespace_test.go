@@ -0,0 +1,48 @@
+package uatype_test
+
+import (
+	"testing"
+
+	"github.com/searis/guma/stack/uatype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNamespaceTable(t *testing.T) {
+	table := uatype.NewNamespaceTable([]string{uatype.DefaultNamespaceURI, "urn:server", "http://vendor/"})
+	ns, ok := table.Index("http://vendor/")
+	assert.True(t, ok, "Index ok")
+	assert.Equal(t, uint16(2), ns, "Index")
+	_, ok = table.Index("http://unknown/")
+	assert.False(t, ok, "Index(unknown) ok")
+	uri, ok := table.URI(1)
+	assert.True(t, ok, "URI ok")
+	assert.Equal(t, "urn:server", uri, "URI")
+	_, ok = table.URI(3)
+	assert.False(t, ok, "URI(3) ok")
+
+	nid := uatype.NewStringNodeID(2, "Tag1")
+	expanded, err := table.Expanded(nid)
+	require.NoError(t, err, "Expanded")
+	assert.Equal(t, "http://vendor/", expanded.NamespaceURI)
+	assert.Equal(t, "nsu=http://vendor/;s=Tag1", expanded.String(), "Expanded")
+	back, err := table.NodeID(expanded)
+	require.NoError(t, err, "NodeID")
+	assert.Equal(t, nid, back)
+
+	// Namespace 0 keeps its index, and small numeric node IDs are widened
+	// to fit the namespace index.
+	expanded, err = table.Expanded(uatype.NewTwoByteNodeID(85))
+	require.NoError(t, err, "Expanded(ns=0)")
+	assert.False(t, bool(expanded.NamespaceURISpecified), "Expanded(ns=0) NamespaceURISpecified")
+	nid, err = table.ParseNodeID("nsu=http://vendor/;i=5")
+	require.NoError(t, err, "ParseNodeID")
+	assert.Equal(t, uatype.NewNodeID(2, 5), nid, "ParseNodeID")
+
+	_, err = table.Expanded(uatype.NewNumericNodeID(3, 1))
+	assert.Error(t, err, "Expanded(unknown index)")
+	_, err = table.ParseNodeID("nsu=http://unknown/;s=Tag1")
+	assert.Error(t, err, "ParseNodeID(unknown URI)")
+	_, err = table.ParseNodeID("svr=1;ns=2;i=5")
+	assert.Error(t, err, "ParseNodeID(other server)")
+}
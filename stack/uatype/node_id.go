@@ -62,7 +62,7 @@ func NewNumericNodeID(ns uint16, id uint32) NodeId {
 func NewStringNodeID(ns uint16, id string) NodeId {
 	return NodeId{
 		NodeIdType: NodeIdTypeString,
-		String: StringNodeId{
+		StringID: StringNodeId{
 			NamespaceIndex: ns,
 			Identifier:     id,
 		},
@@ -107,7 +107,7 @@ func (nid NodeId) Class() NodeClass {
 func (nid NodeId) DisplayName() string {
 	switch nid.NodeIdType {
 	case NodeIdTypeString:
-		return nid.String.Identifier
+		return nid.StringID.Identifier
 	case NodeIdTypeGuid:
 		return fmt.Sprintf("GUID:%s", nid.Guid.Identifier)
 	case NodeIdTypeByteString:
@@ -137,18 +137,14 @@ func (nid NodeId) Description() string {
 }
 
 // Uint returns the identifier of two-byte, four-byte or numeric node IDs as
-// uint16. Calling this method on other types of node IDs will return 0.
+// uint16, for comparison with the NodeId constants of the OPC UA namespace.
+// Calling this method on other types of node IDs, or on numeric node IDs with
+// an identifier that does not fit in an uint16, will return 0. See Uint32.
 func (nid NodeId) Uint() uint16 {
-	switch nid.NodeIdType {
-	case NodeIdTypeTwoByte:
-		return uint16(nid.TwoByte.Identifier)
-	case NodeIdTypeFourByte:
-		return uint16(nid.FourByte.Identifier)
-	case NodeIdTypeNumeric:
-		return uint16(nid.Numeric.Identifier)
-	default:
-		return 0
+	if id := nid.Uint32(); id <= 0xffff {
+		return uint16(id)
 	}
+	return 0
 }
 
 // NamespaceIndex returns the namespace index of nid as uint16.
@@ -163,7 +159,7 @@ func (nid NodeId) NamespaceIndex() uint16 {
 	case NodeIdTypeNumeric:
 		return nid.Numeric.NamespaceIndex
 	case NodeIdTypeString:
-		return nid.String.NamespaceIndex
+		return nid.StringID.NamespaceIndex
 	case NodeIdTypeGuid:
 		return nid.Guid.NamespaceIndex
 	case NodeIdTypeByteString:
@@ -181,25 +177,41 @@ func (nid NodeId) Expanded() ExpandedNodeId {
 		TwoByte:    nid.TwoByte,
 		FourByte:   nid.FourByte,
 		Numeric:    nid.Numeric,
-		String:     nid.String,
+		StringID:   nid.StringID,
+		Guid:       nid.Guid,
+		ByteString: nid.ByteString,
+	}
+}
+
+// NodeID returns nid as a NodeId and true, or the zero NodeId and false if
+// nid refers to a namespace URI or another server.
+func (nid ExpandedNodeId) NodeID() (NodeId, bool) {
+	if nid.NamespaceURISpecified || nid.ServerIndexSpecified {
+		return NodeId{}, false
+	}
+	return nid.node(), true
+}
+
+// node returns the node ID part of nid, ignoring any namespace URI and server
+// index.
+func (nid ExpandedNodeId) node() NodeId {
+	return NodeId{
+		NodeIdType: nid.NodeIdType,
+		TwoByte:    nid.TwoByte,
+		FourByte:   nid.FourByte,
+		Numeric:    nid.Numeric,
+		StringID:   nid.StringID,
 		Guid:       nid.Guid,
 		ByteString: nid.ByteString,
 	}
 }
 
 // Uint returns the identifier of two-byte, four-byte or numeric node IDs as
-// uint16. Calling this method on other types of node IDs will return 0.
+// uint16. Calling this method on other types of node IDs, or on numeric node
+// IDs with an identifier that does not fit in an uint16, will return 0. See
+// Uint32.
 func (nid ExpandedNodeId) Uint() uint16 {
-	switch nid.NodeIdType {
-	case NodeIdTypeTwoByte:
-		return uint16(nid.TwoByte.Identifier)
-	case NodeIdTypeFourByte:
-		return uint16(nid.FourByte.Identifier)
-	case NodeIdTypeNumeric:
-		return uint16(nid.Numeric.Identifier)
-	default:
-		return 0
-	}
+	return nid.node().Uint()
 }
 
 // NamespaceIndex returns the namespace index of nid and true, or 0 and
@@ -219,7 +231,7 @@ func (nid ExpandedNodeId) NamespaceIndex() (uint16, bool) {
 	case NodeIdTypeNumeric:
 		return nid.Numeric.NamespaceIndex, true
 	case NodeIdTypeString:
-		return nid.String.NamespaceIndex, true
+		return nid.StringID.NamespaceIndex, true
 	case NodeIdTypeGuid:
 		return nid.Guid.NamespaceIndex, true
 	case NodeIdTypeByteString:
@@ -236,7 +248,7 @@ func (nid ExpandedNodeId) NamespaceIndex() (uint16, bool) {
 func (nid ExpandedNodeId) DisplayName() string {
 	switch nid.NodeIdType {
 	case NodeIdTypeString:
-		return nid.String.Identifier
+		return nid.StringID.Identifier
 	case NodeIdTypeGuid:
 		return fmt.Sprintf("GUID:%s", nid.Guid.Identifier)
 	case NodeIdTypeByteString:
@@ -265,7 +277,7 @@ func (nid ExpandedNodeId) Size() int {
 	case NodeIdTypeNumeric:
 		size = 1 + 2 + 4
 	case NodeIdTypeString:
-		size = 1 + 2 + 4 + len([]byte(nid.String.Identifier))
+		size = 1 + 2 + 4 + len([]byte(nid.StringID.Identifier))
 	case NodeIdTypeGuid:
 		size = 1 + 2 + 4 + len(nid.Guid.Identifier)
 	case NodeIdTypeByteString:
@@ -0,0 +1,129 @@
+package uatype
+
+// NodeKey is a comparable representation of a NodeId, that can be used as a
+// map key or compared with ==. TwoByte, FourByte and Numeric node IDs with the
+// same namespace index and identifier have the same key.
+type NodeKey struct {
+	Namespace uint16
+
+	// Type is NodeIdTypeNumeric for all numeric node IDs.
+	Type NodeIdType
+
+	// Numeric holds the identifier of numeric node IDs.
+	Numeric uint32
+
+	// Identifier holds the identifier of String, Guid and ByteString node
+	// IDs.
+	Identifier string
+}
+
+// NodeID returns the node ID represented by k. Numeric node IDs use the most
+// compact encoding; see NewNodeID.
+func (k NodeKey) NodeID() NodeId {
+	switch k.Type {
+	case NodeIdTypeString:
+		return NewStringNodeID(k.Namespace, k.Identifier)
+	case NodeIdTypeGuid:
+		var g Guid
+		copy(g[:], k.Identifier)
+		return NewGuidNodeID(k.Namespace, g)
+	case NodeIdTypeByteString:
+		return NewByteStringNodeID(k.Namespace, ByteString(k.Identifier))
+	}
+	return NewNodeID(k.Namespace, k.Numeric)
+}
+
+// String returns the node ID represented by k in the format parsed by
+// ParseNodeID.
+func (k NodeKey) String() string {
+	return k.NodeID().String()
+}
+
+// Key returns the comparable key of nid.
+func (nid NodeId) Key() NodeKey {
+	key := NodeKey{Namespace: nid.NamespaceIndex(), Type: nid.NodeIdType}
+	switch nid.NodeIdType {
+	case NodeIdTypeTwoByte, NodeIdTypeFourByte, NodeIdTypeNumeric:
+		key.Type = NodeIdTypeNumeric
+		key.Numeric = nid.Uint32()
+	case NodeIdTypeString:
+		key.Identifier = nid.StringID.Identifier
+	case NodeIdTypeGuid:
+		key.Identifier = string(nid.Guid.Identifier[:])
+	case NodeIdTypeByteString:
+		key.Identifier = string(nid.ByteString.Identifier)
+	}
+	return key
+}
+
+// Equal returns true if nid and other identify the same node. TwoByte,
+// FourByte and Numeric node IDs with the same value are equal.
+func (nid NodeId) Equal(other NodeId) bool {
+	return nid.Key() == other.Key()
+}
+
+// IsNumeric returns true for TwoByte, FourByte and Numeric node IDs.
+func (nid NodeId) IsNumeric() bool {
+	switch nid.NodeIdType {
+	case NodeIdTypeTwoByte, NodeIdTypeFourByte, NodeIdTypeNumeric:
+		return true
+	}
+	return false
+}
+
+// Uint32 returns the identifier of TwoByte, FourByte and Numeric node IDs.
+// Calling this method on other types of node IDs will return 0.
+func (nid NodeId) Uint32() uint32 {
+	switch nid.NodeIdType {
+	case NodeIdTypeTwoByte:
+		return uint32(nid.TwoByte.Identifier)
+	case NodeIdTypeFourByte:
+		return uint32(nid.FourByte.Identifier)
+	case NodeIdTypeNumeric:
+		return nid.Numeric.Identifier
+	}
+	return 0
+}
+
+// ExpandedNodeKey is a comparable representation of an ExpandedNodeId. See
+// NodeKey.
+type ExpandedNodeKey struct {
+	NodeKey
+
+	// NamespaceURI is set if the node ID specifies a namespace URI, in which
+	// case NodeKey.Namespace is 0.
+	NamespaceURI string
+	ServerIndex  uint32
+}
+
+// Key returns the comparable key of nid. A namespace URI and a namespace
+// index are not considered equal, even if they refer to the same namespace;
+// see NamespaceTable.
+func (nid ExpandedNodeId) Key() ExpandedNodeKey {
+	key := ExpandedNodeKey{NodeKey: nid.node().Key()}
+	if nid.NamespaceURISpecified {
+		key.Namespace = 0
+		key.NamespaceURI = nid.NamespaceURI
+	}
+	if nid.ServerIndexSpecified {
+		key.ServerIndex = nid.ServerIndex
+	}
+	return key
+}
+
+// Equal returns true if nid and other have the same key. See
+// ExpandedNodeId.Key.
+func (nid ExpandedNodeId) Equal(other ExpandedNodeId) bool {
+	return nid.Key() == other.Key()
+}
+
+// IsNumeric returns true for TwoByte, FourByte and Numeric node IDs.
+func (nid ExpandedNodeId) IsNumeric() bool {
+	return nid.node().IsNumeric()
+}
+
+// Uint32 returns the identifier of TwoByte, FourByte and Numeric node IDs.
+// Calling this method on other types of node IDs will return 0.
+func (nid ExpandedNodeId) Uint32() uint32 {
+	return nid.node().Uint32()
+}
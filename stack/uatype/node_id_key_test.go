@@ -0,0 +1,40 @@
+package uatype_test
+
+import (
+	"testing"
+
+	"github.com/searis/guma/stack/uatype"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNodeIDKey(t *testing.T) {
+	assert.True(t, uatype.NewTwoByteNodeID(85).Equal(uatype.NewNumericNodeID(0, 85)), "TwoByte == Numeric")
+	assert.True(t, uatype.NewFourByteNodeID(2, 1000).Equal(uatype.NewNumericNodeID(2, 1000)), "FourByte == Numeric")
+	assert.False(t, uatype.NewFourByteNodeID(2, 1000).Equal(uatype.NewNumericNodeID(3, 1000)), "namespace")
+	assert.False(t, uatype.NewStringNodeID(0, "85").Equal(uatype.NewTwoByteNodeID(85)), "String != Numeric")
+	assert.True(t, uatype.NewByteStringNodeID(1, uatype.ByteString{1, 2}).Equal(uatype.NewByteStringNodeID(1, uatype.ByteString{1, 2})), "ByteString")
+
+	m := map[uatype.NodeKey]int{
+		uatype.NewFourByteNodeID(0, 2258).Key():                       1,
+		uatype.NewByteStringNodeID(1, uatype.ByteString("abc")).Key(): 2,
+	}
+	assert.Equal(t, 1, m[uatype.NewNumericNodeID(0, 2258).Key()])
+	assert.Equal(t, 2, m[uatype.NewByteStringNodeID(1, uatype.ByteString("abc")).Key()])
+	assert.Equal(t, uatype.NewNodeID(0, 2258), uatype.NewNumericNodeID(0, 2258).Key().NodeID())
+	assert.Equal(t, "ns=1;b=YWJj", uatype.NewByteStringNodeID(1, uatype.ByteString("abc")).Key().String())
+
+	big := uatype.NewNumericNodeID(0, 0x10000+uint32(uatype.NodeIdServer))
+	assert.Equal(t, uint32(0x10000)+uint32(uatype.NodeIdServer), big.Uint32(), "Uint32")
+	assert.Equal(t, uint16(0), big.Uint(), "Uint does not truncate")
+	assert.True(t, big.IsNumeric(), "IsNumeric")
+	assert.False(t, uatype.NewStringNodeID(0, "x").IsNumeric(), "IsNumeric")
+
+	expanded := uatype.NewNumericNodeID(2, 1).Expanded()
+	expanded.NamespaceURISpecified = true
+	expanded.NamespaceURI = "http://vendor/"
+	other := uatype.NewFourByteNodeID(0, 1).Expanded()
+	other.NamespaceURISpecified = true
+	other.NamespaceURI = "http://vendor/"
+	assert.True(t, expanded.Equal(other), "namespace index ignored with URI")
+	assert.False(t, expanded.Equal(uatype.NewNumericNodeID(2, 1).Expanded()), "URI != index")
+}
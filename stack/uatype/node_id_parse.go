@@ -0,0 +1,274 @@
+package uatype
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidNodeID is returned when parsing a malformed node ID string.
+var ErrInvalidNodeID = errors.New("invalid node ID")
+
+// String returns g in the canonical form
+// "XXXXXXXX-XXXX-XXXX-XXXX-XXXXXXXXXXXX". The first three groups are stored
+// little-endian in g, as in the binary encoding.
+func (g Guid) String() string {
+	return fmt.Sprintf("%08X-%04X-%04X-%X-%X",
+		binary.LittleEndian.Uint32(g[0:4]),
+		binary.LittleEndian.Uint16(g[4:6]),
+		binary.LittleEndian.Uint16(g[6:8]),
+		g[8:10],
+		g[10:16],
+	)
+}
+
+// ParseGuid parses a GUID in the form "XXXXXXXX-XXXX-XXXX-XXXX-XXXXXXXXXXXX".
+// See Guid.String.
+func ParseGuid(s string) (Guid, error) {
+	var g Guid
+	parts := strings.Split(s, "-")
+	if len(parts) != 5 || len(parts[0]) != 8 || len(parts[1]) != 4 || len(parts[2]) != 4 || len(parts[3]) != 4 || len(parts[4]) != 12 {
+		return g, fmt.Errorf("%s: malformed GUID %q", ErrInvalidNodeID, s)
+	}
+	b, err := hex.DecodeString(strings.Join(parts, ""))
+	if err != nil {
+		return g, fmt.Errorf("%s: malformed GUID %q", ErrInvalidNodeID, s)
+	}
+	binary.LittleEndian.PutUint32(g[0:4], binary.BigEndian.Uint32(b[0:4]))
+	binary.LittleEndian.PutUint16(g[4:6], binary.BigEndian.Uint16(b[4:6]))
+	binary.LittleEndian.PutUint16(g[6:8], binary.BigEndian.Uint16(b[6:8]))
+	copy(g[8:], b[8:])
+	return g, nil
+}
+
+// NewNodeID returns a numeric node ID using the most compact encoding for the
+// values of ns and id: TwoByte, FourByte or Numeric.
+func NewNodeID(ns uint16, id uint32) NodeId {
+	switch {
+	case ns == 0 && id <= 0xff:
+		return NewTwoByteNodeID(uint16(id))
+	case ns <= 0xff && id <= 0xffff:
+		return NewFourByteNodeID(uint8(ns), uint16(id))
+	}
+	return NewNumericNodeID(ns, id)
+}
+
+// ParseNodeID parses a node ID in the string format of the OPC UA
+// specification:
+//
+//	[ns=<namespaceindex>;]<type>=<value>
+//
+// where type is i (numeric), s (string), g (GUID) or b (base64 encoded
+// ByteString), for example "i=2258" or "ns=2;s=Pump.Speed". Numeric node IDs
+// use the most compact encoding; see NewNodeID. Use ParseExpandedNodeID to
+// parse node IDs with a namespace URI or server index.
+func ParseNodeID(s string) (NodeId, error) {
+	nid, err := ParseExpandedNodeID(s)
+	if err != nil {
+		return NodeId{}, err
+	}
+	id, ok := nid.NodeID()
+	if !ok {
+		return NodeId{}, fmt.Errorf("%s: namespace URI or server index in %q", ErrInvalidNodeID, s)
+	}
+	return id, nil
+}
+
+// ParseExpandedNodeID parses an expanded node ID in the string format of the
+// OPC UA specification:
+//
+//	[svr=<serverindex>;][ns=<namespaceindex>;|nsu=<uri>;]<type>=<value>
+//
+// where type is i (numeric), s (string), g (GUID) or b (base64 encoded
+// ByteString), for example "nsu=http://vendor/;s=Tag1". Reserved characters
+// in the namespace URI, such as ';', may be percent-encoded. A namespace URI
+// can be translated to a namespace index with a NamespaceTable. See also
+// ParseNodeID.
+func ParseExpandedNodeID(s string) (ExpandedNodeId, error) {
+	var nid ExpandedNodeId
+	var ns uint16
+	rest := s
+	if strings.HasPrefix(rest, "svr=") {
+		i := strings.IndexByte(rest, ';')
+		if i < 0 {
+			return nid, fmt.Errorf("%s: %q", ErrInvalidNodeID, s)
+		}
+		svr, err := strconv.ParseUint(rest[len("svr="):i], 10, 32)
+		if err != nil {
+			return nid, fmt.Errorf("%s: invalid server index in %q", ErrInvalidNodeID, s)
+		}
+		nid.ServerIndexSpecified = true
+		nid.ServerIndex = uint32(svr)
+		rest = rest[i+1:]
+	}
+	switch {
+	case strings.HasPrefix(rest, "nsu="):
+		i := strings.IndexByte(rest, ';')
+		if i < 0 {
+			return nid, fmt.Errorf("%s: %q", ErrInvalidNodeID, s)
+		}
+		uri, err := url.PathUnescape(rest[len("nsu="):i])
+		if err != nil {
+			return nid, fmt.Errorf("%s: invalid namespace URI in %q", ErrInvalidNodeID, s)
+		}
+		nid.NamespaceURISpecified = true
+		nid.NamespaceURI = uri
+		rest = rest[i+1:]
+	case strings.HasPrefix(rest, "ns="):
+		i := strings.IndexByte(rest, ';')
+		if i < 0 {
+			return nid, fmt.Errorf("%s: %q", ErrInvalidNodeID, s)
+		}
+		n, err := strconv.ParseUint(rest[len("ns="):i], 10, 16)
+		if err != nil {
+			return nid, fmt.Errorf("%s: invalid namespace index in %q", ErrInvalidNodeID, s)
+		}
+		ns = uint16(n)
+		rest = rest[i+1:]
+	}
+
+	id, err := parseIdentifier(ns, rest)
+	if err != nil {
+		return nid, fmt.Errorf("%s in %q", err, s)
+	}
+	expanded := id.Expanded()
+	expanded.ServerIndexSpecified = nid.ServerIndexSpecified
+	expanded.ServerIndex = nid.ServerIndex
+	expanded.NamespaceURISpecified = nid.NamespaceURISpecified
+	expanded.NamespaceURI = nid.NamespaceURI
+	return expanded, nil
+}
+
+// parseIdentifier parses the <type>=<value> part of a node ID string.
+func parseIdentifier(ns uint16, s string) (NodeId, error) {
+	if len(s) < 2 || s[1] != '=' {
+		return NodeId{}, ErrInvalidNodeID
+	}
+	value := s[2:]
+	switch s[0] {
+	case 'i':
+		n, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			return NodeId{}, fmt.Errorf("%s: invalid numeric identifier", ErrInvalidNodeID)
+		}
+		return NewNodeID(ns, uint32(n)), nil
+	case 's':
+		return NewStringNodeID(ns, value), nil
+	case 'g':
+		g, err := ParseGuid(value)
+		if err != nil {
+			return NodeId{}, fmt.Errorf("%s: invalid GUID identifier", ErrInvalidNodeID)
+		}
+		return NewGuidNodeID(ns, g), nil
+	case 'b':
+		b, err := base64.StdEncoding.DecodeString(value)
+		if err != nil {
+			return NodeId{}, fmt.Errorf("%s: invalid base64 identifier", ErrInvalidNodeID)
+		}
+		return NewByteStringNodeID(ns, b), nil
+	}
+	return NodeId{}, fmt.Errorf("%s: unknown identifier type", ErrInvalidNodeID)
+}
+
+// identifier returns the <type>=<value> part of the string form of a node ID.
+func identifier(nid NodeId) string {
+	switch nid.NodeIdType {
+	case NodeIdTypeTwoByte:
+		return "i=" + strconv.FormatUint(uint64(nid.TwoByte.Identifier), 10)
+	case NodeIdTypeFourByte:
+		return "i=" + strconv.FormatUint(uint64(nid.FourByte.Identifier), 10)
+	case NodeIdTypeNumeric:
+		return "i=" + strconv.FormatUint(uint64(nid.Numeric.Identifier), 10)
+	case NodeIdTypeString:
+		return "s=" + nid.StringID.Identifier
+	case NodeIdTypeGuid:
+		return "g=" + nid.Guid.Identifier.String()
+	case NodeIdTypeByteString:
+		return "b=" + base64.StdEncoding.EncodeToString(nid.ByteString.Identifier)
+	}
+	return "i=0"
+}
+
+// String returns nid in the format parsed by ParseNodeID. The namespace index
+// is omitted for namespace 0.
+func (nid NodeId) String() string {
+	if ns := nid.NamespaceIndex(); ns != 0 {
+		return "ns=" + strconv.FormatUint(uint64(ns), 10) + ";" + identifier(nid)
+	}
+	return identifier(nid)
+}
+
+// MarshalText implements encoding.TextMarshaler. See NodeId.String.
+func (nid NodeId) MarshalText() ([]byte, error) {
+	return []byte(nid.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. See ParseNodeID.
+func (nid *NodeId) UnmarshalText(text []byte) error {
+	id, err := ParseNodeID(string(text))
+	if err != nil {
+		return err
+	}
+	*nid = id
+	return nil
+}
+
+// String returns nid in the format parsed by ParseExpandedNodeID. Reserved
+// characters in the namespace URI are percent-encoded.
+func (nid ExpandedNodeId) String() string {
+	var buf strings.Builder
+	if nid.ServerIndexSpecified {
+		buf.WriteString("svr=")
+		buf.WriteString(strconv.FormatUint(uint64(nid.ServerIndex), 10))
+		buf.WriteByte(';')
+	}
+	id := nid.node()
+	if nid.NamespaceURISpecified {
+		buf.WriteString("nsu=")
+		buf.WriteString(escapeNamespaceURI(nid.NamespaceURI))
+		buf.WriteByte(';')
+		buf.WriteString(identifier(id))
+	} else {
+		buf.WriteString(id.String())
+	}
+	return buf.String()
+}
+
+// MarshalText implements encoding.TextMarshaler. See ExpandedNodeId.String.
+func (nid ExpandedNodeId) MarshalText() ([]byte, error) {
+	return []byte(nid.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. See
+// ParseExpandedNodeID.
+func (nid *ExpandedNodeId) UnmarshalText(text []byte) error {
+	id, err := ParseExpandedNodeID(string(text))
+	if err != nil {
+		return err
+	}
+	*nid = id
+	return nil
+}
+
+// escapeNamespaceURI percent-encodes the characters of uri that are reserved
+// in the string format of expanded node IDs.
+func escapeNamespaceURI(uri string) string {
+	if !strings.ContainsAny(uri, ";%") {
+		return uri
+	}
+	var buf strings.Builder
+	for i := 0; i < len(uri); i++ {
+		switch c := uri[i]; c {
+		case ';', '%':
+			fmt.Fprintf(&buf, "%%%02X", c)
+		default:
+			buf.WriteByte(c)
+		}
+	}
+	return buf.String()
+}
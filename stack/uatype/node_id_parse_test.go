@@ -0,0 +1,107 @@
+package uatype_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/searis/guma/stack/uatype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewNodeID(t *testing.T) {
+	assert.Equal(t, uatype.NodeIdTypeTwoByte, uatype.NewNodeID(0, 0xff).NodeIdType)
+	assert.Equal(t, uatype.NodeIdTypeFourByte, uatype.NewNodeID(0, 0x100).NodeIdType)
+	assert.Equal(t, uatype.NodeIdTypeFourByte, uatype.NewNodeID(0xff, 0xffff).NodeIdType)
+	assert.Equal(t, uatype.NodeIdTypeNumeric, uatype.NewNodeID(0x100, 1).NodeIdType)
+	assert.Equal(t, uatype.NodeIdTypeNumeric, uatype.NewNodeID(0, 0x10000).NodeIdType)
+}
+
+func TestParseNodeID(t *testing.T) {
+	cases := []struct {
+		s      string
+		expect uatype.NodeId
+	}{
+		{"i=2258", uatype.NewFourByteNodeID(0, 2258)},
+		{"i=85", uatype.NewTwoByteNodeID(85)},
+		{"ns=300;i=1", uatype.NewNumericNodeID(300, 1)},
+		{"ns=1;i=4294967295", uatype.NewNumericNodeID(1, 4294967295)},
+		{"ns=2;s=Pump.Speed", uatype.NewStringNodeID(2, "Pump.Speed")},
+		{"ns=2;s=a;b=c", uatype.NewStringNodeID(2, "a;b=c")},
+	}
+	for _, tc := range cases {
+		nid, err := uatype.ParseNodeID(tc.s)
+		if assert.NoError(t, err, tc.s) {
+			assert.Equal(t, tc.expect, nid, tc.s)
+			assert.Equal(t, tc.s, nid.String(), "String")
+		}
+	}
+
+	_, err := uatype.ParseNodeID("nsu=http://vendor/;s=Tag1")
+	assert.Error(t, err, "namespace URI")
+	_, err = uatype.ParseNodeID("svr=1;i=1")
+	assert.Error(t, err, "server index")
+}
+
+func TestParseExpandedNodeID(t *testing.T) {
+	g, err := uatype.ParseGuid("72962B91-FA75-4AE6-8D28-B404DC7DAF63")
+	require.NoError(t, err, "ParseGuid")
+	assert.Equal(t, "72962B91-FA75-4AE6-8D28-B404DC7DAF63", g.String())
+
+	cases := []struct {
+		s      string
+		expect uatype.ExpandedNodeId
+	}{
+		{"i=2255", uatype.NewNodeID(0, 2255).Expanded()},
+		{"ns=2;s=Tag1", uatype.NewStringNodeID(2, "Tag1").Expanded()},
+		{"ns=1;g=72962B91-FA75-4AE6-8D28-B404DC7DAF63", uatype.NewGuidNodeID(1, g).Expanded()},
+		{"ns=3;b=AQID", uatype.NewByteStringNodeID(3, uatype.ByteString{1, 2, 3}).Expanded()},
+		{"nsu=http://vendor/%3Bx;s=Tag1", func() uatype.ExpandedNodeId {
+			nid := uatype.NewStringNodeID(0, "Tag1").Expanded()
+			nid.NamespaceURISpecified = true
+			nid.NamespaceURI = "http://vendor/;x"
+			return nid
+		}()},
+		{"svr=1;ns=2;i=5", func() uatype.ExpandedNodeId {
+			nid := uatype.NewNodeID(2, 5).Expanded()
+			nid.ServerIndexSpecified = true
+			nid.ServerIndex = 1
+			return nid
+		}()},
+	}
+	for _, tc := range cases {
+		nid, err := uatype.ParseExpandedNodeID(tc.s)
+		if assert.NoError(t, err, tc.s) {
+			assert.Equal(t, tc.expect, nid, tc.s)
+			assert.Equal(t, tc.s, nid.String(), "String")
+		}
+	}
+
+	for _, s := range []string{"", "Tag1", "ns=2", "ns=x;i=1", "ns=1;i=x", "ns=1;x=1", "nsu=http://vendor/", "ns=1;g=123"} {
+		_, err := uatype.ParseExpandedNodeID(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestNodeIDText(t *testing.T) {
+	type config struct {
+		Node     uatype.NodeId
+		Expanded uatype.ExpandedNodeId
+	}
+	in := config{
+		Node:     uatype.NewStringNodeID(2, "Pump.Speed"),
+		Expanded: uatype.NewStringNodeID(0, "Tag1").Expanded(),
+	}
+	in.Expanded.NamespaceURISpecified = true
+	in.Expanded.NamespaceURI = "http://vendor/"
+
+	data, err := json.Marshal(in)
+	require.NoError(t, err, "json.Marshal")
+	assert.Equal(t, `{"Node":"ns=2;s=Pump.Speed","Expanded":"nsu=http://vendor/;s=Tag1"}`, string(data))
+
+	var out config
+	require.NoError(t, json.Unmarshal(data, &out), "json.Unmarshal")
+	assert.Equal(t, in, out)
+
+	assert.Error(t, json.Unmarshal([]byte(`{"Node":"x"}`), &out), "invalid node ID")
+}
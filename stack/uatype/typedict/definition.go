@@ -0,0 +1,289 @@
+package typedict
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/searis/guma/stack/uatype"
+)
+
+// ns0Types maps the numeric node IDs of DataTypes in the OPC UA namespace to
+// Go types.
+var ns0Types = map[uint32]reflect.Type{
+	uint32(uatype.NodeIdBoolean):        reflect.TypeOf(false),
+	uint32(uatype.NodeIdSByte):          reflect.TypeOf(int8(0)),
+	uint32(uatype.NodeIdByte):           reflect.TypeOf(uint8(0)),
+	uint32(uatype.NodeIdInt16):          reflect.TypeOf(int16(0)),
+	uint32(uatype.NodeIdUInt16):         reflect.TypeOf(uint16(0)),
+	uint32(uatype.NodeIdInt32):          reflect.TypeOf(int32(0)),
+	uint32(uatype.NodeIdUInt32):         reflect.TypeOf(uint32(0)),
+	uint32(uatype.NodeIdInt64):          reflect.TypeOf(int64(0)),
+	uint32(uatype.NodeIdUInt64):         reflect.TypeOf(uint64(0)),
+	uint32(uatype.NodeIdFloat):          reflect.TypeOf(float32(0)),
+	uint32(uatype.NodeIdDouble):         reflect.TypeOf(float64(0)),
+	uint32(uatype.NodeIdString):         reflect.TypeOf(""),
+	uint32(uatype.NodeIdDateTime):       reflect.TypeOf(time.Time{}),
+	uint32(uatype.NodeIdGuid):           reflect.TypeOf(uatype.Guid{}),
+	uint32(uatype.NodeIdByteString):     reflect.TypeOf(uatype.ByteString{}),
+	uint32(uatype.NodeIdXmlElement):     reflect.TypeOf(uatype.XmlElement{}),
+	uint32(uatype.NodeIdNodeId):         reflect.TypeOf(uatype.NodeId{}),
+	uint32(uatype.NodeIdExpandedNodeId): reflect.TypeOf(uatype.ExpandedNodeId{}),
+	uint32(uatype.NodeIdStatusCode):     reflect.TypeOf(uatype.StatusCode(0)),
+	uint32(uatype.NodeIdQualifiedName):  reflect.TypeOf(uatype.QualifiedName{}),
+	uint32(uatype.NodeIdLocalizedText):  reflect.TypeOf(uatype.LocalizedText{}),
+	uint32(uatype.NodeIdStructure):      reflect.TypeOf(uatype.ExtensionObject{}),
+	uint32(uatype.NodeIdDataValue):      reflect.TypeOf(uatype.DataValue{}),
+	uint32(uatype.NodeIdBaseDataType):   reflect.TypeOf(uatype.Variant{}),
+	uint32(uatype.NodeIdDiagnosticInfo): reflect.TypeOf(&uatype.DiagnosticInfo{}),
+	uint32(uatype.NodeIdNumber):         reflect.TypeOf(uatype.Variant{}),
+	uint32(uatype.NodeIdInteger):        reflect.TypeOf(uatype.Variant{}),
+	uint32(uatype.NodeIdUInteger):       reflect.TypeOf(uatype.Variant{}),
+	uint32(uatype.NodeIdEnumeration):    reflect.TypeOf(int32(0)),
+	uint32(uatype.NodeIdImage):          reflect.TypeOf(uatype.ByteString{}),
+	uint32(uatype.NodeIdDuration):       reflect.TypeOf(float64(0)),
+	uint32(uatype.NodeIdUtcTime):        reflect.TypeOf(time.Time{}),
+	uint32(uatype.NodeIdDate):           reflect.TypeOf(time.Time{}),
+	uint32(uatype.NodeIdTime):           reflect.TypeOf(""),
+	uint32(uatype.NodeIdLocaleId):       reflect.TypeOf(""),
+	uint32(uatype.NodeIdNumericRange):   reflect.TypeOf(""),
+	uint32(uatype.NodeIdIntegerId):      reflect.TypeOf(uint32(0)),
+	uint32(uatype.NodeIdCounter):        reflect.TypeOf(uint32(0)),
+}
+
+// definition is a DataType described by its DataTypeDefinition attribute.
+type definition struct {
+	name     string
+	def      interface{} // uatype.StructureDefinition or uatype.EnumDefinition
+	t        *Type
+	building bool
+}
+
+// Define adds the DataType with node ID dataTypeID and the given name to s, as
+// described by def, which must be the value of its DataTypeDefinition
+// attribute; a uatype.StructureDefinition or a uatype.EnumDefinition. Types
+// are built lazily, and structure fields may refer to the node ID of any
+// DataType defined in s, or to DataTypes in the OPC UA namespace. Defining the
+// same node ID again replaces the previous definition.
+func (s *Set) Define(dataTypeID uatype.NodeId, name string, def interface{}) error {
+	switch d := def.(type) {
+	case uatype.StructureDefinition, uatype.EnumDefinition:
+	case *uatype.StructureDefinition:
+		def = *d
+	case *uatype.EnumDefinition:
+		def = *d
+	default:
+		return fmt.Errorf("%s: %T is not a DataTypeDefinition", ErrUnknownType, def)
+	}
+
+	s.m.Lock()
+	defer s.m.Unlock()
+	s.defs[dataTypeID.Key()] = &definition{name: name, def: def}
+	return nil
+}
+
+// DefineSubtype adds the DataType with node ID dataTypeID to s as a simple
+// subtype of the DataType supertypeID, such as a vendor specific subtype of
+// Double. Fields of a simple subtype are encoded like its supertype. Defining
+// the same node ID again replaces the previous supertype.
+func (s *Set) DefineSubtype(dataTypeID, supertypeID uatype.NodeId) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	s.supertypes[dataTypeID.Key()] = supertypeID
+}
+
+// TypeByID returns the structured type defined for dataTypeID, building it if
+// needed.
+func (s *Set) TypeByID(dataTypeID uatype.NodeId) (*Type, error) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	return s.definedType(dataTypeID)
+}
+
+// definedType returns the structured type defined for id. The caller must
+// hold s.m.
+func (s *Set) definedType(id uatype.NodeId) (*Type, error) {
+	key := id.Key()
+	d, ok := s.defs[key]
+	if !ok {
+		return nil, fmt.Errorf("%s: %s", ErrUnknownType, key)
+	}
+	if d.t != nil {
+		return d.t, nil
+	}
+	sd, ok := d.def.(uatype.StructureDefinition)
+	if !ok {
+		return nil, fmt.Errorf("%s: %s is not a structure", ErrUnknownType, d.name)
+	}
+	if d.building {
+		return nil, fmt.Errorf("%s: %s", ErrRecursiveType, d.name)
+	}
+	d.building = true
+	defer func() { d.building = false }()
+
+	t, err := s.buildDefinition(key.String(), d.name, sd)
+	if err != nil {
+		return nil, fmt.Errorf("%s.%s", d.name, err)
+	}
+	d.t = t
+	s.byType[t.rt] = t
+	return t, nil
+}
+
+// lookupID returns the Go type of the DataType with node ID id. Simple
+// subtypes are encoded like their nearest supertype with a known Go type. The
+// caller must hold s.m.
+func (s *Set) lookupID(id uatype.NodeId) (reflect.Type, error) {
+	// The supertypes are followed at most once each, in case they loop.
+	nid := id
+	for i := 0; i <= len(s.supertypes); i++ {
+		rt, err := s.lookupKnownID(nid)
+		if rt != nil || err != nil {
+			return rt, err
+		}
+		super, ok := s.supertypes[nid.Key()]
+		if !ok {
+			break
+		}
+		nid = super
+	}
+	return nil, fmt.Errorf("%s: %s", ErrUnknownType, id)
+}
+
+// lookupKnownID returns the Go type of the DataType with node ID id if it's a
+// DataType in the OPC UA namespace or defined in s, or nil. The caller must
+// hold s.m.
+func (s *Set) lookupKnownID(id uatype.NodeId) (reflect.Type, error) {
+	if id.NamespaceIndex() == 0 {
+		if id.IsNumeric() {
+			if rt, ok := ns0Types[id.Uint32()]; ok {
+				return rt, nil
+			}
+		}
+		if rt, ok := uatype.TypeByName(id.Expanded().DisplayName()); ok {
+			return rt, nil
+		}
+		// Simple subtypes of built-in types, such as Enumerations.
+		if bt, ok := uatype.BuiltinType(id); ok {
+			if rt, ok := ns0Types[uint32(bt)]; ok {
+				return rt, nil
+			}
+		}
+	}
+	if d, ok := s.defs[id.Key()]; ok {
+		if _, ok := d.def.(uatype.EnumDefinition); ok {
+			// Enumerations are encoded as Int32.
+			return reflect.TypeOf(int32(0)), nil
+		}
+		t, err := s.definedType(id)
+		if err != nil {
+			return nil, err
+		}
+		return t.rt, nil
+	}
+	return nil, nil
+}
+
+// buildDefinition builds the Go type for sd. The caller must hold s.m.
+func (s *Set) buildDefinition(key, name string, sd uatype.StructureDefinition) (*Type, error) {
+	t := &Type{
+		Name:     name,
+		set:      s,
+		fields:   make(map[string]int, len(sd.Fields)),
+		presence: make(map[int]presence),
+	}
+	var sfs []reflect.StructField
+	used := make(map[string]bool)
+
+	// add appends a field to the Go struct, and returns its index. Hidden
+	// fields, such as encoding masks, have no original name.
+	add := func(name, orig string, rt reflect.Type, tags []string) int {
+		goName := goFieldName(name, len(sfs))
+		if used[goName] {
+			goName += "_" + strconv.Itoa(len(sfs))
+		}
+		used[goName] = true
+
+		// See buildStruct for why the first field is tagged.
+		var tag []string
+		if len(tags) > 0 {
+			tag = append(tag, fmt.Sprintf(`opcua:"%s"`, strings.Join(tags, ",")))
+		}
+		if len(sfs) == 0 {
+			tag = append(tag, fmt.Sprintf(`typedict:"%s#%s"`, key, name))
+		}
+		sfs = append(sfs, reflect.StructField{Name: goName, Type: rt, Tag: reflect.StructTag(strings.Join(tag, " "))})
+		t.names = append(t.names, orig)
+		if orig != "" {
+			t.fields[orig] = len(sfs) - 1
+		}
+		return len(sfs) - 1
+	}
+
+	// Optional fields are preceded by a 32 bit encoding mask, and unions by a
+	// 32 bit switch field.
+	masks := make(map[int]int)
+	unionSwitch := -1
+	switch sd.StructureType {
+	case uatype.StructureTypeStructure:
+	case uatype.StructureTypeStructureWithOptionalFields:
+		for i, f := range sd.Fields {
+			if f.IsOptional {
+				masks[i] = add(f.Name+"Specified", "", reflect.TypeOf(uatype.Bit(false)), nil)
+			}
+		}
+		if len(masks) > 32 {
+			return nil, fmt.Errorf("%s: more than 32 optional fields", ErrInvalidField)
+		}
+		for n := len(masks); n < 32; {
+			bits := 8 - n%8
+			add("Reserved"+strconv.Itoa(n), "", reflect.TypeOf(byte(0)), []string{fmt.Sprintf("bits=%d", bits)})
+			n += bits
+		}
+	case uatype.StructureTypeUnion:
+		unionSwitch = add("SwitchField", "", reflect.TypeOf(uint32(0)), nil)
+	default:
+		return nil, fmt.Errorf("%s: StructureType %d", ErrInvalidField, sd.StructureType)
+	}
+
+	for i, f := range sd.Fields {
+		if _, ok := t.fields[f.Name]; ok {
+			return nil, fmt.Errorf("%s: %s: duplicate field", f.Name, ErrInvalidField)
+		}
+		rt, err := s.lookupID(f.DataType)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s", f.Name, err)
+		}
+
+		var switchTags []string
+		p, hasPresence := presence{}, false
+		if j, ok := masks[i]; ok {
+			switchTags = []string{"switchField=" + sfs[j].Name}
+			p, hasPresence = presence{field: j}, true
+		} else if unionSwitch >= 0 {
+			switchTags = []string{"switchField=" + sfs[unionSwitch].Name, "switchValue=" + strconv.Itoa(i+1)}
+			p, hasPresence = presence{field: unionSwitch, value: uint32(i + 1)}, true
+		}
+
+		tags := switchTags
+		switch f.ValueRank {
+		case -1:
+			// Scalar.
+		case 1:
+			l := add("NoOf"+f.Name, "", reflect.TypeOf(int32(0)), switchTags)
+			tags = append([]string{"lengthField=" + sfs[l].Name}, switchTags...)
+			rt = reflect.SliceOf(rt)
+		default:
+			return nil, fmt.Errorf("%s: %s: ValueRank %d is not supported", f.Name, ErrInvalidField, f.ValueRank)
+		}
+		j := add(f.Name, f.Name, rt, tags)
+		if hasPresence {
+			t.presence[j] = p
+		}
+	}
+
+	t.rt = reflect.StructOf(sfs)
+	return t, nil
+}
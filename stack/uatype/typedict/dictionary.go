@@ -0,0 +1,239 @@
+package typedict
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/searis/guma/stack/uatype"
+)
+
+// Well known XML namespaces used in OPC Binary type dictionaries.
+const (
+	BinarySchemaNamespace = "http://opcfoundation.org/BinarySchema/"
+	UANamespace           = "http://opcfoundation.org/UA/"
+)
+
+// Errors returned when building types.
+var (
+	ErrUnknownType   = errors.New("unknown type")
+	ErrRecursiveType = errors.New("recursive type")
+	ErrInvalidField  = errors.New("invalid field")
+)
+
+// Set holds a set of type dictionaries and DataTypeDefinitions where types may
+// refer to types in any other dictionary or definition in the set. As the
+// encoding node IDs of registered types depend on the server, a Set should
+// only hold the types of a single server. A Set is safe for concurrent use.
+type Set struct {
+	m          sync.Mutex
+	dicts      map[string]*Dictionary
+	defs       map[uatype.NodeKey]*definition
+	supertypes map[uatype.NodeKey]uatype.NodeId
+	byType     map[reflect.Type]*Type
+	types      uatype.ExtensionObjectTypes
+}
+
+// NewSet returns an empty set of dictionaries.
+func NewSet() *Set {
+	return &Set{
+		dicts:      make(map[string]*Dictionary),
+		defs:       make(map[uatype.NodeKey]*definition),
+		supertypes: make(map[uatype.NodeKey]uatype.NodeId),
+		byType:     make(map[reflect.Type]*Type),
+	}
+}
+
+// ExtensionObjectTypes returns the ExtensionObject types registered for the
+// types of s. See Type.Register.
+func (s *Set) ExtensionObjectTypes() *uatype.ExtensionObjectTypes {
+	return &s.types
+}
+
+// Dictionary is a parsed OPC Binary type dictionary. Types are built lazily
+// on first use.
+type Dictionary struct {
+	TargetNamespace string
+
+	set      *Set
+	prefixes map[string]string
+	enums    map[string]enumType
+	opaques  map[string]opaqueType
+	structs  map[string]structType
+	names    []string
+	types    map[string]*Type
+	building map[string]bool
+}
+
+type xmlDict struct {
+	TargetNamespace string       `xml:"TargetNamespace,attr"`
+	Enums           []enumType   `xml:"EnumeratedType"`
+	Opaques         []opaqueType `xml:"OpaqueType"`
+	Structs         []structType `xml:"StructuredType"`
+}
+
+type enumType struct {
+	Name      string `xml:"Name,attr"`
+	BitLength int    `xml:"LengthInBits,attr"`
+}
+
+type opaqueType struct {
+	Name      string `xml:"Name,attr"`
+	BitLength int    `xml:"LengthInBits,attr"`
+}
+
+type structType struct {
+	Name   string        `xml:"Name,attr"`
+	Fields []structField `xml:"Field"`
+}
+
+type structField struct {
+	Name          string `xml:"Name,attr"`
+	TypeName      string `xml:"TypeName,attr"`
+	Length        int    `xml:"Length,attr"`
+	LengthField   string `xml:"LengthField,attr"`
+	SwitchField   string `xml:"SwitchField,attr"`
+	SwitchValue   string `xml:"SwitchValue,attr"`
+	SwitchOperand string `xml:"SwitchOperand,attr"`
+}
+
+// Parse reads an OPC Binary type dictionary from r and adds it to s. If a
+// dictionary with the same target namespace is already in s, it's replaced.
+func (s *Set) Parse(r io.Reader) (*Dictionary, error) {
+	d := &Dictionary{
+		set: s,
+		prefixes: map[string]string{
+			"opc": BinarySchemaNamespace,
+			"ua":  UANamespace,
+		},
+		enums:    make(map[string]enumType),
+		opaques:  make(map[string]opaqueType),
+		structs:  make(map[string]structType),
+		types:    make(map[string]*Type),
+		building: make(map[string]bool),
+	}
+
+	// Read namespace prefixes from the root element, as they are needed to
+	// resolve TypeName attributes.
+	dec := xml.NewDecoder(r)
+	var start xml.StartElement
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		if se, ok := tok.(xml.StartElement); ok {
+			start = se
+			break
+		}
+	}
+	for _, attr := range start.Attr {
+		if attr.Name.Space == "xmlns" {
+			d.prefixes[attr.Name.Local] = attr.Value
+		}
+	}
+
+	var xd xmlDict
+	if err := dec.DecodeElement(&xd, &start); err != nil {
+		return nil, err
+	}
+	d.TargetNamespace = xd.TargetNamespace
+	for _, e := range xd.Enums {
+		d.enums[e.Name] = e
+	}
+	for _, o := range xd.Opaques {
+		d.opaques[o.Name] = o
+	}
+	for _, st := range xd.Structs {
+		d.structs[st.Name] = st
+		d.names = append(d.names, st.Name)
+	}
+
+	s.m.Lock()
+	s.dicts[d.TargetNamespace] = d
+	s.m.Unlock()
+	return d, nil
+}
+
+// Dictionary returns the dictionary with the given target namespace, or nil.
+func (s *Set) Dictionary(namespace string) *Dictionary {
+	s.m.Lock()
+	defer s.m.Unlock()
+	return s.dicts[namespace]
+}
+
+// TypeOf returns the type that was used to build the Go type of v, or nil and
+// false if v is not a value of a type built by s.
+func (s *Set) TypeOf(v interface{}) (*Type, bool) {
+	rt := reflect.TypeOf(v)
+	for rt != nil && rt.Kind() == reflect.Ptr {
+		rt = rt.Elem()
+	}
+	s.m.Lock()
+	defer s.m.Unlock()
+	t, ok := s.byType[rt]
+	return t, ok
+}
+
+// Map returns v as a map[string]interface{} if v is a value of a type built
+// by s. See Type.Map.
+func (s *Set) Map(v interface{}) (map[string]interface{}, error) {
+	t, ok := s.TypeOf(v)
+	if !ok {
+		return nil, fmt.Errorf("%s: %T", ErrUnknownType, v)
+	}
+	return t.Map(v)
+}
+
+// StructNames returns the names of all structured types in d in the order they
+// are defined.
+func (d *Dictionary) StructNames() []string {
+	return d.names
+}
+
+// Type returns the structured type with the given name, building it if
+// needed.
+func (d *Dictionary) Type(name string) (*Type, error) {
+	d.set.m.Lock()
+	defer d.set.m.Unlock()
+	return d.structType(name)
+}
+
+// structType returns the named structured type of d. The caller must hold
+// d.set.m.
+func (d *Dictionary) structType(name string) (*Type, error) {
+	if t, ok := d.types[name]; ok {
+		return t, nil
+	}
+	st, ok := d.structs[name]
+	if !ok {
+		return nil, fmt.Errorf("%s: %s in %s", ErrUnknownType, name, d.TargetNamespace)
+	}
+	if d.building[name] {
+		return nil, fmt.Errorf("%s: %s in %s", ErrRecursiveType, name, d.TargetNamespace)
+	}
+	d.building[name] = true
+	defer delete(d.building, name)
+
+	t, err := d.buildStruct(st)
+	if err != nil {
+		return nil, fmt.Errorf("%s.%s", name, err)
+	}
+	d.types[name] = t
+	d.set.byType[t.rt] = t
+	return t, nil
+}
+
+// resolve splits a qualified type name on the form "prefix:Name" into the
+// dictionary namespace and the local name.
+func (d *Dictionary) resolve(typeName string) (namespace, name string) {
+	i := strings.IndexRune(typeName, ':')
+	if i < 0 {
+		return d.TargetNamespace, typeName
+	}
+	return d.prefixes[typeName[:i]], typeName[i+1:]
+}
@@ -0,0 +1,17 @@
+// Package typedict builds Go types at runtime from OPC Binary type
+// dictionaries, as defined in OPC UA 1.03 Part 5 annex E. Servers expose such
+// dictionaries to describe the binary layout of vendor specific structured
+// types. Servers implementing OPC UA 1.04 may instead describe such types
+// through the DataTypeDefinition attribute of DataType nodes, which is also
+// supported, including structures with optional fields and unions.
+//
+// The Go types are built with reflect.StructOf, using the same struct tags
+// (`opcua:"bits=x"`, `opcua:"lengthField=x"`, `opcua:"switchField=x"` etc.)
+// as the generated types in the uatype package, so values can be encoded and
+// decoded by the binary package. Types registered with Type.Register are
+// added to the ExtensionObjectTypes of their Set rather than globally, as
+// vendor types are only valid for a single server. ExtensionObjects of those
+// types are encoded and decoded automatically by binary Encoders and Decoders
+// given Set.ExtensionObjectTypes through SetExtensionObjectTypes. Values of
+// the built types may be converted to and from map[string]interface{}.
+package typedict
@@ -0,0 +1,487 @@
+package typedict
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/searis/guma/stack/encoding/binary"
+	"github.com/searis/guma/stack/uatype"
+)
+
+// opcTypes maps the built-in types of the OPC Binary schema to Go types.
+var opcTypes = map[string]reflect.Type{
+	"Bit":        reflect.TypeOf(uatype.Bit(false)),
+	"Boolean":    reflect.TypeOf(false),
+	"SByte":      reflect.TypeOf(int8(0)),
+	"Byte":       reflect.TypeOf(uint8(0)),
+	"Int16":      reflect.TypeOf(int16(0)),
+	"UInt16":     reflect.TypeOf(uint16(0)),
+	"Int32":      reflect.TypeOf(int32(0)),
+	"UInt32":     reflect.TypeOf(uint32(0)),
+	"Int64":      reflect.TypeOf(int64(0)),
+	"UInt64":     reflect.TypeOf(uint64(0)),
+	"Float":      reflect.TypeOf(float32(0)),
+	"Double":     reflect.TypeOf(float64(0)),
+	"Char":       reflect.TypeOf(uint8(0)),
+	"String":     reflect.TypeOf(""),
+	"CharArray":  reflect.TypeOf(""),
+	"WideString": reflect.TypeOf(""),
+	"DateTime":   reflect.TypeOf(time.Time{}),
+	"ByteString": reflect.TypeOf(uatype.ByteString{}),
+	"Guid":       reflect.TypeOf(uatype.Guid{}),
+	"StatusCode": reflect.TypeOf(uatype.StatusCode(0)),
+}
+
+// Type is a structured type from a type dictionary or a DataTypeDefinition,
+// together with the Go type built for it.
+type Type struct {
+	Namespace string
+	Name      string
+
+	set      *Set
+	rt       reflect.Type
+	fields   map[string]int   // original field name to Go field index
+	names    []string         // Go field index to original field name, or "" for hidden fields
+	presence map[int]presence // Go field index of optional and union fields
+}
+
+// presence describes how an optional or union field is marked as present.
+type presence struct {
+	field int    // Go field index of the encoding mask bit or union switch
+	value uint32 // union switch value, or 0 for encoding mask bits
+}
+
+func (p presence) isSet(rv reflect.Value) bool {
+	f := rv.Field(p.field)
+	if p.value == 0 {
+		return f.Bool()
+	}
+	return uint32(f.Uint()) == p.value
+}
+
+func (p presence) set(rv reflect.Value) error {
+	f := rv.Field(p.field)
+	if p.value == 0 {
+		f.SetBool(true)
+		return nil
+	}
+	if v := uint32(f.Uint()); v != 0 && v != p.value {
+		return fmt.Errorf("%s: only one field of a union may be set", ErrInvalidField)
+	}
+	f.SetUint(uint64(p.value))
+	return nil
+}
+
+// GoType returns the Go struct type built for t.
+func (t *Type) GoType() reflect.Type {
+	return t.rt
+}
+
+// Register registers the Go type of t in the ExtensionObject types of its set,
+// for automatic encoding and decoding of ExtensionObjects with a TypeId equal
+// to encodingID by Encoders and Decoders that are given those types. See
+// Set.ExtensionObjectTypes.
+func (t *Type) Register(encodingID uatype.NodeId) {
+	t.set.types.Register(encodingID, reflect.Zero(t.rt).Interface())
+}
+
+// New returns a value of the Go type of t, with fields set from m. Map values
+// are converted to the field type where possible; nested structured types may
+// be given as map[string]interface{}. Length fields that are not in m are set
+// from the length of the array they describe.
+func (t *Type) New(m map[string]interface{}) (interface{}, error) {
+	rv := reflect.New(t.rt).Elem()
+	if err := t.set.fromMap(rv, m); err != nil {
+		return nil, err
+	}
+	return rv.Interface(), nil
+}
+
+// Map returns the fields of v as a map from the field names in the type
+// dictionary to values. Nested structured types are returned as
+// map[string]interface{}, and arrays of them as []map[string]interface{}. v
+// must be a value of, or a pointer to, the Go type of t.
+func (t *Type) Map(v interface{}) (map[string]interface{}, error) {
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	if rv.Type() != t.rt {
+		return nil, fmt.Errorf("%s: %T is not a %s", ErrUnknownType, v, t.Name)
+	}
+	return t.set.toMap(rv), nil
+}
+
+// Decode decodes the binary encoded body of an ExtensionObject of type t into
+// a map. Nested ExtensionObjects of the types of the set are decoded as well.
+// See Map.
+func (t *Type) Decode(data []byte) (map[string]interface{}, error) {
+	rv := reflect.New(t.rt)
+	dec := binary.NewDecoder(bytes.NewReader(data))
+	dec.SetExtensionObjectTypes(t.set.ExtensionObjectTypes())
+	if err := dec.Decode(rv.Interface()); err != nil {
+		return nil, err
+	}
+	return t.set.toMap(rv.Elem()), nil
+}
+
+// Encode returns the binary encoding of a value of type t with fields set
+// from m. Nested ExtensionObjects of the types of the set are encoded as well.
+// See New.
+func (t *Type) Encode(m map[string]interface{}) ([]byte, error) {
+	v, err := t.New(m)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	enc := binary.NewEncoder(&buf)
+	enc.SetExtensionObjectTypes(t.set.ExtensionObjectTypes())
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (s *Set) toMap(rv reflect.Value) map[string]interface{} {
+	s.m.Lock()
+	t := s.byType[rv.Type()]
+	s.m.Unlock()
+
+	m := make(map[string]interface{}, len(t.names))
+	for i, name := range t.names {
+		if name == "" {
+			continue
+		}
+		if p, ok := t.presence[i]; ok && !p.isSet(rv) {
+			continue
+		}
+		m[name] = s.toMapValue(rv.Field(i))
+	}
+	return m
+}
+
+func (s *Set) toMapValue(rv reflect.Value) interface{} {
+	s.m.Lock()
+	_, isStruct := s.byType[rv.Type()]
+	_, isStructSlice := s.byType[elemType(rv.Type())]
+	s.m.Unlock()
+
+	switch {
+	case isStruct:
+		return s.toMap(rv)
+	case isStructSlice:
+		l := make([]map[string]interface{}, rv.Len())
+		for i := range l {
+			l[i] = s.toMap(rv.Index(i))
+		}
+		return l
+	}
+	return rv.Interface()
+}
+
+func (s *Set) fromMap(rv reflect.Value, m map[string]interface{}) error {
+	s.m.Lock()
+	t := s.byType[rv.Type()]
+	s.m.Unlock()
+
+	for name, v := range m {
+		i, ok := t.fields[name]
+		if !ok {
+			return fmt.Errorf("%s: %s has no field %s", ErrInvalidField, t.Name, name)
+		}
+		if err := s.setValue(rv.Field(i), v); err != nil {
+			return fmt.Errorf("%s.%s", name, err)
+		}
+		if p, ok := t.presence[i]; ok {
+			if err := p.set(rv); err != nil {
+				return fmt.Errorf("%s.%s", name, err)
+			}
+		}
+	}
+
+	// Fill in length fields that were left out.
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		lengthField := lengthFieldTag(rt.Field(i))
+		if lengthField == "" {
+			continue
+		}
+		lf, _ := rt.FieldByName(lengthField)
+		j := lf.Index[0]
+		if _, ok := m[t.names[j]]; !ok {
+			rv.Field(j).SetInt(int64(rv.Field(i).Len()))
+		}
+	}
+	return nil
+}
+
+func (s *Set) setValue(rv reflect.Value, v interface{}) error {
+	if v == nil {
+		return nil
+	}
+	if m, ok := v.(map[string]interface{}); ok {
+		s.m.Lock()
+		_, isStruct := s.byType[rv.Type()]
+		s.m.Unlock()
+		if !isStruct {
+			return fmt.Errorf("%s: can not set %s from map", ErrInvalidField, rv.Type())
+		}
+		return s.fromMap(rv, m)
+	}
+
+	sv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.String && sv.Kind() != reflect.String && sv.Kind() != reflect.Slice {
+		// Avoid converting integers to strings as runes.
+		return fmt.Errorf("%s: can not set %s from %T", ErrInvalidField, rv.Type(), v)
+	}
+	if sv.Type().ConvertibleTo(rv.Type()) {
+		rv.Set(sv.Convert(rv.Type()))
+		return nil
+	}
+
+	// Convert lists element by element.
+	if (sv.Kind() == reflect.Slice || sv.Kind() == reflect.Array) && (rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array) {
+		l := sv.Len()
+		if rv.Kind() == reflect.Slice {
+			rv.Set(reflect.MakeSlice(rv.Type(), l, l))
+		} else if l != rv.Len() {
+			return fmt.Errorf("%s: expected %d elements, got %d", ErrInvalidField, rv.Len(), l)
+		}
+		for i := 0; i < l; i++ {
+			if err := s.setValue(rv.Index(i), sv.Index(i).Interface()); err != nil {
+				return fmt.Errorf("%d.%s", i, err)
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("%s: can not set %s from %T", ErrInvalidField, rv.Type(), v)
+}
+
+// buildStruct builds the Go type for st. The caller must hold d.set.m.
+func (d *Dictionary) buildStruct(st structType) (*Type, error) {
+	t := &Type{
+		Namespace: d.TargetNamespace,
+		Name:      st.Name,
+		set:       d.set,
+		fields:    make(map[string]int, len(st.Fields)),
+	}
+
+	goNames := make(map[string]string, len(st.Fields))
+	used := make(map[string]bool, len(st.Fields))
+	sfs := make([]reflect.StructField, 0, len(st.Fields))
+	for _, f := range st.Fields {
+		if _, ok := t.fields[f.Name]; ok {
+			return nil, fmt.Errorf("%s: %s: duplicate field", f.Name, ErrInvalidField)
+		}
+		goName := goFieldName(f.Name, len(sfs))
+		if used[goName] {
+			goName += "_" + strconv.Itoa(len(sfs))
+		}
+		used[goName] = true
+		goNames[f.Name] = goName
+
+		ft, tags, err := d.fieldType(f)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s", f.Name, err)
+		}
+		if f.LengthField != "" {
+			tags = append(tags, "lengthField="+goNames[f.LengthField])
+		}
+		if f.SwitchField != "" {
+			tags = append(tags, "switchField="+goNames[f.SwitchField])
+		}
+		if f.SwitchValue != "" {
+			if _, err := strconv.Atoi(f.SwitchValue); err != nil {
+				return nil, fmt.Errorf("%s: %s: SwitchValue %q", f.Name, ErrInvalidField, f.SwitchValue)
+			}
+			tags = append(tags, "switchValue="+f.SwitchValue)
+		}
+		if f.SwitchOperand != "" {
+			tags = append(tags, "switchOperand="+f.SwitchOperand)
+		}
+
+		// reflect.StructOf returns the same type for identical struct
+		// definitions, so the first field is tagged with the qualified type
+		// name to keep types from different dictionaries apart.
+		var tag []string
+		if len(tags) > 0 {
+			tag = append(tag, fmt.Sprintf(`opcua:"%s"`, strings.Join(tags, ",")))
+		}
+		if len(sfs) == 0 {
+			tag = append(tag, fmt.Sprintf(`typedict:"%s#%s"`, d.TargetNamespace, st.Name))
+		}
+		sf := reflect.StructField{Name: goName, Type: ft, Tag: reflect.StructTag(strings.Join(tag, " "))}
+		t.fields[f.Name] = len(sfs)
+		t.names = append(t.names, f.Name)
+		sfs = append(sfs, sf)
+	}
+
+	// Validate references to length and switch fields.
+	for _, f := range st.Fields {
+		for _, ref := range []string{f.LengthField, f.SwitchField} {
+			if ref == "" {
+				continue
+			}
+			if i, ok := t.fields[ref]; !ok || i >= t.fields[f.Name] {
+				return nil, fmt.Errorf("%s: %s: no preceding field %s", f.Name, ErrInvalidField, ref)
+			}
+		}
+		if f.LengthField != "" {
+			switch sfs[t.fields[f.LengthField]].Type.Kind() {
+			case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			default:
+				return nil, fmt.Errorf("%s: %s: length field %s is not a signed integer", f.Name, ErrInvalidField, f.LengthField)
+			}
+		}
+	}
+
+	t.rt = reflect.StructOf(sfs)
+	return t, nil
+}
+
+// fieldType returns the Go type and any bits tag for the field f.
+func (d *Dictionary) fieldType(f structField) (reflect.Type, []string, error) {
+	ns, name := d.resolve(f.TypeName)
+
+	if ns == BinarySchemaNamespace && name == "Bit" && f.Length > 1 {
+		// Bit arrays are stored in an unsigned integer of appropriate size.
+		rt, err := typeFromBitSize(f.Length)
+		if err != nil {
+			return nil, nil, err
+		}
+		return rt, []string{fmt.Sprintf("bits=%d", f.Length)}, nil
+	}
+
+	rt, bits, err := d.set.lookup(ns, name)
+	if err != nil {
+		return nil, nil, err
+	}
+	var tags []string
+	if bits > 0 {
+		tags = append(tags, fmt.Sprintf("bits=%d", bits))
+	}
+
+	if f.Length > 1 {
+		rt = reflect.ArrayOf(f.Length, rt)
+	} else if f.LengthField != "" {
+		rt = reflect.SliceOf(rt)
+	}
+	return rt, tags, nil
+}
+
+// lookup returns the Go type of the named type in the namespace ns. If the
+// type must be encoded with a bit length that is not the size of the Go type,
+// bits is set. The caller must
+// hold s.m.
+func (s *Set) lookup(ns, name string) (rt reflect.Type, bits int, err error) {
+	switch ns {
+	case BinarySchemaNamespace:
+		if rt, ok := opcTypes[name]; ok {
+			return rt, 0, nil
+		}
+	case UANamespace:
+		if rt, ok := opcTypes[name]; ok {
+			return rt, 0, nil
+		}
+		if rt, ok := uatype.TypeByName(name); ok {
+			return rt, 0, nil
+		}
+	}
+
+	d, ok := s.dicts[ns]
+	if !ok {
+		return nil, 0, fmt.Errorf("%s: %s in %s", ErrUnknownType, name, ns)
+	}
+	if e, ok := d.enums[name]; ok {
+		return bitType(e.BitLength)
+	}
+	if o, ok := d.opaques[name]; ok {
+		if o.BitLength == 0 {
+			return reflect.TypeOf(uatype.ByteString{}), 0, nil
+		} else if o.BitLength%8 == 0 {
+			return reflect.ArrayOf(o.BitLength/8, reflect.TypeOf(byte(0))), 0, nil
+		}
+		return bitType(o.BitLength)
+	}
+	t, err := d.structType(name)
+	if err != nil {
+		return nil, 0, err
+	}
+	return t.rt, 0, nil
+}
+
+// bitType returns the Go type used for values of n bits, and n if it must be
+// encoded with an explicit bit length, as n is not the size of the type.
+func bitType(n int) (reflect.Type, int, error) {
+	rt, err := typeFromBitSize(n)
+	if err != nil {
+		return nil, 0, err
+	}
+	if n == rt.Bits() {
+		return rt, 0, nil
+	}
+	return rt, n, nil
+}
+
+// typeFromBitSize returns the smallest unsigned integer type that holds n
+// bits.
+func typeFromBitSize(n int) (reflect.Type, error) {
+	if n < 1 || n > 64 {
+		return nil, fmt.Errorf("%s: unexpected bit length %d", ErrInvalidField, n)
+	}
+	switch (n - 1) / 8 {
+	case 0:
+		return reflect.TypeOf(uint8(0)), nil
+	case 1:
+		return reflect.TypeOf(uint16(0)), nil
+	case 2, 3:
+		return reflect.TypeOf(uint32(0)), nil
+	default:
+		return reflect.TypeOf(uint64(0)), nil
+	}
+}
+
+// goFieldName returns an exported Go identifier for the field name s, which
+// is the i'th field of its struct.
+func goFieldName(s string, i int) string {
+	var b strings.Builder
+	for _, r := range s {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' {
+			b.WriteRune(r)
+		} else {
+			b.WriteRune('_')
+		}
+	}
+	name := b.String()
+	if name == "" {
+		return "Field" + strconv.Itoa(i)
+	}
+	r := []rune(name)
+	if !unicode.IsLetter(r[0]) {
+		return "X" + name
+	}
+	r[0] = unicode.ToUpper(r[0])
+	if !unicode.IsUpper(r[0]) {
+		return "X" + name
+	}
+	return string(r)
+}
+
+func lengthFieldTag(sf reflect.StructField) string {
+	const lengthFieldPrefix = "lengthField="
+	for _, s := range strings.Split(sf.Tag.Get("opcua"), ",") {
+		if strings.HasPrefix(s, lengthFieldPrefix) {
+			return s[len(lengthFieldPrefix):]
+		}
+	}
+	return ""
+}
+
+func elemType(rt reflect.Type) reflect.Type {
+	if rt.Kind() == reflect.Slice || rt.Kind() == reflect.Array {
+		return rt.Elem()
+	}
+	return nil
+}
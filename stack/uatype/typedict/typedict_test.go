@@ -0,0 +1,298 @@
+package typedict_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/searis/guma/stack/encoding/binary"
+	"github.com/searis/guma/stack/uatype"
+	"github.com/searis/guma/stack/uatype/typedict"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const vendorDict = `<?xml version="1.0" encoding="utf-8"?>
+<opc:TypeDictionary
+  xmlns:opc="http://opcfoundation.org/BinarySchema/"
+  xmlns:ua="http://opcfoundation.org/UA/"
+  xmlns:tns="urn:vendor:types"
+  DefaultByteOrder="LittleEndian"
+  TargetNamespace="urn:vendor:types">
+  <opc:Import Namespace="http://opcfoundation.org/UA/" />
+  <opc:EnumeratedType Name="PumpState" LengthInBits="32">
+    <opc:EnumeratedValue Name="Off" Value="0" />
+    <opc:EnumeratedValue Name="On" Value="1" />
+  </opc:EnumeratedType>
+  <opc:StructuredType Name="Point">
+    <opc:Field Name="x" TypeName="opc:Double" />
+    <opc:Field Name="y" TypeName="opc:Double" />
+  </opc:StructuredType>
+  <opc:StructuredType Name="Pump" BaseType="ua:ExtensionObject">
+    <opc:Field Name="LabelSpecified" TypeName="opc:Bit" />
+    <opc:Field Name="Flags" TypeName="opc:Bit" Length="3" />
+    <opc:Field Name="Reserved1" TypeName="opc:Bit" Length="4" />
+    <opc:Field Name="State" TypeName="tns:PumpState" />
+    <opc:Field Name="Label" TypeName="ua:LocalizedText" SwitchField="LabelSpecified" />
+    <opc:Field Name="NoOfPath" TypeName="opc:Int32" />
+    <opc:Field Name="Path" TypeName="tns:Point" LengthField="NoOfPath" />
+  </opc:StructuredType>
+</opc:TypeDictionary>`
+
+func TestTypeDictionary(t *testing.T) {
+	set := typedict.NewSet()
+	d, err := set.Parse(strings.NewReader(vendorDict))
+	require.NoError(t, err, "set.Parse")
+	assert.Equal(t, "urn:vendor:types", d.TargetNamespace, "d.TargetNamespace")
+	assert.Equal(t, []string{"Point", "Pump"}, d.StructNames(), "d.StructNames()")
+
+	pump, err := d.Type("Pump")
+	require.NoError(t, err, `d.Type("Pump")`)
+
+	data, err := pump.Encode(map[string]interface{}{
+		"LabelSpecified": true,
+		"Flags":          5,
+		"State":          1,
+		"Label":          uatype.LocalizedText{TextSpecified: true, Text: "P1"},
+		"Path": []map[string]interface{}{
+			{"x": 1.0, "y": 2.0},
+		},
+	})
+	require.NoError(t, err, "pump.Encode")
+	expect := []byte{
+		0x0B,       // LabelSpecified, Flags
+		1, 0, 0, 0, // State
+		0x02, 2, 0, 0, 0, 'P', '1', // Label
+		1, 0, 0, 0, // NoOfPath
+		0, 0, 0, 0, 0, 0, 0xF0, 0x3F, // x
+		0, 0, 0, 0, 0, 0, 0x00, 0x40, // y
+	}
+	assert.Equal(t, expect, data, "pump.Encode")
+
+	m, err := pump.Decode(data)
+	require.NoError(t, err, "pump.Decode")
+	assert.Equal(t, map[string]interface{}{
+		"LabelSpecified": uatype.Bit(true),
+		"Flags":          uint8(5),
+		"Reserved1":      uint8(0),
+		"State":          uint32(1),
+		"Label":          uatype.LocalizedText{TextSpecified: true, Text: "P1"},
+		"NoOfPath":       int32(1),
+		"Path": []map[string]interface{}{
+			{"x": 1.0, "y": 2.0},
+		},
+	}, m, "pump.Decode")
+}
+
+func TestTypeDictionaryBitFields(t *testing.T) {
+	const dict = `<opc:TypeDictionary xmlns:opc="http://opcfoundation.org/BinarySchema/" xmlns:tns="urn:bits" TargetNamespace="urn:bits">
+  <opc:EnumeratedType Name="Mode" LengthInBits="12">
+    <opc:EnumeratedValue Name="Auto" Value="2748" />
+  </opc:EnumeratedType>
+  <opc:OpaqueType Name="Raw" LengthInBits="12" />
+  <opc:StructuredType Name="Packed">
+    <opc:Field Name="Flags" TypeName="opc:Bit" Length="4" />
+    <opc:Field Name="Counter" TypeName="opc:Bit" Length="24" />
+    <opc:Field Name="Mode" TypeName="tns:Mode" />
+    <opc:Field Name="Raw" TypeName="tns:Raw" />
+    <opc:Field Name="Reserved1" TypeName="opc:Bit" Length="4" />
+  </opc:StructuredType>
+</opc:TypeDictionary>`
+	set := typedict.NewSet()
+	d, err := set.Parse(strings.NewReader(dict))
+	require.NoError(t, err, "set.Parse")
+	packed, err := d.Type("Packed")
+	require.NoError(t, err, `d.Type("Packed")`)
+
+	data, err := packed.Encode(map[string]interface{}{
+		"Flags":   0x5,
+		"Counter": 0x123456,
+		"Mode":    0xABC,
+		"Raw":     0x789,
+	})
+	require.NoError(t, err, "packed.Encode")
+	assert.Equal(t, []byte{0x65, 0x45, 0x23, 0xC1, 0xAB, 0x89, 0x07}, data, "packed.Encode")
+
+	m, err := packed.Decode(data)
+	require.NoError(t, err, "packed.Decode")
+	assert.Equal(t, map[string]interface{}{
+		"Flags":     uint8(0x5),
+		"Counter":   uint32(0x123456),
+		"Mode":      uint16(0xABC),
+		"Raw":       uint16(0x789),
+		"Reserved1": uint8(0),
+	}, m, "packed.Decode")
+}
+
+func TestTypeDictionaryExtensionObject(t *testing.T) {
+	set := typedict.NewSet()
+	d, err := set.Parse(strings.NewReader(vendorDict))
+	require.NoError(t, err, "set.Parse")
+	point, err := d.Type("Point")
+	require.NoError(t, err, `d.Type("Point")`)
+	point.Register(uatype.NewNumericNodeID(2, 5001))
+
+	v, err := point.New(map[string]interface{}{"x": 3, "y": 4})
+	require.NoError(t, err, "point.New")
+	var buf bytes.Buffer
+	enc := binary.NewEncoder(&buf)
+	enc.SetExtensionObjectTypes(set.ExtensionObjectTypes())
+	require.NoError(t, enc.Encode(uatype.ExtensionObject{Value: v}), "enc.Encode")
+	data := buf.Bytes()
+
+	// Types are only registered in the set, not globally.
+	_, err = binary.Marshal(uatype.ExtensionObject{Value: v})
+	assert.Error(t, err, "binary.Marshal")
+	var eo uatype.ExtensionObject
+	require.NoError(t, binary.Unmarshal(data, &eo), "binary.Unmarshal")
+	assert.Nil(t, eo.Value, "binary.Unmarshal: eo.Value")
+
+	dec := binary.NewDecoder(bytes.NewReader(data))
+	dec.SetExtensionObjectTypes(set.ExtensionObjectTypes())
+	require.NoError(t, dec.Decode(&eo), "dec.Decode")
+	assert.Equal(t, uint32(5001), eo.TypeId.Numeric.Identifier, "eo.TypeId")
+	m, err := set.Map(eo.Value)
+	require.NoError(t, err, "set.Map")
+	assert.Equal(t, map[string]interface{}{"x": 3.0, "y": 4.0}, m, "set.Map")
+
+	// Nested ExtensionObjects of the types of the set are encoded and decoded
+	// by Type.Encode and Type.Decode.
+	holders, err := set.Parse(strings.NewReader(holderDict))
+	require.NoError(t, err, "set.Parse(holderDict)")
+	holder, err := holders.Type("Holder")
+	require.NoError(t, err, `holders.Type("Holder")`)
+	data, err = holder.Encode(map[string]interface{}{"Item": uatype.ExtensionObject{Value: v}})
+	require.NoError(t, err, "holder.Encode")
+	m, err = holder.Decode(data)
+	require.NoError(t, err, "holder.Decode")
+	item := m["Item"].(uatype.ExtensionObject)
+	assert.Equal(t, v, item.Value, "holder.Decode: Item.Value")
+}
+
+const holderDict = `<opc:TypeDictionary
+  xmlns:opc="http://opcfoundation.org/BinarySchema/"
+  xmlns:ua="http://opcfoundation.org/UA/"
+  TargetNamespace="urn:vendor:holders">
+  <opc:Import Namespace="http://opcfoundation.org/UA/" />
+  <opc:StructuredType Name="Holder">
+    <opc:Field Name="Item" TypeName="ua:ExtensionObject" />
+  </opc:StructuredType>
+</opc:TypeDictionary>`
+
+func TestTypeDictionaryErrors(t *testing.T) {
+	const dict = `<opc:TypeDictionary xmlns:opc="http://opcfoundation.org/BinarySchema/" xmlns:tns="urn:bad" TargetNamespace="urn:bad">
+  <opc:StructuredType Name="Loop">
+    <opc:Field Name="Next" TypeName="tns:Loop" />
+  </opc:StructuredType>
+  <opc:StructuredType Name="Unknown">
+    <opc:Field Name="Value" TypeName="opc:Nothing" />
+  </opc:StructuredType>
+</opc:TypeDictionary>`
+	set := typedict.NewSet()
+	d, err := set.Parse(strings.NewReader(dict))
+	require.NoError(t, err, "set.Parse")
+
+	_, err = d.Type("Unknown")
+	assert.EqualError(t, err, "Unknown.Value: unknown type: Nothing in http://opcfoundation.org/BinarySchema/", `d.Type("Unknown")`)
+	_, err = d.Type("Loop")
+	assert.EqualError(t, err, "Loop.Next: recursive type: Loop in urn:bad", `d.Type("Loop")`)
+	_, err = d.Type("Missing")
+	assert.EqualError(t, err, "unknown type: Missing in urn:bad", `d.Type("Missing")`)
+}
+
+func TestDataTypeDefinition(t *testing.T) {
+	set := typedict.NewSet()
+	nodeID := func(id uint32) uatype.NodeId { return uatype.NewNumericNodeID(2, id) }
+	int32ID := uatype.NewTwoByteNodeID(uatype.NodeIdInt32)
+	stringID := uatype.NewTwoByteNodeID(uatype.NodeIdString)
+
+	require.NoError(t, set.Define(nodeID(1), "Mode", uatype.EnumDefinition{}), "set.Define(Mode)")
+	require.NoError(t, set.Define(nodeID(2), "Setting", uatype.StructureDefinition{
+		StructureType: uatype.StructureTypeUnion,
+		Fields: []uatype.StructureField{
+			{Name: "Number", DataType: int32ID, ValueRank: -1},
+			{Name: "Text", DataType: stringID, ValueRank: -1},
+		},
+	}), "set.Define(Setting)")
+	require.NoError(t, set.Define(nodeID(3), "Config", &uatype.StructureDefinition{
+		StructureType: uatype.StructureTypeStructureWithOptionalFields,
+		Fields: []uatype.StructureField{
+			{Name: "Mode", DataType: nodeID(1), ValueRank: -1},
+			{Name: "Comment", DataType: stringID, ValueRank: -1, IsOptional: true},
+			{Name: "Settings", DataType: nodeID(2), ValueRank: 1, IsOptional: true},
+		},
+	}), "set.Define(Config)")
+
+	config, err := set.TypeByID(nodeID(3))
+	require.NoError(t, err, "set.TypeByID(Config)")
+
+	in := map[string]interface{}{
+		"Mode": 2,
+		"Settings": []map[string]interface{}{
+			{"Text": "a"},
+			{},
+		},
+	}
+	data, err := config.Encode(in)
+	require.NoError(t, err, "config.Encode")
+	expect := []byte{
+		0x02, 0, 0, 0, // EncodingMask
+		2, 0, 0, 0, // Mode
+		2, 0, 0, 0, // NoOfSettings
+		2, 0, 0, 0, 1, 0, 0, 0, 'a', // Settings[0]
+		0, 0, 0, 0, // Settings[1]
+	}
+	assert.Equal(t, expect, data, "config.Encode")
+
+	m, err := config.Decode(data)
+	require.NoError(t, err, "config.Decode")
+	assert.Equal(t, map[string]interface{}{
+		"Mode": int32(2),
+		"Settings": []map[string]interface{}{
+			{"Text": "a"},
+			{},
+		},
+	}, m, "config.Decode")
+
+	_, err = config.Encode(map[string]interface{}{
+		"Settings": []map[string]interface{}{{"Text": "a", "Number": 1}},
+	})
+	assert.Error(t, err, "config.Encode(two union fields)")
+}
+
+func TestDataTypeDefinitionSubtypes(t *testing.T) {
+	set := typedict.NewSet()
+	nodeID := func(id uint32) uatype.NodeId { return uatype.NewNumericNodeID(2, id) }
+
+	// Speed is a vendor specific subtype of a subtype of Double.
+	set.DefineSubtype(nodeID(1), nodeID(2))
+	set.DefineSubtype(nodeID(2), uatype.NewTwoByteNodeID(uatype.NodeIdDouble))
+	// Types 3 and 4 are each other's supertype, and never reach a known type.
+	set.DefineSubtype(nodeID(3), nodeID(4))
+	set.DefineSubtype(nodeID(4), nodeID(3))
+	require.NoError(t, set.Define(nodeID(5), "Status", uatype.StructureDefinition{
+		Fields: []uatype.StructureField{
+			{Name: "State", DataType: uatype.NewFourByteNodeID(0, uatype.NodeIdServerState), ValueRank: -1},
+			{Name: "Class", DataType: uatype.NewFourByteNodeID(0, uatype.NodeIdNodeClass), ValueRank: -1},
+			{Name: "Speed", DataType: nodeID(1), ValueRank: -1},
+		},
+	}), "set.Define(Status)")
+	require.NoError(t, set.Define(nodeID(6), "Broken", uatype.StructureDefinition{
+		Fields: []uatype.StructureField{
+			{Name: "Loop", DataType: nodeID(3), ValueRank: -1},
+		},
+	}), "set.Define(Broken)")
+
+	status, err := set.TypeByID(nodeID(5))
+	require.NoError(t, err, "set.TypeByID(Status)")
+	data, err := status.Encode(map[string]interface{}{"State": 1, "Class": 2, "Speed": 1.5})
+	require.NoError(t, err, "status.Encode")
+	assert.Equal(t, []byte{
+		1, 0, 0, 0, // State
+		2, 0, 0, 0, // Class
+		0, 0, 0, 0, 0, 0, 0xf8, 0x3f, // Speed
+	}, data, "status.Encode")
+
+	_, err = set.TypeByID(nodeID(6))
+	assert.EqualError(t, err, "Broken.Loop: unknown type: ns=2;i=3", "set.TypeByID(Broken)")
+}
@@ -7,7 +7,6 @@ package uatype
 
 import (
 	"encoding/binary"
-	"fmt"
 	"io"
 )
 
@@ -24,7 +23,9 @@ func (b Bit) BitLength() int {
 type Guid [16]byte
 
 // ByteString is encoded as a string of bytes prefixed by the length as int32.
-// -1 is used to indicate a null string.
+// -1 is used to indicate a null string. By default, both nil and empty
+// ByteStrings are encoded as null, and decoded as nil. The binary Encoder and
+// Decoder can be set to tell them apart through SetPreserveNull.
 type ByteString []byte
 
 // MarshalBinary returns the binary representation of bs.
@@ -32,8 +33,7 @@ func (bs ByteString) MarshalBinary() ([]byte, error) {
 	size := int32(len(bs))
 	target := make([]byte, size+4)
 
-	// At least initially, we don't distinguish between null values and arrays
-	// with length null. We could change this if it's required.
+	// Encode empty values as null.
 	if size == 0 {
 		size = -1
 		binary.LittleEndian.PutUint32(target, uint32(size))
@@ -56,10 +56,10 @@ func (bs *ByteString) UnmarshalBinary(data []byte) error {
 		return io.ErrShortBuffer
 	}
 	size := int32(binary.LittleEndian.Uint32(data[0:4]))
-	if size == -1 || size == 0 {
-		// At least initially, we don't distinguish between null values and
-		// arrays with length 0. We could change this if it's required.
-		bs = nil
+	if size <= 0 {
+		// Negative sizes describe null values. Empty values are decoded as
+		// null as well.
+		*bs = nil
 		return nil
 	}
 
@@ -79,12 +79,62 @@ func (bs ByteString) BitLength() int {
 	return 32 + 8*len(bs)
 }
 
+// NullString is a String that may be null. OPC UA distinguishes null strings
+// from empty ones, which a plain Go string is not able to, so NullString can
+// be used in place of string where the difference matters. Unlike string, it
+// always encodes and decodes null and empty values exactly.
+type NullString struct {
+	String string
+	Valid  bool // Valid is true if String is not null.
+}
+
+// NewNullString returns a NullString holding s that is not null.
+func NewNullString(s string) NullString {
+	return NullString{String: s, Valid: true}
+}
+
+// MarshalBinary returns the binary representation of ns.
+func (ns NullString) MarshalBinary() ([]byte, error) {
+	if !ns.Valid {
+		return []byte{0xff, 0xff, 0xff, 0xff}, nil
+	}
+	target := make([]byte, 4+len(ns.String))
+	binary.LittleEndian.PutUint32(target, uint32(len(ns.String)))
+	copy(target[4:], ns.String)
+	return target, nil
+}
+
+// UnmarshalBinary reads from the head of data and sets ns. If there is not
+// enough data available, the io.ErrShortBuffer error is returned.
+func (ns *NullString) UnmarshalBinary(data []byte) error {
+	l := len(data)
+	if l < 4 {
+		return io.ErrShortBuffer
+	}
+	size := int32(binary.LittleEndian.Uint32(data[0:4]))
+	if size < 0 {
+		*ns = NullString{}
+		return nil
+	}
+
+	stop := int(size) + 4
+	if stop > l {
+		return io.ErrShortBuffer
+	}
+	*ns = NullString{String: string(data[4:stop]), Valid: true}
+	return nil
+}
+
+// BitLength returns the size in bits of ns when encoded to binary. The number
+// is at least 32, and always a multiplum of 8.
+func (ns NullString) BitLength() int {
+	if !ns.Valid {
+		return 32
+	}
+	return 32 + 8*len(ns.String)
+}
+
 // Error implements the built-in error interface.
 func (f ServiceFault) Error() string {
-	code := f.ResponseHeader.ServiceResult
-	s := statusText[code]
-	if s == "" {
-		return fmt.Sprintf("unknown status code 0x%.8X", code)
-	}
-	return fmt.Sprintf("status code 0x%.8X: %s", code, s)
+	return f.ResponseHeader.ServiceResult.Error()
 }
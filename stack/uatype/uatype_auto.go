@@ -2,7 +2,10 @@
 
 package uatype
 
-import "time"
+import (
+	"strconv"
+	"time"
+)
 
 type NodeIdType byte
 
@@ -21,6 +24,25 @@ const (
 	NodeIdTypeByteString NodeIdType = 5
 )
 
+// String returns the name of t, or its value if t is unknown.
+func (t NodeIdType) String() string {
+	switch t {
+	case NodeIdTypeTwoByte:
+		return "TwoByte"
+	case NodeIdTypeFourByte:
+		return "FourByte"
+	case NodeIdTypeNumeric:
+		return "Numeric"
+	case NodeIdTypeString:
+		return "String"
+	case NodeIdTypeGuid:
+		return "Guid"
+	case NodeIdTypeByteString:
+		return "ByteString"
+	}
+	return strconv.FormatUint(uint64(t), 10)
+}
+
 type NamingRuleType uint32
 
 const (
@@ -29,6 +51,19 @@ const (
 	NamingRuleTypeConstraint NamingRuleType = 3
 )
 
+// String returns the name of t, or its value if t is unknown.
+func (t NamingRuleType) String() string {
+	switch t {
+	case NamingRuleTypeMandatory:
+		return "Mandatory"
+	case NamingRuleTypeOptional:
+		return "Optional"
+	case NamingRuleTypeConstraint:
+		return "Constraint"
+	}
+	return strconv.FormatUint(uint64(t), 10)
+}
+
 type OpenFileMode uint32
 
 const (
@@ -38,6 +73,21 @@ const (
 	OpenFileModeAppend        OpenFileMode = 8
 )
 
+// String returns the name of t, or its value if t is unknown.
+func (t OpenFileMode) String() string {
+	switch t {
+	case OpenFileModeRead:
+		return "Read"
+	case OpenFileModeWrite:
+		return "Write"
+	case OpenFileModeEraseExisting:
+		return "EraseExisting"
+	case OpenFileModeAppend:
+		return "Append"
+	}
+	return strconv.FormatUint(uint64(t), 10)
+}
+
 type TrustListMasks uint32
 
 const (
@@ -49,6 +99,25 @@ const (
 	TrustListMasksAll                 TrustListMasks = 15
 )
 
+// String returns the name of t, or its value if t is unknown.
+func (t TrustListMasks) String() string {
+	switch t {
+	case TrustListMasksNone:
+		return "None"
+	case TrustListMasksTrustedCertificates:
+		return "TrustedCertificates"
+	case TrustListMasksTrustedCrls:
+		return "TrustedCrls"
+	case TrustListMasksIssuerCertificates:
+		return "IssuerCertificates"
+	case TrustListMasksIssuerCrls:
+		return "IssuerCrls"
+	case TrustListMasksAll:
+		return "All"
+	}
+	return strconv.FormatUint(uint64(t), 10)
+}
+
 type IdType uint32
 
 // The type of identifier used in a node id.
@@ -59,6 +128,21 @@ const (
 	IdTypeOpaque  IdType = 3
 )
 
+// String returns the name of t, or its value if t is unknown.
+func (t IdType) String() string {
+	switch t {
+	case IdTypeNumeric:
+		return "Numeric"
+	case IdTypeString:
+		return "String"
+	case IdTypeGuid:
+		return "Guid"
+	case IdTypeOpaque:
+		return "Opaque"
+	}
+	return strconv.FormatUint(uint64(t), 10)
+}
+
 type NodeClass uint32
 
 // A mask specifying the class of the node.
@@ -74,6 +158,31 @@ const (
 	NodeClassView          NodeClass = 128
 )
 
+// String returns the name of t, or its value if t is unknown.
+func (t NodeClass) String() string {
+	switch t {
+	case NodeClassUnspecified:
+		return "Unspecified"
+	case NodeClassObject:
+		return "Object"
+	case NodeClassVariable:
+		return "Variable"
+	case NodeClassMethod:
+		return "Method"
+	case NodeClassObjectType:
+		return "ObjectType"
+	case NodeClassVariableType:
+		return "VariableType"
+	case NodeClassReferenceType:
+		return "ReferenceType"
+	case NodeClassDataType:
+		return "DataType"
+	case NodeClassView:
+		return "View"
+	}
+	return strconv.FormatUint(uint64(t), 10)
+}
+
 type ApplicationType uint32
 
 // The types of applications.
@@ -84,6 +193,21 @@ const (
 	ApplicationTypeDiscoveryServer ApplicationType = 3
 )
 
+// String returns the name of t, or its value if t is unknown.
+func (t ApplicationType) String() string {
+	switch t {
+	case ApplicationTypeServer:
+		return "Server"
+	case ApplicationTypeClient:
+		return "Client"
+	case ApplicationTypeClientAndServer:
+		return "ClientAndServer"
+	case ApplicationTypeDiscoveryServer:
+		return "DiscoveryServer"
+	}
+	return strconv.FormatUint(uint64(t), 10)
+}
+
 type MessageSecurityMode uint32
 
 // The type of security to use on a message.
@@ -94,6 +218,21 @@ const (
 	MessageSecurityModeSignAndEncrypt MessageSecurityMode = 3
 )
 
+// String returns the name of t, or its value if t is unknown.
+func (t MessageSecurityMode) String() string {
+	switch t {
+	case MessageSecurityModeInvalid:
+		return "Invalid"
+	case MessageSecurityModeNone:
+		return "None"
+	case MessageSecurityModeSign:
+		return "Sign"
+	case MessageSecurityModeSignAndEncrypt:
+		return "SignAndEncrypt"
+	}
+	return strconv.FormatUint(uint64(t), 10)
+}
+
 type UserTokenType uint32
 
 // The possible user token types.
@@ -104,6 +243,21 @@ const (
 	UserTokenTypeIssuedToken UserTokenType = 3
 )
 
+// String returns the name of t, or its value if t is unknown.
+func (t UserTokenType) String() string {
+	switch t {
+	case UserTokenTypeAnonymous:
+		return "Anonymous"
+	case UserTokenTypeUserName:
+		return "UserName"
+	case UserTokenTypeCertificate:
+		return "Certificate"
+	case UserTokenTypeIssuedToken:
+		return "IssuedToken"
+	}
+	return strconv.FormatUint(uint64(t), 10)
+}
+
 type SecurityTokenRequestType uint32
 
 // Indicates whether a token if being created or renewed.
@@ -112,6 +266,17 @@ const (
 	SecurityTokenRequestTypeRenew SecurityTokenRequestType = 1
 )
 
+// String returns the name of t, or its value if t is unknown.
+func (t SecurityTokenRequestType) String() string {
+	switch t {
+	case SecurityTokenRequestTypeIssue:
+		return "Issue"
+	case SecurityTokenRequestTypeRenew:
+		return "Renew"
+	}
+	return strconv.FormatUint(uint64(t), 10)
+}
+
 type NodeAttributesMask uint32
 
 // The bits used to specify default attributes for a new node.
@@ -150,6 +315,77 @@ const (
 	NodeAttributesMaskView                    NodeAttributesMask = 1335532
 )
 
+// String returns the name of t, or its value if t is unknown.
+func (t NodeAttributesMask) String() string {
+	switch t {
+	case NodeAttributesMaskNone:
+		return "None"
+	case NodeAttributesMaskAccessLevel:
+		return "AccessLevel"
+	case NodeAttributesMaskArrayDimensions:
+		return "ArrayDimensions"
+	case NodeAttributesMaskBrowseName:
+		return "BrowseName"
+	case NodeAttributesMaskContainsNoLoops:
+		return "ContainsNoLoops"
+	case NodeAttributesMaskDataType:
+		return "DataType"
+	case NodeAttributesMaskDescription:
+		return "Description"
+	case NodeAttributesMaskDisplayName:
+		return "DisplayName"
+	case NodeAttributesMaskEventNotifier:
+		return "EventNotifier"
+	case NodeAttributesMaskExecutable:
+		return "Executable"
+	case NodeAttributesMaskHistorizing:
+		return "Historizing"
+	case NodeAttributesMaskInverseName:
+		return "InverseName"
+	case NodeAttributesMaskIsAbstract:
+		return "IsAbstract"
+	case NodeAttributesMaskMinimumSamplingInterval:
+		return "MinimumSamplingInterval"
+	case NodeAttributesMaskNodeClass:
+		return "NodeClass"
+	case NodeAttributesMaskNodeId:
+		return "NodeId"
+	case NodeAttributesMaskSymmetric:
+		return "Symmetric"
+	case NodeAttributesMaskUserAccessLevel:
+		return "UserAccessLevel"
+	case NodeAttributesMaskUserExecutable:
+		return "UserExecutable"
+	case NodeAttributesMaskUserWriteMask:
+		return "UserWriteMask"
+	case NodeAttributesMaskValueRank:
+		return "ValueRank"
+	case NodeAttributesMaskWriteMask:
+		return "WriteMask"
+	case NodeAttributesMaskValue:
+		return "Value"
+	case NodeAttributesMaskAll:
+		return "All"
+	case NodeAttributesMaskBaseNode:
+		return "BaseNode"
+	case NodeAttributesMaskObject:
+		return "Object"
+	case NodeAttributesMaskObjectTypeOrDataType:
+		return "ObjectTypeOrDataType"
+	case NodeAttributesMaskVariable:
+		return "Variable"
+	case NodeAttributesMaskVariableType:
+		return "VariableType"
+	case NodeAttributesMaskMethod:
+		return "Method"
+	case NodeAttributesMaskReferenceType:
+		return "ReferenceType"
+	case NodeAttributesMaskView:
+		return "View"
+	}
+	return strconv.FormatUint(uint64(t), 10)
+}
+
 type AttributeWriteMask uint32
 
 // Define bits used to indicate which attributes are writable.
@@ -179,6 +415,59 @@ const (
 	AttributeWriteMaskValueForVariableType    AttributeWriteMask = 2097152
 )
 
+// String returns the name of t, or its value if t is unknown.
+func (t AttributeWriteMask) String() string {
+	switch t {
+	case AttributeWriteMaskNone:
+		return "None"
+	case AttributeWriteMaskAccessLevel:
+		return "AccessLevel"
+	case AttributeWriteMaskArrayDimensions:
+		return "ArrayDimensions"
+	case AttributeWriteMaskBrowseName:
+		return "BrowseName"
+	case AttributeWriteMaskContainsNoLoops:
+		return "ContainsNoLoops"
+	case AttributeWriteMaskDataType:
+		return "DataType"
+	case AttributeWriteMaskDescription:
+		return "Description"
+	case AttributeWriteMaskDisplayName:
+		return "DisplayName"
+	case AttributeWriteMaskEventNotifier:
+		return "EventNotifier"
+	case AttributeWriteMaskExecutable:
+		return "Executable"
+	case AttributeWriteMaskHistorizing:
+		return "Historizing"
+	case AttributeWriteMaskInverseName:
+		return "InverseName"
+	case AttributeWriteMaskIsAbstract:
+		return "IsAbstract"
+	case AttributeWriteMaskMinimumSamplingInterval:
+		return "MinimumSamplingInterval"
+	case AttributeWriteMaskNodeClass:
+		return "NodeClass"
+	case AttributeWriteMaskNodeId:
+		return "NodeId"
+	case AttributeWriteMaskSymmetric:
+		return "Symmetric"
+	case AttributeWriteMaskUserAccessLevel:
+		return "UserAccessLevel"
+	case AttributeWriteMaskUserExecutable:
+		return "UserExecutable"
+	case AttributeWriteMaskUserWriteMask:
+		return "UserWriteMask"
+	case AttributeWriteMaskValueRank:
+		return "ValueRank"
+	case AttributeWriteMaskWriteMask:
+		return "WriteMask"
+	case AttributeWriteMaskValueForVariableType:
+		return "ValueForVariableType"
+	}
+	return strconv.FormatUint(uint64(t), 10)
+}
+
 type BrowseDirection uint32
 
 // The directions of the references to return.
@@ -189,6 +478,21 @@ const (
 	BrowseDirectionInvalid BrowseDirection = 3
 )
 
+// String returns the name of t, or its value if t is unknown.
+func (t BrowseDirection) String() string {
+	switch t {
+	case BrowseDirectionForward:
+		return "Forward"
+	case BrowseDirectionInverse:
+		return "Inverse"
+	case BrowseDirectionBoth:
+		return "Both"
+	case BrowseDirectionInvalid:
+		return "Invalid"
+	}
+	return strconv.FormatUint(uint64(t), 10)
+}
+
 type BrowseResultMask uint32
 
 // A bit mask which specifies what should be returned in a browse response.
@@ -205,6 +509,33 @@ const (
 	BrowseResultMaskTargetInfo        BrowseResultMask = 60
 )
 
+// String returns the name of t, or its value if t is unknown.
+func (t BrowseResultMask) String() string {
+	switch t {
+	case BrowseResultMaskNone:
+		return "None"
+	case BrowseResultMaskReferenceTypeId:
+		return "ReferenceTypeId"
+	case BrowseResultMaskIsForward:
+		return "IsForward"
+	case BrowseResultMaskNodeClass:
+		return "NodeClass"
+	case BrowseResultMaskBrowseName:
+		return "BrowseName"
+	case BrowseResultMaskDisplayName:
+		return "DisplayName"
+	case BrowseResultMaskTypeDefinition:
+		return "TypeDefinition"
+	case BrowseResultMaskAll:
+		return "All"
+	case BrowseResultMaskReferenceTypeInfo:
+		return "ReferenceTypeInfo"
+	case BrowseResultMaskTargetInfo:
+		return "TargetInfo"
+	}
+	return strconv.FormatUint(uint64(t), 10)
+}
+
 type FilterOperator uint32
 
 const (
@@ -228,6 +559,49 @@ const (
 	FilterOperatorBitwiseOr          FilterOperator = 17
 )
 
+// String returns the name of t, or its value if t is unknown.
+func (t FilterOperator) String() string {
+	switch t {
+	case FilterOperatorEquals:
+		return "Equals"
+	case FilterOperatorIsNull:
+		return "IsNull"
+	case FilterOperatorGreaterThan:
+		return "GreaterThan"
+	case FilterOperatorLessThan:
+		return "LessThan"
+	case FilterOperatorGreaterThanOrEqual:
+		return "GreaterThanOrEqual"
+	case FilterOperatorLessThanOrEqual:
+		return "LessThanOrEqual"
+	case FilterOperatorLike:
+		return "Like"
+	case FilterOperatorNot:
+		return "Not"
+	case FilterOperatorBetween:
+		return "Between"
+	case FilterOperatorInList:
+		return "InList"
+	case FilterOperatorAnd:
+		return "And"
+	case FilterOperatorOr:
+		return "Or"
+	case FilterOperatorCast:
+		return "Cast"
+	case FilterOperatorInView:
+		return "InView"
+	case FilterOperatorOfType:
+		return "OfType"
+	case FilterOperatorRelatedTo:
+		return "RelatedTo"
+	case FilterOperatorBitwiseAnd:
+		return "BitwiseAnd"
+	case FilterOperatorBitwiseOr:
+		return "BitwiseOr"
+	}
+	return strconv.FormatUint(uint64(t), 10)
+}
+
 type TimestampsToReturn uint32
 
 const (
@@ -238,6 +612,23 @@ const (
 	TimestampsToReturnInvalid TimestampsToReturn = 4
 )
 
+// String returns the name of t, or its value if t is unknown.
+func (t TimestampsToReturn) String() string {
+	switch t {
+	case TimestampsToReturnSource:
+		return "Source"
+	case TimestampsToReturnServer:
+		return "Server"
+	case TimestampsToReturnBoth:
+		return "Both"
+	case TimestampsToReturnNeither:
+		return "Neither"
+	case TimestampsToReturnInvalid:
+		return "Invalid"
+	}
+	return strconv.FormatUint(uint64(t), 10)
+}
+
 type HistoryUpdateType uint32
 
 const (
@@ -247,6 +638,21 @@ const (
 	HistoryUpdateTypeDelete  HistoryUpdateType = 4
 )
 
+// String returns the name of t, or its value if t is unknown.
+func (t HistoryUpdateType) String() string {
+	switch t {
+	case HistoryUpdateTypeInsert:
+		return "Insert"
+	case HistoryUpdateTypeReplace:
+		return "Replace"
+	case HistoryUpdateTypeUpdate:
+		return "Update"
+	case HistoryUpdateTypeDelete:
+		return "Delete"
+	}
+	return strconv.FormatUint(uint64(t), 10)
+}
+
 type PerformUpdateType uint32
 
 const (
@@ -256,6 +662,21 @@ const (
 	PerformUpdateTypeRemove  PerformUpdateType = 4
 )
 
+// String returns the name of t, or its value if t is unknown.
+func (t PerformUpdateType) String() string {
+	switch t {
+	case PerformUpdateTypeInsert:
+		return "Insert"
+	case PerformUpdateTypeReplace:
+		return "Replace"
+	case PerformUpdateTypeUpdate:
+		return "Update"
+	case PerformUpdateTypeRemove:
+		return "Remove"
+	}
+	return strconv.FormatUint(uint64(t), 10)
+}
+
 type MonitoringMode uint32
 
 const (
@@ -264,6 +685,19 @@ const (
 	MonitoringModeReporting MonitoringMode = 2
 )
 
+// String returns the name of t, or its value if t is unknown.
+func (t MonitoringMode) String() string {
+	switch t {
+	case MonitoringModeDisabled:
+		return "Disabled"
+	case MonitoringModeSampling:
+		return "Sampling"
+	case MonitoringModeReporting:
+		return "Reporting"
+	}
+	return strconv.FormatUint(uint64(t), 10)
+}
+
 type DataChangeTrigger uint32
 
 const (
@@ -272,6 +706,19 @@ const (
 	DataChangeTriggerStatusValueTimestamp DataChangeTrigger = 2
 )
 
+// String returns the name of t, or its value if t is unknown.
+func (t DataChangeTrigger) String() string {
+	switch t {
+	case DataChangeTriggerStatus:
+		return "Status"
+	case DataChangeTriggerStatusValue:
+		return "StatusValue"
+	case DataChangeTriggerStatusValueTimestamp:
+		return "StatusValueTimestamp"
+	}
+	return strconv.FormatUint(uint64(t), 10)
+}
+
 type DeadbandType uint32
 
 const (
@@ -280,6 +727,19 @@ const (
 	DeadbandTypePercent  DeadbandType = 2
 )
 
+// String returns the name of t, or its value if t is unknown.
+func (t DeadbandType) String() string {
+	switch t {
+	case DeadbandTypeNone:
+		return "None"
+	case DeadbandTypeAbsolute:
+		return "Absolute"
+	case DeadbandTypePercent:
+		return "Percent"
+	}
+	return strconv.FormatUint(uint64(t), 10)
+}
+
 type RedundancySupport uint32
 
 const (
@@ -291,6 +751,25 @@ const (
 	RedundancySupportHotAndMirrored RedundancySupport = 5
 )
 
+// String returns the name of t, or its value if t is unknown.
+func (t RedundancySupport) String() string {
+	switch t {
+	case RedundancySupportNone:
+		return "None"
+	case RedundancySupportCold:
+		return "Cold"
+	case RedundancySupportWarm:
+		return "Warm"
+	case RedundancySupportHot:
+		return "Hot"
+	case RedundancySupportTransparent:
+		return "Transparent"
+	case RedundancySupportHotAndMirrored:
+		return "HotAndMirrored"
+	}
+	return strconv.FormatUint(uint64(t), 10)
+}
+
 type ServerState uint32
 
 const (
@@ -304,6 +783,29 @@ const (
 	ServerStateUnknown            ServerState = 7
 )
 
+// String returns the name of t, or its value if t is unknown.
+func (t ServerState) String() string {
+	switch t {
+	case ServerStateRunning:
+		return "Running"
+	case ServerStateFailed:
+		return "Failed"
+	case ServerStateNoConfiguration:
+		return "NoConfiguration"
+	case ServerStateSuspended:
+		return "Suspended"
+	case ServerStateShutdown:
+		return "Shutdown"
+	case ServerStateTest:
+		return "Test"
+	case ServerStateCommunicationFault:
+		return "CommunicationFault"
+	case ServerStateUnknown:
+		return "Unknown"
+	}
+	return strconv.FormatUint(uint64(t), 10)
+}
+
 type ModelChangeStructureVerbMask uint32
 
 const (
@@ -314,6 +816,23 @@ const (
 	ModelChangeStructureVerbMaskDataTypeChanged  ModelChangeStructureVerbMask = 16
 )
 
+// String returns the name of t, or its value if t is unknown.
+func (t ModelChangeStructureVerbMask) String() string {
+	switch t {
+	case ModelChangeStructureVerbMaskNodeAdded:
+		return "NodeAdded"
+	case ModelChangeStructureVerbMaskNodeDeleted:
+		return "NodeDeleted"
+	case ModelChangeStructureVerbMaskReferenceAdded:
+		return "ReferenceAdded"
+	case ModelChangeStructureVerbMaskReferenceDeleted:
+		return "ReferenceDeleted"
+	case ModelChangeStructureVerbMaskDataTypeChanged:
+		return "DataTypeChanged"
+	}
+	return strconv.FormatUint(uint64(t), 10)
+}
+
 type AxisScaleEnumeration uint32
 
 const (
@@ -322,6 +841,19 @@ const (
 	AxisScaleEnumerationLn     AxisScaleEnumeration = 2
 )
 
+// String returns the name of t, or its value if t is unknown.
+func (t AxisScaleEnumeration) String() string {
+	switch t {
+	case AxisScaleEnumerationLinear:
+		return "Linear"
+	case AxisScaleEnumerationLog:
+		return "Log"
+	case AxisScaleEnumerationLn:
+		return "Ln"
+	}
+	return strconv.FormatUint(uint64(t), 10)
+}
+
 type ExceptionDeviationFormat uint32
 
 const (
@@ -332,6 +864,23 @@ const (
 	ExceptionDeviationFormatUnknown          ExceptionDeviationFormat = 4
 )
 
+// String returns the name of t, or its value if t is unknown.
+func (t ExceptionDeviationFormat) String() string {
+	switch t {
+	case ExceptionDeviationFormatAbsoluteValue:
+		return "AbsoluteValue"
+	case ExceptionDeviationFormatPercentOfValue:
+		return "PercentOfValue"
+	case ExceptionDeviationFormatPercentOfRange:
+		return "PercentOfRange"
+	case ExceptionDeviationFormatPercentOfEURange:
+		return "PercentOfEURange"
+	case ExceptionDeviationFormatUnknown:
+		return "Unknown"
+	}
+	return strconv.FormatUint(uint64(t), 10)
+}
+
 // XmlElement is an XML element encoded as a UTF-8 string.
 type XmlElement struct {
 	Length int32
@@ -370,11 +919,13 @@ type ByteStringNodeId struct {
 // NodeId is an identifier for a node in a UA server address space.
 type NodeId struct {
 	NodeIdType NodeIdType
-	Reserved1  byte             `opcua:"bits=2"`
-	TwoByte    TwoByteNodeId    `opcua:"switchField=NodeIdType,switchValue=0"`
-	FourByte   FourByteNodeId   `opcua:"switchField=NodeIdType,switchValue=1"`
-	Numeric    NumericNodeId    `opcua:"switchField=NodeIdType,switchValue=2"`
-	String     StringNodeId     `opcua:"switchField=NodeIdType,switchValue=3"`
+	Reserved1  byte           `opcua:"bits=2"`
+	TwoByte    TwoByteNodeId  `opcua:"switchField=NodeIdType,switchValue=0"`
+	FourByte   FourByteNodeId `opcua:"switchField=NodeIdType,switchValue=1"`
+	Numeric    NumericNodeId  `opcua:"switchField=NodeIdType,switchValue=2"`
+	// StringID holds the string identifier of String NodeIds. It's named
+	// String in the schema, which collides with the String method.
+	StringID   StringNodeId     `opcua:"switchField=NodeIdType,switchValue=3"`
 	Guid       GuidNodeId       `opcua:"switchField=NodeIdType,switchValue=4"`
 	ByteString ByteStringNodeId `opcua:"switchField=NodeIdType,switchValue=5"`
 }
@@ -384,14 +935,16 @@ type ExpandedNodeId struct {
 	NodeIdType            NodeIdType
 	ServerIndexSpecified  Bit
 	NamespaceURISpecified Bit
-	TwoByte               TwoByteNodeId    `opcua:"switchField=NodeIdType,switchValue=0"`
-	FourByte              FourByteNodeId   `opcua:"switchField=NodeIdType,switchValue=1"`
-	Numeric               NumericNodeId    `opcua:"switchField=NodeIdType,switchValue=2"`
-	String                StringNodeId     `opcua:"switchField=NodeIdType,switchValue=3"`
-	Guid                  GuidNodeId       `opcua:"switchField=NodeIdType,switchValue=4"`
-	ByteString            ByteStringNodeId `opcua:"switchField=NodeIdType,switchValue=5"`
-	NamespaceURI          string           `opcua:"switchField=NamespaceURISpecified"`
-	ServerIndex           uint32           `opcua:"switchField=ServerIndexSpecified"`
+	TwoByte               TwoByteNodeId  `opcua:"switchField=NodeIdType,switchValue=0"`
+	FourByte              FourByteNodeId `opcua:"switchField=NodeIdType,switchValue=1"`
+	Numeric               NumericNodeId  `opcua:"switchField=NodeIdType,switchValue=2"`
+	// StringID holds the string identifier of String NodeIds. It's named
+	// String in the schema, which collides with the String method.
+	StringID     StringNodeId     `opcua:"switchField=NodeIdType,switchValue=3"`
+	Guid         GuidNodeId       `opcua:"switchField=NodeIdType,switchValue=4"`
+	ByteString   ByteStringNodeId `opcua:"switchField=NodeIdType,switchValue=5"`
+	NamespaceURI string           `opcua:"switchField=NamespaceURISpecified"`
+	ServerIndex  uint32           `opcua:"switchField=ServerIndexSpecified"`
 }
 
 // DiagnosticInfo is a recursive structure containing diagnostic information associated with a status code.
@@ -451,6 +1004,14 @@ type ExtensionObject struct {
 	Encoding   uint8
 	BodyLength int32   `opcua:"switchField=Encoding,switchValue=0,switchOperand=NotEqual"`
 	Body       []uint8 `opcua:"lengthField=BodyLength,switchField=Encoding,switchValue=0,switchOperand=NotEqual"`
+
+	// Value holds the decoded Body of registered types, and may be set
+	// instead of Body when encoding. See RegisterExtensionObject.
+	Value interface{} `opcua:"-"`
+
+	// ValueError is set by binary Decoders when the Body of a registered
+	// type can not be decoded. Value is then nil, and Body is kept as is.
+	ValueError error `opcua:"-"`
 }
 
 // Variant is a union of several types.
@@ -486,6 +1047,11 @@ type Variant struct {
 	DiagnosticInfo           []DiagnosticInfo  `opcua:"lengthField=ArrayLength,switchField=VariantType,switchValue=25"`
 	NoOfArrayDimensions      int32             `opcua:"switchField=ArrayDimensionsSpecified"`
 	ArrayDimensions          []int32           `opcua:"lengthField=NoOfArrayDimensions,switchField=ArrayDimensionsSpecified"`
+
+	// StringNull marks the String values that are null. It's only
+	// encoded and decoded by binary Encoders and Decoders that preserve
+	// null values. See NewVariant and NullStringValue.
+	StringNull []bool `opcua:"-"`
 }
 
 type TrustListDataType struct {
@@ -2400,3 +2966,475 @@ type Annotation struct {
 	UserName       string
 	AnnotationTime time.Time
 }
+
+func init() {
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdTrustListDataType_Encoding_DefaultBinary), TrustListDataType{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdTrustListDataType_Encoding_DefaultXml), TrustListDataType{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdNode_Encoding_DefaultBinary), Node{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdNode_Encoding_DefaultXml), Node{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdInstanceNode_Encoding_DefaultBinary), InstanceNode{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdInstanceNode_Encoding_DefaultXml), InstanceNode{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdTypeNode_Encoding_DefaultBinary), TypeNode{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdTypeNode_Encoding_DefaultXml), TypeNode{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdObjectNode_Encoding_DefaultBinary), ObjectNode{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdObjectNode_Encoding_DefaultXml), ObjectNode{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdObjectTypeNode_Encoding_DefaultBinary), ObjectTypeNode{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdObjectTypeNode_Encoding_DefaultXml), ObjectTypeNode{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdVariableNode_Encoding_DefaultBinary), VariableNode{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdVariableNode_Encoding_DefaultXml), VariableNode{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdVariableTypeNode_Encoding_DefaultBinary), VariableTypeNode{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdVariableTypeNode_Encoding_DefaultXml), VariableTypeNode{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdReferenceTypeNode_Encoding_DefaultBinary), ReferenceTypeNode{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdReferenceTypeNode_Encoding_DefaultXml), ReferenceTypeNode{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdMethodNode_Encoding_DefaultBinary), MethodNode{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdMethodNode_Encoding_DefaultXml), MethodNode{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdViewNode_Encoding_DefaultBinary), ViewNode{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdViewNode_Encoding_DefaultXml), ViewNode{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdDataTypeNode_Encoding_DefaultBinary), DataTypeNode{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdDataTypeNode_Encoding_DefaultXml), DataTypeNode{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdReferenceNode_Encoding_DefaultBinary), ReferenceNode{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdReferenceNode_Encoding_DefaultXml), ReferenceNode{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdArgument_Encoding_DefaultBinary), Argument{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdArgument_Encoding_DefaultXml), Argument{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdEnumValueType_Encoding_DefaultBinary), EnumValueType{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdEnumValueType_Encoding_DefaultXml), EnumValueType{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdOptionSet_Encoding_DefaultBinary), OptionSet{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdOptionSet_Encoding_DefaultXml), OptionSet{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdUnion_Encoding_DefaultBinary), Union{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdUnion_Encoding_DefaultXml), Union{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdTimeZoneDataType_Encoding_DefaultBinary), TimeZoneDataType{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdTimeZoneDataType_Encoding_DefaultXml), TimeZoneDataType{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdApplicationDescription_Encoding_DefaultBinary), ApplicationDescription{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdApplicationDescription_Encoding_DefaultXml), ApplicationDescription{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdRequestHeader_Encoding_DefaultBinary), RequestHeader{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdRequestHeader_Encoding_DefaultXml), RequestHeader{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdResponseHeader_Encoding_DefaultBinary), ResponseHeader{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdResponseHeader_Encoding_DefaultXml), ResponseHeader{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdServiceFault_Encoding_DefaultBinary), ServiceFault{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdServiceFault_Encoding_DefaultXml), ServiceFault{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdFindServersRequest_Encoding_DefaultBinary), FindServersRequest{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdFindServersRequest_Encoding_DefaultXml), FindServersRequest{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdFindServersResponse_Encoding_DefaultBinary), FindServersResponse{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdFindServersResponse_Encoding_DefaultXml), FindServersResponse{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdServerOnNetwork_Encoding_DefaultBinary), ServerOnNetwork{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdServerOnNetwork_Encoding_DefaultXml), ServerOnNetwork{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdFindServersOnNetworkRequest_Encoding_DefaultBinary), FindServersOnNetworkRequest{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdFindServersOnNetworkRequest_Encoding_DefaultXml), FindServersOnNetworkRequest{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdFindServersOnNetworkResponse_Encoding_DefaultBinary), FindServersOnNetworkResponse{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdFindServersOnNetworkResponse_Encoding_DefaultXml), FindServersOnNetworkResponse{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdUserTokenPolicy_Encoding_DefaultBinary), UserTokenPolicy{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdUserTokenPolicy_Encoding_DefaultXml), UserTokenPolicy{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdEndpointDescription_Encoding_DefaultBinary), EndpointDescription{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdEndpointDescription_Encoding_DefaultXml), EndpointDescription{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdGetEndpointsRequest_Encoding_DefaultBinary), GetEndpointsRequest{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdGetEndpointsRequest_Encoding_DefaultXml), GetEndpointsRequest{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdGetEndpointsResponse_Encoding_DefaultBinary), GetEndpointsResponse{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdGetEndpointsResponse_Encoding_DefaultXml), GetEndpointsResponse{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdRegisteredServer_Encoding_DefaultBinary), RegisteredServer{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdRegisteredServer_Encoding_DefaultXml), RegisteredServer{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdRegisterServerRequest_Encoding_DefaultBinary), RegisterServerRequest{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdRegisterServerRequest_Encoding_DefaultXml), RegisterServerRequest{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdRegisterServerResponse_Encoding_DefaultBinary), RegisterServerResponse{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdRegisterServerResponse_Encoding_DefaultXml), RegisterServerResponse{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdDiscoveryConfiguration_Encoding_DefaultBinary), DiscoveryConfiguration{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdDiscoveryConfiguration_Encoding_DefaultXml), DiscoveryConfiguration{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdMdnsDiscoveryConfiguration_Encoding_DefaultBinary), MdnsDiscoveryConfiguration{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdMdnsDiscoveryConfiguration_Encoding_DefaultXml), MdnsDiscoveryConfiguration{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdRegisterServer2Request_Encoding_DefaultBinary), RegisterServer2Request{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdRegisterServer2Request_Encoding_DefaultXml), RegisterServer2Request{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdRegisterServer2Response_Encoding_DefaultBinary), RegisterServer2Response{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdRegisterServer2Response_Encoding_DefaultXml), RegisterServer2Response{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdChannelSecurityToken_Encoding_DefaultBinary), ChannelSecurityToken{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdChannelSecurityToken_Encoding_DefaultXml), ChannelSecurityToken{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdOpenSecureChannelRequest_Encoding_DefaultBinary), OpenSecureChannelRequest{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdOpenSecureChannelRequest_Encoding_DefaultXml), OpenSecureChannelRequest{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdOpenSecureChannelResponse_Encoding_DefaultBinary), OpenSecureChannelResponse{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdOpenSecureChannelResponse_Encoding_DefaultXml), OpenSecureChannelResponse{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdCloseSecureChannelRequest_Encoding_DefaultBinary), CloseSecureChannelRequest{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdCloseSecureChannelRequest_Encoding_DefaultXml), CloseSecureChannelRequest{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdCloseSecureChannelResponse_Encoding_DefaultBinary), CloseSecureChannelResponse{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdCloseSecureChannelResponse_Encoding_DefaultXml), CloseSecureChannelResponse{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdSignedSoftwareCertificate_Encoding_DefaultBinary), SignedSoftwareCertificate{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdSignedSoftwareCertificate_Encoding_DefaultXml), SignedSoftwareCertificate{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdSignatureData_Encoding_DefaultBinary), SignatureData{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdSignatureData_Encoding_DefaultXml), SignatureData{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdCreateSessionRequest_Encoding_DefaultBinary), CreateSessionRequest{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdCreateSessionRequest_Encoding_DefaultXml), CreateSessionRequest{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdCreateSessionResponse_Encoding_DefaultBinary), CreateSessionResponse{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdCreateSessionResponse_Encoding_DefaultXml), CreateSessionResponse{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdUserIdentityToken_Encoding_DefaultBinary), UserIdentityToken{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdUserIdentityToken_Encoding_DefaultXml), UserIdentityToken{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdAnonymousIdentityToken_Encoding_DefaultBinary), AnonymousIdentityToken{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdAnonymousIdentityToken_Encoding_DefaultXml), AnonymousIdentityToken{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdUserNameIdentityToken_Encoding_DefaultBinary), UserNameIdentityToken{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdUserNameIdentityToken_Encoding_DefaultXml), UserNameIdentityToken{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdX509IdentityToken_Encoding_DefaultBinary), X509IdentityToken{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdX509IdentityToken_Encoding_DefaultXml), X509IdentityToken{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdIssuedIdentityToken_Encoding_DefaultBinary), IssuedIdentityToken{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdIssuedIdentityToken_Encoding_DefaultXml), IssuedIdentityToken{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdActivateSessionRequest_Encoding_DefaultBinary), ActivateSessionRequest{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdActivateSessionRequest_Encoding_DefaultXml), ActivateSessionRequest{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdActivateSessionResponse_Encoding_DefaultBinary), ActivateSessionResponse{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdActivateSessionResponse_Encoding_DefaultXml), ActivateSessionResponse{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdCloseSessionRequest_Encoding_DefaultBinary), CloseSessionRequest{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdCloseSessionRequest_Encoding_DefaultXml), CloseSessionRequest{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdCloseSessionResponse_Encoding_DefaultBinary), CloseSessionResponse{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdCloseSessionResponse_Encoding_DefaultXml), CloseSessionResponse{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdCancelRequest_Encoding_DefaultBinary), CancelRequest{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdCancelRequest_Encoding_DefaultXml), CancelRequest{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdCancelResponse_Encoding_DefaultBinary), CancelResponse{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdCancelResponse_Encoding_DefaultXml), CancelResponse{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdNodeAttributes_Encoding_DefaultBinary), NodeAttributes{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdNodeAttributes_Encoding_DefaultXml), NodeAttributes{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdObjectAttributes_Encoding_DefaultBinary), ObjectAttributes{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdObjectAttributes_Encoding_DefaultXml), ObjectAttributes{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdVariableAttributes_Encoding_DefaultBinary), VariableAttributes{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdVariableAttributes_Encoding_DefaultXml), VariableAttributes{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdMethodAttributes_Encoding_DefaultBinary), MethodAttributes{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdMethodAttributes_Encoding_DefaultXml), MethodAttributes{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdObjectTypeAttributes_Encoding_DefaultBinary), ObjectTypeAttributes{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdObjectTypeAttributes_Encoding_DefaultXml), ObjectTypeAttributes{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdVariableTypeAttributes_Encoding_DefaultBinary), VariableTypeAttributes{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdVariableTypeAttributes_Encoding_DefaultXml), VariableTypeAttributes{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdReferenceTypeAttributes_Encoding_DefaultBinary), ReferenceTypeAttributes{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdReferenceTypeAttributes_Encoding_DefaultXml), ReferenceTypeAttributes{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdDataTypeAttributes_Encoding_DefaultBinary), DataTypeAttributes{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdDataTypeAttributes_Encoding_DefaultXml), DataTypeAttributes{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdViewAttributes_Encoding_DefaultBinary), ViewAttributes{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdViewAttributes_Encoding_DefaultXml), ViewAttributes{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdAddNodesItem_Encoding_DefaultBinary), AddNodesItem{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdAddNodesItem_Encoding_DefaultXml), AddNodesItem{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdAddNodesResult_Encoding_DefaultBinary), AddNodesResult{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdAddNodesResult_Encoding_DefaultXml), AddNodesResult{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdAddNodesRequest_Encoding_DefaultBinary), AddNodesRequest{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdAddNodesRequest_Encoding_DefaultXml), AddNodesRequest{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdAddNodesResponse_Encoding_DefaultBinary), AddNodesResponse{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdAddNodesResponse_Encoding_DefaultXml), AddNodesResponse{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdAddReferencesItem_Encoding_DefaultBinary), AddReferencesItem{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdAddReferencesItem_Encoding_DefaultXml), AddReferencesItem{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdAddReferencesRequest_Encoding_DefaultBinary), AddReferencesRequest{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdAddReferencesRequest_Encoding_DefaultXml), AddReferencesRequest{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdAddReferencesResponse_Encoding_DefaultBinary), AddReferencesResponse{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdAddReferencesResponse_Encoding_DefaultXml), AddReferencesResponse{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdDeleteNodesItem_Encoding_DefaultBinary), DeleteNodesItem{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdDeleteNodesItem_Encoding_DefaultXml), DeleteNodesItem{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdDeleteNodesRequest_Encoding_DefaultBinary), DeleteNodesRequest{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdDeleteNodesRequest_Encoding_DefaultXml), DeleteNodesRequest{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdDeleteNodesResponse_Encoding_DefaultBinary), DeleteNodesResponse{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdDeleteNodesResponse_Encoding_DefaultXml), DeleteNodesResponse{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdDeleteReferencesItem_Encoding_DefaultBinary), DeleteReferencesItem{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdDeleteReferencesItem_Encoding_DefaultXml), DeleteReferencesItem{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdDeleteReferencesRequest_Encoding_DefaultBinary), DeleteReferencesRequest{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdDeleteReferencesRequest_Encoding_DefaultXml), DeleteReferencesRequest{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdDeleteReferencesResponse_Encoding_DefaultBinary), DeleteReferencesResponse{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdDeleteReferencesResponse_Encoding_DefaultXml), DeleteReferencesResponse{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdViewDescription_Encoding_DefaultBinary), ViewDescription{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdViewDescription_Encoding_DefaultXml), ViewDescription{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdBrowseDescription_Encoding_DefaultBinary), BrowseDescription{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdBrowseDescription_Encoding_DefaultXml), BrowseDescription{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdReferenceDescription_Encoding_DefaultBinary), ReferenceDescription{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdReferenceDescription_Encoding_DefaultXml), ReferenceDescription{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdBrowseResult_Encoding_DefaultBinary), BrowseResult{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdBrowseResult_Encoding_DefaultXml), BrowseResult{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdBrowseRequest_Encoding_DefaultBinary), BrowseRequest{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdBrowseRequest_Encoding_DefaultXml), BrowseRequest{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdBrowseResponse_Encoding_DefaultBinary), BrowseResponse{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdBrowseResponse_Encoding_DefaultXml), BrowseResponse{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdBrowseNextRequest_Encoding_DefaultBinary), BrowseNextRequest{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdBrowseNextRequest_Encoding_DefaultXml), BrowseNextRequest{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdBrowseNextResponse_Encoding_DefaultBinary), BrowseNextResponse{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdBrowseNextResponse_Encoding_DefaultXml), BrowseNextResponse{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdRelativePathElement_Encoding_DefaultBinary), RelativePathElement{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdRelativePathElement_Encoding_DefaultXml), RelativePathElement{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdRelativePath_Encoding_DefaultBinary), RelativePath{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdRelativePath_Encoding_DefaultXml), RelativePath{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdBrowsePath_Encoding_DefaultBinary), BrowsePath{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdBrowsePath_Encoding_DefaultXml), BrowsePath{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdBrowsePathTarget_Encoding_DefaultBinary), BrowsePathTarget{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdBrowsePathTarget_Encoding_DefaultXml), BrowsePathTarget{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdBrowsePathResult_Encoding_DefaultBinary), BrowsePathResult{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdBrowsePathResult_Encoding_DefaultXml), BrowsePathResult{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdTranslateBrowsePathsToNodeIdsRequest_Encoding_DefaultBinary), TranslateBrowsePathsToNodeIdsRequest{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdTranslateBrowsePathsToNodeIdsRequest_Encoding_DefaultXml), TranslateBrowsePathsToNodeIdsRequest{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdTranslateBrowsePathsToNodeIdsResponse_Encoding_DefaultBinary), TranslateBrowsePathsToNodeIdsResponse{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdTranslateBrowsePathsToNodeIdsResponse_Encoding_DefaultXml), TranslateBrowsePathsToNodeIdsResponse{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdRegisterNodesRequest_Encoding_DefaultBinary), RegisterNodesRequest{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdRegisterNodesRequest_Encoding_DefaultXml), RegisterNodesRequest{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdRegisterNodesResponse_Encoding_DefaultBinary), RegisterNodesResponse{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdRegisterNodesResponse_Encoding_DefaultXml), RegisterNodesResponse{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdUnregisterNodesRequest_Encoding_DefaultBinary), UnregisterNodesRequest{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdUnregisterNodesRequest_Encoding_DefaultXml), UnregisterNodesRequest{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdUnregisterNodesResponse_Encoding_DefaultBinary), UnregisterNodesResponse{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdUnregisterNodesResponse_Encoding_DefaultXml), UnregisterNodesResponse{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdEndpointConfiguration_Encoding_DefaultBinary), EndpointConfiguration{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdEndpointConfiguration_Encoding_DefaultXml), EndpointConfiguration{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdQueryDataDescription_Encoding_DefaultBinary), QueryDataDescription{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdQueryDataDescription_Encoding_DefaultXml), QueryDataDescription{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdNodeTypeDescription_Encoding_DefaultBinary), NodeTypeDescription{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdNodeTypeDescription_Encoding_DefaultXml), NodeTypeDescription{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdQueryDataSet_Encoding_DefaultBinary), QueryDataSet{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdQueryDataSet_Encoding_DefaultXml), QueryDataSet{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdNodeReference_Encoding_DefaultBinary), NodeReference{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdNodeReference_Encoding_DefaultXml), NodeReference{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdContentFilterElement_Encoding_DefaultBinary), ContentFilterElement{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdContentFilterElement_Encoding_DefaultXml), ContentFilterElement{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdContentFilter_Encoding_DefaultBinary), ContentFilter{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdContentFilter_Encoding_DefaultXml), ContentFilter{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdFilterOperand_Encoding_DefaultBinary), FilterOperand{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdFilterOperand_Encoding_DefaultXml), FilterOperand{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdElementOperand_Encoding_DefaultBinary), ElementOperand{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdElementOperand_Encoding_DefaultXml), ElementOperand{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdLiteralOperand_Encoding_DefaultBinary), LiteralOperand{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdLiteralOperand_Encoding_DefaultXml), LiteralOperand{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdAttributeOperand_Encoding_DefaultBinary), AttributeOperand{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdAttributeOperand_Encoding_DefaultXml), AttributeOperand{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdSimpleAttributeOperand_Encoding_DefaultBinary), SimpleAttributeOperand{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdSimpleAttributeOperand_Encoding_DefaultXml), SimpleAttributeOperand{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdContentFilterElementResult_Encoding_DefaultBinary), ContentFilterElementResult{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdContentFilterElementResult_Encoding_DefaultXml), ContentFilterElementResult{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdContentFilterResult_Encoding_DefaultBinary), ContentFilterResult{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdContentFilterResult_Encoding_DefaultXml), ContentFilterResult{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdParsingResult_Encoding_DefaultBinary), ParsingResult{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdParsingResult_Encoding_DefaultXml), ParsingResult{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdQueryFirstRequest_Encoding_DefaultBinary), QueryFirstRequest{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdQueryFirstRequest_Encoding_DefaultXml), QueryFirstRequest{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdQueryFirstResponse_Encoding_DefaultBinary), QueryFirstResponse{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdQueryFirstResponse_Encoding_DefaultXml), QueryFirstResponse{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdQueryNextRequest_Encoding_DefaultBinary), QueryNextRequest{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdQueryNextRequest_Encoding_DefaultXml), QueryNextRequest{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdQueryNextResponse_Encoding_DefaultBinary), QueryNextResponse{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdQueryNextResponse_Encoding_DefaultXml), QueryNextResponse{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdReadValueId_Encoding_DefaultBinary), ReadValueId{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdReadValueId_Encoding_DefaultXml), ReadValueId{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdReadRequest_Encoding_DefaultBinary), ReadRequest{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdReadRequest_Encoding_DefaultXml), ReadRequest{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdReadResponse_Encoding_DefaultBinary), ReadResponse{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdReadResponse_Encoding_DefaultXml), ReadResponse{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdHistoryReadValueId_Encoding_DefaultBinary), HistoryReadValueId{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdHistoryReadValueId_Encoding_DefaultXml), HistoryReadValueId{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdHistoryReadResult_Encoding_DefaultBinary), HistoryReadResult{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdHistoryReadResult_Encoding_DefaultXml), HistoryReadResult{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdHistoryReadDetails_Encoding_DefaultBinary), HistoryReadDetails{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdHistoryReadDetails_Encoding_DefaultXml), HistoryReadDetails{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdReadEventDetails_Encoding_DefaultBinary), ReadEventDetails{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdReadEventDetails_Encoding_DefaultXml), ReadEventDetails{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdReadRawModifiedDetails_Encoding_DefaultBinary), ReadRawModifiedDetails{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdReadRawModifiedDetails_Encoding_DefaultXml), ReadRawModifiedDetails{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdReadProcessedDetails_Encoding_DefaultBinary), ReadProcessedDetails{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdReadProcessedDetails_Encoding_DefaultXml), ReadProcessedDetails{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdReadAtTimeDetails_Encoding_DefaultBinary), ReadAtTimeDetails{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdReadAtTimeDetails_Encoding_DefaultXml), ReadAtTimeDetails{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdHistoryData_Encoding_DefaultBinary), HistoryData{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdHistoryData_Encoding_DefaultXml), HistoryData{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdModificationInfo_Encoding_DefaultBinary), ModificationInfo{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdModificationInfo_Encoding_DefaultXml), ModificationInfo{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdHistoryModifiedData_Encoding_DefaultBinary), HistoryModifiedData{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdHistoryModifiedData_Encoding_DefaultXml), HistoryModifiedData{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdHistoryEvent_Encoding_DefaultBinary), HistoryEvent{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdHistoryEvent_Encoding_DefaultXml), HistoryEvent{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdHistoryReadRequest_Encoding_DefaultBinary), HistoryReadRequest{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdHistoryReadRequest_Encoding_DefaultXml), HistoryReadRequest{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdHistoryReadResponse_Encoding_DefaultBinary), HistoryReadResponse{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdHistoryReadResponse_Encoding_DefaultXml), HistoryReadResponse{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdWriteValue_Encoding_DefaultBinary), WriteValue{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdWriteValue_Encoding_DefaultXml), WriteValue{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdWriteRequest_Encoding_DefaultBinary), WriteRequest{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdWriteRequest_Encoding_DefaultXml), WriteRequest{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdWriteResponse_Encoding_DefaultBinary), WriteResponse{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdWriteResponse_Encoding_DefaultXml), WriteResponse{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdHistoryUpdateDetails_Encoding_DefaultBinary), HistoryUpdateDetails{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdHistoryUpdateDetails_Encoding_DefaultXml), HistoryUpdateDetails{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdUpdateDataDetails_Encoding_DefaultBinary), UpdateDataDetails{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdUpdateDataDetails_Encoding_DefaultXml), UpdateDataDetails{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdUpdateStructureDataDetails_Encoding_DefaultBinary), UpdateStructureDataDetails{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdUpdateStructureDataDetails_Encoding_DefaultXml), UpdateStructureDataDetails{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdUpdateEventDetails_Encoding_DefaultBinary), UpdateEventDetails{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdUpdateEventDetails_Encoding_DefaultXml), UpdateEventDetails{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdDeleteRawModifiedDetails_Encoding_DefaultBinary), DeleteRawModifiedDetails{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdDeleteRawModifiedDetails_Encoding_DefaultXml), DeleteRawModifiedDetails{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdDeleteAtTimeDetails_Encoding_DefaultBinary), DeleteAtTimeDetails{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdDeleteAtTimeDetails_Encoding_DefaultXml), DeleteAtTimeDetails{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdDeleteEventDetails_Encoding_DefaultBinary), DeleteEventDetails{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdDeleteEventDetails_Encoding_DefaultXml), DeleteEventDetails{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdHistoryUpdateResult_Encoding_DefaultBinary), HistoryUpdateResult{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdHistoryUpdateResult_Encoding_DefaultXml), HistoryUpdateResult{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdHistoryUpdateRequest_Encoding_DefaultBinary), HistoryUpdateRequest{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdHistoryUpdateRequest_Encoding_DefaultXml), HistoryUpdateRequest{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdHistoryUpdateResponse_Encoding_DefaultBinary), HistoryUpdateResponse{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdHistoryUpdateResponse_Encoding_DefaultXml), HistoryUpdateResponse{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdCallMethodRequest_Encoding_DefaultBinary), CallMethodRequest{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdCallMethodRequest_Encoding_DefaultXml), CallMethodRequest{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdCallMethodResult_Encoding_DefaultBinary), CallMethodResult{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdCallMethodResult_Encoding_DefaultXml), CallMethodResult{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdCallRequest_Encoding_DefaultBinary), CallRequest{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdCallRequest_Encoding_DefaultXml), CallRequest{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdCallResponse_Encoding_DefaultBinary), CallResponse{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdCallResponse_Encoding_DefaultXml), CallResponse{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdMonitoringFilter_Encoding_DefaultBinary), MonitoringFilter{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdMonitoringFilter_Encoding_DefaultXml), MonitoringFilter{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdDataChangeFilter_Encoding_DefaultBinary), DataChangeFilter{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdDataChangeFilter_Encoding_DefaultXml), DataChangeFilter{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdEventFilter_Encoding_DefaultBinary), EventFilter{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdEventFilter_Encoding_DefaultXml), EventFilter{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdAggregateConfiguration_Encoding_DefaultBinary), AggregateConfiguration{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdAggregateConfiguration_Encoding_DefaultXml), AggregateConfiguration{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdAggregateFilter_Encoding_DefaultBinary), AggregateFilter{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdAggregateFilter_Encoding_DefaultXml), AggregateFilter{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdMonitoringFilterResult_Encoding_DefaultBinary), MonitoringFilterResult{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdMonitoringFilterResult_Encoding_DefaultXml), MonitoringFilterResult{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdEventFilterResult_Encoding_DefaultBinary), EventFilterResult{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdEventFilterResult_Encoding_DefaultXml), EventFilterResult{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdAggregateFilterResult_Encoding_DefaultBinary), AggregateFilterResult{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdAggregateFilterResult_Encoding_DefaultXml), AggregateFilterResult{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdMonitoringParameters_Encoding_DefaultBinary), MonitoringParameters{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdMonitoringParameters_Encoding_DefaultXml), MonitoringParameters{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdMonitoredItemCreateRequest_Encoding_DefaultBinary), MonitoredItemCreateRequest{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdMonitoredItemCreateRequest_Encoding_DefaultXml), MonitoredItemCreateRequest{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdMonitoredItemCreateResult_Encoding_DefaultBinary), MonitoredItemCreateResult{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdMonitoredItemCreateResult_Encoding_DefaultXml), MonitoredItemCreateResult{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdCreateMonitoredItemsRequest_Encoding_DefaultBinary), CreateMonitoredItemsRequest{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdCreateMonitoredItemsRequest_Encoding_DefaultXml), CreateMonitoredItemsRequest{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdCreateMonitoredItemsResponse_Encoding_DefaultBinary), CreateMonitoredItemsResponse{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdCreateMonitoredItemsResponse_Encoding_DefaultXml), CreateMonitoredItemsResponse{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdMonitoredItemModifyRequest_Encoding_DefaultBinary), MonitoredItemModifyRequest{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdMonitoredItemModifyRequest_Encoding_DefaultXml), MonitoredItemModifyRequest{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdMonitoredItemModifyResult_Encoding_DefaultBinary), MonitoredItemModifyResult{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdMonitoredItemModifyResult_Encoding_DefaultXml), MonitoredItemModifyResult{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdModifyMonitoredItemsRequest_Encoding_DefaultBinary), ModifyMonitoredItemsRequest{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdModifyMonitoredItemsRequest_Encoding_DefaultXml), ModifyMonitoredItemsRequest{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdModifyMonitoredItemsResponse_Encoding_DefaultBinary), ModifyMonitoredItemsResponse{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdModifyMonitoredItemsResponse_Encoding_DefaultXml), ModifyMonitoredItemsResponse{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdSetMonitoringModeRequest_Encoding_DefaultBinary), SetMonitoringModeRequest{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdSetMonitoringModeRequest_Encoding_DefaultXml), SetMonitoringModeRequest{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdSetMonitoringModeResponse_Encoding_DefaultBinary), SetMonitoringModeResponse{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdSetMonitoringModeResponse_Encoding_DefaultXml), SetMonitoringModeResponse{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdSetTriggeringRequest_Encoding_DefaultBinary), SetTriggeringRequest{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdSetTriggeringRequest_Encoding_DefaultXml), SetTriggeringRequest{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdSetTriggeringResponse_Encoding_DefaultBinary), SetTriggeringResponse{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdSetTriggeringResponse_Encoding_DefaultXml), SetTriggeringResponse{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdDeleteMonitoredItemsRequest_Encoding_DefaultBinary), DeleteMonitoredItemsRequest{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdDeleteMonitoredItemsRequest_Encoding_DefaultXml), DeleteMonitoredItemsRequest{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdDeleteMonitoredItemsResponse_Encoding_DefaultBinary), DeleteMonitoredItemsResponse{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdDeleteMonitoredItemsResponse_Encoding_DefaultXml), DeleteMonitoredItemsResponse{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdCreateSubscriptionRequest_Encoding_DefaultBinary), CreateSubscriptionRequest{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdCreateSubscriptionRequest_Encoding_DefaultXml), CreateSubscriptionRequest{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdCreateSubscriptionResponse_Encoding_DefaultBinary), CreateSubscriptionResponse{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdCreateSubscriptionResponse_Encoding_DefaultXml), CreateSubscriptionResponse{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdModifySubscriptionRequest_Encoding_DefaultBinary), ModifySubscriptionRequest{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdModifySubscriptionRequest_Encoding_DefaultXml), ModifySubscriptionRequest{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdModifySubscriptionResponse_Encoding_DefaultBinary), ModifySubscriptionResponse{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdModifySubscriptionResponse_Encoding_DefaultXml), ModifySubscriptionResponse{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdSetPublishingModeRequest_Encoding_DefaultBinary), SetPublishingModeRequest{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdSetPublishingModeRequest_Encoding_DefaultXml), SetPublishingModeRequest{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdSetPublishingModeResponse_Encoding_DefaultBinary), SetPublishingModeResponse{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdSetPublishingModeResponse_Encoding_DefaultXml), SetPublishingModeResponse{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdNotificationMessage_Encoding_DefaultBinary), NotificationMessage{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdNotificationMessage_Encoding_DefaultXml), NotificationMessage{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdNotificationData_Encoding_DefaultBinary), NotificationData{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdNotificationData_Encoding_DefaultXml), NotificationData{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdDataChangeNotification_Encoding_DefaultBinary), DataChangeNotification{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdDataChangeNotification_Encoding_DefaultXml), DataChangeNotification{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdMonitoredItemNotification_Encoding_DefaultBinary), MonitoredItemNotification{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdMonitoredItemNotification_Encoding_DefaultXml), MonitoredItemNotification{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdEventNotificationList_Encoding_DefaultBinary), EventNotificationList{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdEventNotificationList_Encoding_DefaultXml), EventNotificationList{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdEventFieldList_Encoding_DefaultBinary), EventFieldList{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdEventFieldList_Encoding_DefaultXml), EventFieldList{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdHistoryEventFieldList_Encoding_DefaultBinary), HistoryEventFieldList{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdHistoryEventFieldList_Encoding_DefaultXml), HistoryEventFieldList{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdStatusChangeNotification_Encoding_DefaultBinary), StatusChangeNotification{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdStatusChangeNotification_Encoding_DefaultXml), StatusChangeNotification{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdSubscriptionAcknowledgement_Encoding_DefaultBinary), SubscriptionAcknowledgement{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdSubscriptionAcknowledgement_Encoding_DefaultXml), SubscriptionAcknowledgement{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdPublishRequest_Encoding_DefaultBinary), PublishRequest{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdPublishRequest_Encoding_DefaultXml), PublishRequest{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdPublishResponse_Encoding_DefaultBinary), PublishResponse{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdPublishResponse_Encoding_DefaultXml), PublishResponse{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdRepublishRequest_Encoding_DefaultBinary), RepublishRequest{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdRepublishRequest_Encoding_DefaultXml), RepublishRequest{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdRepublishResponse_Encoding_DefaultBinary), RepublishResponse{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdRepublishResponse_Encoding_DefaultXml), RepublishResponse{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdTransferResult_Encoding_DefaultBinary), TransferResult{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdTransferResult_Encoding_DefaultXml), TransferResult{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdTransferSubscriptionsRequest_Encoding_DefaultBinary), TransferSubscriptionsRequest{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdTransferSubscriptionsRequest_Encoding_DefaultXml), TransferSubscriptionsRequest{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdTransferSubscriptionsResponse_Encoding_DefaultBinary), TransferSubscriptionsResponse{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdTransferSubscriptionsResponse_Encoding_DefaultXml), TransferSubscriptionsResponse{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdDeleteSubscriptionsRequest_Encoding_DefaultBinary), DeleteSubscriptionsRequest{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdDeleteSubscriptionsRequest_Encoding_DefaultXml), DeleteSubscriptionsRequest{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdDeleteSubscriptionsResponse_Encoding_DefaultBinary), DeleteSubscriptionsResponse{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdDeleteSubscriptionsResponse_Encoding_DefaultXml), DeleteSubscriptionsResponse{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdBuildInfo_Encoding_DefaultBinary), BuildInfo{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdBuildInfo_Encoding_DefaultXml), BuildInfo{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdRedundantServerDataType_Encoding_DefaultBinary), RedundantServerDataType{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdRedundantServerDataType_Encoding_DefaultXml), RedundantServerDataType{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdEndpointUrlListDataType_Encoding_DefaultBinary), EndpointUrlListDataType{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdEndpointUrlListDataType_Encoding_DefaultXml), EndpointUrlListDataType{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdNetworkGroupDataType_Encoding_DefaultBinary), NetworkGroupDataType{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdNetworkGroupDataType_Encoding_DefaultXml), NetworkGroupDataType{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdSamplingIntervalDiagnosticsDataType_Encoding_DefaultBinary), SamplingIntervalDiagnosticsDataType{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdSamplingIntervalDiagnosticsDataType_Encoding_DefaultXml), SamplingIntervalDiagnosticsDataType{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdServerDiagnosticsSummaryDataType_Encoding_DefaultBinary), ServerDiagnosticsSummaryDataType{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdServerDiagnosticsSummaryDataType_Encoding_DefaultXml), ServerDiagnosticsSummaryDataType{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdServerStatusDataType_Encoding_DefaultBinary), ServerStatusDataType{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdServerStatusDataType_Encoding_DefaultXml), ServerStatusDataType{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdSessionDiagnosticsDataType_Encoding_DefaultBinary), SessionDiagnosticsDataType{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdSessionDiagnosticsDataType_Encoding_DefaultXml), SessionDiagnosticsDataType{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdSessionSecurityDiagnosticsDataType_Encoding_DefaultBinary), SessionSecurityDiagnosticsDataType{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdSessionSecurityDiagnosticsDataType_Encoding_DefaultXml), SessionSecurityDiagnosticsDataType{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdServiceCounterDataType_Encoding_DefaultBinary), ServiceCounterDataType{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdServiceCounterDataType_Encoding_DefaultXml), ServiceCounterDataType{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdStatusResult_Encoding_DefaultBinary), StatusResult{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdStatusResult_Encoding_DefaultXml), StatusResult{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdSubscriptionDiagnosticsDataType_Encoding_DefaultBinary), SubscriptionDiagnosticsDataType{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdSubscriptionDiagnosticsDataType_Encoding_DefaultXml), SubscriptionDiagnosticsDataType{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdModelChangeStructureDataType_Encoding_DefaultBinary), ModelChangeStructureDataType{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdModelChangeStructureDataType_Encoding_DefaultXml), ModelChangeStructureDataType{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdSemanticChangeStructureDataType_Encoding_DefaultBinary), SemanticChangeStructureDataType{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdSemanticChangeStructureDataType_Encoding_DefaultXml), SemanticChangeStructureDataType{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdRange_Encoding_DefaultBinary), Range{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdRange_Encoding_DefaultXml), Range{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdEUInformation_Encoding_DefaultBinary), EUInformation{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdEUInformation_Encoding_DefaultXml), EUInformation{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdComplexNumberType_Encoding_DefaultBinary), ComplexNumberType{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdComplexNumberType_Encoding_DefaultXml), ComplexNumberType{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdDoubleComplexNumberType_Encoding_DefaultBinary), DoubleComplexNumberType{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdDoubleComplexNumberType_Encoding_DefaultXml), DoubleComplexNumberType{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdAxisInformation_Encoding_DefaultBinary), AxisInformation{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdAxisInformation_Encoding_DefaultXml), AxisInformation{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdXVType_Encoding_DefaultBinary), XVType{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdXVType_Encoding_DefaultXml), XVType{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdProgramDiagnosticDataType_Encoding_DefaultBinary), ProgramDiagnosticDataType{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdProgramDiagnosticDataType_Encoding_DefaultXml), ProgramDiagnosticDataType{})
+	RegisterExtensionObject(NewFourByteNodeID(0, NodeIdAnnotation_Encoding_DefaultBinary), Annotation{})
+	RegisterExtensionObjectXML(NewFourByteNodeID(0, NodeIdAnnotation_Encoding_DefaultXml), Annotation{})
+}
+
+// enumDataTypes lists the Enumeration DataTypes of the OPC UA namespace.
+var enumDataTypes = map[uint16]bool{
+	NodeIdNamingRuleType:               true,
+	NodeIdOpenFileMode:                 true,
+	NodeIdTrustListMasks:               true,
+	NodeIdIdType:                       true,
+	NodeIdNodeClass:                    true,
+	NodeIdApplicationType:              true,
+	NodeIdMessageSecurityMode:          true,
+	NodeIdUserTokenType:                true,
+	NodeIdSecurityTokenRequestType:     true,
+	NodeIdNodeAttributesMask:           true,
+	NodeIdAttributeWriteMask:           true,
+	NodeIdBrowseDirection:              true,
+	NodeIdBrowseResultMask:             true,
+	NodeIdFilterOperator:               true,
+	NodeIdTimestampsToReturn:           true,
+	NodeIdHistoryUpdateType:            true,
+	NodeIdPerformUpdateType:            true,
+	NodeIdMonitoringMode:               true,
+	NodeIdDataChangeTrigger:            true,
+	NodeIdDeadbandType:                 true,
+	NodeIdRedundancySupport:            true,
+	NodeIdServerState:                  true,
+	NodeIdModelChangeStructureVerbMask: true,
+	NodeIdAxisScaleEnumeration:         true,
+	NodeIdExceptionDeviationFormat:     true,
+}
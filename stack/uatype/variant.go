@@ -0,0 +1,479 @@
+package uatype
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// MaxVariantArrayLength is the maximum number of elements that a Variant array
+// may describe through its ArrayLength and ArrayDimensions. It guards against
+// allocating huge (nested) slices from corrupt or hostile input. Decoders
+// may be limited further through their MaxArrayLength limit.
+const MaxVariantArrayLength = 16 * 1024 * 1024
+
+// Errors returned when a Variant can not be created or interpreted.
+var (
+	ErrVariantType         = errors.New("type can not be stored in a Variant")
+	ErrVariantRagged       = errors.New("nested slices must not be ragged")
+	ErrVariantDimensions   = errors.New("array dimensions do not match array length")
+	ErrVariantArrayTooLong = errors.New("array length exceeds MaxVariantArrayLength")
+	ErrVariantRange        = errors.New("value out of range for Variant type")
+)
+
+// variantFields maps VariantType values to the index of the matching slice
+// field in the Variant struct, while variantTypeIDs maps the slice element
+// types back to a VariantType. Both are initialized from the struct tags of
+// the generated Variant struct.
+var (
+	variantFields  = map[byte]int{}
+	variantTypeIDs = map[reflect.Type]byte{}
+)
+
+// variantTypeString is the VariantType of the String type.
+const variantTypeString = 12
+
+var (
+	stringType     = reflect.TypeOf("")
+	nullStringType = reflect.TypeOf(NullString{})
+)
+
+func init() {
+	const switchValuePrefix = "switchValue="
+
+	rt := reflect.TypeOf(Variant{})
+	for i := 0; i < rt.NumField(); i++ {
+		f := rt.Field(i)
+		tag := f.Tag.Get("opcua")
+		if f.Type.Kind() != reflect.Slice || !strings.Contains(tag, "switchField=VariantType") {
+			continue
+		}
+		for _, s := range strings.Split(tag, ",") {
+			if !strings.HasPrefix(s, switchValuePrefix) {
+				continue
+			}
+			id, err := strconv.Atoi(s[len(switchValuePrefix):])
+			if err != nil {
+				panic(fmt.Errorf("uatype: invalid Variant tag for field %s: %s", f.Name, err))
+			}
+			variantFields[byte(id)] = i
+			variantTypeIDs[f.Type.Elem()] = byte(id)
+		}
+	}
+}
+
+// NewVariant returns a Variant holding v. Scalar values are stored without an
+// array length, slices are stored as one-dimensional arrays, and nested slices
+// such as [][]float32 are flattened in row-major order with ArrayDimensions set
+// to the length of each dimension, outermost first. Nested slices must not be
+// ragged. A nil v gives a null Variant.
+//
+// NullString values, and slices of them, are stored as the String type, with
+// the null values marked in StringNull. Null strings are encoded as such only
+// by Encoders that preserve null values, and as empty strings otherwise.
+func NewVariant(v interface{}) (Variant, error) {
+	var variant Variant
+	if v == nil {
+		return variant, nil
+	}
+
+	rv := reflect.ValueOf(v)
+	if base, depth := variantBaseType(rv.Type()); base == nullStringType {
+		strs, nulls := splitNullStrings(rv, depth, nil)
+		variant, err := NewVariant(strs.Interface())
+		for _, null := range nulls {
+			if null {
+				variant.StringNull = nulls
+				break
+			}
+		}
+		return variant, err
+	}
+
+	// Find the element type and dimensions.
+	var dims []int32
+	rt := rv.Type()
+	for e := rv; rt.Kind() == reflect.Slice; rt = rt.Elem() {
+		if _, ok := variantTypeIDs[rt]; ok {
+			break
+		}
+		dims = append(dims, int32(e.Len()))
+		if e.Len() > 0 {
+			e = e.Index(0)
+		} else {
+			e = reflect.Zero(rt.Elem())
+		}
+	}
+	id, ok := variantTypeIDs[rt]
+	if !ok {
+		return variant, fmt.Errorf("%s: %s", ErrVariantType, rv.Type())
+	}
+	variant.VariantType = id
+	field := reflect.ValueOf(&variant).Elem().Field(variantFields[id])
+
+	// Scalar value.
+	if len(dims) == 0 {
+		s := reflect.MakeSlice(field.Type(), 1, 1)
+		s.Index(0).Set(rv)
+		field.Set(s)
+		return variant, nil
+	}
+
+	// Array value.
+	n := 1
+	for _, d := range dims {
+		n *= int(d)
+		if n > MaxVariantArrayLength {
+			return variant, ErrVariantArrayTooLong
+		}
+	}
+	flat := reflect.MakeSlice(field.Type(), 0, n)
+	if err := flattenVariantArray(&flat, rv, dims); err != nil {
+		return Variant{}, err
+	}
+	field.Set(flat)
+	variant.ArrayLengthSpecified = true
+	variant.ArrayLength = int32(n)
+	if len(dims) > 1 {
+		variant.ArrayDimensionsSpecified = true
+		variant.NoOfArrayDimensions = int32(len(dims))
+		variant.ArrayDimensions = dims
+	}
+	return variant, nil
+}
+
+// NewNullArrayVariant returns a Variant holding a null array of the built-in
+// type variantType, e.g. 12 for String. Null arrays are encoded with an array
+// length of -1, unlike the empty arrays NewVariant returns for empty or nil
+// slices.
+func NewNullArrayVariant(variantType byte) (Variant, error) {
+	if _, ok := variantFields[variantType]; !ok {
+		return Variant{}, fmt.Errorf("%s: VariantType %d", ErrVariantType, variantType)
+	}
+	return Variant{
+		VariantType:          variantType,
+		ArrayLengthSpecified: true,
+		ArrayLength:          -1,
+	}, nil
+}
+
+// NewVariantAs is like NewVariant, but stores v as the built-in type
+// variantType, e.g. 11 for Double. Numeric values, including elements of
+// (nested) slices, are converted to the element type of variantType, and an
+// error is returned if a value does not fit. Other values must be convertible
+// to the element type, e.g. a string based type to String.
+func NewVariantAs(variantType byte, v interface{}) (Variant, error) {
+	fi, ok := variantFields[variantType]
+	if !ok {
+		return Variant{}, fmt.Errorf("%s: VariantType %d", ErrVariantType, variantType)
+	}
+	if v == nil {
+		return Variant{}, nil
+	}
+	et := reflect.TypeOf(Variant{}).Field(fi).Type.Elem()
+	rv := reflect.ValueOf(v)
+	if base, _ := variantBaseType(rv.Type()); base == nullStringType && et == stringType {
+		return NewVariant(v)
+	}
+
+	// Find the number of slice levels above the values to convert.
+	rt := rv.Type()
+	depth := 0
+	for !variantConvertible(rt, et) {
+		if rt.Kind() != reflect.Slice {
+			return Variant{}, fmt.Errorf("%s: can not convert %s to %s", ErrVariantType, rv.Type(), et)
+		}
+		rt = rt.Elem()
+		depth++
+	}
+	cv, err := convertVariantValue(rv, et, depth)
+	if err != nil {
+		return Variant{}, err
+	}
+	return NewVariant(cv.Interface())
+}
+
+// variantBaseType returns the element type of rt after removing all levels of
+// slices, and the number of levels removed. Slice types that are stored in a
+// Variant as is, such as ByteString, are not removed.
+func variantBaseType(rt reflect.Type) (reflect.Type, int) {
+	depth := 0
+	for rt.Kind() == reflect.Slice {
+		if _, ok := variantTypeIDs[rt]; ok {
+			break
+		}
+		rt = rt.Elem()
+		depth++
+	}
+	return rt, depth
+}
+
+// splitNullStrings converts rv, which holds depth levels of slices of
+// NullStrings, to slices of strings of the same shape. Whether each string is
+// null is appended to nulls in row-major order. Nil slices stay nil.
+func splitNullStrings(rv reflect.Value, depth int, nulls []bool) (reflect.Value, []bool) {
+	if depth == 0 {
+		ns := rv.Interface().(NullString)
+		return reflect.ValueOf(ns.String), append(nulls, !ns.Valid)
+	}
+	tt := stringType
+	for i := 0; i < depth; i++ {
+		tt = reflect.SliceOf(tt)
+	}
+	if rv.IsNil() {
+		return reflect.Zero(tt), nulls
+	}
+	ret := reflect.MakeSlice(tt, rv.Len(), rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		var e reflect.Value
+		e, nulls = splitNullStrings(rv.Index(i), depth-1, nulls)
+		ret.Index(i).Set(e)
+	}
+	return ret, nulls
+}
+
+// variantConvertible returns true if values of type rt can be converted to et
+// by convertVariantValue.
+func variantConvertible(rt, et reflect.Type) bool {
+	if rt == et {
+		return true
+	}
+	if et.Kind() == reflect.String && rt.Kind() != reflect.String {
+		// Avoid converting integers to strings as runes.
+		return false
+	}
+	return rt.ConvertibleTo(et)
+}
+
+// convertVariantValue converts rv, which holds depth levels of slices, to
+// slices of et.
+func convertVariantValue(rv reflect.Value, et reflect.Type, depth int) (reflect.Value, error) {
+	if depth == 0 {
+		return convertVariantScalar(rv, et)
+	}
+	tt := et
+	for i := 0; i < depth; i++ {
+		tt = reflect.SliceOf(tt)
+	}
+	if rv.Type() == tt {
+		return rv, nil
+	}
+	ret := reflect.MakeSlice(tt, rv.Len(), rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		e, err := convertVariantValue(rv.Index(i), et, depth-1)
+		if err != nil {
+			return ret, err
+		}
+		ret.Index(i).Set(e)
+	}
+	return ret, nil
+}
+
+// convertVariantScalar converts rv to et, checking numeric ranges.
+func convertVariantScalar(rv reflect.Value, et reflect.Type) (reflect.Value, error) {
+	ev := reflect.New(et).Elem()
+	switch et.Kind() {
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var i int64
+		switch rv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			i = rv.Int()
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			u := rv.Uint()
+			if u > math.MaxInt64 {
+				return ev, ErrVariantRange
+			}
+			i = int64(u)
+		case reflect.Float32, reflect.Float64:
+			f := rv.Float()
+			if f != math.Trunc(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+				return ev, ErrVariantRange
+			}
+			i = int64(f)
+		default:
+			return rv.Convert(et), nil
+		}
+		if ev.OverflowInt(i) {
+			return ev, ErrVariantRange
+		}
+		ev.SetInt(i)
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		var u uint64
+		switch rv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			i := rv.Int()
+			if i < 0 {
+				return ev, ErrVariantRange
+			}
+			u = uint64(i)
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			u = rv.Uint()
+		case reflect.Float32, reflect.Float64:
+			f := rv.Float()
+			if f != math.Trunc(f) || f < 0 || f >= math.MaxUint64 {
+				return ev, ErrVariantRange
+			}
+			u = uint64(f)
+		default:
+			return rv.Convert(et), nil
+		}
+		if ev.OverflowUint(u) {
+			return ev, ErrVariantRange
+		}
+		ev.SetUint(u)
+	case reflect.Float32:
+		if (rv.Kind() == reflect.Float64 || rv.Kind() == reflect.Float32) && ev.OverflowFloat(rv.Float()) {
+			return ev, ErrVariantRange
+		}
+		return rv.Convert(et), nil
+	default:
+		return rv.Convert(et), nil
+	}
+	return ev, nil
+}
+
+// flattenVariantArray appends all elements of rv to flat in row-major order,
+// asserting that each nested slice match dims.
+func flattenVariantArray(flat *reflect.Value, rv reflect.Value, dims []int32) error {
+	if rv.Len() != int(dims[0]) {
+		return ErrVariantRagged
+	}
+	if len(dims) == 1 {
+		*flat = reflect.AppendSlice(*flat, rv)
+		return nil
+	}
+	for i := 0; i < rv.Len(); i++ {
+		if err := flattenVariantArray(flat, rv.Index(i), dims[1:]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// IsArray returns true if v holds an array value rather than a scalar.
+func (v Variant) IsArray() bool {
+	return bool(v.ArrayLengthSpecified)
+}
+
+// Dimensions returns the length of each dimension for array values, outermost
+// first, or nil for scalar values. An error is returned if the dimensions are
+// inconsistent with the array length, or if the array length is above
+// MaxVariantArrayLength.
+func (v Variant) Dimensions() ([]int, error) {
+	if !v.ArrayLengthSpecified {
+		return nil, nil
+	}
+	if v.ArrayLength < 0 {
+		// A negative length encodes a null array.
+		return []int{0}, nil
+	}
+	if int(v.ArrayLength) > MaxVariantArrayLength {
+		return nil, ErrVariantArrayTooLong
+	}
+	if !v.ArrayDimensionsSpecified {
+		return []int{int(v.ArrayLength)}, nil
+	}
+	if len(v.ArrayDimensions) == 0 {
+		return nil, ErrVariantDimensions
+	}
+
+	dims := make([]int, len(v.ArrayDimensions))
+	n := 1
+	for i, d := range v.ArrayDimensions {
+		// Dimensions may be zero for empty arrays, e.g. [][]float32{{}, {}},
+		// but not negative. Once a dimension is zero the product stays zero,
+		// so it can still be checked against the limit as we go.
+		if d < 0 {
+			return nil, ErrVariantDimensions
+		}
+		n *= int(d)
+		if n > MaxVariantArrayLength {
+			return nil, ErrVariantArrayTooLong
+		}
+		dims[i] = int(d)
+	}
+	if n != int(v.ArrayLength) {
+		return nil, ErrVariantDimensions
+	}
+	return dims, nil
+}
+
+// Value returns the value held by v as a Go value. Scalars are returned as
+// their element type, e.g. float32, one-dimensional arrays as a slice, e.g.
+// []float32, and multi-dimensional arrays as nested slices, e.g. [][]float32,
+// built from ArrayDimensions in row-major order. A null Variant returns nil,
+// and a null array, which has a negative ArrayLength, returns a nil slice.
+func (v Variant) Value() (interface{}, error) {
+	if v.VariantType == 0 {
+		return nil, nil
+	}
+	fi, ok := variantFields[v.VariantType]
+	if !ok {
+		return nil, fmt.Errorf("%s: VariantType %d", ErrVariantType, v.VariantType)
+	}
+	return v.value(reflect.ValueOf(v).Field(fi))
+}
+
+// NullStringValue is like Value for Variants of the String type, but returns
+// NullString values, e.g. []NullString, where the values marked in StringNull
+// are null.
+func (v Variant) NullStringValue() (interface{}, error) {
+	if v.VariantType != variantTypeString {
+		return nil, fmt.Errorf("%s: VariantType %d is not String", ErrVariantType, v.VariantType)
+	}
+	ns := make([]NullString, len(v.String))
+	for i, s := range v.String {
+		if i >= len(v.StringNull) || !v.StringNull[i] {
+			ns[i] = NewNullString(s)
+		}
+	}
+	return v.value(reflect.ValueOf(ns))
+}
+
+// value returns flat, the values held by v, shaped as described by Value.
+func (v Variant) value(flat reflect.Value) (interface{}, error) {
+	dims, err := v.Dimensions()
+	if err != nil {
+		return nil, err
+	}
+	if v.ArrayLengthSpecified && v.ArrayLength < 0 {
+		if flat.Len() != 0 {
+			return nil, ErrVariantDimensions
+		}
+		return reflect.Zero(flat.Type()).Interface(), nil
+	}
+
+	if dims == nil {
+		if flat.Len() != 1 {
+			return nil, ErrVariantDimensions
+		}
+		return flat.Index(0).Interface(), nil
+	}
+	if flat.Len() != int(v.ArrayLength) {
+		return nil, ErrVariantDimensions
+	}
+	return nestVariantArray(flat, dims).Interface(), nil
+}
+
+// nestVariantArray returns flat reshaped into nested slices according to dims.
+func nestVariantArray(flat reflect.Value, dims []int) reflect.Value {
+	if len(dims) == 1 {
+		return flat
+	}
+	rt := flat.Type()
+	for range dims[1:] {
+		rt = reflect.SliceOf(rt)
+	}
+	ret := reflect.MakeSlice(rt, dims[0], dims[0])
+	if dims[0] == 0 {
+		return ret
+	}
+	step := flat.Len() / dims[0]
+	for i := 0; i < dims[0]; i++ {
+		ret.Index(i).Set(nestVariantArray(flat.Slice(i*step, (i+1)*step), dims[1:]))
+	}
+	return ret
+}
@@ -0,0 +1,279 @@
+package stack
+
+import (
+	"sync"
+	"time"
+
+	"github.com/searis/guma/stack/transport"
+	"github.com/searis/guma/stack/uatype"
+)
+
+// DefaultWatchdogInterval is the interval between server status reads used
+// by a Watchdog when neither Interval nor SessionTimeout is set.
+const DefaultWatchdogInterval = 10 * time.Second
+
+// watchdogQueueSize is the number of status changes a Watchdog buffers
+// before it starts dropping the oldest ones.
+const watchdogQueueSize = 16
+
+// ServerStatus is the status of the server as seen by a Watchdog.
+type ServerStatus struct {
+	// State is the state of the server, or ServerStateCommunicationFault if
+	// the status could not be read.
+	State uatype.ServerState
+
+	CurrentTime         time.Time
+	SecondsTillShutdown uint32
+	ShutdownReason      uatype.LocalizedText
+
+	// Err is the error that caused a communication fault, or nil.
+	Err error
+}
+
+// changed returns true if s differs from prev in other ways than the time.
+func (s ServerStatus) changed(prev ServerStatus) bool {
+	return s.State != prev.State ||
+		s.SecondsTillShutdown != prev.SecondsTillShutdown ||
+		s.ShutdownReason != prev.ShutdownReason ||
+		(s.Err == nil) != (prev.Err == nil)
+}
+
+// WatchdogOptions configures a Watchdog.
+type WatchdogOptions struct {
+	// Interval is the time between server status reads. If zero, a third of
+	// SessionTimeout is used, or DefaultWatchdogInterval if SessionTimeout is
+	// also zero.
+	Interval time.Duration
+
+	// SessionTimeout should be set to the revised session timeout returned
+	// by CreateSession, so that the status is read often enough to keep the
+	// session alive.
+	SessionTimeout time.Duration
+
+	// Timeout limits each status read and recovery attempt. If zero,
+	// Interval is used.
+	Timeout time.Duration
+
+	// ActivateSession is sent to activate the session again after the
+	// channel has been reconnected; the request header is replaced. If nil,
+	// the session is not activated again.
+	ActivateSession *uatype.ActivateSessionRequest
+
+	// Recover is called on communication faults to restore the session. If
+	// nil, the channel is reconnected if it implements transport.Reconnector,
+	// and the session is activated again with ActivateSession. Use
+	// Session.Recover to also recover lost sessions and subscriptions.
+	Recover func(deadline time.Time) error
+}
+
+// Watchdog keeps a session alive by reading the server status periodically,
+// and tries to recover the session when the server stops responding. Status
+// changes are reported on the States channel.
+type Watchdog struct {
+	c        *Client
+	interval time.Duration
+	timeout  time.Duration
+	activate *uatype.ActivateSessionRequest
+	recover  func(deadline time.Time) error
+
+	mu     sync.Mutex
+	status ServerStatus
+	states chan ServerStatus
+
+	stopOnce sync.Once
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+// StartWatchdog starts a watchdog for the session of c. The server status is
+// read immediately, and then every interval. Stop must be called to release
+// the watchdog.
+func (c *Client) StartWatchdog(opts WatchdogOptions) *Watchdog {
+	w := &Watchdog{
+		c:        c,
+		interval: opts.Interval,
+		timeout:  opts.Timeout,
+		activate: opts.ActivateSession,
+		recover:  opts.Recover,
+		status:   ServerStatus{State: uatype.ServerStateUnknown},
+		states:   make(chan ServerStatus, watchdogQueueSize),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	if w.interval <= 0 {
+		w.interval = opts.SessionTimeout / 3
+	}
+	if w.interval <= 0 {
+		w.interval = DefaultWatchdogInterval
+	}
+	if w.timeout <= 0 {
+		w.timeout = w.interval
+	}
+	if w.recover == nil {
+		w.recover = w.reconnect
+	}
+	go w.run()
+	return w
+}
+
+// States returns a channel that receives the server status each time the
+// state, the time till shutdown or the shutdown reason changes. If the
+// receiver falls behind, the oldest status changes are dropped. The channel
+// is closed when the watchdog is stopped.
+func (w *Watchdog) States() <-chan ServerStatus {
+	return w.states
+}
+
+// Status returns the last server status read by w.
+func (w *Watchdog) Status() ServerStatus {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.status
+}
+
+// Stop stops the watchdog, and waits for any ongoing status read or recovery
+// attempt to complete. It is safe to call Stop more than once, and from
+// several goroutines.
+func (w *Watchdog) Stop() {
+	w.stopOnce.Do(func() { close(w.stop) })
+	<-w.done
+}
+
+func (w *Watchdog) run() {
+	defer close(w.done)
+	defer close(w.states)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		w.check()
+		select {
+		case <-w.stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// check reads the server status, and tries to recover on communication
+// faults.
+func (w *Watchdog) check() {
+	status := w.read(time.Now().Add(w.timeout))
+	w.report(status)
+	if status.State != uatype.ServerStateCommunicationFault {
+		return
+	}
+	if err := w.recover(time.Now().Add(w.timeout)); err != nil {
+		status.Err = err
+		w.report(status)
+		return
+	}
+	w.report(w.read(time.Now().Add(w.timeout)))
+}
+
+// report stores status, and queues it if it's changed.
+func (w *Watchdog) report(status ServerStatus) {
+	w.mu.Lock()
+	prev := w.status
+	w.status = status
+	w.mu.Unlock()
+	if !status.changed(prev) {
+		return
+	}
+	for {
+		select {
+		case w.states <- status:
+			return
+		default:
+		}
+		// Drop the oldest status change to make room.
+		select {
+		case <-w.states:
+		default:
+		}
+	}
+}
+
+// read reads the server status. Communication errors are reported as a
+// status with State set to ServerStateCommunicationFault.
+func (w *Watchdog) read(deadline time.Time) ServerStatus {
+	nodes := []uint16{
+		uatype.NodeIdServer_ServerStatus_State,
+		uatype.NodeIdServer_ServerStatus_CurrentTime,
+		uatype.NodeIdServer_ServerStatus_SecondsTillShutdown,
+		uatype.NodeIdServer_ServerStatus_ShutdownReason,
+	}
+	ids := make([]uatype.ReadValueId, len(nodes))
+	for i, n := range nodes {
+		ids[i] = uatype.ReadValueId{
+			NodeId:      uatype.NewFourByteNodeID(0, n),
+			AttributeId: uint32(uatype.AttrTypeValue),
+		}
+	}
+	resp, err := w.c.Read(uatype.ReadRequest{
+		RequestHeader:      w.c.requestHeader(),
+		TimestampsToReturn: uatype.TimestampsToReturnNeither,
+		NoOfNodesToRead:    int32(len(ids)),
+		NodesToRead:        ids,
+	}, deadline)
+	if err == nil && resp.ResponseHeader.ServiceResult.IsBad() {
+		err = resp.ResponseHeader.ServiceResult
+	} else if err == nil && len(resp.Results) != len(ids) {
+		err = uatype.StatusBadUnexpectedError
+	}
+	if err != nil {
+		return ServerStatus{State: uatype.ServerStateCommunicationFault, Err: err}
+	}
+
+	status := ServerStatus{State: uatype.ServerStateUnknown}
+	for i, dv := range resp.Results {
+		if dv.StatusCode.IsBad() {
+			continue
+		}
+		v, err := dv.Value.Value()
+		if err != nil {
+			continue
+		}
+		switch i {
+		case 0:
+			switch state := v.(type) {
+			case int32:
+				status.State = uatype.ServerState(state)
+			case uint32:
+				status.State = uatype.ServerState(state)
+			}
+		case 1:
+			status.CurrentTime, _ = v.(time.Time)
+		case 2:
+			status.SecondsTillShutdown, _ = v.(uint32)
+		case 3:
+			status.ShutdownReason, _ = v.(uatype.LocalizedText)
+		}
+	}
+	return status
+}
+
+// reconnect is the default recovery; it reconnects the channel and activates
+// the session again.
+func (w *Watchdog) reconnect(deadline time.Time) error {
+	r, ok := w.c.Channel.(transport.Reconnector)
+	if !ok {
+		return uatype.StatusBadNotSupported
+	}
+	if err := r.Reconnect(deadline); err != nil {
+		return err
+	}
+	if w.activate == nil {
+		return nil
+	}
+	req := *w.activate
+	req.RequestHeader = w.c.requestHeader()
+	resp, err := w.c.ActivateSession(req, deadline)
+	if err != nil {
+		return err
+	}
+	if resp.ResponseHeader.ServiceResult.IsBad() {
+		return resp.ResponseHeader.ServiceResult
+	}
+	return nil
+}
@@ -0,0 +1,146 @@
+package stack_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/searis/guma/stack"
+	"github.com/searis/guma/stack/transport"
+	"github.com/searis/guma/stack/uatype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// reconnectChannel is a fakeChannel that fails all requests while broken,
+// until it's reconnected.
+type reconnectChannel struct {
+	fakeChannel
+
+	mu         sync.Mutex
+	broken     bool
+	reconnects int
+}
+
+func (ch *reconnectChannel) Send(req transport.Request, deadline time.Time) (*transport.Response, error) {
+	ch.mu.Lock()
+	broken := ch.broken
+	ch.mu.Unlock()
+	if broken {
+		return nil, transport.LocalError(uatype.StatusBadConnectionClosed, nil)
+	}
+	return ch.fakeChannel.Send(req, deadline)
+}
+
+func (ch *reconnectChannel) Reconnect(deadline time.Time) error {
+	ch.mu.Lock()
+	defer ch.mu.Unlock()
+	ch.broken = false
+	ch.reconnects++
+	return nil
+}
+
+func nextStatus(t *testing.T, w *stack.Watchdog) stack.ServerStatus {
+	select {
+	case status, ok := <-w.States():
+		require.True(t, ok, "states closed")
+		return status
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout waiting for server status")
+	}
+	return stack.ServerStatus{}
+}
+
+func TestWatchdog(t *testing.T) {
+	var mu sync.Mutex
+	state := uatype.ServerStateRunning
+	var secondsTillShutdown uint32
+	var activated int
+
+	ch := &reconnectChannel{}
+	ch.fakeChannel = fakeChannel{t: t, handle: func(req interface{}) interface{} {
+		mu.Lock()
+		defer mu.Unlock()
+		switch r := req.(type) {
+		case uatype.ActivateSessionRequest:
+			activated++
+			return uatype.ActivateSessionResponse{ResponseHeader: responseHeader()}
+		case uatype.ReadRequest:
+			require.Len(t, r.NodesToRead, 4, "nodes to read")
+			values := []interface{}{
+				int32(state),
+				time.Now().UTC(),
+				secondsTillShutdown,
+				uatype.LocalizedText{TextSpecified: secondsTillShutdown > 0, Text: "maintenance"},
+			}
+			results := make([]uatype.DataValue, len(values))
+			for i, v := range values {
+				variant, err := uatype.NewVariant(v)
+				require.NoError(t, err, "NewVariant")
+				results[i] = uatype.DataValue{ValueSpecified: true, Value: variant}
+			}
+			return uatype.ReadResponse{
+				ResponseHeader: responseHeader(),
+				NoOfResults:    int32(len(results)),
+				Results:        results,
+			}
+		}
+		t.Fatalf("unexpected request %T", req)
+		return nil
+	}}
+
+	c := &stack.Client{Channel: ch}
+	w := c.StartWatchdog(stack.WatchdogOptions{
+		Interval:        10 * time.Millisecond,
+		ActivateSession: &uatype.ActivateSessionRequest{},
+	})
+
+	status := nextStatus(t, w)
+	assert.Equal(t, uatype.ServerStateRunning, status.State, "state")
+	assert.False(t, status.CurrentTime.IsZero(), "current time")
+
+	mu.Lock()
+	state = uatype.ServerStateShutdown
+	secondsTillShutdown = 30
+	mu.Unlock()
+	status = nextStatus(t, w)
+	assert.Equal(t, uatype.ServerStateShutdown, status.State, "state")
+	assert.Equal(t, uint32(30), status.SecondsTillShutdown, "seconds till shutdown")
+	assert.Equal(t, "maintenance", status.ShutdownReason.Text, "shutdown reason")
+
+	// A broken connection is reported and recovered.
+	mu.Lock()
+	state = uatype.ServerStateRunning
+	secondsTillShutdown = 0
+	mu.Unlock()
+	ch.mu.Lock()
+	ch.broken = true
+	ch.mu.Unlock()
+	for status.State != uatype.ServerStateCommunicationFault {
+		status = nextStatus(t, w)
+	}
+	assert.Error(t, status.Err, "communication fault error")
+	status = nextStatus(t, w)
+	assert.Equal(t, uatype.ServerStateRunning, status.State, "state after recovery")
+	assert.NoError(t, status.Err, "error after recovery")
+
+	// The states channel is closed after Stop, which may be called
+	// concurrently.
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			w.Stop()
+		}()
+	}
+	wg.Wait()
+	for range w.States() {
+	}
+	ch.mu.Lock()
+	assert.Equal(t, 1, ch.reconnects, "reconnects")
+	ch.mu.Unlock()
+	mu.Lock()
+	assert.Equal(t, 1, activated, "session activations")
+	mu.Unlock()
+}